@@ -3,10 +3,18 @@ package common
 import (
 	"errors"
 	"flag"
+	"github.com/rhodeon/moviescreen/internal/mailer"
+	"net/url"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// hexColorRX matches six-digit hex colour codes.
+var hexColorRX = regexp.MustCompile("^#[0-9a-fA-F]{6}$")
+
 type Config struct {
 	Env            string
 	Version        string
@@ -26,12 +34,64 @@ type Config struct {
 		Burst   int
 	}
 
+	Mail struct {
+		Transport    string
+		Dir          string
+		BrandName    string
+		BrandColor   string
+		BrandLogoUrl string
+
+		UnsubscribeUrl    string
+		UnsubscribeSecret string
+
+		// WebhookSecret authenticates the bounce and complaint notifications of the mail provider,
+		// with the webhook being disabled if it's empty.
+		WebhookSecret string
+	}
+
 	Smtp struct {
 		Host     string
 		Port     int
 		User     string
 		Password string
 		Sender   string
+		PoolSize int
+	}
+
+	Outbox struct {
+		Workers      int
+		BatchSize    int
+		PollInterval time.Duration
+		MaxAttempts  int
+	}
+
+	Import struct {
+		MaxBytes  int64
+		BatchSize int
+		Timeout   time.Duration
+	}
+
+	Trash struct {
+		Retention         time.Duration
+		RevisionRetention time.Duration
+		PurgeInterval     time.Duration
+	}
+
+	Similarities struct {
+		RefreshInterval time.Duration
+	}
+
+	Stats struct {
+		CacheTtl time.Duration
+	}
+
+	Images struct {
+		MaxBytes int64
+	}
+
+	Storage struct {
+		Dir string
+		Url string
 	}
 }
 
@@ -49,11 +109,43 @@ func (c *Config) Parse() {
 	flag.Float64Var(&c.Limiter.Rps, "limiter-rps", c.defaultLimiterRps(), "Rate limiter maximum requests per second\nDotenv variable: LIMITER_RPS\n")
 	flag.IntVar(&c.Limiter.Burst, "limiter-burst", c.defaultLimiterBurst(), "Rate limiter maximum burst\nDotenv variable: LIMITER_BURST\n")
 
+	flag.StringVar(&c.Mail.Transport, "mail-transport", c.defaultMailTransport(), "Transport emails are delivered through (smtp|file|stdout|memory)\nDotenv variable: MAIL_TRANSPORT\n")
+	flag.StringVar(&c.Mail.Dir, "mail-dir", c.defaultMailDir(), "Maildir emails are written to by the file transport\nDotenv variable: MAIL_DIR\n")
+	flag.StringVar(&c.Mail.BrandName, "mail-brand-name", c.defaultMailBrandName(), "Brand name shown in emails\nDotenv variable: MAIL_BRAND_NAME\n")
+	flag.StringVar(&c.Mail.BrandColor, "mail-brand-color", c.defaultMailBrandColor(), "Brand colour of emails as a hex code, such as #e11d48\nDotenv variable: MAIL_BRAND_COLOR\n")
+	flag.StringVar(&c.Mail.BrandLogoUrl, "mail-brand-logo-url", c.defaultMailBrandLogoUrl(), "URL of the logo shown in emails instead of the brand name\nDotenv variable: MAIL_BRAND_LOGO_URL\n")
+	flag.StringVar(&c.Mail.UnsubscribeUrl, "mail-unsubscribe-url", c.defaultMailUnsubscribeUrl(), "Public URL of the unsubscribe endpoint linked in non-transactional emails\nDotenv variable: MAIL_UNSUBSCRIBE_URL\n")
+	flag.StringVar(&c.Mail.UnsubscribeSecret, "mail-unsubscribe-secret", c.defaultMailUnsubscribeSecret(), "Secret signing the unsubscribe links of emails, of at least 32 characters\nDotenv variable: MAIL_UNSUBSCRIBE_SECRET\n")
+	flag.StringVar(&c.Mail.WebhookSecret, "mail-webhook-secret", c.defaultMailWebhookSecret(), "Bearer token of the bounce and complaint webhook, which is disabled if empty\nDotenv variable: MAIL_WEBHOOK_SECRET\n")
+
 	flag.StringVar(&c.Smtp.Host, "smtp-host", c.defaultSmtpHost(), "SMTP hostname\nDotenv variable: SMTP_HOST\n")
 	flag.IntVar(&c.Smtp.Port, "smtp-port", c.defaultSmtpPort(), "SMTP port\nDotenv variable: SMTP_PORT\n")
 	flag.StringVar(&c.Smtp.User, "smtp-user", c.defaultSmtpUser(), "SMTP username\nDotenv variable: SMTP_USER\n")
 	flag.StringVar(&c.Smtp.Password, "smtp-pass", c.defaultSmtpPassword(), "SMTP password\nDotenv variable: SMTP_PASS\n")
 	flag.StringVar(&c.Smtp.Sender, "smtp-sender", c.defaultSmtpSender(), "SMTP sender\nDotenv variable: SMTP_SENDER\n")
+	flag.IntVar(&c.Smtp.PoolSize, "smtp-pool-size", c.defaultSmtpPoolSize(), "Maximum number of persistent SMTP connections\nDotenv variable: SMTP_POOL_SIZE\n")
+
+	flag.IntVar(&c.Outbox.Workers, "outbox-workers", c.defaultOutboxWorkers(), "Number of workers sending emails from the outbox concurrently\nDotenv variable: OUTBOX_WORKERS\n")
+	flag.IntVar(&c.Outbox.BatchSize, "outbox-batch-size", c.defaultOutboxBatchSize(), "Number of emails claimed from the outbox by a worker at once\nDotenv variable: OUTBOX_BATCH_SIZE\n")
+	flag.DurationVar(&c.Outbox.PollInterval, "outbox-poll-interval", c.defaultOutboxPollInterval(), "Interval between claims of emails from the outbox by each worker\nDotenv variable: OUTBOX_POLL_INTERVAL\n")
+	flag.IntVar(&c.Outbox.MaxAttempts, "outbox-max-attempts", c.defaultOutboxMaxAttempts(), "Number of attempts to send an email before it's dead-lettered\nDotenv variable: OUTBOX_MAX_ATTEMPTS\n")
+
+	flag.Int64Var(&c.Import.MaxBytes, "import-max-bytes", c.defaultImportMaxBytes(), "Maximum size of a movie import body in bytes\nDotenv variable: IMPORT_MAX_BYTES\n")
+	flag.IntVar(&c.Import.BatchSize, "import-batch-size", c.defaultImportBatchSize(), "Number of imported movies inserted per transaction\nDotenv variable: IMPORT_BATCH_SIZE\n")
+	flag.DurationVar(&c.Import.Timeout, "import-timeout", c.defaultImportTimeout(), "Duration a movie import is given to be uploaded and inserted, in place of the server timeouts\nDotenv variable: IMPORT_TIMEOUT\n")
+
+	flag.DurationVar(&c.Trash.Retention, "trash-retention", c.defaultTrashRetention(), "Duration deleted movies are kept in the trash before being purged\nDotenv variable: TRASH_RETENTION\n")
+	flag.DurationVar(&c.Trash.RevisionRetention, "trash-revision-retention", c.defaultTrashRevisionRetention(), "Duration the revisions of purged or merged movies are kept after their last change\nDotenv variable: TRASH_REVISION_RETENTION\n")
+	flag.DurationVar(&c.Trash.PurgeInterval, "trash-purge-interval", c.defaultTrashPurgeInterval(), "Interval between purges of the trash\nDotenv variable: TRASH_PURGE_INTERVAL\n")
+
+	flag.DurationVar(&c.Similarities.RefreshInterval, "similarities-refresh-interval", c.defaultSimilaritiesRefreshInterval(), "Interval between refreshes of the similarities of movies\nDotenv variable: SIMILARITIES_REFRESH_INTERVAL\n")
+
+	flag.DurationVar(&c.Stats.CacheTtl, "stats-cache-ttl", c.defaultStatsCacheTtl(), "Duration catalogue statistics are cached before being recomputed, with 0 disabling the cache\nDotenv variable: STATS_CACHE_TTL\n")
+
+	flag.Int64Var(&c.Images.MaxBytes, "images-max-bytes", c.defaultImagesMaxBytes(), "Maximum size of an uploaded movie image in bytes\nDotenv variable: IMAGES_MAX_BYTES\n")
+
+	flag.StringVar(&c.Storage.Dir, "storage-dir", c.defaultStorageDir(), "Directory uploaded files are stored in\nDotenv variable: STORAGE_DIR\n")
+	flag.StringVar(&c.Storage.Url, "storage-url", c.defaultStorageUrl(), "Base URL of stored files, which are served by the API if it's a path\nDotenv variable: STORAGE_URL\n")
 
 	flag.Parse()
 }
@@ -63,12 +155,105 @@ func (c *Config) Validate() error {
 		return errors.New("the 'db-dsn' flag is required")
 	}
 
-	if c.Smtp.Host == "" {
-		return errors.New("the 'smtp-host' flag is required")
+	switch c.Mail.Transport {
+	case mailer.TransportSmtp:
+		if c.Smtp.Host == "" {
+			return errors.New("the 'smtp-host' flag is required for the smtp mail transport")
+		}
+
+		if c.Smtp.User == "" {
+			return errors.New("the 'smtp-user' flag is required for the smtp mail transport")
+		}
+
+		if c.Smtp.PoolSize < 1 {
+			return errors.New("the 'smtp-pool-size' flag must be greater than zero")
+		}
+
+	case mailer.TransportFile:
+		if c.Mail.Dir == "" {
+			return errors.New("the 'mail-dir' flag is required for the file mail transport")
+		}
+
+	case mailer.TransportStdout, mailer.TransportMemory:
+
+	default:
+		return errors.New("the 'mail-transport' flag must be one of smtp, file, stdout or memory")
+	}
+
+	if strings.TrimSpace(c.Mail.BrandName) == "" {
+		return errors.New("the 'mail-brand-name' flag must not be blank")
+	}
+
+	if !hexColorRX.MatchString(c.Mail.BrandColor) {
+		return errors.New("the 'mail-brand-color' flag must be a hex colour code, such as #e11d48")
+	}
+
+	if u, err := url.Parse(c.Mail.UnsubscribeUrl); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("the 'mail-unsubscribe-url' flag must be an absolute http or https URL")
+	}
+
+	if len(c.Mail.UnsubscribeSecret) < 32 {
+		return errors.New("the 'mail-unsubscribe-secret' flag is required and must be at least 32 characters long")
+	}
+
+	if c.Mail.WebhookSecret != "" && len(c.Mail.WebhookSecret) < 32 {
+		return errors.New("the 'mail-webhook-secret' flag must be at least 32 characters long")
+	}
+
+	if c.Outbox.Workers < 1 {
+		return errors.New("the 'outbox-workers' flag must be greater than zero")
+	}
+
+	if c.Outbox.BatchSize < 1 {
+		return errors.New("the 'outbox-batch-size' flag must be greater than zero")
+	}
+
+	if c.Outbox.PollInterval <= 0 {
+		return errors.New("the 'outbox-poll-interval' flag must be a positive duration")
+	}
+
+	if c.Outbox.MaxAttempts < 1 {
+		return errors.New("the 'outbox-max-attempts' flag must be greater than zero")
+	}
+
+	if c.Import.MaxBytes < 1 {
+		return errors.New("the 'import-max-bytes' flag must be greater than zero")
+	}
+
+	if c.Import.BatchSize < 1 {
+		return errors.New("the 'import-batch-size' flag must be greater than zero")
+	}
+
+	if c.Import.Timeout <= 0 {
+		return errors.New("the 'import-timeout' flag must be a positive duration")
+	}
+
+	if c.Trash.Retention <= 0 {
+		return errors.New("the 'trash-retention' flag must be a positive duration")
 	}
 
-	if c.Smtp.User == "" {
-		return errors.New("the 'smtp-user' flag is required")
+	if c.Trash.RevisionRetention <= 0 {
+		return errors.New("the 'trash-revision-retention' flag must be a positive duration")
+	}
+
+	if c.Trash.PurgeInterval <= 0 {
+		return errors.New("the 'trash-purge-interval' flag must be a positive duration")
+	}
+
+	if c.Similarities.RefreshInterval <= 0 {
+		return errors.New("the 'similarities-refresh-interval' flag must be a positive duration")
+	}
+
+	if c.Stats.CacheTtl < 0 {
+		return errors.New("the 'stats-cache-ttl' flag must not be negative")
+	}
+
+	if c.Images.MaxBytes < 1 {
+		return errors.New("the 'images-max-bytes' flag must be greater than zero")
+	}
+
+	if c.Storage.Dir == "" {
+		return errors.New("the 'storage-dir' flag is required")
 	}
 
 	return nil
@@ -173,6 +358,70 @@ func (c *Config) defaultLimiterBurst() int {
 	return defaultBurst
 }
 
+func (c *Config) defaultMailTransport() string {
+	if transport, exists := os.LookupEnv("MAIL_TRANSPORT"); exists {
+		return transport
+	}
+	return mailer.TransportSmtp
+}
+
+func (c *Config) defaultMailDir() string {
+	const defaultDir = "./mail"
+
+	if dir, exists := os.LookupEnv("MAIL_DIR"); exists {
+		return dir
+	}
+	return defaultDir
+}
+
+func (c *Config) defaultMailBrandName() string {
+	const defaultName = "Moviescreen"
+
+	if name, exists := os.LookupEnv("MAIL_BRAND_NAME"); exists {
+		return name
+	}
+	return defaultName
+}
+
+func (c *Config) defaultMailBrandColor() string {
+	const defaultColor = "#e11d48"
+
+	if color, exists := os.LookupEnv("MAIL_BRAND_COLOR"); exists {
+		return color
+	}
+	return defaultColor
+}
+
+func (c *Config) defaultMailBrandLogoUrl() string {
+	if url, exists := os.LookupEnv("MAIL_BRAND_LOGO_URL"); exists {
+		return url
+	}
+	return ""
+}
+
+func (c *Config) defaultMailUnsubscribeUrl() string {
+	const defaultUrl = "http://localhost:4000/v1/users/unsubscribe"
+
+	if url, exists := os.LookupEnv("MAIL_UNSUBSCRIBE_URL"); exists {
+		return url
+	}
+	return defaultUrl
+}
+
+func (c *Config) defaultMailUnsubscribeSecret() string {
+	if secret, exists := os.LookupEnv("MAIL_UNSUBSCRIBE_SECRET"); exists {
+		return secret
+	}
+	return ""
+}
+
+func (c *Config) defaultMailWebhookSecret() string {
+	if secret, exists := os.LookupEnv("MAIL_WEBHOOK_SECRET"); exists {
+		return secret
+	}
+	return ""
+}
+
 func (c *Config) defaultSmtpHost() string {
 	if host, exists := os.LookupEnv("SMTP_HOST"); exists {
 		return host
@@ -212,3 +461,189 @@ func (c *Config) defaultSmtpSender() string {
 	}
 	return defaultSender
 }
+
+func (c *Config) defaultOutboxWorkers() int {
+	const defaultWorkers = 2
+
+	if workersEnv, exists := os.LookupEnv("OUTBOX_WORKERS"); exists {
+		workers, err := strconv.Atoi(workersEnv)
+		if err == nil {
+			return workers
+		}
+	}
+	return defaultWorkers
+}
+
+func (c *Config) defaultOutboxBatchSize() int {
+	const defaultBatchSize = 10
+
+	if batchSizeEnv, exists := os.LookupEnv("OUTBOX_BATCH_SIZE"); exists {
+		batchSize, err := strconv.Atoi(batchSizeEnv)
+		if err == nil {
+			return batchSize
+		}
+	}
+	return defaultBatchSize
+}
+
+func (c *Config) defaultOutboxPollInterval() time.Duration {
+	const defaultInterval = 5 * time.Second
+
+	if intervalEnv, exists := os.LookupEnv("OUTBOX_POLL_INTERVAL"); exists {
+		interval, err := time.ParseDuration(intervalEnv)
+		if err == nil {
+			return interval
+		}
+	}
+	return defaultInterval
+}
+
+func (c *Config) defaultOutboxMaxAttempts() int {
+	const defaultMaxAttempts = 8
+
+	if maxAttemptsEnv, exists := os.LookupEnv("OUTBOX_MAX_ATTEMPTS"); exists {
+		maxAttempts, err := strconv.Atoi(maxAttemptsEnv)
+		if err == nil {
+			return maxAttempts
+		}
+	}
+	return defaultMaxAttempts
+}
+
+func (c *Config) defaultSmtpPoolSize() int {
+	const defaultPoolSize = 2
+
+	if poolSizeEnv, exists := os.LookupEnv("SMTP_POOL_SIZE"); exists {
+		poolSize, err := strconv.Atoi(poolSizeEnv)
+		if err == nil {
+			return poolSize
+		}
+	}
+	return defaultPoolSize
+}
+
+func (c *Config) defaultImportMaxBytes() int64 {
+	const defaultMaxBytes = 50 * 1_048_576
+
+	if maxBytesEnv, exists := os.LookupEnv("IMPORT_MAX_BYTES"); exists {
+		maxBytes, err := strconv.ParseInt(maxBytesEnv, 10, 64)
+		if err == nil {
+			return maxBytes
+		}
+	}
+	return defaultMaxBytes
+}
+
+func (c *Config) defaultImportBatchSize() int {
+	const defaultBatchSize = 500
+
+	if batchSizeEnv, exists := os.LookupEnv("IMPORT_BATCH_SIZE"); exists {
+		batchSize, err := strconv.Atoi(batchSizeEnv)
+		if err == nil {
+			return batchSize
+		}
+	}
+	return defaultBatchSize
+}
+
+func (c *Config) defaultImportTimeout() time.Duration {
+	const defaultTimeout = 10 * time.Minute
+
+	if timeoutEnv, exists := os.LookupEnv("IMPORT_TIMEOUT"); exists {
+		timeout, err := time.ParseDuration(timeoutEnv)
+		if err == nil {
+			return timeout
+		}
+	}
+	return defaultTimeout
+}
+
+func (c *Config) defaultTrashRetention() time.Duration {
+	const defaultRetention = 30 * 24 * time.Hour
+
+	if retentionEnv, exists := os.LookupEnv("TRASH_RETENTION"); exists {
+		retention, err := time.ParseDuration(retentionEnv)
+		if err == nil {
+			return retention
+		}
+	}
+	return defaultRetention
+}
+
+func (c *Config) defaultTrashRevisionRetention() time.Duration {
+	const defaultRetention = 365 * 24 * time.Hour
+
+	if retentionEnv, exists := os.LookupEnv("TRASH_REVISION_RETENTION"); exists {
+		retention, err := time.ParseDuration(retentionEnv)
+		if err == nil {
+			return retention
+		}
+	}
+	return defaultRetention
+}
+
+func (c *Config) defaultTrashPurgeInterval() time.Duration {
+	const defaultInterval = time.Hour
+
+	if intervalEnv, exists := os.LookupEnv("TRASH_PURGE_INTERVAL"); exists {
+		interval, err := time.ParseDuration(intervalEnv)
+		if err == nil {
+			return interval
+		}
+	}
+	return defaultInterval
+}
+
+func (c *Config) defaultSimilaritiesRefreshInterval() time.Duration {
+	const defaultInterval = time.Hour
+
+	if intervalEnv, exists := os.LookupEnv("SIMILARITIES_REFRESH_INTERVAL"); exists {
+		interval, err := time.ParseDuration(intervalEnv)
+		if err == nil {
+			return interval
+		}
+	}
+	return defaultInterval
+}
+
+func (c *Config) defaultStatsCacheTtl() time.Duration {
+	const defaultTtl = time.Minute
+
+	if ttlEnv, exists := os.LookupEnv("STATS_CACHE_TTL"); exists {
+		ttl, err := time.ParseDuration(ttlEnv)
+		if err == nil {
+			return ttl
+		}
+	}
+	return defaultTtl
+}
+
+func (c *Config) defaultImagesMaxBytes() int64 {
+	const defaultMaxBytes = 10 * 1_048_576
+
+	if maxBytesEnv, exists := os.LookupEnv("IMAGES_MAX_BYTES"); exists {
+		maxBytes, err := strconv.ParseInt(maxBytesEnv, 10, 64)
+		if err == nil {
+			return maxBytes
+		}
+	}
+	return defaultMaxBytes
+}
+
+func (c *Config) defaultStorageDir() string {
+	const defaultDir = "./media"
+
+	if dir, exists := os.LookupEnv("STORAGE_DIR"); exists {
+		return dir
+	}
+	return defaultDir
+}
+
+func (c *Config) defaultStorageUrl() string {
+	const defaultUrl = "/media"
+
+	if url, exists := os.LookupEnv("STORAGE_URL"); exists {
+		return url
+	}
+	return defaultUrl
+}
@@ -6,6 +6,7 @@ const (
 	MetricTimestamp                         = "timestamp"
 	MetricGoroutines                        = "goroutines"
 	MetricDatabase                          = "database"
+	MetricOutbox                            = "outbox"
 	MetricTotalRequestsReceived             = "total_requests_received"
 	MetricTotalResponsesSent                = "total_responses_sent"
 	MetricTotalProcessingTimeInMicroseconds = "total_processing_time_microseconds"
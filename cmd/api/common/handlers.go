@@ -6,10 +6,16 @@ import (
 
 // RouteHandlers hosts the handlers to be passed into the router.
 type RouteHandlers struct {
-	Error  ErrorHandler
-	Misc   MiscHandler
-	Movies MovieHandler
-	Users  UserHandler
+	Error       ErrorHandler
+	Misc        MiscHandler
+	Movies      MovieHandler
+	Genres      GenreHandler
+	Collections CollectionHandler
+	Series      SeriesHandler
+	Search      SearchHandler
+	Stats       StatsHandler
+	Emails      EmailHandler
+	Users       UserHandler
 }
 
 type ErrorHandler interface {
@@ -22,6 +28,9 @@ type ErrorHandler interface {
 	UnauthenticatedUser(ctx *gin.Context)
 	UnactivatedUser(ctx *gin.Context)
 	NotPermitted(ctx *gin.Context)
+	PreconditionFailed(ctx *gin.Context)
+	UnsupportedMediaType(ctx *gin.Context)
+	RequestTooLarge(ctx *gin.Context)
 }
 
 type MiscHandler interface {
@@ -35,6 +44,68 @@ type MovieHandler interface {
 	Create(ctx *gin.Context)
 	Update(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	Trash(ctx *gin.Context)
+	Restore(ctx *gin.Context)
+	Revisions(ctx *gin.Context)
+	DiffRevisions(ctx *gin.Context)
+	RevertRevision(ctx *gin.Context)
+	Translations(ctx *gin.Context)
+	PutTranslation(ctx *gin.Context)
+	DeleteTranslation(ctx *gin.Context)
+	PutPoster(ctx *gin.Context)
+	DeletePoster(ctx *gin.Context)
+	PutBackdrop(ctx *gin.Context)
+	DeleteBackdrop(ctx *gin.Context)
+	Import(ctx *gin.Context)
+	Export(ctx *gin.Context)
+	Lookup(ctx *gin.Context)
+	Similar(ctx *gin.Context)
+	Duplicates(ctx *gin.Context)
+	Merge(ctx *gin.Context)
+}
+
+type GenreHandler interface {
+	List(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Rename(ctx *gin.Context)
+	Merge(ctx *gin.Context)
+}
+
+type CollectionHandler interface {
+	List(ctx *gin.Context)
+	GetById(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	AddMovie(ctx *gin.Context)
+	RemoveMovie(ctx *gin.Context)
+	Reorder(ctx *gin.Context)
+}
+
+type SeriesHandler interface {
+	List(ctx *gin.Context)
+	GetById(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	PutSeason(ctx *gin.Context)
+	DeleteSeason(ctx *gin.Context)
+	PutEpisode(ctx *gin.Context)
+	DeleteEpisode(ctx *gin.Context)
+}
+
+type SearchHandler interface {
+	Search(ctx *gin.Context)
+}
+
+type StatsHandler interface {
+	Movies(ctx *gin.Context)
+}
+
+type EmailHandler interface {
+	Preview(ctx *gin.Context)
+	TestSend(ctx *gin.Context)
+	Webhook(ctx *gin.Context)
 }
 
 type UserHandler interface {
@@ -44,4 +115,8 @@ type UserHandler interface {
 	Authenticate(ctx *gin.Context)
 	CreatePasswordResetToken(ctx *gin.Context)
 	UpdatePassword(ctx *gin.Context)
+	Notifications(ctx *gin.Context)
+	UpdateNotifications(ctx *gin.Context)
+	Unsubscribe(ctx *gin.Context)
+	GetById(ctx *gin.Context)
 }
@@ -0,0 +1,220 @@
+package docs
+
+// ROUTES
+
+// swagger:route GET /admin/movies/duplicates admin listDuplicateMovies
+// List duplicate movies.
+// Returns the pairs of movies outside the trash which are likely to be duplicates, with the most similar first.
+// Movies are paired if their titles match once lowercased and stripped of punctuation and spaces and they share a year,
+// or if their titles are similar and they share a runtime.
+// Requires a user with the "movies:merge" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: duplicateMoviesResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// swagger:route POST /admin/movies/merge admin mergeMovies
+// Merge movies.
+// Merges the duplicates into the surviving movie in a single transaction, and returns the merged movie.
+// The surviving movie keeps its title, year and runtime, gains the genres, countries and external ids of the duplicates,
+// and takes over the other metadata, images, translations and collection entries it doesn't have.
+// The duplicates are then removed, and requests for them are redirected to the surviving movie.
+// A merge revision is recorded with the changes to the surviving movie.
+// Requires a user with the "movies:merge" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// swagger:route GET /admin/emails/{template}/preview admin previewEmail
+// Preview email.
+// Renders the email template with sample data, without sending it or wrapping it in the API response object.
+// The HTML format returns the HTML body, and the text format returns the subject followed by the plain text body.
+// The template is rendered in the default language if it isn't translated to the locale,
+// with the language rendered in the Content-Language header.
+// Requires a user with the "emails:manage" permission.
+//
+// Produces:
+//	- text/html
+//	- text/plain
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: previewEmailResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route POST /admin/emails/{template}/test admin testEmail
+// Send test email.
+// Renders the email template with sample data and sends it to the given address through the configured mail transport.
+// The email is sent immediately instead of being queued in the outbox, and isn't retried if sending fails.
+// Requires a user with the "emails:manage" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: testEmailResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+//	502: sendEmailError
+
+// swagger:route GET /admin/users/{id} admin getUser
+// Get user.
+// Returns the user with the suppression of their email address,
+// which stops emails from being sent to it after a hard bounce or spam complaint.
+// Requires a user with the "users:read" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: adminUserResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+
+// PARAMETERS
+
+// swagger:parameters listDuplicateMovies
+type listDuplicateMoviesQueries struct {
+	// Page number.
+	// minimum: 1
+	// maximum: 10_000_000
+	// in: query
+	Page int `json:"page"`
+
+	// Number of pairs per page.
+	// minimum: 1
+	// maximum: 100
+	// in: query
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters mergeMovies
+type mergeMoviesRequestBody struct {
+	// in:body
+	Body struct {
+		// Id of the movie to keep.
+		// required: true
+		// example: 2
+		SurvivorId *int `json:"survivor_id"`
+
+		// Ids of the movies to merge into the surviving movie.
+		// required: true
+		// maxItems: 50
+		// example: [5]
+		DuplicateIds []int `json:"duplicate_ids"`
+	}
+}
+
+// swagger:parameters previewEmail testEmail
+type emailTemplateParam struct {
+	// Name of the email template.
+	// in: path
+	// required: true
+	// enum: user_welcome,activation_token,reset_password
+	Template string `json:"template"`
+}
+
+// swagger:parameters previewEmail
+type previewEmailQueries struct {
+	// Format of the preview.
+	// in: query
+	// enum: html,text
+	// default: html
+	Format string `json:"format"`
+
+	// ISO 639-1 code of the language to render the template in.
+	// in: query
+	// default: en
+	Locale string `json:"locale"`
+}
+
+// swagger:parameters testEmail
+type testEmailRequestBody struct {
+	// in:body
+	Body struct {
+		// Address to send the test email to.
+		// required: true
+		// example: operator@example.com
+		Email *string `json:"email"`
+
+		// ISO 639-1 code of the language to render the template in.
+		// default: en
+		// example: fr
+		Locale *string `json:"locale"`
+	}
+}
+
+// swagger:parameters getUser
+type getUserParam struct {
+	// Id of the user.
+	// in: path
+	// required: true
+	Id int `json:"id"`
+}
+
+// RESPONSES
+
+// swagger:response adminUserResponse
+type adminUserResponseWrapper struct {
+	// in: body
+	Body adminUserResponse
+}
+
+// swagger:response duplicateMoviesResponse
+type duplicateMoviesResponseWrapper struct {
+	// in: body
+	Body []duplicateCandidateResponse
+}
+
+// swagger:response previewEmailResponse
+type previewEmailResponse struct {
+	// Language the template was rendered in.
+	// example: en
+	ContentLanguage string `json:"Content-Language"`
+
+	// in: body
+	Body string
+}
+
+// swagger:response testEmailResponse
+type testEmailResponse struct {
+	// in: body
+	Body struct {
+		// example: test email sent successfully
+		Message string `json:"message"`
+	}
+}
+
+// A SendEmailError is returned when the mail transport fails to send the test email.
+// swagger:response sendEmailError
+type sendEmailError struct {
+	// in: body
+	Body struct {
+		genericType
+
+		// Required: true
+		// Example: {"message": "the email could not be sent: dial tcp: connection refused"}
+		Data map[string]string `json:"data"`
+	}
+}
@@ -0,0 +1,244 @@
+package docs
+
+// ROUTES
+
+// swagger:route GET /collections/ collections listCollections
+// List collections.
+// Returns the franchises, the public lists and the lists of the current user, without their movies.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: collectionsResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// swagger:route POST /collections/ collections createCollection
+// Create collection.
+// Creates a franchise or a list, and returns it.
+// Lists are owned by the current user, while franchises are always public and require a user with the "movies:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	201: collectionResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// swagger:route GET /collections/{id} collections getCollection
+// Get collection.
+// Returns the collection with the given id and its movies in order.
+// Private lists of other users are not found.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: collectionResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+
+// swagger:route PATCH /collections/{id} collections updateCollection
+// Update collection.
+// Changes the name, description and visibility of the collection with the given id.
+// The kind of a collection can't be changed.
+// Lists can only be changed by their owners, and franchises by users with the "movies:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: collectionResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route DELETE /collections/{id} collections deleteCollection
+// Delete collection.
+// Deletes the collection with the given id. The movies in it are left intact.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: deleteCollectionResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+
+// swagger:route POST /collections/{id}/movies collections addCollectionMovie
+// Add movie to collection.
+// Inserts a movie into the collection with the given id at the requested position, or at the end if there is none.
+// Movies at or after the position are moved back by one.
+// Returns the updated collection.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: collectionResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route DELETE /collections/{id}/movies/{movie_id} collections removeCollectionMovie
+// Remove movie from collection.
+// Removes a movie from the collection with the given id, closing the gap in positions.
+// Returns the updated collection.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: collectionResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+
+// swagger:route PUT /collections/{id}/order collections reorderCollection
+// Reorder collection.
+// Moves the given movies to the start of the collection with the given id in the given order,
+// followed by the remaining movies in their current order.
+// Returns the updated collection.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: collectionResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// PARAMETERS
+
+// swagger:parameters listCollections
+type listCollectionQueries struct {
+	// Possible values: franchise | list
+	// in: query
+	Kind string `json:"kind"`
+
+	// Id of the owner of the lists.
+	// in: query
+	UserId int `json:"user_id"`
+
+	// Page number.
+	// minimum: 1
+	// maximum: 10_000_000
+	// in: query
+	Page int `json:"page"`
+
+	// Number of collections per page.
+	// minimum: 1
+	// maximum: 100
+	// in: query
+	Limit int `json:"limit"`
+
+	// Possible values: id | name | updated_at
+	// Sort values can be prefixed with a "-" to denote descending order.
+	// in: query
+	Sort string `json:"sort"`
+}
+
+// swagger:parameters getCollection updateCollection deleteCollection addCollectionMovie removeCollectionMovie reorderCollection
+type collectionIdPath struct {
+	// Collection ID.
+	// in:path
+	Id int `json:"id"`
+}
+
+// swagger:parameters removeCollectionMovie
+type collectionMovieIdPath struct {
+	// Movie ID.
+	// in:path
+	MovieId int `json:"movie_id"`
+}
+
+// swagger:parameters createCollection updateCollection
+type collectionRequestBody struct {
+	// in:body
+	Body struct {
+		// Required when creating, and can't be changed.
+		// Possible values: franchise | list
+		// example: list
+		Kind *string `json:"kind"`
+
+		// Required when creating.
+		// maxLength: 200
+		// example: Weekend picks
+		Name *string `json:"name"`
+
+		// maxLength: 2000
+		// example: Movies to watch this weekend.
+		Description *string `json:"description"`
+
+		// Must be true for a franchise.
+		// Defaults to false for a list.
+		// example: false
+		Public *bool `json:"public"`
+	}
+}
+
+// swagger:parameters addCollectionMovie
+type collectionMovieRequestBody struct {
+	// in:body
+	Body struct {
+		// required: true
+		// example: 2
+		MovieId *int `json:"movie_id"`
+
+		// Position to insert the movie at, starting from 1.
+		// Defaults to the end of the collection.
+		// example: 1
+		Position *int `json:"position"`
+	}
+}
+
+// swagger:parameters reorderCollection
+type collectionOrderRequestBody struct {
+	// in:body
+	Body struct {
+		// Ids of movies in the collection in their new order.
+		// required: true
+		// maxItems: 1000
+		// example: [2, 1]
+		MovieIds []int `json:"movie_ids"`
+	}
+}
+
+// RESPONSES
+
+// swagger:response collectionResponse
+type collectionResponseWrapper struct {
+	// in: body
+	Body struct {
+		collectionResponse
+	}
+}
+
+// swagger:response collectionsResponse
+type collectionsResponseWrapper struct {
+	// in: body
+	Body []collectionResponse
+}
+
+// swagger:response deleteCollectionResponse
+type deleteCollectionResponse struct {
+	// in: body
+	Body struct {
+		// example: collection deleted successfully
+		Message string `json:"message"`
+	}
+}
@@ -102,6 +102,45 @@ type permissionError struct {
 	}
 }
 
+// A PreconditionFailedError is returned when the If-Match header of the request doesn't match the current entity tag of the resource.
+// swagger:response preconditionFailedError
+type preconditionFailedError struct {
+	// in: body
+	Body struct {
+		genericType
+
+		// Required: true
+		// Example: {"message": "the resource has been modified since it was last fetched, please fetch it again and retry"}
+		Data map[string]string `json:"data"`
+	}
+}
+
+// A RequestTooLargeError is returned when the request body exceeds the size limit of the route.
+// swagger:response requestTooLargeError
+type requestTooLargeError struct {
+	// in: body
+	Body struct {
+		genericType
+
+		// Required: true
+		// Example: {"message": "request body is too large"}
+		Data map[string]string `json:"data"`
+	}
+}
+
+// An UnsupportedMediaTypeError is returned when the content type of the request body isn't accepted by the route.
+// swagger:response unsupportedMediaTypeError
+type unsupportedMediaTypeError struct {
+	// in: body
+	Body struct {
+		genericType
+
+		// Required: true
+		// Example: {"message": "the content type of the request body is not supported"}
+		Data map[string]string `json:"data"`
+	}
+}
+
 // A ValidationError is returned when the required input fails validation.
 // swagger:response validationError
 type validationError struct {
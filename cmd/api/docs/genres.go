@@ -0,0 +1,111 @@
+package docs
+
+// ROUTES
+
+// swagger:route GET /genres/ genres listGenres
+// List genres.
+// Returns all genres ordered by name, with the number of movies in each.
+// Movies can only be given genres from this list.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: genresResponse
+//	401: unauthenticatedError
+//	403: permissionError
+
+// swagger:route POST /genres/ genres createGenre
+// Create genre.
+// Creates a genre with the given name.
+// Names are compared by their slugs, so a genre can't be created if a spelling of it already exists.
+// Requires a user with the "genres:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	201: genreResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// swagger:route PATCH /genres/{id} genres renameGenre
+// Rename genre.
+// Changes the name of the genre with the given id, along with the genre of the movies which have it.
+// Each changed movie gets a new version with a recorded revision.
+// Requires a user with the "genres:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: genreResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route POST /genres/{id}/merge genres mergeGenre
+// Merge genre.
+// Replaces the genre with the given id by another genre in all movies, and deletes it.
+// Each changed movie gets a new version with a recorded revision.
+// Returns the genre merged into.
+// Requires a user with the "genres:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: genreResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// PARAMETERS
+
+// swagger:parameters renameGenre mergeGenre
+type genreIdPath struct {
+	// Genre ID.
+	// in:path
+	Id int `json:"id"`
+}
+
+// swagger:parameters createGenre renameGenre
+type genreRequestBody struct {
+	// in:body
+	Body struct {
+		// example: Science Fiction
+		Name *string `json:"name"`
+	}
+}
+
+// swagger:parameters mergeGenre
+type mergeGenreRequestBody struct {
+	// in:body
+	Body struct {
+		// Id of the genre to merge into.
+		// example: 4
+		Into *int `json:"into"`
+	}
+}
+
+// RESPONSES
+
+// swagger:response genreResponse
+type genreResponseWrapper struct {
+	// in: body
+	Body struct {
+		genreResponse
+	}
+}
+
+// swagger:response genresResponse
+type genresResponseWrapper struct {
+	// in: body
+	Body []genreResponse
+}
@@ -65,8 +65,120 @@ type movieResponse struct {
 	// example: ["adventure", "fantasy"]
 	Genres []string `json:"genres"`
 
+	// example: Harry Potter and the Philosopher's Stone
+	OriginalTitle string `json:"original_title,omitempty"`
+
+	// example: en
+	OriginalLanguage string `json:"original_language,omitempty"`
+
+	// example: ["GB", "US"]
+	Countries []string `json:"countries,omitempty"`
+
+	ReleaseDates []releaseDate `json:"release_dates,omitempty"`
+
+	Certifications []certification `json:"certifications,omitempty"`
+
+	Synopsis string `json:"synopsis,omitempty"`
+
+	// example: Let the magic begin.
+	Tagline string `json:"tagline,omitempty"`
+
+	// Ids of the movie in external databases, keyed by source.
+	// example: {"imdb": "tt0241527", "tmdb": "671", "wikidata": "Q102438"}
+	ExternalIds map[string]string `json:"external_ids,omitempty"`
+
+	// It is only present for movies with a poster.
+	Poster *movieImage `json:"poster,omitempty"`
+
+	// It is only present for movies with a backdrop.
+	Backdrop *movieImage `json:"backdrop,omitempty"`
+
+	// Language of the translated title, synopsis and tagline.
+	// It is only present for translated movies.
+	// example: fr
+	Translation string `json:"translation,omitempty"`
+
+	// Collections the movie belongs to.
+	// It is only present if the collections are included.
+	Collections []movieCollection `json:"collections,omitempty"`
+
 	// example: 1
 	Version int `json:"version"`
+
+	// Time the movie was moved to the trash.
+	// It is only present for movies in the trash.
+	// example: 2022-04-10T10:00:00Z
+	Deleted time.Time `json:"deleted_at,omitempty"`
+}
+
+// swagger:model MovieImage
+type movieImage struct {
+	// URL of the image as uploaded.
+	// example: /media/movies/1/poster/3f2a9c1d0b8e7a65/original.png
+	Url string `json:"url"`
+
+	// example: image/png
+	ContentType string `json:"content_type"`
+
+	// example: 1000
+	Width int `json:"width"`
+
+	// example: 1500
+	Height int `json:"height"`
+
+	// JPEG thumbnails in increasing sizes, limited to those narrower than the image.
+	Thumbnails []movieThumbnail `json:"thumbnails"`
+}
+
+// swagger:model MovieThumbnail
+type movieThumbnail struct {
+	// Name of the thumbnail size, derived from its width.
+	// example: w185
+	Size string `json:"size"`
+
+	// example: /media/movies/1/poster/3f2a9c1d0b8e7a65/w185.jpg
+	Url string `json:"url"`
+
+	// example: 185
+	Width int `json:"width"`
+
+	// example: 278
+	Height int `json:"height"`
+}
+
+// swagger:model ReleaseDate
+type releaseDate struct {
+	// example: GB
+	Country string `json:"country"`
+
+	// example: 2001-11-16
+	Date string `json:"date"`
+}
+
+// swagger:model Certification
+type certification struct {
+	// example: GB
+	Country string `json:"country"`
+
+	// example: PG
+	Rating string `json:"rating"`
+}
+
+// swagger:model MovieTranslation
+type movieTranslationResponse struct {
+	// example: fr
+	Language string `json:"language"`
+
+	// example: Harry Potter à l'école des sorciers
+	Title string `json:"title"`
+
+	Synopsis string `json:"synopsis,omitempty"`
+
+	// example: Laissez la magie commencer.
+	Tagline string `json:"tagline,omitempty"`
+
+	// example: 2022-04-10T10:00:00Z
+	Updated time.Time `json:"updated_at"`
 }
 
 // swagger:model User
@@ -86,6 +198,31 @@ type userResponse struct {
 	// example: true
 	Activated bool `json:"activated"`
 
+	// ISO 639-1 code of the language emails are sent in.
+	// example: en
+	Language string `json:"language"`
+
+	Created time.Time `json:"created"`
+}
+
+// swagger:model AdminUser
+type adminUserResponse struct {
+	userResponse
+
+	// Suppression of the user's email address, or null if emails are sent to it.
+	Suppression *emailSuppressionResponse `json:"suppression"`
+}
+
+// swagger:model EmailSuppression
+type emailSuppressionResponse struct {
+	// Either "bounce" for addresses emails bounced from permanently, or "complaint" for addresses which reported an email as spam.
+	// example: bounce
+	Reason string `json:"reason"`
+
+	// Diagnostic of the bounce or complaint.
+	// example: 550 5.1.1 user unknown
+	Detail string `json:"detail"`
+
 	Created time.Time `json:"created"`
 }
 
@@ -96,3 +233,318 @@ type tokenResponse struct {
 
 	Expires time.Time `json:"expires"`
 }
+
+// swagger:model MovieRevision
+type movieRevisionResponse struct {
+	// example: 2
+	Version int `json:"version"`
+
+	// Possible values: create | update | delete | restore | merge
+	// example: update
+	Action string `json:"action"`
+
+	// Snapshot of the movie after the action.
+	Movie movieResponse `json:"movie"`
+
+	// Mapping of fields changed by an update or merge to their previous and new values.
+	// It is omitted for other actions.
+	// example: {"runtime": {"from": 140, "to": 160}}
+	Changes map[string]movieChange `json:"changes,omitempty"`
+
+	// Id of the acting user, omitted if unknown.
+	// example: 1
+	UserId int `json:"user_id,omitempty"`
+
+	// example: 2022-04-10T10:00:00Z
+	Created time.Time `json:"created_at"`
+}
+
+// swagger:model MovieChange
+type movieChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// swagger:model Genre
+type genreResponse struct {
+	// example: 1
+	Id int `json:"id"`
+
+	// Lowercase name with its words joined by hyphens, shared by all spellings of the genre.
+	// example: science-fiction
+	Slug string `json:"slug"`
+
+	// example: Science Fiction
+	Name string `json:"name"`
+
+	// Number of movies outside the trash with the genre.
+	// example: 12
+	MovieCount int `json:"movie_count"`
+}
+
+// swagger:model Collection
+type collectionResponse struct {
+	// example: 1
+	Id int `json:"id"`
+
+	// Either "franchise" for an official series of movies, or "list" for a list curated by a user.
+	// example: franchise
+	Kind string `json:"kind"`
+
+	// example: The Lord of the Rings
+	Name string `json:"name"`
+
+	// example: The adaptations of the novels by J. R. R. Tolkien.
+	Description string `json:"description"`
+
+	// Franchises are always public, while private lists are only visible to their owners.
+	// example: true
+	Public bool `json:"public"`
+
+	// Id of the owner of a list, and null for franchises.
+	// example: 1
+	UserId *int `json:"user_id"`
+
+	// Number of movies outside the trash in the collection.
+	// example: 3
+	MovieCount int `json:"movie_count"`
+
+	// Movies outside the trash in the collection, ordered by position.
+	// It is only present for a single collection.
+	Entries []collectionEntry `json:"entries,omitempty"`
+
+	// example: 2022-04-10T10:00:00Z
+	Created time.Time `json:"created_at"`
+
+	// example: 2022-04-10T10:00:00Z
+	Updated time.Time `json:"updated_at"`
+}
+
+// swagger:model CollectionEntry
+type collectionEntry struct {
+	// Position of the movie in the collection, starting from 1.
+	// Positions of movies in the trash are skipped.
+	// example: 1
+	Position int `json:"position"`
+
+	// The movie with only its id, title, year, runtime and genres.
+	Movie movieResponse `json:"movie"`
+}
+
+// swagger:model MovieCollection
+type movieCollection struct {
+	// example: 1
+	Id int `json:"id"`
+
+	// example: franchise
+	Kind string `json:"kind"`
+
+	// example: The Lord of the Rings
+	Name string `json:"name"`
+
+	// Position of the movie in the collection.
+	// example: 2
+	Position int `json:"position"`
+}
+
+// swagger:model Series
+type seriesResponse struct {
+	// example: 1
+	Id int `json:"id"`
+
+	// example: Severance
+	Title string `json:"title"`
+
+	// example: 2022
+	StartYear int `json:"start_year"`
+
+	// Year of the final season, and null for running series.
+	// example: 2025
+	EndYear *int `json:"end_year"`
+
+	// example: ["Drama", "Thriller"]
+	Genres []string `json:"genres"`
+
+	// example: Office workers have their memories surgically divided between their work and personal lives.
+	Synopsis string `json:"synopsis"`
+
+	// example: 2
+	SeasonCount int `json:"season_count"`
+
+	// example: 19
+	EpisodeCount int `json:"episode_count"`
+
+	// Seasons ordered by number, with their episodes.
+	// It is only present for a single series.
+	Seasons []seasonResponse `json:"seasons,omitempty"`
+
+	// example: 1
+	Version int `json:"version"`
+
+	// example: 2022-04-10T10:00:00Z
+	Created time.Time `json:"created_at"`
+
+	// example: 2022-04-10T10:00:00Z
+	Updated time.Time `json:"updated_at"`
+}
+
+// swagger:model Season
+type seasonResponse struct {
+	// example: 1
+	Number int `json:"number"`
+
+	// example: Season 1
+	Title string `json:"title"`
+
+	// Date of the premiere, and null if it is unknown.
+	// example: 2022-02-18
+	AirDate *string `json:"air_date"`
+
+	// Episodes ordered by number.
+	Episodes []episodeResponse `json:"episodes"`
+}
+
+// swagger:model Episode
+type episodeResponse struct {
+	// example: 1
+	Number int `json:"number"`
+
+	// example: Good News About Hell
+	Title string `json:"title"`
+
+	// Runtime in minutes.
+	// example: 57
+	Runtime int `json:"runtime"`
+
+	// example: 2022-02-18
+	AirDate *string `json:"air_date"`
+
+	// example: Mark is promoted to lead of his department.
+	Synopsis string `json:"synopsis"`
+}
+
+// swagger:model SearchResult
+type searchResultResponse struct {
+	// Either "movie" or "series".
+	// example: series
+	Type string `json:"type"`
+
+	// Id of the movie or series.
+	// example: 1
+	Id int `json:"id"`
+
+	// example: Severance
+	Title string `json:"title"`
+
+	// Release year of a movie, or start year of a series.
+	// example: 2022
+	Year int `json:"year"`
+
+	// example: ["Drama", "Thriller"]
+	Genres []string `json:"genres"`
+}
+
+// swagger:model SimilarMovie
+type similarMovieResponse struct {
+	// Similarity to the requested movie, between 0 and 1.
+	// example: 0.82
+	Score float64 `json:"score"`
+
+	Movie movieResponse `json:"movie"`
+}
+
+// swagger:model DuplicateCandidate
+type duplicateCandidateResponse struct {
+	// Possible values: same_title_year | similar_title
+	// example: same_title_year
+	Reason string `json:"reason"`
+
+	// Trigram similarity of the titles, between 0 and 1.
+	// example: 0.75
+	Similarity float64 `json:"similarity"`
+
+	// The pair of movies, in order of their ids.
+	Movies []movieResponse `json:"movies"`
+}
+
+// swagger:model MovieStats
+type movieStatsResponse struct {
+	// Number of movies.
+	// example: 120
+	Total int `json:"total"`
+
+	// Number of movies with each genre, with the most common first.
+	Genres []genreCount `json:"genres"`
+
+	// Number of movies released in each year, ordered by year.
+	Years []yearCount `json:"years"`
+
+	// Number of movies released in each decade, ordered by decade.
+	Decades []decadeCount `json:"decades"`
+
+	Runtime runtimeStats `json:"runtime"`
+
+	// Most recently added movies, latest first.
+	Recent []movieResponse `json:"recent"`
+
+	// Time the statistics were computed.
+	// example: 2022-04-10T10:00:00Z
+	Generated time.Time `json:"generated_at"`
+}
+
+// swagger:model GenreCount
+type genreCount struct {
+	// example: Drama
+	Genre string `json:"genre"`
+
+	// example: 42
+	Count int `json:"count"`
+}
+
+// swagger:model YearCount
+type yearCount struct {
+	// example: 2022
+	Year int `json:"year"`
+
+	// example: 12
+	Count int `json:"count"`
+}
+
+// swagger:model DecadeCount
+type decadeCount struct {
+	// First year of the decade.
+	// example: 2020
+	Decade int `json:"decade"`
+
+	// example: 30
+	Count int `json:"count"`
+}
+
+// swagger:model RuntimeStats
+type runtimeStats struct {
+	// Sum of the runtimes in minutes.
+	// example: 13440
+	Total int `json:"total"`
+
+	// example: 112
+	Mean float64 `json:"mean"`
+
+	// example: 81
+	Min int `json:"min"`
+
+	// Smallest runtime of at least 25% of the movies at or below it.
+	// example: 98
+	P25 int `json:"p25"`
+
+	// example: 109
+	Median int `json:"median"`
+
+	// example: 124
+	P75 int `json:"p75"`
+
+	// example: 141
+	P90 int `json:"p90"`
+
+	// example: 201
+	Max int `json:"max"`
+}
@@ -5,7 +5,10 @@ package docs
 // swagger:route POST /movies/ movies createMovie
 // Create movie.
 // Creates a movie with the given data in the request body.
-// All fields in the request body are required.
+// The title, year, runtime and genres are required, while the other fields are optional.
+// Genres must be amongst the listed genres, and are stored with their canonical names.
+// Countries use ISO 3166-1 alpha-2 codes, and the original language uses an ISO 639-1 code.
+// External ids already registered to another movie are rejected with a validation error.
 // Requires a user with the "movies:write" permission.
 //
 // Security:
@@ -18,36 +21,123 @@ package docs
 //	403: permissionError
 //  422: validationError
 
+// swagger:route POST /movies/import movies importMovies
+// Import movies.
+// Creates movies in bulk from a CSV or newline-delimited JSON request body.
+// A CSV body must begin with a header naming its columns out of title, year, runtime and genres,
+// with the genres of each record separated by "|".
+// The id and version columns of a CSV export are ignored, so exports can be imported as new movies.
+// Each record is validated as in movie creation, and invalid records are skipped and reported by their row.
+// Records with external ids registered to another movie or used by an earlier record are also skipped.
+// Valid records are inserted in batches, with nothing inserted in a dry run.
+// The records of a batch which fails to be inserted are reported by their row as failed, and the import continues.
+// An error reading the body stops the import, and is returned along with the report of the movies imported before it.
+// Requires a user with the "movies:write" permission.
+//
+// Consumes:
+//	- text/csv
+//	- application/x-ndjson
+//	- application/jsonl
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: importMoviesResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	413: requestTooLargeError
+//	415: unsupportedMediaTypeError
+
 // swagger:route GET /movies/ movies listMovies
 // List movies.
 // Returns a list of movies satisfying the query parameters.
+// The title is searched for in both the original and translated titles.
+// Each movie is translated into the language of the Accept-Language header which best matches it, if any.
+// Unknown fields and relations are rejected with a validation error.
+// The response carries a weak ETag of the list and the Last-Modified time of its latest movie.
 //
 // Security:
 //	bearer:
 //
 // Responses:
 //	200: moviesResponse
+//	304: notModifiedResponse
 //	401: unauthenticatedError
 //  422: validationError
 
+// swagger:route GET /movies/export movies exportMovies
+// Export movies.
+// Streams all the movies satisfying the query parameters as an attachment, without pagination.
+// The CSV format has a header row of id, title, year, runtime, genres and version, with genres separated by "|".
+// The NDJSON format has a movie object per line, and the JSON format is a single array of movie objects.
+// Neither format is wrapped in the API response object.
+// Requires a user with the "movies:export" permission.
+//
+// Produces:
+//	- text/csv
+//	- application/x-ndjson
+//	- application/json
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: exportMoviesResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// swagger:route GET /movies/lookup movies lookupMovie
+// Look up movie.
+// Returns the movie registered with the given external id, outside the trash.
+// The movie is translated as in getMovie, and its canonical URL is in the Content-Location header.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieResponse
+//	401: unauthenticatedError
+//	404: notFoundError
+//	422: validationError
+
 // swagger:route GET /movies/{id} movies getMovie
 // Get movie.
 // Returns the details of the movie with the given id.
+// The movie is translated into the language of the Accept-Language header which best matches it, if any,
+// with the language of the translation in the Content-Language header.
+// Unknown fields and relations are rejected with a validation error.
+// The response carries a strong ETag derived from the movie id and version,
+// or a weak ETag of the movie if it is translated.
+// Requests for movies which were merged into other movies are redirected to the surviving movies.
 //
 // Security:
 //	bearer:
 //
 // Responses:
 //	200: movieResponse
+//	301: movedPermanentlyResponse
+//	304: notModifiedResponse
 //	401: unauthenticatedError
 //	404: notFoundError
+//	422: validationError
 
 // swagger:route PATCH /movies/{id} movies updateMovie
 // Update movie.
 // Updates the details of the movie with the given id with those in the request body.
 // Fields in the request body are optional.
+// The body can alternatively be a JSON merge patch (RFC 7396) with the "application/merge-patch+json" content type,
+// or a JSON patch (RFC 6902) with the "application/json-patch+json" content type supporting the add, remove, replace and test operations.
+// Patches are applied to the current movie, and the result must satisfy the same validation as a created movie.
 // Requires a user with the "movies:write" permission.
 //
+// Consumes:
+//	- application/json
+//	- application/merge-patch+json
+//	- application/json-patch+json
+//
 // Security:
 //	bearer:
 //
@@ -58,11 +148,13 @@ package docs
 //	403: permissionError
 //	404: notFoundError
 //	409: editConflictError
+//	412: preconditionFailedError
 //  422: validationError
 
 // swagger:route DELETE /movies/{id} movies deleteMovie
 // Delete movie.
-// Deletes the movie with the given id.
+// Moves the movie with the given id to the trash.
+// Movies in the trash are excluded from other routes, and are permanently purged after the configured retention period.
 // Requires a user with the "movies:write" permission.
 //
 // Security:
@@ -73,10 +165,234 @@ package docs
 //	401: unauthenticatedError
 //	403: permissionError
 //	404: notFoundError
+//	409: editConflictError
+//	412: preconditionFailedError
+
+// swagger:route GET /movies/trash movies listTrash
+// List trash.
+// Returns a list of the deleted movies which haven't been purged yet, with their deletion times.
+// Requires a user with the "movies:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: moviesResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// swagger:route POST /movies/{id}/restore movies restoreMovie
+// Restore movie.
+// Moves the deleted movie with the given id out of the trash.
+// Requires a user with the "movies:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+
+// swagger:route GET /movies/{id}/revisions movies listMovieRevisions
+// List movie revisions.
+// Returns the revisions recorded for every creation, update, deletion and restoration of the movie with the given id,
+// each with a snapshot of the movie, the changes made by updates, and the id of the acting user.
+// Revisions of movies in the trash are included.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieRevisionsResponse
+//	401: unauthenticatedError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route GET /movies/{id}/revisions/diff movies diffMovieRevisions
+// Diff movie revisions.
+// Returns the changes of the movie with the given id between two versions.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieDiffResponse
+//	401: unauthenticatedError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route POST /movies/{id}/revisions/{version}/revert movies revertMovieRevision
+// Revert movie.
+// Updates the movie with the given id to its state at the given version.
+// The reverted state must satisfy the same validation as an updated movie, and is recorded as a new version.
+// Requires a user with the "movies:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	409: editConflictError
+//	412: preconditionFailedError
+//	422: validationError
+
+// swagger:route GET /movies/{id}/similar movies listSimilarMovies
+// List similar movies.
+// Returns the movies most similar to the movie with the given id, with the most similar first.
+// Movies are scored by the overlap of their genres, the proximity of their decades,
+// the similarity of their runtimes and the similarity of their titles.
+// Only movies sharing a genre or with similar titles are scored.
+// The scores are recomputed periodically, so recently added or changed movies may be missing or outdated.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: similarMoviesResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route GET /movies/{id}/translations movies listMovieTranslations
+// List movie translations.
+// Returns the translations of the movie with the given id, ordered by language.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieTranslationsResponse
+//	401: unauthenticatedError
+//	404: notFoundError
+
+// swagger:route PUT /movies/{id}/translations/{language} movies putMovieTranslation
+// Create or replace movie translation.
+// Saves the translation of the movie with the given id in the given language, replacing any existing one.
+// The language is stored as its canonical BCP 47 tag.
+// Responds with a 201 status if the translation is created.
+// Requires a user with the "movies:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieTranslationResponse
+//	201: movieTranslationResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route DELETE /movies/{id}/translations/{language} movies deleteMovieTranslation
+// Delete movie translation.
+// Removes the translation of the movie with the given id in the given language.
+// Requires a user with the "movies:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: deleteMovieTranslationResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route PUT /movies/{id}/poster movies putPoster
+// Upload movie poster.
+// Replaces the poster of the movie with the given id by the JPEG or PNG image in the "image" field of a multipart form.
+// The format is detected from the image content rather than its declared type, and images must have at most 40 megapixels.
+// JPEG thumbnails 185, 342 and 780 pixels wide are generated for those widths smaller than the image's.
+// The files of the replaced poster are deleted, and the movie's version is incremented.
+// Requires a user with the "movies:write" permission.
+//
+// Consumes:
+//	- multipart/form-data
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	409: editConflictError
+//	412: preconditionFailedError
+//	413: requestTooLargeError
+//	415: unsupportedMediaTypeError
+//	422: validationError
+
+// swagger:route DELETE /movies/{id}/poster movies deletePoster
+// Delete movie poster.
+// Removes the poster of the movie with the given id along with its files, and increments the movie's version.
+// Requires a user with the "movies:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	409: editConflictError
+//	412: preconditionFailedError
+
+// swagger:route PUT /movies/{id}/backdrop movies putBackdrop
+// Upload movie backdrop.
+// Replaces the backdrop of the movie with the given id by the JPEG or PNG image in the "image" field of a multipart form.
+// The format is detected from the image content rather than its declared type, and images must have at most 40 megapixels.
+// JPEG thumbnails 300, 780 and 1280 pixels wide are generated for those widths smaller than the image's.
+// The files of the replaced backdrop are deleted, and the movie's version is incremented.
+// Requires a user with the "movies:write" permission.
+//
+// Consumes:
+//	- multipart/form-data
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	409: editConflictError
+//	412: preconditionFailedError
+//	413: requestTooLargeError
+//	415: unsupportedMediaTypeError
+//	422: validationError
+
+// swagger:route DELETE /movies/{id}/backdrop movies deleteBackdrop
+// Delete movie backdrop.
+// Removes the backdrop of the movie with the given id along with its files, and increments the movie's version.
+// Requires a user with the "movies:write" permission.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	409: editConflictError
+//	412: preconditionFailedError
 
 // PARAMETERS
 
-// swagger:parameters getMovie deleteMovie
+// swagger:parameters getMovie deleteMovie restoreMovie listMovieRevisions diffMovieRevisions revertMovieRevision listSimilarMovies listMovieTranslations putPoster deletePoster putBackdrop deleteBackdrop
 type movieIdPath struct {
 	// Movie ID.
 	// in:path
@@ -97,8 +413,39 @@ type movieRequestBody struct {
 		// example: 200
 		Runtime *int `json:"runtime"`
 
-		// example: ["action", "western"]
+		// Names of listed genres, matched regardless of case and punctuation.
+		// example: ["Action", "Western"]
 		Genres []string `json:"genres"`
+
+		// example: Per qualche dollaro in più
+		OriginalTitle *string `json:"original_title"`
+
+		// ISO 639-1 language code.
+		// example: it
+		OriginalLanguage *string `json:"original_language"`
+
+		// ISO 3166-1 alpha-2 country codes.
+		// example: ["IT", "ES", "DE"]
+		Countries []string `json:"countries"`
+
+		// Release dates formatted as YYYY-MM-DD, with at most one per country.
+		ReleaseDates []releaseDate `json:"release_dates"`
+
+		// Certifications with at most one per country.
+		// Ratings of the US, GB, DE, FR and AU must be amongst those of their certification systems.
+		Certifications []certification `json:"certifications"`
+
+		// maxLength: 5000
+		Synopsis *string `json:"synopsis"`
+
+		// maxLength: 300
+		// example: The man with no name is back.
+		Tagline *string `json:"tagline"`
+
+		// Ids of the movie in external databases, keyed by source (imdb, tmdb or wikidata).
+		// Each id can only be registered to one movie.
+		// example: {"imdb": "tt0059578", "tmdb": "938"}
+		ExternalIds map[string]string `json:"external_ids"`
 	}
 }
 
@@ -113,6 +460,92 @@ type listMovieQueries struct {
 	// in: query
 	Genres []string `json:"genres"`
 
+	// ISO 639-1 code of the original language.
+	// Example: language=en
+	// in: query
+	Language string `json:"language"`
+
+	// Comma-separated list of ISO 3166-1 alpha-2 country codes, all of which the movies must have.
+	// Example: countries=US,GB
+	// in: query
+	Countries []string `json:"countries"`
+
+	// Country code and rating separated by a colon.
+	// Example: certification=US:PG-13
+	// in: query
+	Certification string `json:"certification"`
+
+	// Earliest release date in any country, formatted as YYYY-MM-DD.
+	// in: query
+	ReleasedFrom string `json:"released_from"`
+
+	// Latest release date in any country, formatted as YYYY-MM-DD.
+	// in: query
+	ReleasedTo string `json:"released_to"`
+
+	// Page number.
+	// minimum: 1
+	// maximum: 10_000_000
+	// in: query
+	Page int `json:"page"`
+
+	// Number of movies per page.
+	// minimum: 1
+	// maximum: 100
+	// in: query
+	Limit int `json:"limit"`
+
+	// Possible values: id | title | year | runtime
+	// Sort values can be prefixed with a "-" to denote descending order.
+	// in: query
+	Sort string `json:"sort"`
+}
+
+// swagger:parameters listMovieRevisions
+type listMovieRevisionsQueries struct {
+	// Page number.
+	// minimum: 1
+	// maximum: 10_000_000
+	// in: query
+	Page int `json:"page"`
+
+	// Number of revisions per page.
+	// minimum: 1
+	// maximum: 100
+	// in: query
+	Limit int `json:"limit"`
+
+	// Possible values: version
+	// Sort values can be prefixed with a "-" to denote descending order.
+	// Defaults to -version.
+	// in: query
+	Sort string `json:"sort"`
+}
+
+// swagger:parameters diffMovieRevisions
+type diffMovieRevisionsQueries struct {
+	// Version to compare from.
+	// required: true
+	// minimum: 1
+	// in: query
+	From int `json:"from"`
+
+	// Version to compare to.
+	// required: true
+	// minimum: 1
+	// in: query
+	To int `json:"to"`
+}
+
+// swagger:parameters revertMovieRevision
+type movieVersionPath struct {
+	// Version to revert to.
+	// in: path
+	Version int `json:"version"`
+}
+
+// swagger:parameters listTrash
+type listTrashQueries struct {
 	// Page number.
 	// minimum: 1
 	// maximum: 10_000_000
@@ -125,10 +558,168 @@ type listMovieQueries struct {
 	// in: query
 	Limit int `json:"limit"`
 
+	// Possible values: id | title | deleted_at
+	// Sort values can be prefixed with a "-" to denote descending order.
+	// Defaults to -deleted_at.
+	// in: query
+	Sort string `json:"sort"`
+}
+
+// swagger:parameters exportMovies
+type exportMoviesQueries struct {
+	// Movie title (partial or complete).
+	// in: query
+	Title string `json:"title"`
+
+	// Comma-separated list of movie genres.
+	// Example: genres=action,comedy
+	// in: query
+	Genres []string `json:"genres"`
+
+	// ISO 639-1 code of the original language.
+	// Example: language=en
+	// in: query
+	Language string `json:"language"`
+
+	// Comma-separated list of ISO 3166-1 alpha-2 country codes, all of which the movies must have.
+	// Example: countries=US,GB
+	// in: query
+	Countries []string `json:"countries"`
+
+	// Country code and rating separated by a colon.
+	// Example: certification=US:PG-13
+	// in: query
+	Certification string `json:"certification"`
+
+	// Earliest release date in any country, formatted as YYYY-MM-DD.
+	// in: query
+	ReleasedFrom string `json:"released_from"`
+
+	// Latest release date in any country, formatted as YYYY-MM-DD.
+	// in: query
+	ReleasedTo string `json:"released_to"`
+
 	// Possible values: id | title | year | runtime
 	// Sort values can be prefixed with a "-" to denote descending order.
 	// in: query
 	Sort string `json:"sort"`
+
+	// Possible values: csv | ndjson | json
+	// Defaults to json.
+	// in: query
+	Format string `json:"format"`
+}
+
+// swagger:parameters lookupMovie
+type lookupMovieQueries struct {
+	// Possible values: imdb | tmdb | wikidata
+	// in: query
+	// required: true
+	Source string `json:"source"`
+
+	// Id of the movie in the source, e.g. tt0059578 for IMDb, 938 for TMDB or Q226765 for Wikidata.
+	// in: query
+	// required: true
+	Id string `json:"id"`
+}
+
+// swagger:parameters importMovies
+type importMoviesParams struct {
+	// Validate the records without importing them.
+	// in: query
+	DryRun bool `json:"dry_run"`
+
+	// in: body
+	// example: title,year,runtime,genres\nThe Godfather,1972,175,Crime|Drama
+	Body string
+}
+
+// swagger:parameters getMovie listMovies
+type movieFieldsQueries struct {
+	// Comma-separated list of fields to return.
+	// All fields are returned if omitted.
+	// Possible values: id | title | year | runtime | genres | original_title | original_language | countries | release_dates | certifications | synopsis | tagline | external_ids | poster | backdrop | version
+	// Example: fields=id,title
+	// in: query
+	Fields []string `json:"fields"`
+
+	// Comma-separated list of related resources to embed.
+	// The collections are those visible to the user.
+	// Possible values: collections
+	// Example: include=collections
+	// in: query
+	Include []string `json:"include"`
+}
+
+// swagger:parameters getMovie listMovies lookupMovie listSimilarMovies
+type acceptLanguageHeader struct {
+	// Preferred languages of the movie titles, synopses and taglines.
+	// Example: fr-CA, fr;q=0.9, en;q=0.5
+	// in: header
+	AcceptLanguage string `json:"Accept-Language"`
+}
+
+// swagger:parameters listSimilarMovies
+type listSimilarMoviesQueries struct {
+	// Number of movies.
+	// minimum: 1
+	// maximum: 20
+	// default: 10
+	// in: query
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters putMovieTranslation deleteMovieTranslation
+type movieTranslationPath struct {
+	// Movie ID.
+	// in: path
+	Id int `json:"id"`
+
+	// BCP 47 language tag.
+	// Example: es-419
+	// in: path
+	Language string `json:"language"`
+}
+
+// swagger:parameters putMovieTranslation
+type movieTranslationRequestBody struct {
+	// in: body
+	Body struct {
+		// maxLength: 500
+		// example: Por unos dólares más
+		Title *string `json:"title"`
+
+		// maxLength: 5000
+		Synopsis *string `json:"synopsis"`
+
+		// maxLength: 300
+		Tagline *string `json:"tagline"`
+	}
+}
+
+// swagger:parameters getMovie listMovies
+type ifNoneMatchHeader struct {
+	// Entity tags of the client's cached copies.
+	// A 304 status is returned if any matches the current tag.
+	// in: header
+	IfNoneMatch string `json:"If-None-Match"`
+}
+
+// swagger:parameters updateMovie deleteMovie revertMovieRevision putPoster deletePoster putBackdrop deleteBackdrop
+type ifMatchHeader struct {
+	// Strong entity tag of the client's copy of the movie.
+	// The request fails with a 412 error if it doesn't match the current tag.
+	// in: header
+	IfMatch string `json:"If-Match"`
+}
+
+// swagger:parameters putPoster putBackdrop
+type movieImageForm struct {
+	// JPEG or PNG image, limited to 10MB by default.
+	// in: formData
+	// required: true
+	// swagger:file
+	Image []byte `json:"image"`
 }
 
 // swagger:parameters updateMovie
@@ -153,6 +744,121 @@ type moviesResponseWrapper struct {
 	Body []movieResponse
 }
 
+// swagger:response movieTranslationResponse
+type movieTranslationResponseWrapper struct {
+	// in: body
+	Body struct {
+		movieTranslationResponse
+	}
+}
+
+// swagger:response movieTranslationsResponse
+type movieTranslationsResponseWrapper struct {
+	// in: body
+	Body []movieTranslationResponse
+}
+
+// swagger:response similarMoviesResponse
+type similarMoviesResponseWrapper struct {
+	// in: body
+	Body []similarMovieResponse
+}
+
+// swagger:response deleteMovieTranslationResponse
+type deleteMovieTranslationResponse struct {
+	// in: body
+	Body struct {
+		// example: translation deleted successfully
+		Message string `json:"message"`
+	}
+}
+
+// swagger:response importMoviesResponse
+type importMoviesResponse struct {
+	// in: body
+	Body struct {
+		// True if the records were only validated.
+		// example: false
+		DryRun bool `json:"dry_run"`
+
+		// Number of records read.
+		// example: 3
+		Total int `json:"total"`
+
+		// Number of records which passed validation.
+		// example: 2
+		Valid int `json:"valid"`
+
+		// Number of skipped records.
+		// example: 1
+		Invalid int `json:"invalid"`
+
+		// Number of created movies.
+		// example: 2
+		Imported int `json:"imported"`
+
+		// Number of valid records in batches which failed to be inserted.
+		// example: 0
+		Failed int `json:"failed"`
+
+		// Errors of the first 100 invalid and failed records.
+		// example: [{"row": 2, "errors": {"year": "must not be in the future"}}]
+		Errors []struct {
+			Row    int               `json:"row"`
+			Errors map[string]string `json:"errors"`
+		} `json:"errors"`
+
+		// Row at which an error stopped the import, with none of the records from it onward imported.
+		// It is omitted if the whole body was read.
+		// example: 0
+		StoppedAt int `json:"stopped_at,omitempty"`
+	}
+}
+
+// swagger:response movieRevisionsResponse
+type movieRevisionsResponseWrapper struct {
+	// in: body
+	Body []movieRevisionResponse
+}
+
+// swagger:response movieDiffResponse
+type movieDiffResponseWrapper struct {
+	// in: body
+	Body struct {
+		// example: 1
+		From int `json:"from"`
+
+		// example: 2
+		To int `json:"to"`
+
+		// Mapping of changed fields to their values in both versions.
+		// example: {"runtime": {"from": 140, "to": 160}}
+		Changes map[string]movieChange `json:"changes"`
+	}
+}
+
+// swagger:response exportMoviesResponse
+type exportMoviesResponse struct {
+	// Attachment filename of the export.
+	// example: attachment; filename="movies.csv"
+	ContentDisposition string `json:"Content-Disposition"`
+
+	// in: body
+	Body []movieResponse
+}
+
+// The cached copy of the resource is up-to-date.
+// swagger:response notModifiedResponse
+type notModifiedResponse struct{}
+
+// The movie was merged into another movie.
+// swagger:response movedPermanentlyResponse
+type movedPermanentlyResponse struct {
+	// URL of the surviving movie, with the queries of the request.
+	// example: /v1/movies/2
+	Location string
+}
+
 // swagger:response deleteMovieResponse
 type deleteMovieResponse struct {
 	// in: body
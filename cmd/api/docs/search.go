@@ -0,0 +1,60 @@
+package docs
+
+// ROUTES
+
+// swagger:route GET /search/ search search
+// Search.
+// Returns the movies outside the trash and the series matching the queries as a single list.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: searchResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// PARAMETERS
+
+// swagger:parameters search
+type searchQueries struct {
+	// Title (partial or complete). The titles of movie translations are searched as well.
+	// in: query
+	Title string `json:"title"`
+
+	// Possible values: movie | series
+	// Both types are returned if it is omitted.
+	// in: query
+	Type string `json:"type"`
+
+	// Comma-separated list of genres.
+	// Example: genres=drama,thriller
+	// in: query
+	Genres []string `json:"genres"`
+
+	// Page number.
+	// minimum: 1
+	// maximum: 10_000_000
+	// in: query
+	Page int `json:"page"`
+
+	// Number of results per page.
+	// minimum: 1
+	// maximum: 100
+	// in: query
+	Limit int `json:"limit"`
+
+	// Possible values: title | year
+	// Sort values can be prefixed with a "-" to denote descending order.
+	// in: query
+	Sort string `json:"sort"`
+}
+
+// RESPONSES
+
+// swagger:response searchResponse
+type searchResponseWrapper struct {
+	// in: body
+	Body []searchResultResponse
+}
@@ -0,0 +1,314 @@
+package docs
+
+// ROUTES
+
+// swagger:route GET /series/ series listSeries
+// List series.
+// Returns the series matching the queries, without their seasons.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: seriesListResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// swagger:route POST /series/ series createSeries
+// Create series.
+// Creates a series without seasons, and returns it.
+// Genres are matched case-insensitively against the genres of movies and saved in their canonical form.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	201: seriesResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	422: validationError
+
+// swagger:route GET /series/{id} series getSeries
+// Get series.
+// Returns the series with the given id, and its seasons and episodes in order.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: seriesResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+
+// swagger:route PATCH /series/{id} series updateSeries
+// Update series.
+// Changes the given fields of the series with the given id, and returns it.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: seriesResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	409: editConflictError
+//	422: validationError
+
+// swagger:route DELETE /series/{id} series deleteSeries
+// Delete series.
+// Deletes the series with the given id, along with its seasons and episodes.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: deleteSeriesResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+
+// swagger:route PUT /series/{id}/seasons/{season} series putSeason
+// Create or replace season.
+// Saves the season with the given number, keeping its episodes if it already exists.
+// The air date must not be before that of an earlier season or after that of a later one.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: seasonResponse
+//	201: seasonResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route DELETE /series/{id}/seasons/{season} series deleteSeason
+// Delete season.
+// Deletes the season with the given number, along with its episodes.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: deleteSeasonResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+
+// swagger:route PUT /series/{id}/seasons/{season}/episodes/{episode} series putEpisode
+// Create or replace episode.
+// Saves the episode with the given number in an existing season.
+// The air date must not be before that of an earlier episode or after that of a later one.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: episodeResponse
+//	201: episodeResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+//	422: validationError
+
+// swagger:route DELETE /series/{id}/seasons/{season}/episodes/{episode} series deleteEpisode
+// Delete episode.
+// Deletes the episode with the given number.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: deleteEpisodeResponse
+//	401: unauthenticatedError
+//	403: permissionError
+//	404: notFoundError
+
+// PARAMETERS
+
+// swagger:parameters listSeries
+type listSeriesQueries struct {
+	// Series title (partial or complete).
+	// in: query
+	Title string `json:"title"`
+
+	// Comma-separated list of genres.
+	// Example: genres=drama,thriller
+	// in: query
+	Genres []string `json:"genres"`
+
+	// Page number.
+	// minimum: 1
+	// maximum: 10_000_000
+	// in: query
+	Page int `json:"page"`
+
+	// Number of series per page.
+	// minimum: 1
+	// maximum: 100
+	// in: query
+	Limit int `json:"limit"`
+
+	// Possible values: id | title | start_year
+	// Sort values can be prefixed with a "-" to denote descending order.
+	// in: query
+	Sort string `json:"sort"`
+}
+
+// swagger:parameters getSeries updateSeries deleteSeries putSeason deleteSeason putEpisode deleteEpisode
+type seriesIdPath struct {
+	// Series ID.
+	// in:path
+	Id int `json:"id"`
+}
+
+// swagger:parameters putSeason deleteSeason putEpisode deleteEpisode
+type seasonNumberPath struct {
+	// Season number.
+	// minimum: 1
+	// in:path
+	Season int `json:"season"`
+}
+
+// swagger:parameters putEpisode deleteEpisode
+type episodeNumberPath struct {
+	// Episode number.
+	// minimum: 1
+	// in:path
+	Episode int `json:"episode"`
+}
+
+// swagger:parameters createSeries updateSeries
+type seriesRequestBody struct {
+	// in:body
+	Body struct {
+		// Required when creating.
+		// maxLength: 500
+		// example: Severance
+		Title *string `json:"title"`
+
+		// Required when creating.
+		// minimum: 1928
+		// example: 2022
+		StartYear *int `json:"start_year"`
+
+		// Omitted for running series.
+		// example: 2025
+		EndYear *int `json:"end_year"`
+
+		// Required when creating, and must be amongst the listed genres.
+		// minItems: 1
+		// example: ["Drama", "Thriller"]
+		Genres []string `json:"genres"`
+
+		// maxLength: 5000
+		// example: Office workers have their memories surgically divided between their work and personal lives.
+		Synopsis *string `json:"synopsis"`
+	}
+}
+
+// swagger:parameters putSeason
+type seasonRequestBody struct {
+	// in:body
+	Body struct {
+		// maxLength: 500
+		// example: Season 1
+		Title *string `json:"title"`
+
+		// Formatted as YYYY-MM-DD.
+		// example: 2022-02-18
+		AirDate *string `json:"air_date"`
+	}
+}
+
+// swagger:parameters putEpisode
+type episodeRequestBody struct {
+	// in:body
+	Body struct {
+		// required: true
+		// maxLength: 500
+		// example: Good News About Hell
+		Title *string `json:"title"`
+
+		// Runtime in minutes.
+		// required: true
+		// minimum: 1
+		// example: 57
+		Runtime *int `json:"runtime"`
+
+		// Formatted as YYYY-MM-DD.
+		// example: 2022-02-18
+		AirDate *string `json:"air_date"`
+
+		// maxLength: 5000
+		// example: Mark is promoted to lead of his department.
+		Synopsis *string `json:"synopsis"`
+	}
+}
+
+// RESPONSES
+
+// swagger:response seriesResponse
+type seriesResponseWrapper struct {
+	// in: body
+	Body struct {
+		seriesResponse
+	}
+}
+
+// swagger:response seriesListResponse
+type seriesListResponseWrapper struct {
+	// in: body
+	Body []seriesResponse
+}
+
+// swagger:response seasonResponse
+type seasonResponseWrapper struct {
+	// in: body
+	Body struct {
+		seasonResponse
+	}
+}
+
+// swagger:response episodeResponse
+type episodeResponseWrapper struct {
+	// in: body
+	Body struct {
+		episodeResponse
+	}
+}
+
+// swagger:response deleteSeriesResponse
+type deleteSeriesResponse struct {
+	// in: body
+	Body struct {
+		// example: series deleted successfully
+		Message string `json:"message"`
+	}
+}
+
+// swagger:response deleteSeasonResponse
+type deleteSeasonResponse struct {
+	// in: body
+	Body struct {
+		// example: season deleted successfully
+		Message string `json:"message"`
+	}
+}
+
+// swagger:response deleteEpisodeResponse
+type deleteEpisodeResponse struct {
+	// in: body
+	Body struct {
+		// example: episode deleted successfully
+		Message string `json:"message"`
+	}
+}
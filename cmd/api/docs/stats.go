@@ -0,0 +1,27 @@
+package docs
+
+// ROUTES
+
+// swagger:route GET /stats/movies stats movieStats
+// Movie statistics.
+// Returns the totals, counts per genre, year and decade, runtime distribution and most recently added titles
+// of the movies outside the trash.
+// The statistics are cached for a configured duration, so they may not reflect the latest changes.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: movieStatsResponse
+//	401: unauthenticatedError
+//	403: permissionError
+
+// RESPONSES
+
+// swagger:response movieStatsResponse
+type movieStatsResponseWrapper struct {
+	// in: body
+	Body struct {
+		movieStatsResponse
+	}
+}
@@ -58,6 +58,7 @@ package docs
 // swagger:route PUT /users/update-password users updatePassword
 // Update password.
 // Updates the user password.
+// A security alert is also mailed to the user, unless they've opted out of security alerts.
 // All fields in the request body are required.
 //
 // Responses:
@@ -65,6 +66,46 @@ package docs
 //	409: editConflictError
 //  422: validationError
 
+// swagger:route GET /users/notifications users getNotifications
+// Get notification preferences.
+// Returns whether the authenticated user receives each category of non-transactional emails.
+// Users who haven't changed their preferences receive security and saved search alerts, but not product updates.
+// Activation and password reset emails are always sent.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: notificationPreferencesResponse
+//	401: unauthenticatedError
+//	403: unactivatedUserError
+
+// swagger:route PATCH /users/notifications users updateNotifications
+// Update notification preferences.
+// Opts the authenticated user into or out of the categories in the request body, leaving omitted categories unchanged,
+// and returns the updated preferences.
+//
+// Security:
+//	bearer:
+//
+// Responses:
+//	200: notificationPreferencesResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	403: unactivatedUserError
+//	422: validationError
+
+// swagger:route POST /users/unsubscribe users unsubscribe
+// Unsubscribe.
+// Opts the user out of the category of emails signed into the token, without authentication.
+// Non-transactional emails link to this endpoint with their token in the List-Unsubscribe header,
+// which mail clients post to with a "List-Unsubscribe=One-Click" body as described in RFC 8058.
+// The body is ignored.
+//
+// Responses:
+//	200: unsubscribeResponse
+//	422: validationError
+
 // PARAMETERS
 // swagger:parameters registerUser
 type userRequest struct {
@@ -81,6 +122,11 @@ type userRequest struct {
 		// required: true
 		// example: password
 		Password string `json:"password"`
+
+		// ISO 639-1 code of the language emails are sent in, which defaults to English.
+		// Emails fall back to English if they aren't translated to the language.
+		// example: fr
+		Language string `json:"language"`
 	}
 }
 
@@ -132,6 +178,33 @@ type passwordResetTokenRequest struct {
 	}
 }
 
+// swagger:parameters updateNotifications
+type updateNotificationsRequest struct {
+	// in: body
+	Body struct {
+		// Whether alerts of account changes, like password changes, are received.
+		// example: true
+		SecurityAlerts *bool `json:"security_alerts"`
+
+		// Whether news of the service are received.
+		// example: false
+		ProductUpdates *bool `json:"product_updates"`
+
+		// Whether alerts of new results of saved searches are received.
+		// example: true
+		SavedSearchAlerts *bool `json:"saved_search_alerts"`
+	}
+}
+
+// swagger:parameters unsubscribe
+type unsubscribeQueries struct {
+	// Signed token of the user and category to unsubscribe from.
+	// in: query
+	// required: true
+	// example: 1.product_updates.Irybb7z8P7s7kEeZXeLFMTTS4oXCgE6sU5XDM7nK_V8
+	Token string `json:"token"`
+}
+
 // RESPONSES
 
 // swagger:response registerUserResponse
@@ -190,3 +263,27 @@ type refreshActivationTokenResponse struct {
 		Message string `json:"message"`
 	}
 }
+
+// swagger:response notificationPreferencesResponse
+type notificationPreferencesResponse struct {
+	// in: body
+	Body struct {
+		// example: true
+		SecurityAlerts bool `json:"security_alerts"`
+
+		// example: false
+		ProductUpdates bool `json:"product_updates"`
+
+		// example: true
+		SavedSearchAlerts bool `json:"saved_search_alerts"`
+	}
+}
+
+// swagger:response unsubscribeResponse
+type unsubscribeResponse struct {
+	// in: body
+	Body struct {
+		// example: you have been unsubscribed successfully
+		Message string `json:"message"`
+	}
+}
@@ -0,0 +1,58 @@
+package docs
+
+// ROUTES
+
+// swagger:route POST /webhooks/mail webhooks mailEvent
+// Report mail event.
+// Records a bounce or spam complaint reported by the mail provider.
+// Hard bounces and complaints suppress the address, so no more emails are sent to it,
+// while soft bounces are acknowledged without suppressing it.
+// The provider authenticates with the mail webhook secret as a bearer token,
+// and the webhook doesn't exist if no secret is configured.
+//
+// Responses:
+//	200: mailEventResponse
+//	400: badRequestError
+//	401: unauthenticatedError
+//	404: notFoundError
+//	422: validationError
+
+// PARAMETERS
+
+// swagger:parameters mailEvent
+type mailEventRequestBody struct {
+	// in:body
+	Body struct {
+		// Type of the event.
+		// required: true
+		// enum: bounce,complaint
+		// example: bounce
+		Type *string `json:"type"`
+
+		// Address the event is about.
+		// required: true
+		// example: johndoe@mail.com
+		Email *string `json:"email"`
+
+		// Whether a bounce is permanent.
+		// enum: hard,soft
+		// default: hard
+		BounceType *string `json:"bounce_type"`
+
+		// Explanation of the provider, such as the reply of the recipient's SMTP server.
+		// maxLength: 1000
+		// example: 550 5.1.1 user unknown
+		Diagnostic *string `json:"diagnostic"`
+	}
+}
+
+// RESPONSES
+
+// swagger:response mailEventResponse
+type mailEventResponse struct {
+	// in: body
+	Body struct {
+		// example: email address suppressed
+		Message string `json:"message"`
+	}
+}
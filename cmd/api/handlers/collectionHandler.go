@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"net/http"
+	"path"
+	"strconv"
+)
+
+type collectionHandler struct {
+	repositories repository.Repositories
+}
+
+func NewCollectionHandler(repositories repository.Repositories) common.CollectionHandler {
+	return &collectionHandler{
+		repositories: repositories,
+	}
+}
+
+// List returns the collections visible to the current user,
+// being franchises, public lists and the user's own lists.
+func (c collectionHandler) List(ctx *gin.Context) {
+	// set the queries
+	queries := ctx.Request.URL.Query()
+	collectionQuery := request.CollectionQuery{
+		Kind:   parseQueryString(queries, request.CollectionQueryFieldKind, ""),
+		UserId: parseQueryInt(queries, request.CollectionQueryFieldUserId, 0),
+	}
+
+	// set and validate the filters and queries
+	filters := request.Filters{
+		Page:  parseQueryInt(queries, "page", 1),
+		Limit: parseQueryInt(queries, "limit", 20),
+		Sort:  parseQueryString(queries, "sort", request.CollectionFilterSortId),
+		ValidSorts: []string{
+			request.CollectionFilterSortId,
+			request.CollectionFilterSortName,
+			request.CollectionFilterSortUpdated,
+		},
+	}
+
+	v := filters.Validate()
+	collectionQuery.Validate(v)
+	if !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	collections, metadata, err := c.repositories.Collections.List(collectionQuery, filters, common.ContextGetUser(ctx).Id)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.BaseResponse{
+			Success:  true,
+			Status:   http.StatusOK,
+			Data:     collections.ToResponse(),
+			Metadata: &metadata,
+		},
+	)
+}
+
+// GetById returns the collection with the given id and its movies in order.
+func (c collectionHandler) GetById(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	collection, err := c.fetchCollection(ctx, id)
+	if err != nil {
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			collection.ToResponse(),
+		),
+	)
+}
+
+// Create adds a new collection, and returns the newly created collection.
+// Lists are owned by the current user, while franchises can only be created by users who can write movies.
+func (c collectionHandler) Create(ctx *gin.Context) {
+	// parse and validate the request body
+	collectionRequest := &request.CollectionRequest{}
+	err := parseJsonRequest(ctx, collectionRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, collectionRequest, []string{
+		request.CollectionFieldKind,
+		request.CollectionFieldName,
+	})
+	if err != nil {
+		return
+	}
+
+	user := common.ContextGetUser(ctx)
+	collection := collectionRequest.ToModel()
+	if collection.Kind == models.CollectionKindList {
+		collection.UserId = user.Id
+	}
+
+	// ensure the user can manage the new collection
+	if _, err := c.checkEditable(ctx, collection); err != nil {
+		return
+	}
+
+	err = c.repositories.Collections.Create(&collection)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+	collection.Entries = []models.CollectionEntry{}
+
+	ctx.Header("Location", path.Join("/v1/collections", strconv.Itoa(collection.Id)))
+	ctx.JSON(
+		http.StatusCreated,
+		response.SuccessResponse(
+			http.StatusCreated,
+			collection.ToResponse(),
+		),
+	)
+}
+
+// Update changes the name, description and visibility of the collection with the given id.
+// The kind of a collection can't be changed.
+func (c collectionHandler) Update(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	collection, err := c.fetchEditableCollection(ctx, id)
+	if err != nil {
+		return
+	}
+
+	// parse and validate the request body against the kind of the collection
+	collectionRequest := &request.CollectionRequest{}
+	err = parseJsonRequest(ctx, collectionRequest)
+	if err != nil {
+		return
+	}
+	if collectionRequest.Kind != nil && *collectionRequest.Kind != collection.Kind {
+		abortWithInvalidCollection(ctx, request.CollectionFieldKind, "cannot be changed")
+		return
+	}
+	collectionRequest.Kind = &collection.Kind
+	err = validateJsonRequest(ctx, collectionRequest, []string{})
+	if err != nil {
+		return
+	}
+
+	collectionRequest.Apply(&collection)
+	err = c.repositories.Collections.Update(&collection)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			collection.ToResponse(),
+		),
+	)
+}
+
+// Delete removes the collection with the given id. The movies in it are left intact.
+func (c collectionHandler) Delete(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	_, err = c.fetchEditableCollection(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = c.repositories.Collections.Delete(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			map[string]string{"message": "collection deleted successfully"},
+		),
+	)
+}
+
+// AddMovie inserts a movie into the collection with the given id at the requested position,
+// or at the end if there is none, and returns the updated collection.
+func (c collectionHandler) AddMovie(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	_, err = c.fetchEditableCollection(ctx, id)
+	if err != nil {
+		return
+	}
+
+	// parse and validate the request body
+	movieRequest := &request.CollectionMovieRequest{}
+	err = parseJsonRequest(ctx, movieRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, movieRequest, []string{request.CollectionMovieFieldMovieId})
+	if err != nil {
+		return
+	}
+
+	err = c.repositories.Collections.AddMovie(id, *movieRequest.MovieId, movieRequest.PositionValue())
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRecordNotFound):
+			abortWithInvalidCollection(ctx, request.CollectionMovieFieldMovieId, "must be an existing movie")
+		case errors.Is(err, repository.ErrDuplicateCollectionMovie):
+			abortWithInvalidCollection(ctx, request.CollectionMovieFieldMovieId, "is already in the collection")
+		default:
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	c.respondWithCollection(ctx, id)
+}
+
+// RemoveMovie removes a movie from the collection with the given id, and returns the updated collection.
+func (c collectionHandler) RemoveMovie(ctx *gin.Context) {
+	// validate ids
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+	movieId, err := strconv.Atoi(ctx.Param("movie_id"))
+	if err != nil {
+		responseErrors.NewErrorHandler().NotFound(ctx)
+		return
+	}
+
+	_, err = c.fetchEditableCollection(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = c.repositories.Collections.RemoveMovie(id, movieId)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	c.respondWithCollection(ctx, id)
+}
+
+// Reorder moves the requested movies to the start of the collection with the given id in the requested order,
+// followed by any remaining movies in their current order, and returns the updated collection.
+func (c collectionHandler) Reorder(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	_, err = c.fetchEditableCollection(ctx, id)
+	if err != nil {
+		return
+	}
+
+	// parse and validate the request body
+	orderRequest := &request.CollectionOrderRequest{}
+	err = parseJsonRequest(ctx, orderRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, orderRequest, []string{request.CollectionOrderFieldMovieIds})
+	if err != nil {
+		return
+	}
+
+	err = c.repositories.Collections.Reorder(id, orderRequest.MovieIds)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			abortWithInvalidCollection(ctx, request.CollectionOrderFieldMovieIds, "must only contain movies in the collection")
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	c.respondWithCollection(ctx, id)
+}
+
+// fetchCollection returns the collection with the given id.
+// A 404 error is returned if it doesn't exist or is a private list of another user.
+func (c collectionHandler) fetchCollection(ctx *gin.Context, id int) (models.Collection, error) {
+	collection, err := c.repositories.Collections.Get(id)
+	if err == nil && !collection.VisibleTo(common.ContextGetUser(ctx).Id) {
+		err = repository.ErrRecordNotFound
+	}
+
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return models.Collection{}, err
+	}
+	return collection, nil
+}
+
+// fetchEditableCollection returns the collection with the given id if the current user can modify it.
+// A 404 error is returned if the user can't view it, and a 403 error if the user can't modify it.
+func (c collectionHandler) fetchEditableCollection(ctx *gin.Context, id int) (models.Collection, error) {
+	collection, err := c.fetchCollection(ctx, id)
+	if err != nil {
+		return models.Collection{}, err
+	}
+	return c.checkEditable(ctx, collection)
+}
+
+// checkEditable returns the collection if the current user can modify it, and a 403 error otherwise.
+func (c collectionHandler) checkEditable(ctx *gin.Context, collection models.Collection) (models.Collection, error) {
+	user := common.ContextGetUser(ctx)
+	permissions, err := c.repositories.Permissions.GetAllForUser(user)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return models.Collection{}, err
+	}
+
+	if !collection.EditableBy(user.Id, permissions) {
+		responseErrors.NewErrorHandler().NotPermitted(ctx)
+		return models.Collection{}, errors.New("not permitted")
+	}
+	return collection, nil
+}
+
+// respondWithCollection responds with the collection with the given id after its entries have changed.
+func (c collectionHandler) respondWithCollection(ctx *gin.Context, id int) {
+	collection, err := c.repositories.Collections.Get(id)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			collection.ToResponse(),
+		),
+	)
+}
+
+// abortWithInvalidCollection aborts the request with a 422 error for the field of a collection request.
+func abortWithInvalidCollection(ctx *gin.Context, field string, message string) {
+	v := validator.New("collection")
+	v.AddError(field, message)
+	ctx.AbortWithStatusJSON(
+		http.StatusUnprocessableEntity,
+		response.UnprocessableEntityError(v),
+	)
+}
@@ -0,0 +1,500 @@
+package handlers
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/infrastructure/mock"
+	"net/http"
+)
+
+// expected responses of the mock collections visible to the test user, without their entries
+var (
+	testUserId  = 1
+	otherUserId = 2
+
+	franchiseCollectionResponse = response.CollectionResponse{
+		Id:          1,
+		Kind:        "franchise",
+		Name:        "Lin-Manuel Miranda Musicals",
+		Description: "Stage musicals by Lin-Manuel Miranda brought to the screen.",
+		Public:      true,
+		MovieCount:  1,
+		Created:     mock.MockDate,
+		Updated:     mock.MockDate,
+	}
+
+	privateListResponse = response.CollectionResponse{
+		Id:          2,
+		Kind:        "list",
+		Name:        "Weekend picks",
+		Description: "Movies to watch this weekend.",
+		UserId:      &testUserId,
+		MovieCount:  2,
+		Created:     mock.MockDate,
+		Updated:     mock.MockDate,
+	}
+
+	publicListResponse = response.CollectionResponse{
+		Id:         3,
+		Kind:       "list",
+		Name:       "Summer blockbusters",
+		Public:     true,
+		UserId:     &otherUserId,
+		MovieCount: 1,
+		Created:    mock.MockDate,
+		Updated:    mock.MockDate,
+	}
+)
+
+// withEntries returns a copy of the collection response with the entries.
+func withEntries(collection response.CollectionResponse, entries ...response.CollectionEntryResponse) response.CollectionResponse {
+	collection.Entries = entries
+	return collection
+}
+
+var bulletTrainEntryMovie = response.MovieResponse{
+	Id:      1,
+	Title:   "Bullet Train",
+	Year:    2022,
+	Runtime: 108,
+	Genres:  []string{"Action", "Comedy"},
+}
+
+var hamiltonEntryMovie = response.MovieResponse{
+	Id:      2,
+	Title:   "Hamilton",
+	Year:    2020,
+	Runtime: 140,
+	Genres:  []string{"Musical", "Drama"},
+}
+
+var notFoundResponse = response.ErrorResponse(404, response.Error{
+	Type: "generic",
+	Data: map[string]string{
+		"message": responseErrors.ErrMessageNotFound,
+	},
+})
+
+var notPermittedResponse = response.ErrorResponse(403, response.Error{
+	Type: "generic",
+	Data: map[string]string{
+		"message": responseErrors.ErrMessageNotPermitted,
+	},
+})
+
+var listCollectionsTestCases = map[string]struct {
+	requestUrl string
+	wantCode   int
+	wantBody   response.BaseResponse
+}{
+	"all visible collections": {
+		requestUrl: "/v1/collections/",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Data: []response.CollectionResponse{
+				franchiseCollectionResponse,
+				privateListResponse,
+				publicListResponse,
+			},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 3},
+		},
+	},
+
+	"filtered by kind": {
+		requestUrl: "/v1/collections/?kind=franchise",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Data:     []response.CollectionResponse{franchiseCollectionResponse},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 1},
+		},
+	},
+
+	"filtered by another user": {
+		requestUrl: "/v1/collections/?user_id=2",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Data:     []response.CollectionResponse{publicListResponse},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 1},
+		},
+	},
+
+	"sorted by name": {
+		requestUrl: "/v1/collections/?sort=-name",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Data: []response.CollectionResponse{
+				privateListResponse,
+				publicListResponse,
+				franchiseCollectionResponse,
+			},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 3},
+		},
+	},
+
+	"invalid kind": {
+		requestUrl: "/v1/collections/?kind=series&sort=movies",
+		wantCode:   422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"kind": "must be one of franchise, list",
+				"sort": "invalid sort value",
+			},
+		}),
+	},
+}
+
+var getCollectionTestCases = map[string]struct {
+	requestId string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"franchise": {
+		requestId: "1",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(200, withEntries(
+			franchiseCollectionResponse,
+			response.CollectionEntryResponse{Position: 1, Movie: hamiltonEntryMovie},
+		)),
+	},
+
+	"own private list with a movie in the trash": {
+		requestId: "2",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(200, withEntries(
+			privateListResponse,
+			response.CollectionEntryResponse{Position: 1, Movie: bulletTrainEntryMovie},
+			response.CollectionEntryResponse{Position: 3, Movie: hamiltonEntryMovie},
+		)),
+	},
+
+	"private list of another user": {
+		requestId: "4",
+		wantCode:  404,
+		wantBody:  notFoundResponse,
+	},
+
+	"non-existent id": {
+		requestId: "99",
+		wantCode:  404,
+		wantBody:  notFoundResponse,
+	},
+}
+
+var createCollectionTestCases = map[string]struct {
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+	wantHeaders http.Header
+}{
+	"private list": {
+		requestBody: `{"kind": "list", "name": " Rainy days ", "description": "Cosy movies."}`,
+		wantCode:    201,
+		wantBody: response.SuccessResponse(201, response.CollectionResponse{
+			Id:          5,
+			Kind:        "list",
+			Name:        "Rainy days",
+			Description: "Cosy movies.",
+			UserId:      &testUserId,
+			Created:     mock.MockDate,
+			Updated:     mock.MockDate,
+		}),
+		wantHeaders: map[string][]string{
+			"Location": {"/v1/collections/5"},
+		},
+	},
+
+	"franchise": {
+		requestBody: `{"kind": "franchise", "name": "Bullet Train"}`,
+		wantCode:    201,
+		wantBody: response.SuccessResponse(201, response.CollectionResponse{
+			Id:      5,
+			Kind:    "franchise",
+			Name:    "Bullet Train",
+			Public:  true,
+			Created: mock.MockDate,
+			Updated: mock.MockDate,
+		}),
+		wantHeaders: map[string][]string{
+			"Location": {"/v1/collections/5"},
+		},
+	},
+
+	"private franchise": {
+		requestBody: `{"kind": "franchise", "name": "Bullet Train", "public": false}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"public": "must be true for a franchise",
+			},
+		}),
+	},
+
+	"missing fields": {
+		requestBody: `{}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"kind": "must be provided",
+				"name": "must be provided",
+			},
+		}),
+	},
+
+	"invalid fields": {
+		requestBody: `{"kind": "series", "name": "  "}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"kind": "must be one of franchise, list",
+				"name": "must not be blank",
+			},
+		}),
+	},
+}
+
+var updateCollectionTestCases = map[string]struct {
+	requestId   string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+}{
+	"own list": {
+		requestId:   "2",
+		requestBody: `{"name": "Friday night", "public": true}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, response.CollectionResponse{
+			Id:          2,
+			Kind:        "list",
+			Name:        "Friday night",
+			Description: "Movies to watch this weekend.",
+			Public:      true,
+			UserId:      &testUserId,
+			MovieCount:  2,
+			Entries: []response.CollectionEntryResponse{
+				{Position: 1, Movie: bulletTrainEntryMovie},
+				{Position: 3, Movie: hamiltonEntryMovie},
+			},
+			Created: mock.MockDate,
+			Updated: mock.MockDate,
+		}),
+	},
+
+	"hidden franchise": {
+		requestId:   "1",
+		requestBody: `{"public": false}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"public": "must be true for a franchise",
+			},
+		}),
+	},
+
+	"changed kind": {
+		requestId:   "2",
+		requestBody: `{"kind": "franchise"}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"kind": "cannot be changed",
+			},
+		}),
+	},
+
+	"public list of another user": {
+		requestId:   "3",
+		requestBody: `{"name": "Mine now"}`,
+		wantCode:    403,
+		wantBody:    notPermittedResponse,
+	},
+
+	"private list of another user": {
+		requestId:   "4",
+		requestBody: `{"name": "Mine now"}`,
+		wantCode:    404,
+		wantBody:    notFoundResponse,
+	},
+}
+
+var deleteCollectionTestCases = map[string]struct {
+	requestId string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"own list": {
+		requestId: "2",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(200, map[string]string{
+			"message": "collection deleted successfully",
+		}),
+	},
+
+	"public list of another user": {
+		requestId: "3",
+		wantCode:  403,
+		wantBody:  notPermittedResponse,
+	},
+
+	"non-existent id": {
+		requestId: "99",
+		wantCode:  404,
+		wantBody:  notFoundResponse,
+	},
+}
+
+var addCollectionMovieTestCases = map[string]struct {
+	requestId   string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+}{
+	// the returned collection is unchanged as mock data is not persistent
+	"valid movie": {
+		requestId:   "1",
+		requestBody: `{"movie_id": 1, "position": 1}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, withEntries(
+			franchiseCollectionResponse,
+			response.CollectionEntryResponse{Position: 1, Movie: hamiltonEntryMovie},
+		)),
+	},
+
+	"movie already in collection": {
+		requestId:   "2",
+		requestBody: `{"movie_id": 1}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"movie_id": "is already in the collection",
+			},
+		}),
+	},
+
+	"movie in the trash": {
+		requestId:   "2",
+		requestBody: `{"movie_id": 4}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"movie_id": "must be an existing movie",
+			},
+		}),
+	},
+
+	"invalid fields": {
+		requestId:   "2",
+		requestBody: `{"position": 0}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"movie_id": "must be provided",
+				"position": "must be a positive integer",
+			},
+		}),
+	},
+
+	"public list of another user": {
+		requestId:   "3",
+		requestBody: `{"movie_id": 2}`,
+		wantCode:    403,
+		wantBody:    notPermittedResponse,
+	},
+}
+
+var removeCollectionMovieTestCases = map[string]struct {
+	requestUrl string
+	wantCode   int
+	wantBody   response.BaseResponse
+}{
+	"movie in collection": {
+		requestUrl: "/v1/collections/1/movies/2",
+		wantCode:   200,
+		wantBody: response.SuccessResponse(200, withEntries(
+			franchiseCollectionResponse,
+			response.CollectionEntryResponse{Position: 1, Movie: hamiltonEntryMovie},
+		)),
+	},
+
+	"movie not in collection": {
+		requestUrl: "/v1/collections/1/movies/1",
+		wantCode:   404,
+		wantBody:   notFoundResponse,
+	},
+
+	"invalid movie id": {
+		requestUrl: "/v1/collections/1/movies/hamilton",
+		wantCode:   404,
+		wantBody:   notFoundResponse,
+	},
+}
+
+var reorderCollectionTestCases = map[string]struct {
+	requestId   string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+}{
+	"valid order": {
+		requestId:   "2",
+		requestBody: `{"movie_ids": [2, 1]}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, withEntries(
+			privateListResponse,
+			response.CollectionEntryResponse{Position: 1, Movie: bulletTrainEntryMovie},
+			response.CollectionEntryResponse{Position: 3, Movie: hamiltonEntryMovie},
+		)),
+	},
+
+	"movie not in collection": {
+		requestId:   "2",
+		requestBody: `{"movie_ids": [3, 1]}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"movie_ids": "must only contain movies in the collection",
+			},
+		}),
+	},
+
+	"duplicate movies": {
+		requestId:   "2",
+		requestBody: `{"movie_ids": [1, 1]}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"movie_ids": "must not contain duplicate movies",
+			},
+		}),
+	},
+
+	"missing movies": {
+		requestId:   "2",
+		requestBody: `{}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "collection",
+			Data: map[string]string{
+				"movie_ids": "must contain at least 1 movie",
+			},
+		}),
+	},
+}
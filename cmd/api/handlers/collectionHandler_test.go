@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestCollectionHandler_List(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := listCollectionsTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.requestUrl, nil)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestCollectionHandler_GetById(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := getCollectionTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, path.Join("/v1/collections", tc.requestId), nil)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestCollectionHandler_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := createCollectionTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/collections/", strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, headers := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert headers
+			assertHeaders(t, headers, tc.wantHeaders)
+		})
+	}
+}
+
+func TestCollectionHandler_Update(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := updateCollectionTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, path.Join("/v1/collections", tc.requestId), strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestCollectionHandler_Delete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := deleteCollectionTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, path.Join("/v1/collections", tc.requestId), nil)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestCollectionHandler_AddMovie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := addCollectionMovieTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, path.Join("/v1/collections", tc.requestId, "movies"), strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestCollectionHandler_RemoveMovie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := removeCollectionMovieTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, tc.requestUrl, nil)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestCollectionHandler_Reorder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := reorderCollectionTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, path.Join("/v1/collections", tc.requestId, "order"), strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// movieETag returns a strong entity tag derived from the movie id and version.
+// The version is incremented on every update, so the tag changes whenever the movie does.
+func movieETag(id int, version int) string {
+	return fmt.Sprintf(`"%d-%d"`, id, version)
+}
+
+// weakETag returns a weak entity tag derived from the hash of the JSON encoding of the data.
+// It is used for representations without a single version, like lists.
+func weakETag(data any) (string, error) {
+	js, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(js)
+	return fmt.Sprintf(`W/"%x"`, hash[:16]), nil
+}
+
+// matchesETag checks the entity tag against a comma-separated list of tags from
+// an If-Match or If-None-Match header, with "*" matching any tag.
+// Weak comparison ignores the weakness indicator of both tags, while strong comparison
+// requires both to be strong and identical.
+func matchesETag(header string, etag string, weak bool) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if weak {
+			if strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		} else if !strings.HasPrefix(tag, "W/") && !strings.HasPrefix(etag, "W/") && tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified sets the ETag header of the response, and returns true after responding
+// with a 304 status if the request's If-None-Match header matches the tag.
+func notModified(ctx *gin.Context, etag string) bool {
+	ctx.Header("ETag", etag)
+
+	if header := ctx.GetHeader("If-None-Match"); header != "" && matchesETag(header, etag, true) {
+		ctx.AbortWithStatus(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// preconditionFailed returns true after responding with a 412 error if the request has
+// an If-Match header which doesn't match the entity tag.
+// Requests without the header are let through.
+func preconditionFailed(ctx *gin.Context, etag string) bool {
+	if header := ctx.GetHeader("If-Match"); header != "" && !matchesETag(header, etag, false) {
+		responseErrors.NewErrorHandler().PreconditionFailed(ctx)
+		return true
+	}
+	return false
+}
+
+// setLastModified sets the Last-Modified header of the response to the given time
+// if it isn't zero.
+func setLastModified(ctx *gin.Context, lastModified time.Time) {
+	if !lastModified.IsZero() {
+		ctx.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/mailer"
+	"github.com/rhodeon/prettylog"
+	"net/http"
+	"time"
+)
+
+// emailTemplateExt is the extension of the template files, which is left out of the template names in routes.
+const emailTemplateExt = ".gotmpl"
+
+// sampleToken is the plain text of the tokens in sample emails, in the format of real tokens.
+const sampleToken = "Y3QMGX3PJ3WLRL2YRTQGQ6KRHU"
+
+type emailHandler struct {
+	config       common.Config
+	repositories repository.Repositories
+	mailer       *mailer.Mailer
+}
+
+// NewEmailHandler returns an EmailHandler which renders and sends the templates of the mailer,
+// letting operators check changes to the templates without triggering the emails as users,
+// and records the bounces and complaints reported by the mail provider.
+func NewEmailHandler(config common.Config, repositories repository.Repositories, mail *mailer.Mailer) common.EmailHandler {
+	return &emailHandler{
+		config:       config,
+		repositories: repositories,
+		mailer:       mail,
+	}
+}
+
+// Preview renders the template with sample data and returns its HTML body,
+// or its subject and plain text body if the text format is requested.
+// The Content-Language header holds the language rendered, which is the default one if the template isn't translated.
+func (e emailHandler) Preview(ctx *gin.Context) {
+	template, exists := e.parseTemplateParam(ctx)
+	if !exists {
+		return
+	}
+
+	queries := ctx.Request.URL.Query()
+	query := request.EmailPreviewQuery{
+		Format: parseQueryString(queries, request.EmailPreviewFieldFormat, request.EmailFormatHtml),
+		Locale: parseQueryString(queries, request.EmailPreviewFieldLocale, mailer.DefaultLanguage),
+	}
+
+	v := query.Validate()
+	if !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	msg, err := e.mailer.Render(mailer.Email{
+		Template: template,
+		Language: query.Locale,
+		Data:     sampleEmailData(template, e.mailer.Now()),
+	})
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Language", msg.Language)
+	if query.Format == request.EmailFormatText {
+		ctx.String(http.StatusOK, "Subject: %s\n%s", msg.Subject, msg.PlainBody)
+		return
+	}
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(msg.HtmlBody))
+}
+
+// TestSend renders the template with sample data and sends it to the given address through the configured transport.
+// The email is sent immediately instead of being queued, so failures of the transport are returned to the operator.
+func (e emailHandler) TestSend(ctx *gin.Context) {
+	template, exists := e.parseTemplateParam(ctx)
+	if !exists {
+		return
+	}
+
+	testRequest := &request.EmailTestRequest{}
+	err := parseJsonRequest(ctx, testRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, testRequest, []string{request.EmailTestFieldEmail})
+	if err != nil {
+		return
+	}
+
+	locale := mailer.DefaultLanguage
+	if testRequest.Locale != nil {
+		locale = *testRequest.Locale
+	}
+
+	err = e.mailer.Send(mailer.Email{
+		Recipient: *testRequest.Email,
+		Template:  template,
+		Language:  locale,
+		Data:      sampleEmailData(template, e.mailer.Now()),
+	})
+	if err != nil {
+		prettylog.ErrorF("test email %s to %s failed: %s", template, *testRequest.Email, err.Error())
+		responseErrors.SetStatusAndBody(
+			ctx,
+			http.StatusBadGateway,
+			response.GenericError("the email could not be sent: "+err.Error()),
+		)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			map[string]string{"message": "test email sent successfully"},
+		),
+	)
+}
+
+// parseTemplateParam returns the file name of the template in the "template" parameter of the request.
+// A 404 response is returned if the template doesn't exist.
+func (e emailHandler) parseTemplateParam(ctx *gin.Context) (string, bool) {
+	template := ctx.Param("template") + emailTemplateExt
+	if !e.mailer.HasTemplate(template) {
+		responseErrors.NewErrorHandler().NotFound(ctx)
+		return "", false
+	}
+	return template, true
+}
+
+// sampleEmailData returns the data the template is rendered with in previews,
+// which has the keys of the data queued by the user handler for every template
+// and expires as long after now as the token of the template.
+func sampleEmailData(template string, now time.Time) map[string]any {
+	lifetime := activationTokenLifetime
+	switch template {
+	case mailer.TemplateUserWelcome:
+		lifetime = registrationTokenLifetime
+	case mailer.TemplateResetPassword:
+		lifetime = passwordResetTokenLifetime
+	}
+
+	return map[string]any{
+		"Username":           "moviebuff",
+		"ActivationToken":    sampleToken,
+		"PasswordResetToken": sampleToken,
+		"Expires":            now.Add(lifetime),
+	}
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/infrastructure/mock"
+)
+
+// previewEmailTestCases assert on parts of the rendered previews, and on the whole body of error responses.
+var previewEmailTestCases = map[string]struct {
+	template            string
+	queries             map[string]string
+	wantCode            int
+	wantContentType     string
+	wantContentLanguage string
+	wantBodyContains    []string
+	wantBody            response.BaseResponse
+}{
+	"html preview": {
+		template:            "user_welcome",
+		wantCode:            200,
+		wantContentType:     "text/html; charset=utf-8",
+		wantContentLanguage: "en",
+		wantBodyContains: []string{
+			`<html lang="en">`,
+			"<title>Welcome to Moviescreen</title>",
+			"Hello moviebuff,",
+			sampleToken,
+		},
+	},
+
+	"text preview": {
+		template:            "reset_password",
+		queries:             map[string]string{"format": "text"},
+		wantCode:            200,
+		wantContentType:     "text/plain; charset=utf-8",
+		wantContentLanguage: "en",
+		wantBodyContains: []string{
+			"Subject: Reset your password\n",
+			"it will expire in 15 minutes",
+		},
+	},
+
+	"translated preview": {
+		template:            "user_welcome",
+		queries:             map[string]string{"format": "text", "locale": "fr"},
+		wantCode:            200,
+		wantContentType:     "text/plain; charset=utf-8",
+		wantContentLanguage: "fr",
+		wantBodyContains: []string{
+			"Subject: Bienvenue sur Moviescreen\n",
+			"expirera dans 2 jours",
+		},
+	},
+
+	"untranslated locale": {
+		template:            "activation_token",
+		queries:             map[string]string{"format": "text", "locale": "de"},
+		wantCode:            200,
+		wantContentType:     "text/plain; charset=utf-8",
+		wantContentLanguage: "en",
+		wantBodyContains: []string{
+			"Subject: Activate your account\n",
+		},
+	},
+
+	"invalid queries": {
+		template: "user_welcome",
+		queries:  map[string]string{"format": "pdf", "locale": "english"},
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "preview",
+			Data: map[string]string{
+				"format": `must be "html" or "text"`,
+				"locale": "must be an ISO 639-1 language code",
+			},
+		}),
+	},
+
+	"non-existent template": {
+		template: "partials",
+		wantCode: 404,
+		wantBody: response.ErrorResponse(404, response.Error{
+			Type: "generic",
+			Data: map[string]string{
+				"message": responseErrors.ErrMessageNotFound,
+			},
+		}),
+	},
+}
+
+var testEmailTestCases = map[string]struct {
+	template    string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+	wantEmail   *wantEmail
+}{
+	"valid request": {
+		template:    "activation_token",
+		requestBody: `{"email": "operator@mail.com"}`,
+		wantCode:    200,
+		wantBody:    response.SuccessResponse(200, map[string]string{"message": "test email sent successfully"}),
+		wantEmail: &wantEmail{
+			To:                "operator@mail.com",
+			Subject:           "Activate your account",
+			PlainBodyContains: sampleToken,
+		},
+	},
+
+	"valid request (with locale)": {
+		template:    "user_welcome",
+		requestBody: `{"email": "operator@mail.com", "locale": "fr"}`,
+		wantCode:    200,
+		wantBody:    response.SuccessResponse(200, map[string]string{"message": "test email sent successfully"}),
+		wantEmail: &wantEmail{
+			To:                "operator@mail.com",
+			Subject:           "Bienvenue sur Moviescreen",
+			PlainBodyContains: "Bonjour moviebuff,",
+		},
+	},
+
+	"invalid fields": {
+		template:    "activation_token",
+		requestBody: `{"email": "operator", "locale": "fra"}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "email",
+			Data: map[string]string{
+				"email":  "must be a valid email address",
+				"locale": "must be an ISO 639-1 language code",
+			},
+		}),
+	},
+
+	"missing email": {
+		template:    "activation_token",
+		requestBody: `{}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "email",
+			Data: map[string]string{
+				"email": "must be provided",
+			},
+		}),
+	},
+
+	"non-existent template": {
+		template:    "newsletter",
+		requestBody: `{"email": "operator@mail.com"}`,
+		wantCode:    404,
+		wantBody: response.ErrorResponse(404, response.Error{
+			Type: "generic",
+			Data: map[string]string{
+				"message": responseErrors.ErrMessageNotFound,
+			},
+		}),
+	},
+}
+
+// mailWebhookTestCases use a distinct address for each case, as the suppressions persist across tests.
+var mailWebhookTestCases = map[string]struct {
+	authorization   string
+	requestBody     string
+	wantCode        int
+	wantBody        response.BaseResponse
+	email           string
+	wantSuppression *models.EmailSuppression
+}{
+	"hard bounce": {
+		authorization: "Bearer " + testWebhookSecret,
+		requestBody:   `{"type": "bounce", "email": "Bounced@Mail.com", "bounce_type": "hard", "diagnostic": "550 5.1.1 user unknown"}`,
+		wantCode:      200,
+		wantBody:      response.SuccessResponse(200, map[string]string{"message": "email address suppressed"}),
+		email:         "bounced@mail.com",
+		wantSuppression: &models.EmailSuppression{
+			Email:   "bounced@mail.com",
+			Reason:  models.SuppressionReasonBounce,
+			Detail:  "550 5.1.1 user unknown",
+			Created: mock.MockDate,
+		},
+	},
+
+	"bounce without type": {
+		authorization: "Bearer " + testWebhookSecret,
+		requestBody:   `{"type": "bounce", "email": "untyped@mail.com"}`,
+		wantCode:      200,
+		wantBody:      response.SuccessResponse(200, map[string]string{"message": "email address suppressed"}),
+		email:         "untyped@mail.com",
+		wantSuppression: &models.EmailSuppression{
+			Email:   "untyped@mail.com",
+			Reason:  models.SuppressionReasonBounce,
+			Created: mock.MockDate,
+		},
+	},
+
+	"complaint": {
+		authorization: "Bearer " + testWebhookSecret,
+		requestBody:   `{"type": "complaint", "email": "complainer@mail.com"}`,
+		wantCode:      200,
+		wantBody:      response.SuccessResponse(200, map[string]string{"message": "email address suppressed"}),
+		email:         "complainer@mail.com",
+		wantSuppression: &models.EmailSuppression{
+			Email:   "complainer@mail.com",
+			Reason:  models.SuppressionReasonComplaint,
+			Created: mock.MockDate,
+		},
+	},
+
+	"soft bounce": {
+		authorization: "Bearer " + testWebhookSecret,
+		requestBody:   `{"type": "bounce", "email": "full@mail.com", "bounce_type": "soft", "diagnostic": "452 4.2.2 mailbox full"}`,
+		wantCode:      200,
+		wantBody:      response.SuccessResponse(200, map[string]string{"message": "soft bounce recorded without suppressing the address"}),
+		email:         "full@mail.com",
+	},
+
+	"invalid fields": {
+		authorization: "Bearer " + testWebhookSecret,
+		requestBody:   `{"type": "delivery", "email": "invalid", "bounce_type": "permanent"}`,
+		wantCode:      422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "mail_event",
+			Data: map[string]string{
+				"type":        `must be "bounce" or "complaint"`,
+				"email":       "must be a valid email address",
+				"bounce_type": `must be "hard" or "soft"`,
+			},
+		}),
+		email: "invalid",
+	},
+
+	"missing fields": {
+		authorization: "Bearer " + testWebhookSecret,
+		requestBody:   `{}`,
+		wantCode:      422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "mail_event",
+			Data: map[string]string{
+				"type":  "must be provided",
+				"email": "must be provided",
+			},
+		}),
+	},
+
+	"invalid secret": {
+		authorization: "Bearer " + mockRequestToken,
+		requestBody:   `{"type": "complaint", "email": "forged@mail.com"}`,
+		wantCode:      401,
+		wantBody: response.ErrorResponse(401, response.Error{
+			Type: "generic",
+			Data: map[string]string{
+				"message": responseErrors.ErrMessageInvalidAuthToken,
+			},
+		}),
+		email: "forged@mail.com",
+	},
+
+	"missing secret": {
+		requestBody: `{"type": "complaint", "email": "forged@mail.com"}`,
+		wantCode:    401,
+		wantBody: response.ErrorResponse(401, response.Error{
+			Type: "generic",
+			Data: map[string]string{
+				"message": responseErrors.ErrMessageInvalidAuthToken,
+			},
+		}),
+		email: "forged@mail.com",
+	},
+}
@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/cmd/api/jobs"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/infrastructure/mock"
+	"github.com/rhodeon/moviescreen/internal/mailer"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEmailHandler_Preview(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := previewEmailTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/emails/"+tc.template+"/preview", nil)
+			setBearerToken(req)
+
+			q := req.URL.Query()
+			for key, value := range tc.queries {
+				q.Set(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, header := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert the error response body, or the headers and parts of the rendered email
+			if tc.wantBodyContains == nil {
+				wantBody, _ := json.Marshal(tc.wantBody)
+				testhelpers.AssertEqual(t, body, string(wantBody))
+				return
+			}
+
+			testhelpers.AssertEqual(t, header.Get("Content-Type"), tc.wantContentType)
+			testhelpers.AssertEqual(t, header.Get("Content-Language"), tc.wantContentLanguage)
+			for _, want := range tc.wantBodyContains {
+				if !strings.Contains(body, want) {
+					t.Errorf("\nGot:\t%q\nWant to contain:\t%q", body, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEmailHandler_TestSend(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := testEmailTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sentBefore := len(testTransport.Messages())
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/admin/emails/"+tc.template+"/test", strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert the test email was sent without being queued
+			assertSentEmail(t, sentBefore, tc.wantEmail)
+		})
+	}
+}
+
+func TestEmailHandler_Webhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := mailWebhookTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/mail", strings.NewReader(tc.requestBody))
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert whether the address was suppressed
+			suppression, err := testRepos.Suppressions.Get(tc.email)
+			if tc.wantSuppression == nil {
+				testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+				return
+			}
+			testhelpers.AssertFatalError(t, err)
+			testhelpers.AssertStruct(t, suppression, *tc.wantSuppression)
+		})
+	}
+}
+
+func TestEmailHandler_WebhookDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+
+	// the webhook doesn't exist without a secret
+	handlers := testRouteHandlers
+	handlers.Emails = NewEmailHandler(common.Config{}, testRepos, testMailer)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/mail", strings.NewReader(`{"type": "complaint", "email": "disabled@mail.com"}`))
+	req.Header.Set("Authorization", "Bearer ")
+
+	app.Router(handlers).ServeHTTP(rr, req)
+	code, _, _ := parseResponse(t, rr.Result())
+
+	testhelpers.AssertEqual(t, code, http.StatusNotFound)
+	_, err := testRepos.Suppressions.Get("disabled@mail.com")
+	testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+}
+
+// bouncingSender rejects every email as the SMTP server does for unknown recipients.
+type bouncingSender struct{}
+
+func (b bouncingSender) SendBatch(emails []mailer.Email) []error {
+	errs := make([]error, len(emails))
+	for i := range errs {
+		errs[i] = mailer.PermanentError{Err: errors.New("550 5.1.1 user unknown"), Bounce: true}
+	}
+	return errs
+}
+
+func TestEmailHandler_SuppressedRecipients(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+
+	// the emails are queued in a separate outbox, so the emails queued by other tests aren't sent
+	repos := testRepos
+	outbox := mock.NewOutboxController()
+	repos.Outbox = outbox
+
+	enqueue := func(recipient string) models.OutboxEmail {
+		t.Helper()
+		email := &models.OutboxEmail{
+			Recipient: recipient,
+			Template:  mailer.TemplateActivationToken,
+			Language:  mailer.DefaultLanguage,
+			Data:      sampleEmailData(mailer.TemplateActivationToken, testMailer.Now()),
+		}
+		err := outbox.Enqueue(email)
+		testhelpers.AssertFatalError(t, err)
+		return *email
+	}
+
+	status := func(id int) string {
+		t.Helper()
+		for _, email := range outbox.Data {
+			if email.Id == id {
+				return email.Status
+			}
+		}
+		t.Fatalf("outbox email %d not found", id)
+		return ""
+	}
+
+	t.Run("complained address", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/mail", strings.NewReader(`{"type": "complaint", "email": "spam-reporter@mail.com"}`))
+		req.Header.Set("Authorization", "Bearer "+testWebhookSecret)
+		app.Router(testRouteHandlers).ServeHTTP(rr, req)
+		testhelpers.AssertEqual(t, rr.Code, http.StatusOK)
+
+		// later emails to the address are kept in the outbox without being sent
+		sentBefore := len(testTransport.Messages())
+		email := enqueue("Spam-Reporter@mail.com")
+		err := jobs.SendOutbox(repos, testMailer, 10, 1)()
+		testhelpers.AssertFatalError(t, err)
+
+		testhelpers.AssertEqual(t, len(testTransport.Messages()), sentBefore)
+		testhelpers.AssertEqual(t, status(email.Id), models.OutboxStatusSuppressed)
+	})
+
+	t.Run("bounced address", func(t *testing.T) {
+		email := enqueue("gone@mail.com")
+		err := jobs.SendOutbox(repos, bouncingSender{}, 10, 5)()
+		testhelpers.AssertFatalError(t, err)
+
+		// the bounce is dead-lettered without being retried, and suppresses the address
+		testhelpers.AssertEqual(t, status(email.Id), models.OutboxStatusDead)
+		suppression, err := testRepos.Suppressions.Get("gone@mail.com")
+		testhelpers.AssertFatalError(t, err)
+		testhelpers.AssertEqual(t, suppression.Reason, models.SuppressionReasonBounce)
+		testhelpers.AssertEqual(t, suppression.Detail, "550 5.1.1 user unknown")
+	})
+}
+
+// failingOutbox fails to mark the email with the given id as sent.
+type failingOutbox struct {
+	*mock.OutboxController
+	failId int
+}
+
+func (o failingOutbox) MarkSent(id int) error {
+	if id == o.failId {
+		return errors.New("connection reset")
+	}
+	return o.OutboxController.MarkSent(id)
+}
+
+func TestSendOutbox(t *testing.T) {
+	// the emails are queued in separate outboxes, so the emails queued by other tests aren't sent
+	enqueue := func(outbox *mock.OutboxController, email models.OutboxEmail) models.OutboxEmail {
+		t.Helper()
+		err := outbox.Enqueue(&email)
+		testhelpers.AssertFatalError(t, err)
+		return email
+	}
+
+	t.Run("results recorded after a failure", func(t *testing.T) {
+		repos := testRepos
+		outbox := mock.NewOutboxController()
+		repos.Outbox = failingOutbox{OutboxController: outbox, failId: 1}
+
+		failed := enqueue(outbox, models.OutboxEmail{
+			Recipient: "first@mail.com",
+			Template:  mailer.TemplatePasswordChanged,
+			Language:  mailer.DefaultLanguage,
+			Data:      map[string]any{"Username": "first"},
+		})
+		sent := enqueue(outbox, models.OutboxEmail{
+			Recipient: "second@mail.com",
+			Template:  mailer.TemplatePasswordChanged,
+			Language:  mailer.DefaultLanguage,
+			Data:      map[string]any{"Username": "second"},
+		})
+
+		err := jobs.SendOutbox(repos, testMailer, 10, 1)()
+		if err == nil || !strings.Contains(err.Error(), "connection reset") {
+			t.Fatalf("\nGot:\t%v\nWant:\tconnection reset", err)
+		}
+
+		testhelpers.AssertEqual(t, outbox.Data[failed.Id-1].Status, models.OutboxStatusPending)
+		testhelpers.AssertEqual(t, outbox.Data[sent.Id-1].Status, models.OutboxStatusSent)
+	})
+
+	t.Run("token created when sent", func(t *testing.T) {
+		repos := testRepos
+		outbox := mock.NewOutboxController()
+		repos.Outbox = outbox
+
+		email := enqueue(outbox, models.OutboxEmail{
+			Recipient:     "third@mail.com",
+			Template:      mailer.TemplateActivationToken,
+			Language:      mailer.DefaultLanguage,
+			Data:          map[string]any{"Username": "third"},
+			UserId:        3,
+			TokenScope:    models.ScopeActivation,
+			TokenLifetime: activationTokenLifetime,
+		})
+
+		err := jobs.SendOutbox(repos, testMailer, 10, 1)()
+		testhelpers.AssertFatalError(t, err)
+
+		msg, _ := testTransport.Last()
+		testhelpers.AssertEqual(t, msg.To, "third@mail.com")
+		if !strings.Contains(msg.PlainBody, `{"token": "token"}`) {
+			t.Errorf("\nGot:\t%q\nWant to contain:\t%q", msg.PlainBody, `{"token": "token"}`)
+		}
+
+		// the plaintext token isn't stored in the outbox
+		testhelpers.AssertStruct(t, outbox.Data[email.Id-1].Data, map[string]any{"Username": "third"})
+	})
+}
+
+// TestEmailRendering asserts that only the HTML bodies of emails are escaped as HTML.
+func TestEmailRendering(t *testing.T) {
+	msg, err := testMailer.Render(mailer.Email{
+		Recipient: "tom@mail.com",
+		Template:  mailer.TemplateActivationToken,
+		Language:  mailer.DefaultLanguage,
+		Data:      map[string]any{"Username": "Tom & Jerry's <fan>", "ActivationToken": sampleToken},
+	})
+	testhelpers.AssertFatalError(t, err)
+
+	for part, body := range map[string]string{"plain": msg.PlainBody, "html": msg.HtmlBody} {
+		want := "Tom & Jerry's <fan>"
+		if part == "html" {
+			want = "Tom &amp; Jerry&#39;s &lt;fan&gt;"
+		}
+		if !strings.Contains(body, want) {
+			t.Errorf("%s body\nGot:\t%q\nWant to contain:\t%q", part, body, want)
+		}
+	}
+}
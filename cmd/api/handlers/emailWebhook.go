@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/prettylog"
+	"net/http"
+	"strings"
+)
+
+// Webhook records a bounce or complaint notification of the mail provider,
+// suppressing the address on hard bounces and complaints so no more emails are sent to it.
+// Soft bounces are acknowledged without suppressing the address, as retries of the email may succeed.
+// The provider authenticates with the webhook secret as a bearer token,
+// and the webhook doesn't exist if no secret is configured.
+func (e emailHandler) Webhook(ctx *gin.Context) {
+	if e.config.Mail.WebhookSecret == "" {
+		responseErrors.NewErrorHandler().NotFound(ctx)
+		return
+	}
+
+	headerParts := strings.Split(ctx.GetHeader("Authorization"), " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" ||
+		subtle.ConstantTimeCompare([]byte(headerParts[1]), []byte(e.config.Mail.WebhookSecret)) != 1 {
+		responseErrors.NewErrorHandler().InvalidAuthenticationToken(ctx)
+		return
+	}
+
+	eventRequest := &request.MailEventRequest{}
+	err := parseJsonRequest(ctx, eventRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, eventRequest, []string{request.MailEventFieldType, request.MailEventFieldEmail})
+	if err != nil {
+		return
+	}
+
+	if !eventRequest.Suppresses() {
+		prettylog.InfoF("soft bounce reported for %s", *eventRequest.Email)
+		ctx.JSON(
+			http.StatusOK,
+			response.SuccessResponse(
+				http.StatusOK,
+				map[string]string{"message": "soft bounce recorded without suppressing the address"},
+			),
+		)
+		return
+	}
+
+	suppression := &models.EmailSuppression{
+		Email:  *eventRequest.Email,
+		Reason: *eventRequest.Type,
+	}
+	if eventRequest.Diagnostic != nil {
+		suppression.Detail = *eventRequest.Diagnostic
+	}
+
+	err = e.repositories.Suppressions.Suppress(suppression)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+	prettylog.InfoF("%s reported for %s, address suppressed", suppression.Reason, suppression.Email)
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			map[string]string{"message": "email address suppressed"},
+		),
+	)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"net/http"
+	"path"
+	"strconv"
+)
+
+type genreHandler struct {
+	repositories repository.Repositories
+}
+
+func NewGenreHandler(repositories repository.Repositories) common.GenreHandler {
+	return &genreHandler{
+		repositories: repositories,
+	}
+}
+
+// List returns all genres with the number of movies in each.
+func (g genreHandler) List(ctx *gin.Context) {
+	genres, err := g.repositories.Genres.List()
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			genres.ToResponse(),
+		),
+	)
+}
+
+// Create adds a new genre to the repository, and returns the newly created genre.
+func (g genreHandler) Create(ctx *gin.Context) {
+	// parse and validate the request body
+	genreRequest := &request.GenreRequest{}
+	err := parseJsonRequest(ctx, genreRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, genreRequest, []string{request.GenreFieldName})
+	if err != nil {
+		return
+	}
+
+	// attempt to create the genre, returning a 422 error if it already exists
+	genre := genreRequest.ToModel()
+	err = g.repositories.Genres.Create(&genre)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateGenre) {
+			abortWithDuplicateGenre(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.Header("Location", path.Join("/v1/genres", strconv.Itoa(genre.Id)))
+	ctx.JSON(
+		http.StatusCreated,
+		response.SuccessResponse(
+			http.StatusCreated,
+			genre.ToResponse(),
+		),
+	)
+}
+
+// Rename changes the name of the genre with the given id, along with the genre of the movies which have it.
+func (g genreHandler) Rename(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	// parse and validate the request body
+	genreRequest := &request.GenreRequest{}
+	err = parseJsonRequest(ctx, genreRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, genreRequest, []string{request.GenreFieldName})
+	if err != nil {
+		return
+	}
+
+	// attempt to rename the genre
+	genre := genreRequest.ToModel()
+	genre.Id = id
+	err = g.repositories.Genres.Rename(&genre, common.ContextGetUser(ctx).Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRecordNotFound):
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		case errors.Is(err, repository.ErrDuplicateGenre):
+			abortWithDuplicateGenre(ctx)
+		default:
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			genre.ToResponse(),
+		),
+	)
+}
+
+// Merge moves the movies of the genre with the given id into another genre, and deletes the merged genre.
+// The genre merged into is returned with its updated movie count.
+func (g genreHandler) Merge(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	// parse and validate the request body
+	mergeRequest := &request.GenreMergeRequest{}
+	err = parseJsonRequest(ctx, mergeRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, mergeRequest, []string{request.GenreMergeFieldInto})
+	if err != nil {
+		return
+	}
+
+	// a genre can't be merged into itself
+	if *mergeRequest.Into == id {
+		v := validator.New("genre")
+		v.AddError(request.GenreMergeFieldInto, "must be a different genre")
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	// attempt to merge the genres, returning a 404 error if either doesn't exist
+	target, err := g.repositories.Genres.Merge(id, *mergeRequest.Into, common.ContextGetUser(ctx).Id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			target.ToResponse(),
+		),
+	)
+}
+
+// abortWithDuplicateGenre aborts the request with a 422 error for a genre name which is already taken.
+func abortWithDuplicateGenre(ctx *gin.Context) {
+	v := validator.New("genre")
+	v.AddError(request.GenreFieldName, "a genre with this name already exists")
+	ctx.AbortWithStatusJSON(
+		http.StatusUnprocessableEntity,
+		response.UnprocessableEntityError(v),
+	)
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"net/http"
+)
+
+var listGenresTestCase = struct {
+	wantCode int
+	wantBody response.BaseResponse
+}{
+	wantCode: 200,
+	wantBody: response.SuccessResponse(200, []response.GenreResponse{
+		{Id: 1, Slug: "action", Name: "Action", MovieCount: 1},
+		{Id: 2, Slug: "adventure", Name: "Adventure", MovieCount: 0},
+		{Id: 3, Slug: "animation", Name: "Animation", MovieCount: 0},
+		{Id: 4, Slug: "comedy", Name: "Comedy", MovieCount: 1},
+		{Id: 5, Slug: "crime", Name: "Crime", MovieCount: 0},
+		{Id: 6, Slug: "drama", Name: "Drama", MovieCount: 1},
+		{Id: 7, Slug: "family", Name: "Family", MovieCount: 0},
+		{Id: 8, Slug: "fantasy", Name: "Fantasy", MovieCount: 0},
+		{Id: 9, Slug: "history", Name: "History", MovieCount: 0},
+		{Id: 10, Slug: "musical", Name: "Musical", MovieCount: 1},
+		{Id: 11, Slug: "thriller", Name: "Thriller", MovieCount: 0},
+	}),
+}
+
+var createGenreTestCases = map[string]struct {
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+	wantHeaders http.Header
+}{
+	"valid request": {
+		requestBody: `{"name": " Science Fiction "}`,
+		wantCode:    201,
+		wantBody: response.SuccessResponse(201, response.GenreResponse{
+			Id:   12,
+			Slug: "science-fiction",
+			Name: "Science Fiction",
+		}),
+		wantHeaders: map[string][]string{
+			"Location": {"/v1/genres/12"},
+		},
+	},
+
+	"variant of existing genre": {
+		requestBody: `{"name": "ACTION"}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "genre",
+			Data: map[string]string{
+				"name": "a genre with this name already exists",
+			},
+		}),
+	},
+
+	"missing name": {
+		requestBody: `{}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "genre",
+			Data: map[string]string{
+				"name": "must be provided",
+			},
+		}),
+	},
+
+	"name without letters or digits": {
+		requestBody: `{"name": " -- "}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "genre",
+			Data: map[string]string{
+				"name": "must contain letters or digits",
+			},
+		}),
+	},
+}
+
+var renameGenreTestCases = map[string]struct {
+	requestId   string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+}{
+	"valid request": {
+		requestId:   "6",
+		requestBody: `{"name": "Dramatic"}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, response.GenreResponse{
+			Id:         6,
+			Slug:       "dramatic",
+			Name:       "Dramatic",
+			MovieCount: 1,
+		}),
+	},
+
+	"name of another genre": {
+		requestId:   "1",
+		requestBody: `{"name": "Comedy"}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "genre",
+			Data: map[string]string{
+				"name": "a genre with this name already exists",
+			},
+		}),
+	},
+
+	"non-existent id": {
+		requestId:   "99",
+		requestBody: `{"name": "Dramatic"}`,
+		wantCode:    404,
+		wantBody: response.ErrorResponse(404, response.Error{
+			Type: "generic",
+			Data: map[string]string{
+				"message": responseErrors.ErrMessageNotFound,
+			},
+		}),
+	},
+}
+
+var mergeGenreTestCases = map[string]struct {
+	requestId   string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+}{
+	"valid request": {
+		requestId:   "10",
+		requestBody: `{"into": 6}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, response.GenreResponse{
+			Id:         6,
+			Slug:       "drama",
+			Name:       "Drama",
+			MovieCount: 1,
+		}),
+	},
+
+	"merge into itself": {
+		requestId:   "6",
+		requestBody: `{"into": 6}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "genre",
+			Data: map[string]string{
+				"into": "must be a different genre",
+			},
+		}),
+	},
+
+	"missing target": {
+		requestId:   "6",
+		requestBody: `{}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "genre",
+			Data: map[string]string{
+				"into": "must be provided",
+			},
+		}),
+	},
+
+	"non-existent target": {
+		requestId:   "6",
+		requestBody: `{"into": 99}`,
+		wantCode:    404,
+		wantBody: response.ErrorResponse(404, response.Error{
+			Type: "generic",
+			Data: map[string]string{
+				"message": responseErrors.ErrMessageNotFound,
+			},
+		}),
+	},
+}
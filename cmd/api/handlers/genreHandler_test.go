@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestGenreHandler_List(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/genres/", nil)
+	setBearerToken(req)
+
+	app.Router(testRouteHandlers).ServeHTTP(rr, req)
+	code, body, _ := parseResponse(t, rr.Result())
+
+	// assert status code
+	testhelpers.AssertEqual(t, code, listGenresTestCase.wantCode)
+
+	// assert response body
+	wantBody, _ := json.Marshal(listGenresTestCase.wantBody)
+	testhelpers.AssertEqual(t, body, string(wantBody))
+}
+
+func TestGenreHandler_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := createGenreTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/genres/", strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, headers := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert headers
+			assertHeaders(t, headers, tc.wantHeaders)
+		})
+	}
+}
+
+func TestGenreHandler_Rename(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := renameGenreTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, path.Join("/v1/genres", tc.requestId), strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestGenreHandler_Merge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := mergeGenreTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, path.Join("/v1/genres", tc.requestId, "merge"), strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
@@ -1,10 +1,17 @@
 package handlers
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/rhodeon/moviescreen/cmd/api/models/request"
 	"github.com/rhodeon/moviescreen/cmd/api/models/response"
 	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/jsonpatch"
 	"github.com/rhodeon/moviescreen/internal/validator"
 	"net/http"
 	"net/url"
@@ -28,6 +35,113 @@ func parseJsonRequest(ctx *gin.Context, request request.ClientRequest) error {
 	return nil
 }
 
+// Content types of patch documents.
+const (
+	contentTypeMergePatch = "application/merge-patch+json"
+	contentTypeJsonPatch  = "application/json-patch+json"
+)
+
+// isPatchRequest returns true if the request body is a JSON merge patch or JSON patch document.
+func isPatchRequest(ctx *gin.Context) bool {
+	contentType := ctx.ContentType()
+	return contentType == contentTypeMergePatch || contentType == contentTypeJsonPatch
+}
+
+// parsePatchRequest applies the JSON merge patch or JSON patch in the request body to the original request,
+// and populates the patched request struct with the result.
+// A 400 error is returned if the patch document is malformed, and a 422 error
+// if it cannot be applied or produces an invalid document.
+func parsePatchRequest(ctx *gin.Context, original request.ClientRequest, patched request.ClientRequest) error {
+	document, err := json.Marshal(original)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return err
+	}
+
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			response.BadRequestError(err),
+		)
+		return err
+	}
+
+	var result []byte
+	if ctx.ContentType() == contentTypeMergePatch {
+		result, err = jsonpatch.MergePatch(document, patch)
+	} else {
+		result, err = jsonpatch.Apply(document, patch)
+	}
+
+	if err != nil {
+		var operationError *jsonpatch.OperationError
+		if !errors.As(err, &operationError) {
+			// respond with a BadRequestError for malformed patch documents
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				response.BadRequestError(err),
+			)
+			return err
+		}
+
+		v := validator.New("patch")
+		v.AddError("operations", operationError.Error())
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return err
+	}
+
+	// decode the patched document, rejecting unknown fields
+	decoder := json.NewDecoder(bytes.NewReader(result))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(patched)
+	if err != nil {
+		v := validator.New("patch")
+		v.AddError("document", fmt.Sprintf("patched document is invalid: %s", response.BadRequestError(err).Error.Data["message"]))
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// Content types of bulk import bodies.
+const (
+	contentTypeCsv       = "text/csv"
+	contentTypeNdjson    = "application/x-ndjson"
+	contentTypeJsonLines = "application/jsonl"
+)
+
+// handleBodyReadError responds to an error which prevents an import or upload body from being read further.
+// A 413 error is returned if the body exceeds the size limit, and a 400 error otherwise.
+func handleBodyReadError(ctx *gin.Context, err error) {
+	status, message := bodyReadError(err)
+	responseErrors.SetStatusAndBody(ctx, status, response.GenericError(message))
+}
+
+// bodyReadError returns the status code and message of the response to an error
+// which prevents an import or upload body from being read further.
+func bodyReadError(err error) (int, string) {
+	var maxBytesError *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &maxBytesError):
+		return http.StatusRequestEntityTooLarge, responseErrors.ErrMessageRequestTooLarge
+
+	case errors.Is(err, bufio.ErrTooLong):
+		return http.StatusBadRequest, "record cannot be greater than 1MB"
+
+	default:
+		return http.StatusBadRequest, err.Error()
+	}
+}
+
 // validateJsonRequest returns a 422 error if the request doesn't pass all validation rules.
 func validateJsonRequest(ctx *gin.Context, request request.ClientRequest, required []string) error {
 	// validate the response fields with custom checks
@@ -79,6 +193,21 @@ func parseQueryInt(queries url.Values, key string, defaultValue int) int {
 	return queryValue
 }
 
+// parseQueryBool converts a url query parameter to a boolean.
+func parseQueryBool(queries url.Values, key string, defaultValue bool) bool {
+	query := queries.Get(key)
+
+	if query == "" {
+		return defaultValue
+	}
+
+	queryValue, err := strconv.ParseBool(query)
+	if err != nil {
+		return defaultValue
+	}
+	return queryValue
+}
+
 // parseQueryString converts a url query parameter with multiple items to a list.
 func parseQueryCsv(query url.Values, key string, defaultValue []string) []string {
 	csv := query.Get(key)
@@ -88,3 +217,100 @@ func parseQueryCsv(query url.Values, key string, defaultValue []string) []string
 	}
 	return strings.Split(csv, ",")
 }
+
+// parseMovieQuery extracts the movie search queries from the url queries.
+func parseMovieQuery(queries url.Values) request.MovieQuery {
+	return request.MovieQuery{
+		Title:         parseQueryString(queries, "title", ""),
+		Genres:        parseQueryCsv(queries, "genres", []string{}),
+		Language:      parseQueryString(queries, request.MovieQueryFieldLanguage, ""),
+		Countries:     parseQueryCsv(queries, request.MovieQueryFieldCountries, []string{}),
+		Certification: parseQueryString(queries, request.MovieQueryFieldCertification, ""),
+		ReleasedFrom:  parseQueryString(queries, request.MovieQueryFieldReleasedFrom, ""),
+		ReleasedTo:    parseQueryString(queries, request.MovieQueryFieldReleasedTo, ""),
+	}
+}
+
+// parseFieldsQuery extracts the sparse fieldset and embedded relations from the url queries.
+// A 422 error is returned if an unknown field or relation is requested.
+func parseFieldsQuery(ctx *gin.Context, queries url.Values, validFields []string, validIncludes []string) (request.Fields, error) {
+	fields := request.Fields{
+		Selected:      parseQueryCsv(queries, request.FieldsFieldFields, []string{}),
+		Include:       parseQueryCsv(queries, request.FieldsFieldInclude, []string{}),
+		ValidFields:   validFields,
+		ValidIncludes: validIncludes,
+	}
+
+	if v := fields.Validate(); !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return request.Fields{}, validator.NewError()
+	}
+	return fields, nil
+}
+
+// selectMovieFields clears the fields of the movie response which aren't in the sparse fieldset,
+// leaving them to be omitted from the JSON output.
+// The response is returned unchanged if no field is selected.
+func selectMovieFields(movie response.MovieResponse, fields []string) response.MovieResponse {
+	if len(fields) == 0 {
+		return movie
+	}
+
+	// the translation describes the representation rather than the movie,
+	// and the collections are an embedded relation, so they're kept regardless of the fields
+	selected := response.MovieResponse{Translation: movie.Translation, Collections: movie.Collections}
+	for _, field := range fields {
+		switch field {
+		case request.MovieFieldId:
+			selected.Id = movie.Id
+		case request.MovieFieldTitle:
+			selected.Title = movie.Title
+		case request.MovieFieldYear:
+			selected.Year = movie.Year
+		case request.MovieFieldRuntime:
+			selected.Runtime = movie.Runtime
+		case request.MovieFieldGenres:
+			selected.Genres = movie.Genres
+		case request.MovieFieldOriginalTitle:
+			selected.OriginalTitle = movie.OriginalTitle
+		case request.MovieFieldOriginalLanguage:
+			selected.OriginalLanguage = movie.OriginalLanguage
+		case request.MovieFieldCountries:
+			selected.Countries = movie.Countries
+		case request.MovieFieldReleaseDates:
+			selected.ReleaseDates = movie.ReleaseDates
+		case request.MovieFieldCertifications:
+			selected.Certifications = movie.Certifications
+		case request.MovieFieldSynopsis:
+			selected.Synopsis = movie.Synopsis
+		case request.MovieFieldTagline:
+			selected.Tagline = movie.Tagline
+		case request.MovieFieldExternalIds:
+			selected.ExternalIds = movie.ExternalIds
+		case request.MovieFieldPoster:
+			selected.Poster = movie.Poster
+		case request.MovieFieldBackdrop:
+			selected.Backdrop = movie.Backdrop
+		case request.MovieFieldVersion:
+			selected.Version = movie.Version
+		}
+	}
+	return selected
+}
+
+// setValidGenres sets the known genres of the request for its validation,
+// and replaces its genres with their canonical names.
+// A 500 error is returned if the genres can't be fetched.
+func setValidGenres(ctx *gin.Context, repositories repository.Repositories, genreRequest request.GenreListRequest) error {
+	validGenres, err := repositories.Genres.Names()
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return err
+	}
+
+	genreRequest.SetValidGenres(validGenres)
+	return nil
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Formats of movie exports.
+const (
+	exportFormatCsv    = "csv"
+	exportFormatNdjson = "ndjson"
+	exportFormatJson   = "json"
+)
+
+// exportFormats maps each export format to the content type of its response.
+var exportFormats = map[string]string{
+	exportFormatCsv:    "text/csv; charset=utf-8",
+	exportFormatNdjson: contentTypeNdjson,
+	exportFormatJson:   "application/json; charset=utf-8",
+}
+
+// exportFlushInterval is the number of movies written between flushes of an export to the client.
+const exportFlushInterval = 100
+
+// movieExportWriter writes movies to a response in an export format.
+// The CSV format begins with a header row and separates genres with request.MovieCsvGenreSeparator,
+// making it readable by the importer, which ignores its id and version columns.
+// The JSON format is a single array of movies.
+type movieExportWriter struct {
+	format  string
+	buffer  *bufio.Writer
+	csv     *csv.Writer
+	flusher http.Flusher
+	count   int
+}
+
+// newMovieExportWriter returns a writer of the format to the response,
+// writing the opening of the format.
+func newMovieExportWriter(format string, w http.ResponseWriter) (*movieExportWriter, error) {
+	writer := &movieExportWriter{
+		format: format,
+		buffer: bufio.NewWriter(w),
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		writer.flusher = flusher
+	}
+
+	switch format {
+	case exportFormatCsv:
+		writer.csv = csv.NewWriter(writer.buffer)
+		err := writer.csv.Write([]string{
+			request.MovieFieldId,
+			request.MovieFieldTitle,
+			request.MovieFieldYear,
+			request.MovieFieldRuntime,
+			request.MovieFieldGenres,
+			request.MovieFieldVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case exportFormatJson:
+		if _, err := writer.buffer.WriteString("["); err != nil {
+			return nil, err
+		}
+	}
+
+	return writer, nil
+}
+
+// Write writes the movie, flushing the export to the client at every exportFlushInterval movies.
+func (w *movieExportWriter) Write(movie response.MovieResponse) error {
+	var err error
+
+	switch w.format {
+	case exportFormatCsv:
+		err = w.csv.Write([]string{
+			strconv.Itoa(movie.Id),
+			movie.Title,
+			strconv.Itoa(movie.Year),
+			strconv.Itoa(movie.Runtime),
+			strings.Join(movie.Genres, request.MovieCsvGenreSeparator),
+			strconv.Itoa(movie.Version),
+		})
+
+	case exportFormatNdjson, exportFormatJson:
+		var js []byte
+		js, err = json.Marshal(movie)
+		if err != nil {
+			return err
+		}
+
+		// separate the array elements with commas, or the lines with newlines
+		if w.format == exportFormatJson {
+			if w.count > 0 {
+				err = w.buffer.WriteByte(',')
+			}
+		} else {
+			js = append(js, '\n')
+		}
+		if err == nil {
+			_, err = w.buffer.Write(js)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.count++
+	if w.count%exportFlushInterval == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close writes the closing of the format and flushes the remainder of the export.
+func (w *movieExportWriter) Close() error {
+	if w.format == exportFormatJson {
+		if _, err := w.buffer.WriteString("]"); err != nil {
+			return err
+		}
+	}
+	return w.flush()
+}
+
+// flush sends the buffered data to the client.
+func (w *movieExportWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.buffer.Flush(); err != nil {
+		return err
+	}
+
+	if w.flusher != nil {
+		w.flusher.Flush()
+	}
+	return nil
+}
@@ -2,26 +2,35 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/rhodeon/moviescreen/cmd/api/common"
 	"github.com/rhodeon/moviescreen/cmd/api/models/request"
 	"github.com/rhodeon/moviescreen/cmd/api/models/response"
 	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
 	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/prettylog"
+	"io"
 	"net/http"
+	"net/url"
 	"path"
+	"sort"
 	"strconv"
 )
 
 type movieHandler struct {
 	config       common.Config
 	repositories repository.Repositories
+	storage      repository.BlobStorage
 }
 
-func NewMovieHandler(config common.Config, repositories repository.Repositories) common.MovieHandler {
+func NewMovieHandler(config common.Config, repositories repository.Repositories, storage repository.BlobStorage) common.MovieHandler {
 	return &movieHandler{
 		config:       config,
 		repositories: repositories,
+		storage:      storage,
 	}
 }
 
@@ -33,6 +42,10 @@ func (m movieHandler) Create(ctx *gin.Context) {
 	if err != nil {
 		return
 	}
+	err = setValidGenres(ctx, m.repositories, movieRequest)
+	if err != nil {
+		return
+	}
 
 	// validate the request with all fields being mandatory for creation
 	err = validateJsonRequest(ctx, movieRequest, []string{
@@ -45,11 +58,27 @@ func (m movieHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	// attempt to create a new movie in the repository from the request
+	// reject external ids which are already registered to other movies
 	newMovie := movieRequest.ToModel()
-	err = m.repositories.Movies.Create(&newMovie)
+	err = m.checkExternalIds(ctx, newMovie)
 	if err != nil {
-		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	// attempt to create a new movie in the repository from the request, along with its revision
+	err = m.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		err := repositories.Movies.Create(&newMovie)
+		if err != nil {
+			return err
+		}
+		return insertRevisions(ctx, repositories, models.NewMovieRevision(models.RevisionActionCreate, newMovie, nil))
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateExternalId) {
+			abortWithExternalIdConflict(ctx, "already registered to another movie")
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
 		return
 	}
 
@@ -73,24 +102,69 @@ func (m movieHandler) GetById(ctx *gin.Context) {
 		return
 	}
 
+	// set and validate the sparse fieldset
+	fields, err := parseFieldsQuery(ctx, ctx.Request.URL.Query(), request.MovieFields, request.MovieIncludes)
+	if err != nil {
+		return
+	}
+
 	// attempt to fetch movie from the repository
-	movie, err := m.repositories.Movies.Get(id)
+	movie, err := m.repositories.Movies.Get(id, translatableMovieFields(fields.Selected)...)
 	if err != nil {
-		// return a 404 error if the movie id doesn't exist in the repository
+		// redirect to the movie which the movie was merged into,
+		// or return a 404 error if the movie id doesn't exist in the repository
 		if errors.Is(err, repository.ErrRecordNotFound) {
-			responseErrors.NewErrorHandler().NotFound(ctx)
+			m.redirectMerged(ctx, id)
 		} else {
 			responseErrors.HandleInternalServerError(ctx, err)
 		}
 		return
 	}
 
+	// apply the translation which best matches the accepted languages
+	movies := models.Movies{movie}
+	err = m.translateMovies(ctx, movies)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	// embed the collections the movie belongs to if requested
+	if fields.Includes(request.MovieIncludeCollections) {
+		err = m.embedCollections(ctx, movies)
+		if err != nil {
+			responseErrors.HandleInternalServerError(ctx, err)
+			return
+		}
+	}
+	movie = movies[0]
+	resp := selectMovieFields(movie.ToResponse(), fields.Selected)
+
+	// translations and collections change independently of the movie's version,
+	// so translated movies and those with embedded collections are tagged by their content instead
+	etag := movieETag(movie.Id, movie.Version)
+	if movie.Translation != "" {
+		ctx.Header("Content-Language", movie.Translation)
+	}
+	if movie.Translation != "" || movie.Collections != nil {
+		etag, err = weakETag(resp)
+		if err != nil {
+			responseErrors.HandleInternalServerError(ctx, err)
+			return
+		}
+	}
+
+	// respond with a 304 status if the client's copy of the movie is up-to-date
+	if notModified(ctx, etag) {
+		return
+	}
+
 	// send response
 	ctx.JSON(
 		http.StatusOK,
 		response.SuccessResponse(
 			http.StatusOK,
-			movie.ToResponse(),
+			resp,
 		),
 	)
 }
@@ -99,10 +173,9 @@ func (m movieHandler) GetById(ctx *gin.Context) {
 func (m movieHandler) List(ctx *gin.Context) {
 	// set the queries
 	queries := ctx.Request.URL.Query()
-	titleQuery := parseQueryString(queries, "title", "")
-	genreQuery := parseQueryCsv(queries, "genres", []string{})
+	movieQuery := parseMovieQuery(queries)
 
-	// set and validate the filters
+	// set and validate the filters and queries
 	filers := request.Filters{
 		Page:  parseQueryInt(queries, "page", 1),
 		Limit: parseQueryInt(queries, "limit", 20),
@@ -116,6 +189,7 @@ func (m movieHandler) List(ctx *gin.Context) {
 	}
 
 	validator := filers.Validate()
+	movieQuery.Validate(validator)
 	if !validator.Valid() {
 		ctx.AbortWithStatusJSON(
 			http.StatusUnprocessableEntity,
@@ -124,26 +198,66 @@ func (m movieHandler) List(ctx *gin.Context) {
 		return
 	}
 
+	// set and validate the sparse fieldset
+	fields, err := parseFieldsQuery(ctx, queries, request.MovieFields, request.MovieIncludes)
+	if err != nil {
+		return
+	}
+
 	// attempt to retrieve movies
-	movies, metadata, err := m.repositories.Movies.List(titleQuery, genreQuery, filers)
+	movies, metadata, err := m.repositories.Movies.List(movieQuery, filers, translatableMovieFields(fields.Selected)...)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	// apply the translations which best match the accepted languages
+	err = m.translateMovies(ctx, movies)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	// embed the collections of the movies if requested
+	if fields.Includes(request.MovieIncludeCollections) {
+		err = m.embedCollections(ctx, movies)
+		if err != nil {
+			responseErrors.HandleInternalServerError(ctx, err)
+			return
+		}
+	}
+
+	moviesResponse := movies.ToResponse()
+	for i := range moviesResponse {
+		moviesResponse[i] = selectMovieFields(moviesResponse[i], fields.Selected)
+	}
+
+	resp := response.BaseResponse{
+		Success:  true,
+		Status:   http.StatusOK,
+		Data:     moviesResponse,
+		Metadata: &metadata,
+	}
+
+	// set the caching headers from the list content and the latest modification,
+	// responding with a 304 status if the client's copy of the list is up-to-date
+	etag, err := weakETag(resp)
 	if err != nil {
 		responseErrors.HandleInternalServerError(ctx, err)
 		return
 	}
+	setLastModified(ctx, movies.LastModified())
+	if notModified(ctx, etag) {
+		return
+	}
 
 	// return movie list and metadata response
-	ctx.JSON(
-		http.StatusOK,
-		response.BaseResponse{
-			Success:  true,
-			Status:   http.StatusOK,
-			Data:     movies.ToResponse(),
-			Metadata: &metadata,
-		},
-	)
+	ctx.JSON(http.StatusOK, resp)
 }
 
 // Update replaces the data of the movie with the given ID query in the repository.
+// The request body can either be a JSON object of the fields to replace,
+// a JSON merge patch or a JSON patch document.
 func (m movieHandler) Update(ctx *gin.Context) {
 	// validate id
 	id, err := parseIdParam(ctx)
@@ -151,36 +265,94 @@ func (m movieHandler) Update(ctx *gin.Context) {
 		return
 	}
 
-	// parse JSON request body
+	var movie models.Movie
 	movieRequest := &request.MovieRequest{}
-	err = parseJsonRequest(ctx, movieRequest)
-	if err != nil {
-		return
+
+	if isPatchRequest(ctx) {
+		// fetch the movie to apply the patch to
+		movie, err = m.fetchMovie(ctx, id)
+		if err != nil {
+			return
+		}
+
+		// apply the patch to the current movie data
+		err = parsePatchRequest(ctx, request.NewMovieRequest(movie), movieRequest)
+		if err != nil {
+			return
+		}
+		err = setValidGenres(ctx, m.repositories, movieRequest)
+		if err != nil {
+			return
+		}
+
+		// validate the patched movie with all fields being mandatory
+		err = validateJsonRequest(ctx, movieRequest, []string{
+			request.MovieFieldTitle,
+			request.MovieFieldYear,
+			request.MovieFieldRuntime,
+			request.MovieFieldGenres,
+		})
+		if err != nil {
+			return
+		}
+	} else {
+		// parse JSON request body
+		err = parseJsonRequest(ctx, movieRequest)
+		if err != nil {
+			return
+		}
+		err = setValidGenres(ctx, m.repositories, movieRequest)
+		if err != nil {
+			return
+		}
+
+		// validate the request with all fields being optional for update
+		err = validateJsonRequest(ctx, movieRequest, []string{})
+		if err != nil {
+			return
+		}
+
+		// fetch movie from repository
+		movie, err = m.fetchMovie(ctx, id)
+		if err != nil {
+			return
+		}
 	}
 
-	// validate the request with all fields being optional for update
-	err = validateJsonRequest(ctx, movieRequest, []string{})
-	if err != nil {
+	m.update(ctx, movie, movieRequest)
+}
+
+// update applies the validated request to the movie and saves it in the repository,
+// recording the change as a revision and returning the updated movie.
+// A 412 error is returned if the client's copy of the movie is outdated.
+func (m movieHandler) update(ctx *gin.Context, movie models.Movie, movieRequest *request.MovieRequest) {
+	// ensure the client's copy of the movie is up-to-date before updating
+	if preconditionFailed(ctx, movieETag(movie.Id, movie.Version)) {
 		return
 	}
+	previous := movie
+	movieRequest.UpdateModel(&movie)
 
-	// fetch and update movie from repository
-	movie, err := m.repositories.Movies.Get(id)
-	if err != nil {
-		if errors.Is(err, repository.ErrRecordNotFound) {
-			responseErrors.NewErrorHandler().NotFound(ctx)
-		} else {
-			responseErrors.HandleInternalServerError(ctx, err)
+	// reject external ids which are already registered to other movies
+	if movieRequest.ExternalIds != nil {
+		if err := m.checkExternalIds(ctx, movie); err != nil {
+			return
 		}
-		return
 	}
-	movieRequest.UpdateModel(&movie)
 
-	// reinsert updated movie into the repository
-	err = m.repositories.Movies.Update(&movie)
+	// reinsert updated movie into the repository, along with its revision
+	err := m.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		err := repositories.Movies.Update(&movie)
+		if err != nil {
+			return err
+		}
+		return insertRevisions(ctx, repositories, models.NewMovieRevision(models.RevisionActionUpdate, movie, &previous))
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrEditConflict) {
 			responseErrors.NewErrorHandler().EditConflict(ctx)
+		} else if errors.Is(err, repository.ErrDuplicateExternalId) {
+			abortWithExternalIdConflict(ctx, "already registered to another movie")
 		} else {
 			responseErrors.HandleInternalServerError(ctx, err)
 		}
@@ -188,6 +360,7 @@ func (m movieHandler) Update(ctx *gin.Context) {
 	}
 
 	// return updated movie
+	ctx.Header("ETag", movieETag(movie.Id, movie.Version))
 	ctx.JSON(
 		http.StatusOK,
 		response.SuccessResponse(
@@ -197,7 +370,8 @@ func (m movieHandler) Update(ctx *gin.Context) {
 	)
 }
 
-// Delete deletes the movie with the given id parameter from the repository.
+// Delete moves the movie with the given id parameter to the trash of the repository,
+// from which it can be restored until it is purged.
 func (m movieHandler) Delete(ctx *gin.Context) {
 	// validate id
 	id, err := parseIdParam(ctx)
@@ -205,11 +379,29 @@ func (m movieHandler) Delete(ctx *gin.Context) {
 		return
 	}
 
-	// attempt to delete movie from the repository
-	err = m.repositories.Movies.Delete(id)
+	// fetch the movie to record its deletion
+	movie, err := m.fetchMovie(ctx, id)
 	if err != nil {
-		if errors.Is(err, repository.ErrRecordNotFound) {
-			responseErrors.NewErrorHandler().NotFound(ctx)
+		return
+	}
+
+	// ensure the client's copy of the movie is up-to-date before deleting
+	if preconditionFailed(ctx, movieETag(movie.Id, movie.Version)) {
+		return
+	}
+
+	// attempt to delete movie from the repository along with recording its deletion,
+	// failing if it was changed since it was fetched for the precondition
+	err = m.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		err := repositories.Movies.Delete(id, movie.Version)
+		if err != nil {
+			return err
+		}
+		return insertRevisions(ctx, repositories, models.NewMovieRevision(models.RevisionActionDelete, movie, nil))
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrEditConflict) {
+			responseErrors.NewErrorHandler().EditConflict(ctx)
 		} else {
 			responseErrors.HandleInternalServerError(ctx, err)
 		}
@@ -226,3 +418,908 @@ func (m movieHandler) Delete(ctx *gin.Context) {
 		),
 	)
 }
+
+// Trash returns a list of the deleted movies which haven't been purged yet.
+func (m movieHandler) Trash(ctx *gin.Context) {
+	// set and validate the filters, with the most recently deleted movies first by default
+	queries := ctx.Request.URL.Query()
+	filters := request.Filters{
+		Page:  parseQueryInt(queries, "page", 1),
+		Limit: parseQueryInt(queries, "limit", 20),
+		Sort:  parseQueryString(queries, "sort", "-"+request.MovieFilterSortDeleted),
+		ValidSorts: []string{
+			request.MovieFilterSortId,
+			request.MovieFilterSortTitle,
+			request.MovieFilterSortDeleted,
+		},
+	}
+
+	validator := filters.Validate()
+	if !validator.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(validator),
+		)
+		return
+	}
+
+	// attempt to retrieve deleted movies
+	movies, metadata, err := m.repositories.Movies.ListDeleted(filters)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	// return movie list and metadata response
+	ctx.JSON(
+		http.StatusOK,
+		response.BaseResponse{
+			Success:  true,
+			Status:   http.StatusOK,
+			Data:     movies.ToResponse(),
+			Metadata: &metadata,
+		},
+	)
+}
+
+// Restore moves the deleted movie with the given id out of the trash, and returns the restored movie.
+func (m movieHandler) Restore(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	// attempt to restore the movie along with recording its restoration,
+	// returning a 404 error if it isn't in the trash
+	var movie models.Movie
+	err = m.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		movie, err = repositories.Movies.Restore(id)
+		if err != nil {
+			return err
+		}
+		return insertRevisions(ctx, repositories, models.NewMovieRevision(models.RevisionActionRestore, movie, nil))
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	// return restored movie
+	ctx.Header("ETag", movieETag(movie.Id, movie.Version))
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			movie.ToResponse(),
+		),
+	)
+}
+
+// Import creates movies in bulk from a CSV or newline-delimited JSON request body.
+// Each record is validated as in Create, with invalid records being skipped and reported.
+// Valid records are gathered in batches, whose external ids are checked against the existing movies, trashed or not,
+// with a query per source, and inserted unless the "dry_run" query is set.
+// A batch which fails to be inserted doesn't stop the import, with the rows of its records reported as failed
+// so the client knows which records were saved.
+// An error reading the body or looking up the external ids stops the import, discarding the pending batch,
+// and is returned along with the report of the batches saved before it.
+func (m movieHandler) Import(ctx *gin.Context) {
+	dryRun := parseQueryBool(ctx.Request.URL.Query(), "dry_run", false)
+
+	// select the record reader for the content type
+	var reader request.MovieRecordReader
+	switch ctx.ContentType() {
+	case contentTypeCsv:
+		csvReader, err := request.NewMovieCsvReader(ctx.Request.Body)
+		if err != nil {
+			handleBodyReadError(ctx, err)
+			return
+		}
+		reader = csvReader
+
+	case contentTypeNdjson, contentTypeJsonLines:
+		reader = request.NewMovieNdjsonReader(ctx.Request.Body)
+
+	default:
+		responseErrors.NewErrorHandler().UnsupportedMediaType(ctx)
+		return
+	}
+
+	validGenres, err := m.repositories.Genres.Names()
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	report := response.MovieImportResponse{
+		DryRun: dryRun,
+		Errors: []response.MovieImportError{},
+	}
+	batch := make(models.Movies, 0, m.config.Import.BatchSize)
+	batchRows := make([]int, 0, m.config.Import.BatchSize)
+
+	// importedExternalIds maps the external ids of the valid records to their rows,
+	// to detect records sharing an id before they're inserted
+	importedExternalIds := map[string]int{}
+
+	// flushBatch skips the pending records with external ids registered to existing movies,
+	// which are looked up for the whole batch, and inserts the others along with their revisions
+	// unless it's a dry run. The rows of the batch are reported as failed if it can't be inserted.
+	flushBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		conflicts, err := m.externalIdConflicts(batch)
+		if err != nil {
+			return err
+		}
+
+		movies := make(models.Movies, 0, len(batch))
+		rows := make([]int, 0, len(batch))
+		for i, movie := range batch {
+			if conflicts[i] != "" {
+				report.AddError(batchRows[i], map[string]string{request.MovieFieldExternalIds: conflicts[i]})
+				continue
+			}
+			movies = append(movies, movie)
+			rows = append(rows, batchRows[i])
+		}
+		report.Valid += len(movies)
+		batch = batch[:0]
+		batchRows = batchRows[:0]
+
+		if dryRun || len(movies) == 0 {
+			return nil
+		}
+
+		err = m.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+			if err := repositories.Movies.BulkCreate(movies); err != nil {
+				return err
+			}
+
+			revisions := make([]models.MovieRevision, len(movies))
+			for i, movie := range movies {
+				revisions[i] = models.NewMovieRevision(models.RevisionActionCreate, movie, nil)
+			}
+			return insertRevisions(ctx, repositories, revisions...)
+		})
+		switch {
+		case err == nil:
+			report.Imported += len(movies)
+
+		case errors.Is(err, repository.ErrDuplicateExternalId):
+			// external ids registered by other requests since the lookup fail the whole batch
+			report.AddFailure(rows, map[string]string{
+				request.MovieFieldExternalIds: "batch has an external id already registered to another movie",
+			})
+
+		default:
+			prettylog.ErrorF("movie import: %s", err.Error())
+			report.AddFailure(rows, map[string]string{"batch": "batch could not be saved"})
+		}
+		return nil
+	}
+
+	// sortErrors orders the errors by row,
+	// as the external ids registered to existing movies are only checked as their batch is flushed
+	sortErrors := func() {
+		sort.SliceStable(report.Errors, func(i, j int) bool {
+			return report.Errors[i].Row < report.Errors[j].Row
+		})
+	}
+
+	// stopImport responds to an error which stops the import at the row with the report,
+	// discarding the pending batch, so the client knows which records were saved before it.
+	stopImport := func(row int, status int, message string) {
+		if len(batchRows) > 0 {
+			row = batchRows[0]
+		}
+		report.StoppedAt = row
+		sortErrors()
+
+		message = fmt.Sprintf("import stopped at row %d after importing %d movies: %s", row, report.Imported, message)
+		ctx.AbortWithStatusJSON(status, response.PartialResponse(status, report, response.GenericError(message)))
+	}
+
+	for row := 1; ; row++ {
+		movieRequest, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			// skip malformed records, stopping the import on any other error
+			var recordError *request.RecordError
+			if !errors.As(err, &recordError) {
+				status, message := bodyReadError(err)
+				stopImport(row, status, message)
+				return
+			}
+			report.Total++
+			report.AddError(row, map[string]string{recordError.Field: recordError.Message})
+			continue
+		}
+		report.Total++
+		movieRequest.SetValidGenres(validGenres)
+
+		// validate the record with all fields being mandatory for creation
+		v := movieRequest.Validate([]string{
+			request.MovieFieldTitle,
+			request.MovieFieldYear,
+			request.MovieFieldRuntime,
+			request.MovieFieldGenres,
+		})
+		if !v.Valid() {
+			report.AddError(row, v.FirstErrors())
+			continue
+		}
+
+		// skip records with external ids used by earlier records,
+		// leaving those registered to existing movies to be checked with the batch
+		movie := movieRequest.ToModel()
+		conflict := ""
+		for _, source := range models.ExternalIdSources {
+			if id, exists := movie.ExternalIds[source]; exists && conflict == "" {
+				if previousRow, imported := importedExternalIds[source+":"+id]; imported {
+					conflict = fmt.Sprintf("%s id %q is already used by row %d", source, id, previousRow)
+				}
+			}
+		}
+		if conflict != "" {
+			report.AddError(row, map[string]string{request.MovieFieldExternalIds: conflict})
+			continue
+		}
+		for source, id := range movie.ExternalIds {
+			importedExternalIds[source+":"+id] = row
+		}
+
+		batch = append(batch, movie)
+		batchRows = append(batchRows, row)
+		if len(batch) == m.config.Import.BatchSize {
+			if err := flushBatch(); err != nil {
+				prettylog.ErrorF("movie import: %s", err.Error())
+				stopImport(row, http.StatusInternalServerError, responseErrors.ErrMessageInternalServer)
+				return
+			}
+		}
+	}
+
+	// insert the remaining movies
+	if err := flushBatch(); err != nil {
+		prettylog.ErrorF("movie import: %s", err.Error())
+		stopImport(0, http.StatusInternalServerError, responseErrors.ErrMessageInternalServer)
+		return
+	}
+	sortErrors()
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			report,
+		),
+	)
+}
+
+// Export streams all the movies matching the queries in the requested format,
+// as an attachment.
+// The movies are iterated over without pagination, so the export isn't limited in size.
+// Its route removes the server deadlines, so exports taking longer than the write timeout aren't cut short.
+func (m movieHandler) Export(ctx *gin.Context) {
+	// set the queries
+	queries := ctx.Request.URL.Query()
+	movieQuery := parseMovieQuery(queries)
+	format := parseQueryString(queries, "format", exportFormatJson)
+
+	// set and validate the sort filter and format
+	filters := request.Filters{
+		Sort: parseQueryString(queries, "sort", "id"),
+		ValidSorts: []string{
+			request.MovieFilterSortId,
+			request.MovieFilterSortTitle,
+			request.MovieFilterSortYear,
+			request.MovieFilterSortRuntime,
+		},
+	}
+
+	v := filters.ValidateSort()
+	movieQuery.Validate(v)
+	contentType, validFormat := exportFormats[format]
+	v.Check(validFormat, "format", "invalid format value")
+	if !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	// fetch the first movie before writing the response,
+	// so a failure can still be reported with an error status
+	iterator := m.repositories.Movies.Iterate(movieQuery, filters)
+	hasMovie := iterator.Next()
+	if err := iterator.Err(); err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="movies.%s"`, format))
+	ctx.Status(http.StatusOK)
+
+	writer, err := newMovieExportWriter(format, ctx.Writer)
+	if err != nil {
+		prettylog.ErrorF("movie export: %s", err.Error())
+		ctx.Abort()
+		return
+	}
+
+	// errors after this point can't change the response status,
+	// so the export is cut short and the error logged
+	for ; hasMovie; hasMovie = iterator.Next() {
+		movie := iterator.Movie()
+		if err := writer.Write(movie.ToResponse()); err != nil {
+			prettylog.ErrorF("movie export: %s", err.Error())
+			ctx.Abort()
+			return
+		}
+	}
+	if err := iterator.Err(); err != nil {
+		prettylog.ErrorF("movie export: %s", err.Error())
+		ctx.Abort()
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		prettylog.ErrorF("movie export: %s", err.Error())
+		ctx.Abort()
+	}
+}
+
+// Revisions returns the revisions of the movie with the given id, including those
+// of movies in the trash.
+func (m movieHandler) Revisions(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	// set and validate the filters, with the latest revisions first by default
+	queries := ctx.Request.URL.Query()
+	filters := request.Filters{
+		Page:       parseQueryInt(queries, "page", 1),
+		Limit:      parseQueryInt(queries, "limit", 20),
+		Sort:       parseQueryString(queries, "sort", "-"+request.MovieRevisionFilterSortVersion),
+		ValidSorts: []string{request.MovieRevisionFilterSortVersion},
+	}
+
+	validator := filters.Validate()
+	if !validator.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(validator),
+		)
+		return
+	}
+
+	// attempt to retrieve the revisions, returning a 404 error if the movie has none
+	revisions, metadata, err := m.repositories.MovieRevisions.List(id, filters)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+	if metadata.TotalRecords == 0 {
+		responseErrors.NewErrorHandler().NotFound(ctx)
+		return
+	}
+
+	// return revision list and metadata response
+	ctx.JSON(
+		http.StatusOK,
+		response.BaseResponse{
+			Success:  true,
+			Status:   http.StatusOK,
+			Data:     revisions.ToResponse(),
+			Metadata: &metadata,
+		},
+	)
+}
+
+// DiffRevisions returns the changes of the movie with the given id between the "from" and "to" versions.
+func (m movieHandler) DiffRevisions(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	// set and validate the versions
+	queries := ctx.Request.URL.Query()
+	diffRequest := request.MovieDiffRequest{
+		From: parseQueryInt(queries, request.MovieDiffFieldFrom, 0),
+		To:   parseQueryInt(queries, request.MovieDiffFieldTo, 0),
+	}
+
+	validator := diffRequest.Validate()
+	if !validator.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(validator),
+		)
+		return
+	}
+
+	// fetch the revisions of both versions
+	from, err := m.fetchRevision(ctx, id, diffRequest.From)
+	if err != nil {
+		return
+	}
+	to, err := m.fetchRevision(ctx, id, diffRequest.To)
+	if err != nil {
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			response.MovieDiffResponse{
+				From:    from.Version,
+				To:      to.Version,
+				Changes: models.DiffMovieSnapshots(from.Snapshot, to.Snapshot).ToResponse(),
+			},
+		),
+	)
+}
+
+// RevertRevision updates the movie with the given id to its state at the given version.
+// The update goes through the same validation, precondition and revision recording as other updates.
+func (m movieHandler) RevertRevision(ctx *gin.Context) {
+	// validate id and version
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil {
+		responseErrors.NewErrorHandler().NotFound(ctx)
+		return
+	}
+
+	// fetch the movie and its state at the version
+	movie, err := m.fetchMovie(ctx, id)
+	if err != nil {
+		return
+	}
+	revision, err := m.fetchRevision(ctx, id, version)
+	if err != nil {
+		return
+	}
+
+	// validate the reverted state with all fields being mandatory
+	movieRequest := request.NewMovieRequest(revision.Snapshot.ToModel())
+	err = setValidGenres(ctx, m.repositories, movieRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, movieRequest, []string{
+		request.MovieFieldTitle,
+		request.MovieFieldYear,
+		request.MovieFieldRuntime,
+		request.MovieFieldGenres,
+	})
+	if err != nil {
+		return
+	}
+
+	m.update(ctx, movie, movieRequest)
+}
+
+// Translations returns the translations of the movie with the given id.
+func (m movieHandler) Translations(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	// ensure the movie exists outside the trash
+	_, err = m.fetchMovie(ctx, id)
+	if err != nil {
+		return
+	}
+
+	translations, err := m.repositories.MovieTranslations.List(id)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			translations.ToResponse(),
+		),
+	)
+}
+
+// PutTranslation creates or replaces the translation of the movie with the given id in the given language.
+// The language is stored as its canonical BCP 47 tag.
+func (m movieHandler) PutTranslation(ctx *gin.Context) {
+	// validate id and language
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+	lang, err := parseLanguageParam(ctx)
+	if err != nil {
+		return
+	}
+
+	// parse and validate the request body
+	translationRequest := &request.MovieTranslationRequest{}
+	err = parseJsonRequest(ctx, translationRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, translationRequest, []string{request.MovieTranslationFieldTitle})
+	if err != nil {
+		return
+	}
+
+	// attempt to save the translation, returning a 404 error if the movie doesn't exist
+	translation := translationRequest.ToModel(id, lang)
+	created, err := m.repositories.MovieTranslations.Upsert(&translation)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		ctx.Header("Location", path.Join("/v1/movies", strconv.Itoa(id), "translations", lang))
+	}
+	ctx.JSON(
+		status,
+		response.SuccessResponse(
+			status,
+			translation.ToResponse(),
+		),
+	)
+}
+
+// DeleteTranslation removes the translation of the movie with the given id in the given language.
+func (m movieHandler) DeleteTranslation(ctx *gin.Context) {
+	// validate id and language
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+	lang, err := parseLanguageParam(ctx)
+	if err != nil {
+		return
+	}
+
+	err = m.repositories.MovieTranslations.Delete(id, lang)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			map[string]string{"message": "translation deleted successfully"},
+		),
+	)
+}
+
+// Lookup returns the movie with the id of an external source given in the queries.
+func (m movieHandler) Lookup(ctx *gin.Context) {
+	// set and validate the queries
+	queries := ctx.Request.URL.Query()
+	lookupQuery := request.MovieLookupQuery{
+		Source: parseQueryString(queries, request.MovieLookupFieldSource, ""),
+		Id:     parseQueryString(queries, request.MovieLookupFieldId, ""),
+	}
+	if v := lookupQuery.Validate(); !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	// attempt to find the movie, returning a 404 error if the id isn't registered
+	movie, err := m.repositories.Movies.Lookup(lookupQuery.Source, lookupQuery.Id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	// apply the translation which best matches the accepted languages
+	movies := models.Movies{movie}
+	err = m.translateMovies(ctx, movies)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Location", path.Join("/v1/movies", strconv.Itoa(movie.Id)))
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			movies[0].ToResponse(),
+		),
+	)
+}
+
+// Similar returns the movies most similar to the movie with the given id, with the most similar first.
+// The similarities are precomputed periodically, so recently added or changed movies may be missing or outdated.
+func (m movieHandler) Similar(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	// validate the number of movies, which is limited by the number of similarities kept for each movie
+	limit := parseQueryInt(ctx.Request.URL.Query(), request.FilterFieldLimit, 10)
+	v := validator.New("filter")
+	v.Check(limit > 0, request.FilterFieldLimit, "must be greater than zero")
+	v.Check(limit <= repository.MaxSimilarMovies, request.FilterFieldLimit, fmt.Sprintf("must be a maximum of %d", repository.MaxSimilarMovies))
+	if !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	// ensure the movie exists outside the trash
+	_, err = m.fetchMovie(ctx, id)
+	if err != nil {
+		return
+	}
+
+	similarMovies, err := m.repositories.MovieSimilarities.List(id, limit)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	// apply the translations which best match the accepted languages
+	movies := make(models.Movies, len(similarMovies))
+	for i, similar := range similarMovies {
+		movies[i] = similar.Movie
+	}
+	err = m.translateMovies(ctx, movies)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+	for i := range similarMovies {
+		similarMovies[i].Movie = movies[i]
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			similarMovies.ToResponse(),
+		),
+	)
+}
+
+// PutPoster replaces the poster of the movie with the image uploaded in a multipart form,
+// and returns the updated movie.
+func (m movieHandler) PutPoster(ctx *gin.Context) {
+	m.putImage(ctx, models.MovieImageKindPoster)
+}
+
+// DeletePoster removes the poster of the movie, and returns the updated movie.
+func (m movieHandler) DeletePoster(ctx *gin.Context) {
+	m.deleteImage(ctx, models.MovieImageKindPoster)
+}
+
+// PutBackdrop replaces the backdrop of the movie with the image uploaded in a multipart form,
+// and returns the updated movie.
+func (m movieHandler) PutBackdrop(ctx *gin.Context) {
+	m.putImage(ctx, models.MovieImageKindBackdrop)
+}
+
+// DeleteBackdrop removes the backdrop of the movie, and returns the updated movie.
+func (m movieHandler) DeleteBackdrop(ctx *gin.Context) {
+	m.deleteImage(ctx, models.MovieImageKindBackdrop)
+}
+
+// checkExternalIds returns a 422 error if any external id of the movie is registered to another movie.
+func (m movieHandler) checkExternalIds(ctx *gin.Context, movie models.Movie) error {
+	conflict, err := m.externalIdConflict(movie)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return err
+	}
+	if conflict != "" {
+		abortWithExternalIdConflict(ctx, conflict)
+		return validator.NewError()
+	}
+	return nil
+}
+
+// externalIdConflict returns a description of the first external id of the movie which is registered
+// to another movie, including one in the trash, and an empty string if there is none.
+func (m movieHandler) externalIdConflict(movie models.Movie) (string, error) {
+	conflicts, err := m.externalIdConflicts(models.Movies{movie})
+	if err != nil {
+		return "", err
+	}
+	return conflicts[0], nil
+}
+
+// externalIdConflicts returns a description of the first external id of each movie which is registered
+// to another movie, with an empty string for the movies without any.
+// Movies in the trash keep their external ids until they're purged, so they're reported as such.
+// The external ids of all the movies are looked up with a single query per source.
+func (m movieHandler) externalIdConflicts(movies models.Movies) ([]string, error) {
+	conflicts := make([]string, len(movies))
+
+	for _, source := range models.ExternalIdSources {
+		var ids []string
+		for _, movie := range movies {
+			if id, exists := movie.ExternalIds[source]; exists {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		registered, err := m.repositories.Movies.FindByExternalIds(source, ids)
+		if err != nil {
+			return nil, err
+		}
+		registeredMovies := map[string]models.Movie{}
+		for _, movie := range registered {
+			registeredMovies[movie.ExternalIds[source]] = movie
+		}
+
+		// keep the conflict of the earliest source for each movie
+		for i, movie := range movies {
+			id := movie.ExternalIds[source]
+			registeredMovie, exists := registeredMovies[id]
+			if conflicts[i] != "" || !exists || registeredMovie.Id == movie.Id {
+				continue
+			}
+
+			if registeredMovie.Deleted != nil {
+				conflicts[i] = fmt.Sprintf("%s id %q is registered to movie %d in the trash", source, id, registeredMovie.Id)
+			} else {
+				conflicts[i] = fmt.Sprintf("%s id %q is already registered to movie %d", source, id, registeredMovie.Id)
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// abortWithExternalIdConflict aborts the request with a 422 error for an external id registered to another movie.
+func abortWithExternalIdConflict(ctx *gin.Context, message string) {
+	v := validator.New("movie")
+	v.AddError(request.MovieFieldExternalIds, message)
+	ctx.AbortWithStatusJSON(
+		http.StatusUnprocessableEntity,
+		response.UnprocessableEntityError(v),
+	)
+}
+
+// fetchRevision returns the revision of the movie with the given id and version from the repository.
+// A 404 error is returned if the revision doesn't exist.
+func (m movieHandler) fetchRevision(ctx *gin.Context, id int, version int) (models.MovieRevision, error) {
+	revision, err := m.repositories.MovieRevisions.Get(id, version)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return models.MovieRevision{}, err
+	}
+	return revision, nil
+}
+
+// insertRevisions records the revisions of changes made to movies, with the authenticated user as the actor.
+// It's meant to be called within the unit of work of the changes, so they aren't saved without their revisions.
+func insertRevisions(ctx *gin.Context, repositories repository.Repositories, revisions ...models.MovieRevision) error {
+	userId := common.ContextGetUser(ctx).Id
+
+	pointers := make([]*models.MovieRevision, len(revisions))
+	for i := range revisions {
+		revisions[i].UserId = userId
+		pointers[i] = &revisions[i]
+	}
+
+	return repositories.MovieRevisions.Insert(pointers...)
+}
+
+// redirectMerged permanently redirects the request for a movie merged into another movie to the surviving movie,
+// keeping the queries of the request.
+// A 404 error is returned if the movie wasn't merged.
+func (m movieHandler) redirectMerged(ctx *gin.Context, id int) {
+	survivorId, err := m.repositories.Movies.Redirect(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	location := url.URL{
+		Path:     path.Join("/v1/movies", strconv.Itoa(survivorId)),
+		RawQuery: ctx.Request.URL.RawQuery,
+	}
+	ctx.Redirect(http.StatusMovedPermanently, location.String())
+}
+
+// fetchMovie returns the movie with the given id from the repository.
+// A 404 error is returned if the movie doesn't exist.
+func (m movieHandler) fetchMovie(ctx *gin.Context, id int) (models.Movie, error) {
+	movie, err := m.repositories.Movies.Get(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return models.Movie{}, err
+	}
+	return movie, nil
+}
+
+// embedCollections sets the collections visible to the current user on the movies.
+// Movies without collections are given an empty list, so their collections are known to have been fetched.
+func (m movieHandler) embedCollections(ctx *gin.Context, movies models.Movies) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.Id
+	}
+	collections, err := m.repositories.Collections.ListForMovies(ids, common.ContextGetUser(ctx).Id)
+	if err != nil {
+		return err
+	}
+
+	for i := range movies {
+		movies[i].Collections = collections[movies[i].Id]
+		if movies[i].Collections == nil {
+			movies[i].Collections = models.MovieCollections{}
+		}
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package handlers
 import (
 	"github.com/rhodeon/moviescreen/cmd/api/models/response"
 	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/infrastructure/mock"
 	"net/http"
+	"strings"
 )
 
 var createMovieTestCases = map[string]struct {
@@ -95,6 +97,206 @@ var createMovieTestCases = map[string]struct {
 		}),
 	},
 
+	"genre variants": {
+		requestBody: `{
+			"Title":   "The Shawshank Redemption",
+			"Year":    1994,
+			"Runtime": 142,
+			"Genres":  ["DRAMA", "crime"]
+		}`,
+		wantCode: 201,
+		wantBody: response.SuccessResponse(201, response.MovieResponse{
+			Id:      3,
+			Title:   "The Shawshank Redemption",
+			Year:    1994,
+			Runtime: 142,
+			Genres:  []string{"Drama", "Crime"},
+			Version: 1,
+		}),
+		wantHeaders: map[string][]string{
+			"Location": {"/v1/movies/3"},
+		},
+	},
+
+	"valid request with metadata": {
+		requestBody: `{
+			"title":             "Amélie",
+			"year":              2001,
+			"runtime":           122,
+			"genres":            ["Comedy"],
+			"original_title":    " Le Fabuleux Destin d'Amélie Poulain ",
+			"original_language": "fr",
+			"countries":         ["FR", "DE"],
+			"release_dates":     [{"country": "FR", "date": "2001-04-25"}],
+			"certifications":    [{"country": "FR", "rating": "TP"}, {"country": "US", "rating": "R"}],
+			"tagline":           "She'll change your life."
+		}`,
+		wantCode: 201,
+		wantBody: response.SuccessResponse(201, response.MovieResponse{
+			Id:               3,
+			Title:            "Amélie",
+			Year:             2001,
+			Runtime:          122,
+			Genres:           []string{"Comedy"},
+			OriginalTitle:    "Le Fabuleux Destin d'Amélie Poulain",
+			OriginalLanguage: "fr",
+			Countries:        []string{"FR", "DE"},
+			ReleaseDates:     []response.ReleaseDateResponse{{Country: "FR", Date: "2001-04-25"}},
+			Certifications:   []response.CertificationResponse{{Country: "FR", Rating: "TP"}, {Country: "US", Rating: "R"}},
+			Tagline:          "She'll change your life.",
+			Version:          1,
+		}),
+		wantHeaders: map[string][]string{
+			"Location": {"/v1/movies/3"},
+		},
+	},
+
+	"invalid metadata": {
+		requestBody: `{
+			"title":             "Amélie",
+			"year":              2001,
+			"runtime":           122,
+			"genres":            ["Comedy"],
+			"original_language": "french",
+			"countries":         ["FR", "XX"],
+			"release_dates":     [{"country": "FR", "date": "25/04/2001"}],
+			"certifications":    [{"country": "US", "rating": "TP"}]
+		}`,
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"original_language": "must be an ISO 639-1 language code",
+				"countries":         `invalid ISO 3166-1 alpha-2 country code "XX"`,
+				"release_dates":     `invalid date "25/04/2001", must be in the format YYYY-MM-DD`,
+				"certifications":    `invalid rating "TP" for country "US"`,
+			},
+		}),
+	},
+
+	"valid request with external ids": {
+		requestBody: `{
+			"title":        "The Shawshank Redemption",
+			"year":         1994,
+			"runtime":      142,
+			"genres":       ["Drama"],
+			"external_ids": {"imdb": "tt0111161", "tmdb": "278", "wikidata": "Q172241"}
+		}`,
+		wantCode: 201,
+		wantBody: response.SuccessResponse(201, response.MovieResponse{
+			Id:          3,
+			Title:       "The Shawshank Redemption",
+			Year:        1994,
+			Runtime:     142,
+			Genres:      []string{"Drama"},
+			ExternalIds: map[string]string{"imdb": "tt0111161", "tmdb": "278", "wikidata": "Q172241"},
+			Version:     1,
+		}),
+		wantHeaders: map[string][]string{
+			"Location": {"/v1/movies/3"},
+		},
+	},
+
+	"invalid external ids": {
+		requestBody: `{
+			"title":        "The Shawshank Redemption",
+			"year":         1994,
+			"runtime":      142,
+			"genres":       ["Drama"],
+			"external_ids": {"letterboxd": "the-shawshank-redemption", "imdb": "111161"}
+		}`,
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"external_ids": `invalid imdb id "111161"`,
+			},
+		}),
+	},
+
+	"external id registered to another movie": {
+		requestBody: `{
+			"title":        "Hamilton",
+			"year":         2020,
+			"runtime":      160,
+			"genres":       ["Musical"],
+			"external_ids": {"tmdb": "556574"}
+		}`,
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"external_ids": `tmdb id "556574" is already registered to movie 2`,
+			},
+		}),
+	},
+
+	"external id registered to a movie in trash": {
+		requestBody: `{
+			"title":        "Luca",
+			"year":         2021,
+			"runtime":      95,
+			"genres":       ["Animation"],
+			"external_ids": {"imdb": "tt12801262"}
+		}`,
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"external_ids": `imdb id "tt12801262" is registered to movie 4 in the trash`,
+			},
+		}),
+	},
+
+	"repeated release date country": {
+		requestBody: `{
+			"title":         "Amélie",
+			"year":          2001,
+			"runtime":       122,
+			"genres":        ["Comedy"],
+			"release_dates": [{"country": "FR", "date": "2001-04-25"}, {"country": "FR", "date": "2001-05-01"}]
+		}`,
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"release_dates": "must have one release date per country",
+			},
+		}),
+	},
+
+	"unknown genre": {
+		requestBody: `{
+			"Title":   "The Shawshank Redemption",
+			"Year":    1994,
+			"Runtime": 142,
+			"Genres":  ["Drama", "Prison Drama"]
+		}`,
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"genres": `unknown genre "Prison Drama"`,
+			},
+		}),
+	},
+
+	"duplicate genre variants": {
+		requestBody: `{
+			"Title":   "The Shawshank Redemption",
+			"Year":    1994,
+			"Runtime": 142,
+			"Genres":  ["Drama", "drama"]
+		}`,
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"genres": "must have unique genres",
+			},
+		}),
+	},
+
 	"negative runtime": {
 		requestBody: `{
 			"Title":   "The Shawshank Redemption",
@@ -161,9 +363,10 @@ var createMovieTestCases = map[string]struct {
 }
 
 var getMovieByIdTestCases = map[string]struct {
-	requestId string
-	wantCode  int
-	wantBody  response.BaseResponse
+	requestId    string
+	wantCode     int
+	wantBody     response.BaseResponse
+	wantLocation string
 }{
 	"valid request": {
 		requestId: "1",
@@ -181,6 +384,56 @@ var getMovieByIdTestCases = map[string]struct {
 		),
 	},
 
+	"valid request (with fields)": {
+		requestId: "1?fields=id,title",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieResponse{
+				Id:    1,
+				Title: "Bullet Train",
+			},
+		),
+	},
+
+	"valid request (with collections)": {
+		requestId: "1?fields=id,title&include=collections",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieResponse{
+				Id:    1,
+				Title: "Bullet Train",
+				Collections: []response.MovieCollectionResponse{
+					{Id: 3, Kind: "list", Name: "Summer blockbusters", Position: 1},
+					{Id: 2, Kind: "list", Name: "Weekend picks", Position: 1},
+				},
+			},
+		),
+	},
+
+	"unknown field": {
+		requestId: "1?fields=title,plot",
+		wantCode:  422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"fields": `unknown field "plot"`,
+			},
+		}),
+	},
+
+	"unknown include": {
+		requestId: "1?include=cast",
+		wantCode:  422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"include": `unknown relation "cast"`,
+			},
+		}),
+	},
+
 	"non-integer id": {
 		requestId: "one",
 		wantCode:  404,
@@ -208,6 +461,18 @@ var getMovieByIdTestCases = map[string]struct {
 			},
 		),
 	},
+
+	"merged id": {
+		requestId:    "5",
+		wantCode:     301,
+		wantLocation: "/v1/movies/2",
+	},
+
+	"merged id (with fields)": {
+		requestId:    "5?fields=id,title",
+		wantCode:     301,
+		wantLocation: "/v1/movies/2?fields=id,title",
+	},
 }
 
 var listMoviesTestCases = map[string]struct {
@@ -238,12 +503,20 @@ var listMoviesTestCases = map[string]struct {
 					Version: 1,
 				},
 				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
 				},
 			},
 		},
@@ -299,37 +572,37 @@ var listMoviesTestCases = map[string]struct {
 		},
 	},
 
-	"valid request (with page and limit)": {
+	"valid request (with fields)": {
 		filterQueries: map[string]string{
-			"page":  "2",
-			"limit": "1",
+			"fields": "title,year",
 		},
 		wantCode: 200,
 		wantBody: response.BaseResponse{
 			Success: true,
 			Status:  200,
 			Metadata: &response.Metadata{
-				CurrentPage:  2,
-				PageLimit:    1,
-				LastPage:     2,
+				CurrentPage:  1,
+				PageLimit:    20,
+				LastPage:     1,
 				TotalRecords: 2,
 			},
 			Data: []response.MovieResponse{
 				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
+					Title: "Bullet Train",
+					Year:  2022,
+				},
+				{
+					Title: "Hamilton",
+					Year:  2020,
 				},
 			},
 		},
 	},
 
-	"valid request (with sort by id - ascending)": {
+	"valid request (with collections)": {
 		filterQueries: map[string]string{
-			"sort": "id",
+			"fields":  "title",
+			"include": "collections",
 		},
 		wantCode: 200,
 		wantBody: response.BaseResponse{
@@ -343,28 +616,27 @@ var listMoviesTestCases = map[string]struct {
 			},
 			Data: []response.MovieResponse{
 				{
-					Id:      1,
-					Title:   "Bullet Train",
-					Year:    2022,
-					Runtime: 108,
-					Genres:  []string{"Action", "Comedy"},
-					Version: 1,
+					Title: "Bullet Train",
+					Collections: []response.MovieCollectionResponse{
+						{Id: 3, Kind: "list", Name: "Summer blockbusters", Position: 1},
+						{Id: 2, Kind: "list", Name: "Weekend picks", Position: 1},
+					},
 				},
 				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
+					Title: "Hamilton",
+					Collections: []response.MovieCollectionResponse{
+						{Id: 1, Kind: "franchise", Name: "Lin-Manuel Miranda Musicals", Position: 1},
+						{Id: 2, Kind: "list", Name: "Weekend picks", Position: 3},
+					},
 				},
 			},
 		},
 	},
 
-	"valid request (with sort by id - descending)": {
+	"valid request (with language and countries)": {
 		filterQueries: map[string]string{
-			"sort": "-id",
+			"language":  "en",
+			"countries": "US",
 		},
 		wantCode: 200,
 		wantBody: response.BaseResponse{
@@ -374,32 +646,34 @@ var listMoviesTestCases = map[string]struct {
 				CurrentPage:  1,
 				PageLimit:    20,
 				LastPage:     1,
-				TotalRecords: 2,
+				TotalRecords: 1,
 			},
 			Data: []response.MovieResponse{
 				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
-				},
-				{
-					Id:      1,
-					Title:   "Bullet Train",
-					Year:    2022,
-					Runtime: 108,
-					Genres:  []string{"Action", "Comedy"},
-					Version: 1,
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
 				},
 			},
 		},
 	},
 
-	"valid request (with sort by title - ascending)": {
+	"valid request (with certification and release date range)": {
 		filterQueries: map[string]string{
-			"sort": "title",
+			"certification": "US:PG-13",
+			"released_from": "2020-01-01",
+			"released_to":   "2020-12-31",
 		},
 		wantCode: 200,
 		wantBody: response.BaseResponse{
@@ -409,32 +683,113 @@ var listMoviesTestCases = map[string]struct {
 				CurrentPage:  1,
 				PageLimit:    20,
 				LastPage:     1,
-				TotalRecords: 2,
+				TotalRecords: 1,
 			},
 			Data: []response.MovieResponse{
 				{
-					Id:      1,
-					Title:   "Bullet Train",
-					Year:    2022,
-					Runtime: 108,
-					Genres:  []string{"Action", "Comedy"},
-					Version: 1,
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
 				},
+			},
+		},
+	},
+
+	"valid request (with unmatched certification)": {
+		filterQueries: map[string]string{
+			"certification": "US:R",
+		},
+		wantCode: 200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Metadata: &response.Metadata{},
+			Data:     []response.MovieResponse{},
+		},
+	},
+
+	"invalid metadata filters": {
+		filterQueries: map[string]string{
+			"language":      "eng",
+			"countries":     "US,ZZZ",
+			"certification": "PG-13",
+			"released_from": "2020/01/01",
+		},
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"language":      "must be an ISO 639-1 language code",
+				"countries":     "must be ISO 3166-1 alpha-2 country codes",
+				"certification": "must be a country code and a rating separated by a colon",
+				"released_from": "must be a date in the format YYYY-MM-DD",
+			},
+		}),
+	},
+
+	"unknown field": {
+		filterQueries: map[string]string{
+			"fields": "id,rating",
+		},
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"fields": `unknown field "rating"`,
+			},
+		}),
+	},
+
+	"valid request (with page and limit)": {
+		filterQueries: map[string]string{
+			"page":  "2",
+			"limit": "1",
+		},
+		wantCode: 200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Metadata: &response.Metadata{
+				CurrentPage:  2,
+				PageLimit:    1,
+				LastPage:     2,
+				TotalRecords: 2,
+			},
+			Data: []response.MovieResponse{
 				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
 				},
 			},
 		},
 	},
 
-	"valid request (with sort by title - descending)": {
+	"valid request (with sort by id - ascending)": {
 		filterQueries: map[string]string{
-			"sort": "-title",
+			"sort": "id",
 		},
 		wantCode: 200,
 		wantBody: response.BaseResponse{
@@ -447,14 +802,6 @@ var listMoviesTestCases = map[string]struct {
 				TotalRecords: 2,
 			},
 			Data: []response.MovieResponse{
-				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
-				},
 				{
 					Id:      1,
 					Title:   "Bullet Train",
@@ -463,13 +810,29 @@ var listMoviesTestCases = map[string]struct {
 					Genres:  []string{"Action", "Comedy"},
 					Version: 1,
 				},
+				{
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
+				},
 			},
 		},
 	},
 
-	"valid request (with sort by year - ascending)": {
+	"valid request (with sort by id - descending)": {
 		filterQueries: map[string]string{
-			"sort": "year",
+			"sort": "-id",
 		},
 		wantCode: 200,
 		wantBody: response.BaseResponse{
@@ -483,12 +846,149 @@ var listMoviesTestCases = map[string]struct {
 			},
 			Data: []response.MovieResponse{
 				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
+				},
+				{
+					Id:      1,
+					Title:   "Bullet Train",
+					Year:    2022,
+					Runtime: 108,
+					Genres:  []string{"Action", "Comedy"},
+					Version: 1,
+				},
+			},
+		},
+	},
+
+	"valid request (with sort by title - ascending)": {
+		filterQueries: map[string]string{
+			"sort": "title",
+		},
+		wantCode: 200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Metadata: &response.Metadata{
+				CurrentPage:  1,
+				PageLimit:    20,
+				LastPage:     1,
+				TotalRecords: 2,
+			},
+			Data: []response.MovieResponse{
+				{
+					Id:      1,
+					Title:   "Bullet Train",
+					Year:    2022,
+					Runtime: 108,
+					Genres:  []string{"Action", "Comedy"},
+					Version: 1,
+				},
+				{
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
+				},
+			},
+		},
+	},
+
+	"valid request (with sort by title - descending)": {
+		filterQueries: map[string]string{
+			"sort": "-title",
+		},
+		wantCode: 200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Metadata: &response.Metadata{
+				CurrentPage:  1,
+				PageLimit:    20,
+				LastPage:     1,
+				TotalRecords: 2,
+			},
+			Data: []response.MovieResponse{
+				{
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
+				},
+				{
+					Id:      1,
+					Title:   "Bullet Train",
+					Year:    2022,
+					Runtime: 108,
+					Genres:  []string{"Action", "Comedy"},
+					Version: 1,
+				},
+			},
+		},
+	},
+
+	"valid request (with sort by year - ascending)": {
+		filterQueries: map[string]string{
+			"sort": "year",
+		},
+		wantCode: 200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Metadata: &response.Metadata{
+				CurrentPage:  1,
+				PageLimit:    20,
+				LastPage:     1,
+				TotalRecords: 2,
+			},
+			Data: []response.MovieResponse{
+				{
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
 				},
 				{
 					Id:      1,
@@ -526,12 +1026,20 @@ var listMoviesTestCases = map[string]struct {
 					Version: 1,
 				},
 				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
 				},
 			},
 		},
@@ -561,12 +1069,20 @@ var listMoviesTestCases = map[string]struct {
 					Version: 1,
 				},
 				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
 				},
 			},
 		},
@@ -588,12 +1104,20 @@ var listMoviesTestCases = map[string]struct {
 			},
 			Data: []response.MovieResponse{
 				{
-					Id:      2,
-					Title:   "Hamilton",
-					Year:    2020,
-					Runtime: 140,
-					Genres:  []string{"Musical", "Drama"},
-					Version: 1,
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
 				},
 				{
 					Id:      1,
@@ -715,7 +1239,7 @@ var updateMovieTestCases = map[string]struct {
 				Title:   "In The Heights",
 				Year:    2021,
 				Runtime: 110,
-				Genres:  []string{"musical", "comedy"},
+				Genres:  []string{"Musical", "Comedy"},
 				Version: 2,
 			},
 		),
@@ -791,7 +1315,7 @@ var updateMovieTestCases = map[string]struct {
 				Title:   "Bullet Train",
 				Year:    2022,
 				Runtime: 108,
-				Genres:  []string{"musical", "comedy"},
+				Genres:  []string{"Musical", "Comedy"},
 				Version: 2,
 			},
 		),
@@ -879,4 +1403,1637 @@ var deleteMovieTestCases = map[string]struct {
 			},
 		),
 	},
+
+	"deleted id": {
+		requestId: "4",
+		wantCode:  404,
+		wantBody: response.ErrorResponse(
+			404,
+			response.Error{
+				Type: "generic",
+				Data: map[string]string{
+					"message": responseErrors.ErrMessageNotFound,
+				},
+			},
+		),
+	},
+}
+
+var conditionalMovieTestCases = map[string]struct {
+	method         string
+	path           string
+	requestBody    string
+	requestHeaders map[string]string
+	wantCode       int
+	wantHeaders    http.Header
+}{
+	"get with matching If-None-Match": {
+		method:         http.MethodGet,
+		path:           "/v1/movies/1",
+		requestHeaders: map[string]string{"If-None-Match": `"1-1"`},
+		wantCode:       304,
+		wantHeaders: map[string][]string{
+			"Etag": {`"1-1"`},
+		},
+	},
+
+	"get with outdated If-None-Match": {
+		method:         http.MethodGet,
+		path:           "/v1/movies/1",
+		requestHeaders: map[string]string{"If-None-Match": `"1-0"`},
+		wantCode:       200,
+		wantHeaders: map[string][]string{
+			"Etag": {`"1-1"`},
+		},
+	},
+
+	"update with matching If-Match": {
+		method:         http.MethodPatch,
+		path:           "/v1/movies/1",
+		requestBody:    `{"title": "Bullet Train"}`,
+		requestHeaders: map[string]string{"If-Match": `"1-1"`},
+		wantCode:       200,
+		wantHeaders: map[string][]string{
+			"Etag": {`"1-2"`},
+		},
+	},
+
+	"update with outdated If-Match": {
+		method:         http.MethodPatch,
+		path:           "/v1/movies/1",
+		requestBody:    `{"title": "Bullet Train"}`,
+		requestHeaders: map[string]string{"If-Match": `"1-0"`},
+		wantCode:       412,
+	},
+
+	"update with weak If-Match": {
+		method:         http.MethodPatch,
+		path:           "/v1/movies/1",
+		requestBody:    `{"title": "Bullet Train"}`,
+		requestHeaders: map[string]string{"If-Match": `W/"1-1"`},
+		wantCode:       412,
+	},
+
+	"delete with outdated If-Match": {
+		method:         http.MethodDelete,
+		path:           "/v1/movies/2",
+		requestHeaders: map[string]string{"If-Match": `"2-0"`},
+		wantCode:       412,
+	},
+
+	"delete with matching If-Match": {
+		method:         http.MethodDelete,
+		path:           "/v1/movies/2",
+		requestHeaders: map[string]string{"If-Match": `"2-1"`},
+		wantCode:       200,
+	},
+
+	"list with modification time": {
+		method:   http.MethodGet,
+		path:     "/v1/movies/",
+		wantCode: 200,
+		wantHeaders: map[string][]string{
+			"Last-Modified": {"Sun, 10 Apr 2022 10:00:00 GMT"},
+		},
+	},
+}
+
+var patchMovieTestCases = map[string]struct {
+	requestId   string
+	contentType string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+}{
+	"merge patch": {
+		requestId:   "1",
+		contentType: "application/merge-patch+json",
+		requestBody: `{"title": "Bullet Train 2", "runtime": 120}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieResponse{
+				Id:      1,
+				Title:   "Bullet Train 2",
+				Year:    2022,
+				Runtime: 120,
+				Genres:  []string{"Action", "Comedy"},
+				Version: 2,
+			},
+		),
+	},
+
+	"merge patch removing required field": {
+		requestId:   "1",
+		contentType: "application/merge-patch+json",
+		requestBody: `{"title": null}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"title": "must be provided",
+			},
+		}),
+	},
+
+	"merge patch with unknown field": {
+		requestId:   "1",
+		contentType: "application/merge-patch+json",
+		requestBody: `{"rating": 5}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "patch",
+			Data: map[string]string{
+				"document": `patched document is invalid: unknown field: "rating"`,
+			},
+		}),
+	},
+
+	"merge patch with external id of another movie": {
+		requestId:   "1",
+		contentType: "application/merge-patch+json",
+		requestBody: `{"external_ids": {"imdb": "tt8503618"}}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"external_ids": `imdb id "tt8503618" is already registered to movie 2`,
+			},
+		}),
+	},
+
+	"json patch appending genre": {
+		requestId:   "2",
+		contentType: "application/json-patch+json",
+		requestBody: `[
+			{"op": "test", "path": "/title", "value": "Hamilton"},
+			{"op": "add", "path": "/genres/-", "value": "History"},
+			{"op": "replace", "path": "/year", "value": 2016}
+		]`,
+		wantCode: 200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieResponse{
+				Id:               2,
+				Title:            "Hamilton",
+				Year:             2016,
+				Runtime:          140,
+				Genres:           []string{"Musical", "Drama", "History"},
+				OriginalTitle:    "Hamilton",
+				OriginalLanguage: "en",
+				Countries:        []string{"US"},
+				ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+				Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+				Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+				Tagline:          "An American musical.",
+				ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+				Version:          2,
+			},
+		),
+	},
+
+	"json patch removing genre": {
+		requestId:   "2",
+		contentType: "application/json-patch+json",
+		requestBody: `[{"op": "remove", "path": "/genres/0"}]`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieResponse{
+				Id:               2,
+				Title:            "Hamilton",
+				Year:             2020,
+				Runtime:          140,
+				Genres:           []string{"Drama"},
+				OriginalTitle:    "Hamilton",
+				OriginalLanguage: "en",
+				Countries:        []string{"US"},
+				ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+				Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+				Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+				Tagline:          "An American musical.",
+				ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+				Version:          2,
+			},
+		),
+	},
+
+	"json patch with failed test": {
+		requestId:   "2",
+		contentType: "application/json-patch+json",
+		requestBody: `[
+			{"op": "test", "path": "/title", "value": "Luca"},
+			{"op": "replace", "path": "/title", "value": "Encanto"}
+		]`,
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "patch",
+			Data: map[string]string{
+				"operations": "operation 0 (test /title): test failed",
+			},
+		}),
+	},
+
+	"json patch with unsupported operation": {
+		requestId:   "2",
+		contentType: "application/json-patch+json",
+		requestBody: `[{"op": "move", "from": "/title", "path": "/genres/-"}]`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "patch",
+			Data: map[string]string{
+				"operations": "operation 0 (move /genres/-): unsupported operation",
+			},
+		}),
+	},
+
+	"json patch producing duplicate genres": {
+		requestId:   "2",
+		contentType: "application/json-patch+json",
+		requestBody: `[{"op": "add", "path": "/genres/-", "value": "Drama"}]`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "movie",
+			Data: map[string]string{
+				"genres": "must have unique genres",
+			},
+		}),
+	},
+
+	"malformed json patch": {
+		requestId:   "2",
+		contentType: "application/json-patch+json",
+		requestBody: `{"op": "remove", "path": "/title"}`,
+		wantCode:    400,
+		wantBody: response.ErrorResponse(400, response.Error{
+			Type: "generic",
+			Data: map[string]string{
+				"message": `body contains incorrect JSON type (at character 1)`,
+			},
+		}),
+	},
+
+	"non-existent id": {
+		requestId:   "99",
+		contentType: "application/merge-patch+json",
+		requestBody: `{"title": "Encanto"}`,
+		wantCode:    404,
+		wantBody: response.ErrorResponse(
+			404,
+			response.Error{
+				Type: "generic",
+				Data: map[string]string{
+					"message": responseErrors.ErrMessageNotFound,
+				},
+			},
+		),
+	},
+}
+
+var importMoviesTestCases = map[string]struct {
+	contentType string
+	dryRun      bool
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+}{
+	"valid csv": {
+		contentType: "text/csv",
+		requestBody: "title,year,runtime,genres\n" +
+			"The Godfather,1972,175,Crime|Drama\n" +
+			"\"Crouching Tiger, Hidden Dragon\",2000,120,Action|Fantasy\n" +
+			"Parasite,2019,132,Thriller\n",
+		wantCode: 200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieImportResponse{
+				DryRun:   false,
+				Total:    3,
+				Valid:    3,
+				Invalid:  0,
+				Imported: 3,
+				Errors:   []response.MovieImportError{},
+			},
+		),
+	},
+
+	"csv with reordered columns": {
+		contentType: "text/csv",
+		requestBody: "genres,runtime,title,year\n" +
+			"Crime|Drama,175,The Godfather,1972\n",
+		wantCode: 200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieImportResponse{
+				Total:    1,
+				Valid:    1,
+				Imported: 1,
+				Errors:   []response.MovieImportError{},
+			},
+		),
+	},
+
+	"csv with invalid records": {
+		contentType: "text/csv",
+		requestBody: "title,year,runtime,genres\n" +
+			"The Godfather,abc,175,Crime|Drama\n" +
+			"Parasite,2019,-132,Thriller\n" +
+			",2020,140,Musical|Musical\n" +
+			"Luca,2021,95,Animation\n",
+		wantCode: 200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieImportResponse{
+				Total:    4,
+				Valid:    1,
+				Invalid:  3,
+				Imported: 1,
+				Errors: []response.MovieImportError{
+					{Row: 1, Errors: map[string]string{"year": "must be an integer"}},
+					{Row: 2, Errors: map[string]string{"runtime": "must be a positive integer"}},
+					{Row: 3, Errors: map[string]string{"title": "must not be blank", "genres": "must have unique genres"}},
+				},
+			},
+		),
+	},
+
+	"csv dry run": {
+		contentType: "text/csv",
+		dryRun:      true,
+		requestBody: "title,year,runtime,genres\n" +
+			"The Godfather,1972,175,Crime|Drama\n" +
+			"Parasite,2019,0,Thriller\n",
+		wantCode: 200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieImportResponse{
+				DryRun:   true,
+				Total:    2,
+				Valid:    1,
+				Invalid:  1,
+				Imported: 0,
+				Errors: []response.MovieImportError{
+					{Row: 2, Errors: map[string]string{"runtime": "must be a positive integer"}},
+				},
+			},
+		),
+	},
+
+	"csv with unknown column": {
+		contentType: "text/csv",
+		requestBody: "title,year,runtime,genres,rating\n" +
+			"The Godfather,1972,175,Crime|Drama,5\n",
+		wantCode: 400,
+		wantBody: response.ErrorResponse(400, response.GenericError(`unknown CSV column "rating"`)),
+	},
+
+	"empty csv": {
+		contentType: "text/csv",
+		requestBody: "",
+		wantCode:    400,
+		wantBody:    response.ErrorResponse(400, response.GenericError("CSV header is missing")),
+	},
+
+	"ndjson with invalid records": {
+		contentType: "application/x-ndjson",
+		requestBody: `{"title": "The Godfather", "year": 1972, "runtime": 175, "genres": ["Crime", "Drama"]}` + "\n" +
+			"\n" +
+			`{"title": "Parasite", "year": 2019, "runtime": 132, "genres": ["Thriller"], "rating": 5}` + "\n" +
+			`{"title": "Luca", "year": 2021, "runtime": 95}` + "\n" +
+			`{"title": "Hamilton", "year": "2020"}` + "\n" +
+			`{"title": "Soul", "year": 2020, "runtime": 100, "genres": ["Animation"]}`,
+		wantCode: 200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieImportResponse{
+				Total:    5,
+				Valid:    2,
+				Invalid:  3,
+				Imported: 2,
+				Errors: []response.MovieImportError{
+					{Row: 2, Errors: map[string]string{"record": `unknown field: "rating"`}},
+					{Row: 3, Errors: map[string]string{"genres": "must be provided"}},
+					{Row: 4, Errors: map[string]string{"record": `incorrect JSON type for field "year"`}},
+				},
+			},
+		),
+	},
+
+	"ndjson with conflicting external ids": {
+		contentType: "application/x-ndjson",
+		requestBody: `{"title": "The Godfather", "year": 1972, "runtime": 175, "genres": ["Crime", "Drama"], "external_ids": {"imdb": "tt0068646"}}` + "\n" +
+			`{"title": "The Godfather Part II", "year": 1974, "runtime": 202, "genres": ["Crime", "Drama"], "external_ids": {"imdb": "tt0068646"}}` + "\n" +
+			`{"title": "Hamilton", "year": 2020, "runtime": 160, "genres": ["Musical"], "external_ids": {"imdb": "tt8503618"}}` + "\n",
+		wantCode: 200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieImportResponse{
+				Total:    3,
+				Valid:    1,
+				Invalid:  2,
+				Imported: 1,
+				Errors: []response.MovieImportError{
+					{Row: 2, Errors: map[string]string{"external_ids": `imdb id "tt0068646" is already used by row 1`}},
+					{Row: 3, Errors: map[string]string{"external_ids": `imdb id "tt8503618" is already registered to movie 2`}},
+				},
+			},
+		),
+	},
+
+	"ndjson with an external id in the trash": {
+		contentType: "application/x-ndjson",
+		requestBody: `{"title": "The Godfather", "year": 1972, "runtime": 175, "genres": ["Crime", "Drama"]}` + "\n" +
+			`{"title": "Parasite", "year": 2019, "runtime": 132, "genres": ["Thriller"]}` + "\n" +
+			`{"title": "Luca", "year": 2021, "runtime": 95, "genres": ["Animation"], "external_ids": {"imdb": "tt12801262"}}` + "\n" +
+			`{"title": "Soul", "year": 2020, "runtime": 100, "genres": ["Animation"]}` + "\n" +
+			`{"title": "Hamilton", "year": 2020, "runtime": 160, "genres": ["Musical"]}` + "\n",
+		wantCode: 200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieImportResponse{
+				Total:    5,
+				Valid:    4,
+				Invalid:  1,
+				Imported: 4,
+				Errors: []response.MovieImportError{
+					{Row: 3, Errors: map[string]string{"external_ids": `imdb id "tt12801262" is registered to movie 4 in the trash`}},
+				},
+			},
+		),
+	},
+
+	"jsonl": {
+		contentType: "application/jsonl",
+		requestBody: `{"title": "The Godfather", "year": 1972, "runtime": 175, "genres": ["Crime", "Drama"]}` + "\n",
+		wantCode:    200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieImportResponse{
+				Total:    1,
+				Valid:    1,
+				Imported: 1,
+				Errors:   []response.MovieImportError{},
+			},
+		),
+	},
+
+	"body too large": {
+		contentType: "text/csv",
+		requestBody: "title,year,runtime,genres\n" + strings.Repeat("The Godfather,1972,175,Crime|Drama\n", 50),
+		wantCode:    413,
+		wantBody: response.PartialResponse(
+			413,
+			response.MovieImportResponse{
+				Total:     28,
+				Valid:     28,
+				Imported:  28,
+				Errors:    []response.MovieImportError{},
+				StoppedAt: 29,
+			},
+			response.GenericError("import stopped at row 29 after importing 28 movies: "+responseErrors.ErrMessageRequestTooLarge),
+		),
+	},
+
+	"body too large with a pending batch": {
+		contentType: "text/csv",
+		requestBody: "title,year,runtime,genres\n" + strings.Repeat("Soul,2020,100,Animation\n", 50),
+		wantCode:    413,
+		wantBody: response.PartialResponse(
+			413,
+			response.MovieImportResponse{
+				Total:     41,
+				Valid:     40,
+				Imported:  40,
+				Errors:    []response.MovieImportError{},
+				StoppedAt: 41,
+			},
+			response.GenericError("import stopped at row 41 after importing 40 movies: "+responseErrors.ErrMessageRequestTooLarge),
+		),
+	},
+
+	"unsupported content type": {
+		contentType: "application/json",
+		requestBody: `[{"title": "The Godfather", "year": 1972, "runtime": 175, "genres": ["Crime", "Drama"]}]`,
+		wantCode:    415,
+		wantBody:    response.ErrorResponse(415, response.GenericError(responseErrors.ErrMessageUnsupportedMediaType)),
+	},
+}
+
+var exportMoviesTestCases = map[string]struct {
+	queries     map[string]string
+	wantCode    int
+	wantBody    string
+	wantHeaders http.Header
+}{
+	"default format": {
+		queries:  map[string]string{},
+		wantCode: 200,
+		wantBody: `[{"id":1,"title":"Bullet Train","year":2022,"runtime":108,"genres":["Action","Comedy"],"version":1},` +
+			`{"id":2,"title":"Hamilton","year":2020,"runtime":140,"genres":["Musical","Drama"],"original_title":"Hamilton","original_language":"en","countries":["US"],"release_dates":[{"country":"US","date":"2020-07-03"}],"certifications":[{"country":"US","rating":"PG-13"}],"synopsis":"The story of Alexander Hamilton, told through hip-hop.","tagline":"An American musical.","external_ids":{"imdb":"tt8503618","tmdb":"556574"},"version":1}]`,
+		wantHeaders: http.Header{
+			"Content-Type":        []string{"application/json; charset=utf-8"},
+			"Content-Disposition": []string{`attachment; filename="movies.json"`},
+		},
+	},
+
+	"csv sorted by descending id": {
+		queries:  map[string]string{"format": "csv", "sort": "-id"},
+		wantCode: 200,
+		wantBody: "id,title,year,runtime,genres,version\n" +
+			"2,Hamilton,2020,140,Musical|Drama,1\n" +
+			"1,Bullet Train,2022,108,Action|Comedy,1\n",
+		wantHeaders: http.Header{
+			"Content-Type":        []string{"text/csv; charset=utf-8"},
+			"Content-Disposition": []string{`attachment; filename="movies.csv"`},
+		},
+	},
+
+	"ndjson filtered by genre": {
+		queries:  map[string]string{"format": "ndjson", "genres": "drama"},
+		wantCode: 200,
+		wantBody: `{"id":2,"title":"Hamilton","year":2020,"runtime":140,"genres":["Musical","Drama"],"original_title":"Hamilton","original_language":"en","countries":["US"],"release_dates":[{"country":"US","date":"2020-07-03"}],"certifications":[{"country":"US","rating":"PG-13"}],"synopsis":"The story of Alexander Hamilton, told through hip-hop.","tagline":"An American musical.","external_ids":{"imdb":"tt8503618","tmdb":"556574"},"version":1}` + "\n",
+		wantHeaders: http.Header{
+			"Content-Type":        []string{"application/x-ndjson"},
+			"Content-Disposition": []string{`attachment; filename="movies.ndjson"`},
+		},
+	},
+
+	"json with no match": {
+		queries:  map[string]string{"title": "Godfather"},
+		wantCode: 200,
+		wantBody: `[]`,
+		wantHeaders: http.Header{
+			"Content-Disposition": []string{`attachment; filename="movies.json"`},
+		},
+	},
+
+	"invalid format and sort": {
+		queries:  map[string]string{"format": "xml", "sort": "rating"},
+		wantCode: 422,
+		wantBody: `{"success":false,"status":422,"error":{"type":"filter","data":{"format":"invalid format value","sort":"invalid sort value"}}}`,
+	},
+}
+
+var trashMoviesTestCases = map[string]struct {
+	queries  map[string]string
+	wantCode int
+	wantBody response.BaseResponse
+}{
+	"valid request": {
+		queries:  map[string]string{},
+		wantCode: 200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Metadata: &response.Metadata{
+				CurrentPage:  1,
+				PageLimit:    20,
+				LastPage:     1,
+				TotalRecords: 1,
+			},
+			Data: []response.MovieResponse{
+				{
+					Id:          4,
+					Title:       "Luca",
+					Year:        2021,
+					Runtime:     100,
+					Genres:      []string{"Adventure", "Family"},
+					ExternalIds: map[string]string{"imdb": "tt12801262"},
+					Version:     1,
+					Deleted:     &mock.MockDate,
+				},
+			},
+		},
+	},
+
+	"invalid sort": {
+		queries:  map[string]string{"sort": "year"},
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"sort": "invalid sort value",
+			},
+		}),
+	},
+}
+
+var restoreMovieTestCases = map[string]struct {
+	requestId string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"deleted id": {
+		requestId: "4",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieResponse{
+				Id:          4,
+				Title:       "Luca",
+				Year:        2021,
+				Runtime:     100,
+				Genres:      []string{"Adventure", "Family"},
+				ExternalIds: map[string]string{"imdb": "tt12801262"},
+				Version:     1,
+			},
+		),
+	},
+
+	"id not in trash": {
+		requestId: "1",
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+
+	"non-existent id": {
+		requestId: "99",
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+}
+
+var movieRevisionsTestCases = map[string]struct {
+	requestId string
+	queries   map[string]string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"valid request": {
+		requestId: "1",
+		queries:   map[string]string{},
+		wantCode:  200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Metadata: &response.Metadata{
+				CurrentPage:  1,
+				PageLimit:    20,
+				LastPage:     1,
+				TotalRecords: 1,
+			},
+			Data: []response.MovieRevisionResponse{
+				{
+					Version: 1,
+					Action:  "create",
+					Movie: response.MovieResponse{
+						Id:      1,
+						Title:   "Bullet Train",
+						Year:    2022,
+						Runtime: 108,
+						Genres:  []string{"Action", "Comedy"},
+						Version: 1,
+					},
+					UserId:  1,
+					Created: mock.MockDate,
+				},
+			},
+		},
+	},
+
+	"movie in trash": {
+		requestId: "4",
+		queries:   map[string]string{"sort": "-version"},
+		wantCode:  200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Metadata: &response.Metadata{
+				CurrentPage:  1,
+				PageLimit:    20,
+				LastPage:     1,
+				TotalRecords: 2,
+			},
+			Data: []response.MovieRevisionResponse{
+				{
+					Version: 1,
+					Action:  "delete",
+					Movie: response.MovieResponse{
+						Id:          4,
+						Title:       "Luca",
+						Year:        2021,
+						Runtime:     100,
+						Genres:      []string{"Adventure", "Family"},
+						ExternalIds: map[string]string{"imdb": "tt12801262"},
+						Version:     1,
+					},
+					UserId:  1,
+					Created: mock.MockDate,
+				},
+				{
+					Version: 1,
+					Action:  "create",
+					Movie: response.MovieResponse{
+						Id:          4,
+						Title:       "Luca",
+						Year:        2021,
+						Runtime:     100,
+						Genres:      []string{"Adventure", "Family"},
+						ExternalIds: map[string]string{"imdb": "tt12801262"},
+						Version:     1,
+					},
+					UserId:  1,
+					Created: mock.MockDate,
+				},
+			},
+		},
+	},
+
+	"invalid sort": {
+		requestId: "1",
+		queries:   map[string]string{"sort": "action"},
+		wantCode:  422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"sort": "invalid sort value",
+			},
+		}),
+	},
+
+	"non-existent id": {
+		requestId: "99",
+		queries:   map[string]string{},
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+}
+
+var diffMovieRevisionsTestCases = map[string]struct {
+	requestId string
+	queries   map[string]string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"same version": {
+		requestId: "1",
+		queries:   map[string]string{"from": "1", "to": "1"},
+		wantCode:  200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieDiffResponse{
+				From:    1,
+				To:      1,
+				Changes: map[string]response.MovieChangeResponse{},
+			},
+		),
+	},
+
+	"missing versions": {
+		requestId: "1",
+		queries:   map[string]string{},
+		wantCode:  422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "diff",
+			Data: map[string]string{
+				"from": "must be a positive integer",
+				"to":   "must be a positive integer",
+			},
+		}),
+	},
+
+	"non-existent version": {
+		requestId: "1",
+		queries:   map[string]string{"from": "1", "to": "5"},
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+}
+
+var revertMovieRevisionTestCases = map[string]struct {
+	requestId      string
+	version        string
+	requestHeaders map[string]string
+	wantCode       int
+	wantBody       response.BaseResponse
+}{
+	"valid version": {
+		requestId: "1",
+		version:   "1",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(
+			200,
+			response.MovieResponse{
+				Id:      1,
+				Title:   "Bullet Train",
+				Year:    2022,
+				Runtime: 108,
+				Genres:  []string{"Action", "Comedy"},
+				Version: 2,
+			},
+		),
+	},
+
+	"outdated If-Match": {
+		requestId:      "1",
+		version:        "1",
+		requestHeaders: map[string]string{"If-Match": `"1-0"`},
+		wantCode:       412,
+		wantBody:       response.ErrorResponse(412, response.GenericError(responseErrors.ErrMessagePreconditionFailed)),
+	},
+
+	"non-existent version": {
+		requestId: "1",
+		version:   "9",
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+
+	"non-integer version": {
+		requestId: "1",
+		version:   "one",
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+
+	"movie in trash": {
+		requestId: "4",
+		version:   "1",
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+}
+
+var acceptLanguageTestCases = map[string]struct {
+	url            string
+	acceptLanguage string
+	wantCode       int
+	wantBody       response.BaseResponse
+	wantHeaders    http.Header
+}{
+	"translation preferred over original": {
+		url:            "/v1/movies/2",
+		acceptLanguage: "fr-CA, en;q=0.5",
+		wantCode:       200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Id:               2,
+			Title:            "Hamilton : la comédie musicale",
+			Year:             2020,
+			Runtime:          140,
+			Genres:           []string{"Musical", "Drama"},
+			OriginalTitle:    "Hamilton",
+			OriginalLanguage: "en",
+			Countries:        []string{"US"},
+			ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+			Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+			Synopsis:         "L'histoire d'Alexander Hamilton, racontée en hip-hop.",
+			Tagline:          "An American musical.",
+			ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+			Translation:      "fr",
+			Version:          1,
+		}),
+		wantHeaders: http.Header{
+			"Content-Language": []string{"fr"},
+			"Vary":             []string{"Authorization", "Accept-Language"},
+		},
+	},
+
+	"original preferred over translation": {
+		url:            "/v1/movies/2",
+		acceptLanguage: "en-US, fr;q=0.8",
+		wantCode:       200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Id:               2,
+			Title:            "Hamilton",
+			Year:             2020,
+			Runtime:          140,
+			Genres:           []string{"Musical", "Drama"},
+			OriginalTitle:    "Hamilton",
+			OriginalLanguage: "en",
+			Countries:        []string{"US"},
+			ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+			Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+			Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+			Tagline:          "An American musical.",
+			ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+			Version:          1,
+		}),
+		wantHeaders: http.Header{
+			"Etag": []string{`"2-1"`},
+			"Vary": []string{"Authorization", "Accept-Language"},
+		},
+	},
+
+	"regional variant": {
+		url:            "/v1/movies/1",
+		acceptLanguage: "es-MX",
+		wantCode:       200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Id:          1,
+			Title:       "Tren bala",
+			Year:        2022,
+			Runtime:     108,
+			Genres:      []string{"Action", "Comedy"},
+			Translation: "es-419",
+			Version:     1,
+		}),
+		wantHeaders: http.Header{
+			"Content-Language": []string{"es-419"},
+		},
+	},
+
+	"no matching language": {
+		url:            "/v1/movies/1",
+		acceptLanguage: "de-DE, de;q=0.9",
+		wantCode:       200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Id:      1,
+			Title:   "Bullet Train",
+			Year:    2022,
+			Runtime: 108,
+			Genres:  []string{"Action", "Comedy"},
+			Version: 1,
+		}),
+	},
+
+	"malformed header": {
+		url:            "/v1/movies/1",
+		acceptLanguage: "ja;q=high",
+		wantCode:       200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Id:      1,
+			Title:   "Bullet Train",
+			Year:    2022,
+			Runtime: 108,
+			Genres:  []string{"Action", "Comedy"},
+			Version: 1,
+		}),
+	},
+
+	"translation with sparse fieldset": {
+		url:            "/v1/movies/1?fields=title",
+		acceptLanguage: "ja",
+		wantCode:       200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Title:       "ブレット・トレイン",
+			Translation: "ja",
+		}),
+	},
+
+	"original preferred with sparse fieldset": {
+		url:            "/v1/movies/2?fields=title",
+		acceptLanguage: "en-US, fr;q=0.8",
+		wantCode:       200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Title: "Hamilton",
+		}),
+	},
+
+	"list": {
+		url:            "/v1/movies/?fields=id,title",
+		acceptLanguage: "ja, fr;q=0.9",
+		wantCode:       200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Metadata: &response.Metadata{
+				CurrentPage:  1,
+				PageLimit:    20,
+				LastPage:     1,
+				TotalRecords: 2,
+			},
+			Data: []response.MovieResponse{
+				{Id: 1, Title: "ブレット・トレイン", Translation: "ja"},
+				{Id: 2, Title: "Hamilton : la comédie musicale", Translation: "fr"},
+			},
+		},
+		wantHeaders: http.Header{
+			"Vary": []string{"Authorization", "Accept-Language"},
+		},
+	},
+
+	"list searched by translated title": {
+		url:      "/v1/movies/?fields=id,title&title=Tren",
+		wantCode: 200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Metadata: &response.Metadata{
+				CurrentPage:  1,
+				PageLimit:    20,
+				LastPage:     1,
+				TotalRecords: 1,
+			},
+			Data: []response.MovieResponse{
+				{Id: 1, Title: "Bullet Train"},
+			},
+		},
+	},
+}
+
+var movieTranslationsTestCases = map[string]struct {
+	requestId string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"valid request": {
+		requestId: "1",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(200, []response.MovieTranslationResponse{
+			{Language: "es-419", Title: "Tren bala", Updated: mock.MockDate},
+			{Language: "ja", Title: "ブレット・トレイン", Updated: mock.MockDate},
+		}),
+	},
+
+	"non-existent id": {
+		requestId: "99",
+		wantCode:  404,
+		wantBody: response.ErrorResponse(
+			404,
+			response.Error{
+				Type: "generic",
+				Data: map[string]string{
+					"message": responseErrors.ErrMessageNotFound,
+				},
+			},
+		),
+	},
+
+	"movie in trash": {
+		requestId: "4",
+		wantCode:  404,
+		wantBody: response.ErrorResponse(
+			404,
+			response.Error{
+				Type: "generic",
+				Data: map[string]string{
+					"message": responseErrors.ErrMessageNotFound,
+				},
+			},
+		),
+	},
+}
+
+var putMovieTranslationTestCases = map[string]struct {
+	requestId   string
+	language    string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+	wantHeaders http.Header
+}{
+	"new translation": {
+		requestId:   "2",
+		language:    "de",
+		requestBody: `{"title": " Hamilton – Das Musical ", "tagline": "Ein amerikanisches Musical."}`,
+		wantCode:    201,
+		wantBody: response.SuccessResponse(201, response.MovieTranslationResponse{
+			Language: "de",
+			Title:    "Hamilton – Das Musical",
+			Tagline:  "Ein amerikanisches Musical.",
+			Updated:  mock.MockDate,
+		}),
+		wantHeaders: http.Header{
+			"Location": []string{"/v1/movies/2/translations/de"},
+		},
+	},
+
+	"replaced translation with canonical language": {
+		requestId:   "2",
+		language:    "FR",
+		requestBody: `{"title": "Hamilton"}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, response.MovieTranslationResponse{
+			Language: "fr",
+			Title:    "Hamilton",
+			Updated:  mock.MockDate,
+		}),
+	},
+
+	"invalid language": {
+		requestId:   "2",
+		language:    "klingon!",
+		requestBody: `{"title": "Hamilton"}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "translation",
+			Data: map[string]string{
+				"language": "must be a BCP 47 language tag",
+			},
+		}),
+	},
+
+	"undetermined language": {
+		requestId:   "2",
+		language:    "und",
+		requestBody: `{"title": "Hamilton"}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "translation",
+			Data: map[string]string{
+				"language": "must be a BCP 47 language tag",
+			},
+		}),
+	},
+
+	"missing title": {
+		requestId:   "2",
+		language:    "de",
+		requestBody: `{"synopsis": "Die Geschichte von Alexander Hamilton."}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "translation",
+			Data: map[string]string{
+				"title": "must be provided",
+			},
+		}),
+	},
+
+	"movie in trash": {
+		requestId:   "4",
+		language:    "it",
+		requestBody: `{"title": "Luca"}`,
+		wantCode:    404,
+		wantBody: response.ErrorResponse(
+			404,
+			response.Error{
+				Type: "generic",
+				Data: map[string]string{
+					"message": responseErrors.ErrMessageNotFound,
+				},
+			},
+		),
+	},
+}
+
+var deleteMovieTranslationTestCases = map[string]struct {
+	requestId string
+	language  string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"valid request": {
+		requestId: "1",
+		language:  "ja",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(200, map[string]string{
+			"message": "translation deleted successfully",
+		}),
+	},
+
+	"non-existent translation": {
+		requestId: "1",
+		language:  "de",
+		wantCode:  404,
+		wantBody: response.ErrorResponse(
+			404,
+			response.Error{
+				Type: "generic",
+				Data: map[string]string{
+					"message": responseErrors.ErrMessageNotFound,
+				},
+			},
+		),
+	},
+}
+
+var lookupMovieTestCases = map[string]struct {
+	queries     map[string]string
+	wantCode    int
+	wantBody    response.BaseResponse
+	wantHeaders http.Header
+}{
+	"valid request": {
+		queries:  map[string]string{"source": "imdb", "id": "tt8503618"},
+		wantCode: 200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Id:               2,
+			Title:            "Hamilton",
+			Year:             2020,
+			Runtime:          140,
+			Genres:           []string{"Musical", "Drama"},
+			OriginalTitle:    "Hamilton",
+			OriginalLanguage: "en",
+			Countries:        []string{"US"},
+			ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+			Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+			Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+			Tagline:          "An American musical.",
+			ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+			Version:          1,
+		}),
+		wantHeaders: map[string][]string{
+			"Content-Location": {"/v1/movies/2"},
+		},
+	},
+
+	"invalid source": {
+		queries:  map[string]string{"source": "letterboxd", "id": "hamilton"},
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "lookup",
+			Data: map[string]string{
+				"source": "must be one of imdb, tmdb, wikidata",
+			},
+		}),
+	},
+
+	"invalid id": {
+		queries:  map[string]string{"source": "wikidata", "id": "tt8503618"},
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "lookup",
+			Data: map[string]string{
+				"id": "must be a valid wikidata id",
+			},
+		}),
+	},
+
+	"unregistered id": {
+		queries:  map[string]string{"source": "tmdb", "id": "278"},
+		wantCode: 404,
+		wantBody: response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+
+	"movie in trash": {
+		queries:  map[string]string{"source": "imdb", "id": "tt12801262"},
+		wantCode: 404,
+		wantBody: response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+}
+
+var (
+	testPosterPng      = encodeTestPng(400, 600)
+	testBackdropJpeg   = encodeTestJpeg(1000, 500)
+	testPosterPrefix   = mock.MockStorageUrl + "/" + movieImagePrefix(1, "poster", testPosterPng)
+	testBackdropPrefix = mock.MockStorageUrl + "/" + movieImagePrefix(2, "backdrop", testBackdropJpeg)
+)
+
+var putMovieImageTestCases = map[string]struct {
+	requestId   string
+	kind        string
+	contentType string
+	field       string
+	image       []byte
+	ifMatch     string
+	wantCode    int
+	wantBody    response.BaseResponse
+	wantHeaders http.Header
+}{
+	"png poster": {
+		requestId: "1",
+		kind:      "poster",
+		field:     "image",
+		image:     testPosterPng,
+		wantCode:  200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Id:      1,
+			Title:   "Bullet Train",
+			Year:    2022,
+			Runtime: 108,
+			Genres:  []string{"Action", "Comedy"},
+			Poster: &response.MovieImageResponse{
+				Url:         testPosterPrefix + "/original.png",
+				ContentType: "image/png",
+				Width:       400,
+				Height:      600,
+				Thumbnails: []response.MovieThumbnailResponse{
+					{Size: "w185", Url: testPosterPrefix + "/w185.jpg", Width: 185, Height: 278},
+					{Size: "w342", Url: testPosterPrefix + "/w342.jpg", Width: 342, Height: 513},
+				},
+			},
+			Version: 2,
+		}),
+		wantHeaders: map[string][]string{
+			"Etag": {`"1-2"`},
+		},
+	},
+
+	"jpeg backdrop": {
+		requestId: "2",
+		kind:      "backdrop",
+		field:     "image",
+		image:     testBackdropJpeg,
+		ifMatch:   `"2-1"`,
+		wantCode:  200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Id:               2,
+			Title:            "Hamilton",
+			Year:             2020,
+			Runtime:          140,
+			Genres:           []string{"Musical", "Drama"},
+			OriginalTitle:    "Hamilton",
+			OriginalLanguage: "en",
+			Countries:        []string{"US"},
+			ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+			Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+			Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+			Tagline:          "An American musical.",
+			ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+			Backdrop: &response.MovieImageResponse{
+				Url:         testBackdropPrefix + "/original.jpg",
+				ContentType: "image/jpeg",
+				Width:       1000,
+				Height:      500,
+				Thumbnails: []response.MovieThumbnailResponse{
+					{Size: "w300", Url: testBackdropPrefix + "/w300.jpg", Width: 300, Height: 150},
+					{Size: "w780", Url: testBackdropPrefix + "/w780.jpg", Width: 780, Height: 390},
+				},
+			},
+			Version: 2,
+		}),
+	},
+
+	"stale If-Match": {
+		requestId: "1",
+		kind:      "poster",
+		field:     "image",
+		image:     testPosterPng,
+		ifMatch:   `"1-0"`,
+		wantCode:  412,
+		wantBody:  response.ErrorResponse(412, response.GenericError(responseErrors.ErrMessagePreconditionFailed)),
+	},
+
+	"unsupported image format": {
+		requestId: "1",
+		kind:      "poster",
+		field:     "image",
+		image:     []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"),
+		wantCode:  415,
+		wantBody:  response.ErrorResponse(415, response.GenericError(responseErrors.ErrMessageUnsupportedMediaType)),
+	},
+
+	"corrupt image": {
+		requestId: "1",
+		kind:      "poster",
+		field:     "image",
+		image:     append([]byte("\x89PNG\r\n\x1a\n"), strings.Repeat("x", 64)...),
+		wantCode:  422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "image",
+			Data: map[string]string{
+				"image": "must be a valid JPEG or PNG image",
+			},
+		}),
+	},
+
+	"image with too many pixels": {
+		requestId: "1",
+		kind:      "backdrop",
+		field:     "image",
+		image:     encodeTestPngHeader(10_000, 5_000),
+		wantCode:  422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "image",
+			Data: map[string]string{
+				"image": "must have at most 40 megapixels",
+			},
+		}),
+	},
+
+	"missing image": {
+		requestId: "1",
+		kind:      "poster",
+		field:     "file",
+		image:     testPosterPng,
+		wantCode:  422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "image",
+			Data: map[string]string{
+				"image": "must be provided",
+			},
+		}),
+	},
+
+	"body too large": {
+		requestId: "1",
+		kind:      "poster",
+		field:     "image",
+		image:     append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 128*1024)...),
+		wantCode:  413,
+		wantBody:  response.ErrorResponse(413, response.GenericError(responseErrors.ErrMessageRequestTooLarge)),
+	},
+
+	"not a multipart form": {
+		requestId:   "1",
+		kind:        "poster",
+		contentType: "image/png",
+		image:       testPosterPng,
+		wantCode:    415,
+		wantBody:    response.ErrorResponse(415, response.GenericError(responseErrors.ErrMessageUnsupportedMediaType)),
+	},
+
+	"movie in trash": {
+		requestId: "4",
+		kind:      "poster",
+		field:     "image",
+		image:     testPosterPng,
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+}
+
+var deleteMovieImageTestCases = map[string]struct {
+	requestId string
+	kind      string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"movie without poster": {
+		requestId: "1",
+		kind:      "poster",
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+
+	"non-existent id": {
+		requestId: "99",
+		kind:      "backdrop",
+		wantCode:  404,
+		wantBody:  response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+}
+
+var similarMoviesTestCases = map[string]struct {
+	requestUrl string
+	wantCode   int
+	wantBody   response.BaseResponse
+}{
+	"similar movie in trash left out": {
+		requestUrl: "/v1/movies/1/similar",
+		wantCode:   200,
+		wantBody: response.SuccessResponse(200, []response.SimilarMovieResponse{
+			{
+				Score: 0.364,
+				Movie: response.MovieResponse{
+					Id:               2,
+					Title:            "Hamilton",
+					Year:             2020,
+					Runtime:          140,
+					Genres:           []string{"Musical", "Drama"},
+					OriginalTitle:    "Hamilton",
+					OriginalLanguage: "en",
+					Countries:        []string{"US"},
+					ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+					Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+					Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+					Tagline:          "An American musical.",
+					ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+					Version:          1,
+				},
+			},
+		}),
+	},
+
+	"limited": {
+		requestUrl: "/v1/movies/2/similar?limit=1",
+		wantCode:   200,
+		wantBody: response.SuccessResponse(200, []response.SimilarMovieResponse{
+			{
+				Score: 0.364,
+				Movie: response.MovieResponse{
+					Id:      1,
+					Title:   "Bullet Train",
+					Year:    2022,
+					Runtime: 108,
+					Genres:  []string{"Action", "Comedy"},
+					Version: 1,
+				},
+			},
+		}),
+	},
+
+	"limit above the number of similarities kept": {
+		requestUrl: "/v1/movies/1/similar?limit=21",
+		wantCode:   422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"limit": "must be a maximum of 20",
+			},
+		}),
+	},
+
+	"non-positive limit": {
+		requestUrl: "/v1/movies/1/similar?limit=0",
+		wantCode:   422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"limit": "must be greater than zero",
+			},
+		}),
+	},
+
+	"non-existent id": {
+		requestUrl: "/v1/movies/99/similar",
+		wantCode:   404,
+		wantBody:   response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+
+	"movie in trash": {
+		requestUrl: "/v1/movies/4/similar",
+		wantCode:   404,
+		wantBody:   response.ErrorResponse(404, response.GenericError(responseErrors.ErrMessageNotFound)),
+	},
+}
+
+var movieDuplicatesTestCases = map[string]struct {
+	queries  map[string]string
+	wantCode int
+	wantBody response.BaseResponse
+}{
+	"valid request": {
+		wantCode: 200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Metadata: &response.Metadata{},
+			Data:     []response.DuplicateCandidateResponse{},
+		},
+	},
+
+	"invalid limit": {
+		queries:  map[string]string{"limit": "0"},
+		wantCode: 422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"limit": "must be greater than zero",
+			},
+		}),
+	},
+}
+
+var mergeMoviesTestCases = map[string]struct {
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+}{
+	"valid request": {
+		requestBody: `{"survivor_id": 2, "duplicate_ids": [1]}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, response.MovieResponse{
+			Id:               2,
+			Title:            "Hamilton",
+			Year:             2020,
+			Runtime:          140,
+			Genres:           []string{"Musical", "Drama", "Action", "Comedy"},
+			OriginalTitle:    "Hamilton",
+			OriginalLanguage: "en",
+			Countries:        []string{"US"},
+			ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+			Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+			Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+			Tagline:          "An American musical.",
+			ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+			Version:          2,
+		}),
+	},
+
+	"missing fields": {
+		requestBody: `{}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "merge",
+			Data: map[string]string{
+				"survivor_id":   "must be provided",
+				"duplicate_ids": "must contain at least 1 movie",
+			},
+		}),
+	},
+
+	"survivor amongst duplicates": {
+		requestBody: `{"survivor_id": 2, "duplicate_ids": [1, 2]}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "merge",
+			Data: map[string]string{
+				"duplicate_ids": "must not contain the survivor",
+			},
+		}),
+	},
+
+	"repeated duplicates": {
+		requestBody: `{"survivor_id": 2, "duplicate_ids": [1, 1]}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "merge",
+			Data: map[string]string{
+				"duplicate_ids": "must not contain duplicate movies",
+			},
+		}),
+	},
+
+	"non-existent survivor": {
+		requestBody: `{"survivor_id": 99, "duplicate_ids": [1]}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "merge",
+			Data: map[string]string{
+				"survivor_id": "must be an existing movie",
+			},
+		}),
+	},
+
+	"deleted duplicate": {
+		requestBody: `{"survivor_id": 2, "duplicate_ids": [1, 4]}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "merge",
+			Data: map[string]string{
+				"duplicate_ids": "must all be existing movies",
+			},
+		}),
+	},
 }
@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/middleware"
 	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/infrastructure/mock"
 	"github.com/rhodeon/moviescreen/internal/testhelpers"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +16,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMovieHandler_Create(t *testing.T) {
@@ -52,11 +58,17 @@ func TestMovieHandler_GetById(t *testing.T) {
 			setBearerToken(req)
 
 			app.Router(testRouteHandlers).ServeHTTP(rr, req)
-			code, body, _ := parseResponse(t, rr.Result())
+			code, body, header := parseResponse(t, rr.Result())
 
 			// assert status code
 			testhelpers.AssertEqual(t, code, tc.wantCode)
 
+			// assert the redirect location, as redirects have no JSON body
+			testhelpers.AssertEqual(t, header.Get("Location"), tc.wantLocation)
+			if tc.wantLocation != "" {
+				return
+			}
+
 			// assert body
 			wantBody, _ := json.Marshal(tc.wantBody)
 			testhelpers.AssertEqual(t, body, string(wantBody))
@@ -151,3 +163,644 @@ func TestMovieHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestMovieHandler_ConditionalRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := conditionalMovieTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+			for key, value := range tc.requestHeaders {
+				req.Header.Set(key, value)
+			}
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, headers := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert the body is empty for unmodified resources
+			if code == http.StatusNotModified {
+				testhelpers.AssertEqual(t, body, "")
+			}
+
+			// assert headers
+			assertHeaders(t, headers, tc.wantHeaders)
+		})
+	}
+}
+
+func TestMovieHandler_Patch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := patchMovieTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, path.Join("/v1/movies", tc.requestId), strings.NewReader(tc.requestBody))
+			req.Header.Set("Content-Type", tc.contentType)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_Import(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := importMoviesTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/movies/import", strings.NewReader(tc.requestBody))
+			req.Header.Set("Content-Type", tc.contentType)
+			setBearerToken(req)
+
+			if tc.dryRun {
+				q := req.URL.Query()
+				q.Set("dry_run", "true")
+				req.URL.RawQuery = q.Encode()
+			}
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_Export(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := exportMoviesTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/movies/export", nil)
+			setBearerToken(req)
+
+			q := req.URL.Query()
+			for key, value := range tc.queries {
+				q.Set(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, headers := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			testhelpers.AssertEqual(t, body, tc.wantBody)
+
+			// assert headers
+			assertHeaders(t, headers, tc.wantHeaders)
+		})
+	}
+}
+
+// TestMovieHandler_ExportImport asserts that a CSV export can be imported as is, creating its movies anew.
+func TestMovieHandler_ExportImport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/movies/export?format=csv", nil)
+	setBearerToken(req)
+
+	app.Router(testRouteHandlers).ServeHTTP(rr, req)
+	code, export, _ := parseResponse(t, rr.Result())
+	testhelpers.AssertEqual(t, code, http.StatusOK)
+
+	rr = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/v1/movies/import", strings.NewReader(export))
+	req.Header.Set("Content-Type", "text/csv")
+	setBearerToken(req)
+
+	app.Router(testRouteHandlers).ServeHTTP(rr, req)
+	code, body, _ := parseResponse(t, rr.Result())
+	testhelpers.AssertEqual(t, code, http.StatusOK)
+
+	wantBody, _ := json.Marshal(response.SuccessResponse(
+		http.StatusOK,
+		response.MovieImportResponse{
+			Total:    2,
+			Valid:    2,
+			Imported: 2,
+			Errors:   []response.MovieImportError{},
+		},
+	))
+	testhelpers.AssertEqual(t, body, string(wantBody))
+}
+
+// TestMovieHandler_ExportPastWriteTimeout asserts that exports are streamed in full
+// when they take longer than the write timeout of the server.
+func TestMovieHandler_ExportPastWriteTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+
+	// delay each exported movie so the export outlasts the write timeout
+	repositories := testRepos
+	repositories.Movies = slowMovieController{MovieRepository: testRepos.Movies, delay: 100 * time.Millisecond}
+	routeHandlers := testRouteHandlers
+	routeHandlers.Movies = NewMovieHandler(testConfig, repositories, testStorage)
+
+	server := httptest.NewUnstartedServer(middleware.WithResponseController(app.Router(routeHandlers)))
+	server.Config.WriteTimeout = 50 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/movies/export?format=csv", nil)
+	testhelpers.AssertFatalError(t, err)
+	setBearerToken(req)
+
+	result, err := server.Client().Do(req)
+	testhelpers.AssertFatalError(t, err)
+	code, body, _ := parseResponse(t, result)
+
+	testhelpers.AssertEqual(t, code, http.StatusOK)
+	testhelpers.AssertEqual(t, body, "id,title,year,runtime,genres,version\n"+
+		"1,Bullet Train,2022,108,Action|Comedy,1\n"+
+		"2,Hamilton,2020,140,Musical|Drama,1\n")
+}
+
+// slowMovieController delays each movie iterated over by the wrapped repository.
+type slowMovieController struct {
+	repository.MovieRepository
+	delay time.Duration
+}
+
+func (m slowMovieController) Iterate(query request.MovieQuery, filters request.Filters) repository.MovieIterator {
+	return slowMovieIterator{MovieIterator: m.MovieRepository.Iterate(query, filters), delay: m.delay}
+}
+
+type slowMovieIterator struct {
+	repository.MovieIterator
+	delay time.Duration
+}
+
+func (i slowMovieIterator) Next() bool {
+	time.Sleep(i.delay)
+	return i.MovieIterator.Next()
+}
+
+func TestMovieHandler_Trash(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := trashMoviesTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/movies/trash", nil)
+			setBearerToken(req)
+
+			q := req.URL.Query()
+			for key, value := range tc.queries {
+				q.Set(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_Restore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := restoreMovieTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, path.Join("/v1/movies", tc.requestId, "restore"), nil)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_Revisions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := movieRevisionsTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, path.Join("/v1/movies", tc.requestId, "revisions"), nil)
+			setBearerToken(req)
+
+			q := req.URL.Query()
+			for key, value := range tc.queries {
+				q.Set(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_DiffRevisions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := diffMovieRevisionsTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, path.Join("/v1/movies", tc.requestId, "revisions/diff"), nil)
+			setBearerToken(req)
+
+			q := req.URL.Query()
+			for key, value := range tc.queries {
+				q.Set(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_RevertRevision(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := revertMovieRevisionTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, path.Join("/v1/movies", tc.requestId, "revisions", tc.version, "revert"), nil)
+			setBearerToken(req)
+			for key, value := range tc.requestHeaders {
+				req.Header.Set(key, value)
+			}
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_AcceptLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := acceptLanguageTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			setBearerToken(req)
+			req.Header.Set("Accept-Language", tc.acceptLanguage)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, headers := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert headers
+			assertHeaders(t, headers, tc.wantHeaders)
+		})
+	}
+}
+
+func TestMovieHandler_Translations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := movieTranslationsTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, path.Join("/v1/movies", tc.requestId, "translations"), nil)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_PutTranslation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := putMovieTranslationTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			url := path.Join("/v1/movies", tc.requestId, "translations", tc.language)
+			req := httptest.NewRequest(http.MethodPut, url, strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, headers := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert headers
+			assertHeaders(t, headers, tc.wantHeaders)
+		})
+	}
+}
+
+func TestMovieHandler_DeleteTranslation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := deleteMovieTranslationTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			url := path.Join("/v1/movies", tc.requestId, "translations", tc.language)
+			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_Lookup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := lookupMovieTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/movies/lookup", nil)
+			setBearerToken(req)
+
+			q := req.URL.Query()
+			for key, value := range tc.queries {
+				q.Set(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, headers := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert headers
+			assertHeaders(t, headers, tc.wantHeaders)
+		})
+	}
+}
+
+func TestMovieHandler_Similar(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := similarMoviesTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.requestUrl, nil)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_PutImage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := putMovieImageTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			body, contentType := newMultipartBody(tc.field, tc.image)
+			if tc.contentType != "" {
+				body, contentType = bytes.NewBuffer(tc.image), tc.contentType
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, path.Join("/v1/movies", tc.requestId, tc.kind), body)
+			req.Header.Set("Content-Type", contentType)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, gotBody, headers := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, gotBody, string(wantBody))
+
+			// assert headers
+			assertHeaders(t, headers, tc.wantHeaders)
+
+			// assert the image and its thumbnails are in the storage
+			if tc.wantCode == http.StatusOK {
+				movie := tc.wantBody.Data.(response.MovieResponse)
+				image := movie.Poster
+				if tc.kind == "backdrop" {
+					image = movie.Backdrop
+				}
+
+				urls := []string{image.Url}
+				for _, thumbnail := range image.Thumbnails {
+					urls = append(urls, thumbnail.Url)
+				}
+				for _, url := range urls {
+					key := strings.TrimPrefix(url, mock.MockStorageUrl+"/")
+					if _, _, exists := testStorage.Get(key); !exists {
+						t.Errorf("no object stored under %q", key)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMovieHandler_DeleteImage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := deleteMovieImageTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, path.Join("/v1/movies", tc.requestId, tc.kind), nil)
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_Duplicates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := movieDuplicatesTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/movies/duplicates", nil)
+			setBearerToken(req)
+
+			q := req.URL.Query()
+			for key, value := range tc.queries {
+				q.Set(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestMovieHandler_Merge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := mergeMoviesTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/admin/movies/merge", strings.NewReader(tc.requestBody))
+			setBearerToken(req)
+
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.wantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.wantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/imaging"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/prettylog"
+	"image"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+)
+
+const (
+	// imageFormField is the name of the multipart form field holding an uploaded image.
+	imageFormField = "image"
+
+	// maxImagePixels is the maximum number of pixels of an uploaded image (40 megapixels),
+	// which bounds the memory used to decode it regardless of its compressed size.
+	maxImagePixels = 40_000_000
+)
+
+// putImage replaces the image of the given kind of the movie with the id parameter by the uploaded image,
+// which is stored along with its thumbnails before the movie is updated.
+// The files of the previous image are deleted once the movie has been updated.
+func (m movieHandler) putImage(ctx *gin.Context, kind string) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	movie, err := m.fetchMovie(ctx, id)
+	if err != nil {
+		return
+	}
+
+	// ensure the client's copy of the movie is up-to-date before updating
+	if preconditionFailed(ctx, movieETag(movie.Id, movie.Version)) {
+		return
+	}
+
+	data, err := readImageUpload(ctx)
+	if err != nil {
+		return
+	}
+	img, contentType, err := decodeImageUpload(ctx, data)
+	if err != nil {
+		return
+	}
+
+	newImage, err := m.storeMovieImage(movie.Id, kind, data, contentType, img, movie.Image(kind))
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	previous := movie
+	movie.SetImage(kind, newImage)
+	m.updateImage(ctx, movie, previous, kind)
+}
+
+// deleteImage removes the image of the given kind from the movie with the id parameter,
+// and deletes its files.
+// A 404 error is returned if the movie has no such image.
+func (m movieHandler) deleteImage(ctx *gin.Context, kind string) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	movie, err := m.fetchMovie(ctx, id)
+	if err != nil {
+		return
+	}
+	if movie.Image(kind) == nil {
+		responseErrors.NewErrorHandler().NotFound(ctx)
+		return
+	}
+
+	// ensure the client's copy of the movie is up-to-date before updating
+	if preconditionFailed(ctx, movieETag(movie.Id, movie.Version)) {
+		return
+	}
+
+	previous := movie
+	movie.SetImage(kind, nil)
+	m.updateImage(ctx, movie, previous, kind)
+}
+
+// updateImage updates the movie whose image of the given kind has been changed from that of the previous state,
+// and responds with the updated movie.
+// The files of whichever image is no longer referenced by the movie are deleted.
+func (m movieHandler) updateImage(ctx *gin.Context, movie models.Movie, previous models.Movie, kind string) {
+	err := m.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		err := repositories.Movies.Update(&movie)
+		if err != nil {
+			return err
+		}
+		return insertRevisions(ctx, repositories, models.NewMovieRevision(models.RevisionActionUpdate, movie, &previous))
+	})
+	if err != nil {
+		m.deleteUnusedFiles(movie.Image(kind), previous.Image(kind))
+		if errors.Is(err, repository.ErrEditConflict) {
+			responseErrors.NewErrorHandler().EditConflict(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+	m.deleteUnusedFiles(previous.Image(kind), movie.Image(kind))
+
+	// return updated movie
+	ctx.Header("ETag", movieETag(movie.Id, movie.Version))
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			movie.ToResponse(),
+		),
+	)
+}
+
+// deleteUnusedFiles deletes the files of the unused image which aren't shared by the image in use,
+// as re-uploading an image stores it under the same keys.
+// Failures are only logged, leaving the files orphaned in the storage.
+func (m movieHandler) deleteUnusedFiles(unused *models.MovieImage, inUse *models.MovieImage) {
+	inUseKeys := map[string]bool{}
+	for _, key := range inUse.Keys() {
+		inUseKeys[key] = true
+	}
+
+	var keys []string
+	for _, key := range unused.Keys() {
+		if !inUseKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	if err := m.storage.Delete(keys...); err != nil {
+		prettylog.ErrorF("movie images: %s", err.Error())
+	}
+}
+
+// readImageUpload returns the content of the image field of a multipart form request body.
+// A 415 error is returned if the body isn't a multipart form, a 413 error if it's too large,
+// a 400 error if it's malformed, and a 422 error if it has no image.
+func readImageUpload(ctx *gin.Context) ([]byte, error) {
+	if ctx.ContentType() != gin.MIMEMultipartPOSTForm {
+		responseErrors.NewErrorHandler().UnsupportedMediaType(ctx)
+		return nil, errors.New("unsupported media type")
+	}
+
+	reader, err := ctx.Request.MultipartReader()
+	if err != nil {
+		handleBodyReadError(ctx, err)
+		return nil, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			handleBodyReadError(ctx, err)
+			return nil, err
+		}
+		if part.FormName() != imageFormField {
+			continue
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			handleBodyReadError(ctx, err)
+			return nil, err
+		}
+		return data, nil
+	}
+
+	abortWithInvalidImage(ctx, "must be provided")
+	return nil, validator.NewError()
+}
+
+// decodeImageUpload returns the decoded image with its content type sniffed from its data,
+// regardless of the type declared in the request.
+// A 415 error is returned if the image isn't a JPEG or PNG, and a 422 error if it can't be decoded
+// or has too many pixels.
+func decodeImageUpload(ctx *gin.Context, data []byte) (image.Image, string, error) {
+	contentType, err := imaging.DetectContentType(data)
+	if err != nil {
+		responseErrors.NewErrorHandler().UnsupportedMediaType(ctx)
+		return nil, "", err
+	}
+
+	img, err := imaging.Decode(data, contentType, maxImagePixels)
+	if err != nil {
+		if errors.Is(err, imaging.ErrTooLarge) {
+			abortWithInvalidImage(ctx, fmt.Sprintf("must have at most %d megapixels", maxImagePixels/1_000_000))
+		} else {
+			abortWithInvalidImage(ctx, "must be a valid JPEG or PNG image")
+		}
+		return nil, "", err
+	}
+	return img, contentType, nil
+}
+
+// abortWithInvalidImage aborts the request with a 422 error for the uploaded image.
+func abortWithInvalidImage(ctx *gin.Context, message string) {
+	v := validator.New("image")
+	v.AddError(imageFormField, message)
+	ctx.AbortWithStatusJSON(
+		http.StatusUnprocessableEntity,
+		response.UnprocessableEntityError(v),
+	)
+}
+
+// storeMovieImage stores the image of the movie along with its thumbnails, and returns their details.
+// Files are keyed by a hash of the image content, so a replaced image never shares the URLs of its predecessor
+// and they can be cached indefinitely.
+// The stored files which aren't shared with the current image are deleted if any file can't be stored.
+func (m movieHandler) storeMovieImage(movieId int, kind string, data []byte, contentType string, img image.Image, current *models.MovieImage) (*models.MovieImage, error) {
+	prefix := movieImagePrefix(movieId, kind, data)
+	bounds := img.Bounds()
+
+	movieImage := &models.MovieImage{
+		Key:         path.Join(prefix, "original"+imaging.Extensions[contentType]),
+		ContentType: contentType,
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+		Thumbnails:  []models.MovieThumbnail{},
+	}
+	movieImage.Url = m.storage.Url(movieImage.Key)
+
+	err := m.storage.Put(movieImage.Key, contentType, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, width := range models.MovieThumbnailWidths[kind] {
+		if width >= movieImage.Width {
+			continue
+		}
+
+		thumbnail, thumbnailWidth, thumbnailHeight, err := imaging.Thumbnail(img, width)
+		if err != nil {
+			m.deleteUnusedFiles(movieImage, current)
+			return nil, err
+		}
+
+		size := models.ThumbnailSize(width)
+		key := path.Join(prefix, size+imaging.Extensions[imaging.ContentTypeJpeg])
+		err = m.storage.Put(key, imaging.ContentTypeJpeg, bytes.NewReader(thumbnail))
+		if err != nil {
+			m.deleteUnusedFiles(movieImage, current)
+			return nil, err
+		}
+
+		movieImage.Thumbnails = append(movieImage.Thumbnails, models.MovieThumbnail{
+			Size:   size,
+			Key:    key,
+			Url:    m.storage.Url(key),
+			Width:  thumbnailWidth,
+			Height: thumbnailHeight,
+		})
+	}
+
+	return movieImage, nil
+}
+
+// movieImagePrefix returns the key prefix of the files of an image of the movie with the given content.
+func movieImagePrefix(movieId int, kind string, data []byte) string {
+	hash := sha256.Sum256(data)
+	return path.Join("movies", strconv.Itoa(movieId), kind, hex.EncodeToString(hash[:8]))
+}
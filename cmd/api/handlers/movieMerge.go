@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"net/http"
+)
+
+// Duplicates returns the pairs of movies which are likely to be duplicates, with the most similar first.
+func (m movieHandler) Duplicates(ctx *gin.Context) {
+	// set and validate the filters, with the candidates always ordered by similarity
+	queries := ctx.Request.URL.Query()
+	filters := request.Filters{
+		Page:       parseQueryInt(queries, request.FilterFieldPage, 1),
+		Limit:      parseQueryInt(queries, request.FilterFieldLimit, 20),
+		Sort:       "-similarity",
+		ValidSorts: []string{"similarity"},
+	}
+
+	v := filters.Validate()
+	if !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	candidates, metadata, err := m.repositories.Movies.ListDuplicates(filters)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.BaseResponse{
+			Success:  true,
+			Status:   http.StatusOK,
+			Data:     candidates.ToResponse(),
+			Metadata: &metadata,
+		},
+	)
+}
+
+// Merge consolidates the duplicates into the surviving movie and returns the merged movie.
+// The duplicates are removed, and requests for them are redirected to the surviving movie.
+// The images of the duplicates which weren't taken over by the surviving movie are deleted from the storage.
+func (m movieHandler) Merge(ctx *gin.Context) {
+	// parse and validate the request body
+	mergeRequest := &request.MovieMergeRequest{}
+	err := parseJsonRequest(ctx, mergeRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, mergeRequest, []string{request.MovieMergeFieldSurvivorId, request.MovieMergeFieldDuplicateIds})
+	if err != nil {
+		return
+	}
+
+	// fetch the duplicates before they're removed, so their unused images can be deleted afterwards
+	duplicates := models.Movies{}
+	for _, id := range mergeRequest.DuplicateIds {
+		duplicate, err := m.repositories.Movies.Get(id)
+		if err != nil {
+			if errors.Is(err, repository.ErrRecordNotFound) {
+				abortWithMergeError(ctx, request.MovieMergeFieldDuplicateIds, "must all be existing movies")
+			} else {
+				responseErrors.HandleInternalServerError(ctx, err)
+			}
+			return
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+
+	movie, err := m.repositories.Movies.Merge(*mergeRequest.SurvivorId, mergeRequest.DuplicateIds, common.ContextGetUser(ctx).Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRecordNotFound):
+			abortWithMergeError(ctx, request.MovieMergeFieldSurvivorId, "must be an existing movie")
+		case errors.Is(err, repository.ErrMissingDuplicate):
+			abortWithMergeError(ctx, request.MovieMergeFieldDuplicateIds, "must all be existing movies")
+		default:
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	for _, duplicate := range duplicates {
+		m.deleteUnusedFiles(duplicate.Poster, movie.Poster)
+		m.deleteUnusedFiles(duplicate.Backdrop, movie.Backdrop)
+	}
+
+	ctx.Header("ETag", movieETag(movie.Id, movie.Version))
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			movie.ToResponse(),
+		),
+	)
+}
+
+// abortWithMergeError aborts the request with a 422 error for a field of a merge request.
+func abortWithMergeError(ctx *gin.Context, field string, message string) {
+	v := validator.New("merge")
+	v.AddError(field, message)
+	ctx.AbortWithStatusJSON(
+		http.StatusUnprocessableEntity,
+		response.UnprocessableEntityError(v),
+	)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"golang.org/x/text/language"
+	"net/http"
+)
+
+// parseLanguageParam returns the canonical BCP 47 tag of the language parameter of the request.
+// A 422 error is returned if the parameter isn't a valid tag of a known language.
+func parseLanguageParam(ctx *gin.Context) (string, error) {
+	tag, err := language.Parse(ctx.Param("language"))
+	if err != nil || tag == language.Und {
+		v := validator.New("translation")
+		v.AddError(request.MovieTranslationFieldLanguage, "must be a BCP 47 language tag")
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return "", validator.NewError()
+	}
+	return tag.String(), nil
+}
+
+// acceptedLanguages returns the languages of the Accept-Language header of the request in order of preference.
+// Missing and malformed headers accept no language in particular, leaving movies untranslated.
+func acceptedLanguages(ctx *gin.Context) []language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(ctx.GetHeader("Accept-Language"))
+	if err != nil {
+		return nil
+	}
+	return tags
+}
+
+// translatableMovieFields returns the fields to fetch the movies with for the selected fields,
+// adding the original language as the translations are negotiated against it.
+// It is left out of the response by selectMovieFields if it wasn't selected.
+func translatableMovieFields(fields []string) []string {
+	if len(fields) == 0 || rules.In(request.MovieFieldOriginalLanguage, fields) {
+		return fields
+	}
+	return append(append([]string{}, fields...), request.MovieFieldOriginalLanguage)
+}
+
+// translateMovies applies the translations which best match the accepted languages of the request to the movies.
+// Movies whose original language matches better than their translations are left unchanged.
+func (m movieHandler) translateMovies(ctx *gin.Context, movies models.Movies) error {
+	// the representations of movies depend on the accepted languages
+	ctx.Writer.Header().Add("Vary", "Accept-Language")
+
+	accepted := acceptedLanguages(ctx)
+	if len(accepted) == 0 || len(movies) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.Id
+	}
+	translations, err := m.repositories.MovieTranslations.ListForMovies(ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range movies {
+		if translation, ok := bestMovieTranslation(accepted, movies[i], translations[movies[i].Id]); ok {
+			movies[i].Translate(translation)
+		}
+	}
+	return nil
+}
+
+// bestMovieTranslation returns the translation of the movie which best matches the accepted languages.
+// The original language is preferred over its translations, and false is returned if it matches best
+// or no language matches.
+// Movies without an original language accept any matching translation,
+// so movies fetched with a sparse fieldset must include it, as done by translatableMovieFields.
+func bestMovieTranslation(accepted []language.Tag, movie models.Movie, translations models.MovieTranslations) (models.MovieTranslation, bool) {
+	if len(translations) == 0 {
+		return models.MovieTranslation{}, false
+	}
+
+	// the first supported language is the fallback of the matcher
+	original := language.Und
+	if movie.OriginalLanguage != "" {
+		original = language.Make(movie.OriginalLanguage)
+	}
+	supported := []language.Tag{original}
+	for _, translation := range translations {
+		supported = append(supported, language.Make(translation.Language))
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(accepted...)
+	if index == 0 || confidence == language.No {
+		return models.MovieTranslation{}, false
+	}
+	return translations[index-1], true
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"net/http"
+)
+
+type searchHandler struct {
+	repositories repository.Repositories
+}
+
+func NewSearchHandler(repositories repository.Repositories) common.SearchHandler {
+	return &searchHandler{
+		repositories: repositories,
+	}
+}
+
+// Search returns the movies and series matching the queries as a single list,
+// which can be restricted to either type.
+func (s searchHandler) Search(ctx *gin.Context) {
+	// set the queries
+	queries := ctx.Request.URL.Query()
+	searchQuery := request.SearchQuery{
+		Title:  parseQueryString(queries, request.SearchQueryFieldTitle, ""),
+		Type:   parseQueryString(queries, request.SearchQueryFieldType, ""),
+		Genres: parseQueryCsv(queries, request.SearchQueryFieldGenres, []string{}),
+	}
+
+	// set and validate the filters and queries
+	filters := request.Filters{
+		Page:  parseQueryInt(queries, "page", 1),
+		Limit: parseQueryInt(queries, "limit", 20),
+		Sort:  parseQueryString(queries, "sort", request.SearchFilterSortTitle),
+		ValidSorts: []string{
+			request.SearchFilterSortTitle,
+			request.SearchFilterSortYear,
+		},
+	}
+
+	v := filters.Validate()
+	searchQuery.Validate(v)
+	if !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	results, metadata, err := s.repositories.Search.Search(searchQuery, filters)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.BaseResponse{
+			Success:  true,
+			Status:   http.StatusOK,
+			Data:     results.ToResponse(),
+			Metadata: &metadata,
+		},
+	)
+}
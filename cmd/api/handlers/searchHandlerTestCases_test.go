@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+)
+
+// expected search results of the mock movies and series
+var (
+	arcaneSearchResult = response.SearchResultResponse{
+		Type:   "series",
+		Id:     2,
+		Title:  "Arcane",
+		Year:   2021,
+		Genres: []string{"Action", "Adventure", "Animation"},
+	}
+
+	bulletTrainSearchResult = response.SearchResultResponse{
+		Type:   "movie",
+		Id:     1,
+		Title:  "Bullet Train",
+		Year:   2022,
+		Genres: []string{"Action", "Comedy"},
+	}
+
+	hamiltonSearchResult = response.SearchResultResponse{
+		Type:   "movie",
+		Id:     2,
+		Title:  "Hamilton",
+		Year:   2020,
+		Genres: []string{"Musical", "Drama"},
+	}
+
+	severanceSearchResult = response.SearchResultResponse{
+		Type:   "series",
+		Id:     1,
+		Title:  "Severance",
+		Year:   2022,
+		Genres: []string{"Drama", "Thriller"},
+	}
+)
+
+var searchTestCases = map[string]struct {
+	requestUrl string
+	wantCode   int
+	wantBody   response.BaseResponse
+}{
+	"all types": {
+		requestUrl: "/v1/search/",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Data: []response.SearchResultResponse{
+				arcaneSearchResult,
+				bulletTrainSearchResult,
+				hamiltonSearchResult,
+				severanceSearchResult,
+			},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 4},
+		},
+	},
+
+	"series only": {
+		requestUrl: "/v1/search/?type=series",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Data:     []response.SearchResultResponse{arcaneSearchResult, severanceSearchResult},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 2},
+		},
+	},
+
+	"movies only by descending year": {
+		requestUrl: "/v1/search/?type=movie&sort=-year",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Data:     []response.SearchResultResponse{bulletTrainSearchResult, hamiltonSearchResult},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 2},
+		},
+	},
+
+	"ties in year ordered by type": {
+		requestUrl: "/v1/search/?sort=-year",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success: true,
+			Status:  200,
+			Data: []response.SearchResultResponse{
+				bulletTrainSearchResult,
+				severanceSearchResult,
+				arcaneSearchResult,
+				hamiltonSearchResult,
+			},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 4},
+		},
+	},
+
+	"genre across types": {
+		requestUrl: "/v1/search/?genres=drama",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Data:     []response.SearchResultResponse{hamiltonSearchResult, severanceSearchResult},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 2},
+		},
+	},
+
+	"title": {
+		requestUrl: "/v1/search/?title=Arcane",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Data:     []response.SearchResultResponse{arcaneSearchResult},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 1},
+		},
+	},
+
+	"invalid type": {
+		requestUrl: "/v1/search/?type=episode",
+		wantCode:   422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"type": "must be one of movie, series",
+			},
+		}),
+	},
+}
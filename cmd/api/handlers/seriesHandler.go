@@ -0,0 +1,447 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"net/http"
+	"path"
+	"strconv"
+)
+
+type seriesHandler struct {
+	repositories repository.Repositories
+}
+
+func NewSeriesHandler(repositories repository.Repositories) common.SeriesHandler {
+	return &seriesHandler{
+		repositories: repositories,
+	}
+}
+
+// List returns a list of series without their seasons.
+func (s seriesHandler) List(ctx *gin.Context) {
+	// set the queries
+	queries := ctx.Request.URL.Query()
+	seriesQuery := request.SeriesQuery{
+		Title:  parseQueryString(queries, "title", ""),
+		Genres: parseQueryCsv(queries, "genres", []string{}),
+	}
+
+	// set and validate the filters
+	filters := request.Filters{
+		Page:  parseQueryInt(queries, "page", 1),
+		Limit: parseQueryInt(queries, "limit", 20),
+		Sort:  parseQueryString(queries, "sort", request.SeriesFilterSortId),
+		ValidSorts: []string{
+			request.SeriesFilterSortId,
+			request.SeriesFilterSortTitle,
+			request.SeriesFilterSortStartYear,
+		},
+	}
+
+	if v := filters.Validate(); !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	seriesList, metadata, err := s.repositories.Series.List(seriesQuery, filters)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.BaseResponse{
+			Success:  true,
+			Status:   http.StatusOK,
+			Data:     seriesList.ToResponse(),
+			Metadata: &metadata,
+		},
+	)
+}
+
+// GetById returns the series with the given id with its seasons and episodes in order.
+func (s seriesHandler) GetById(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	series, err := s.fetchSeries(ctx, id)
+	if err != nil {
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			series.ToResponse(),
+		),
+	)
+}
+
+// Create adds a new series without seasons, and returns the newly created series.
+func (s seriesHandler) Create(ctx *gin.Context) {
+	// parse and validate the request body, with the genres checked against the known genres
+	seriesRequest := &request.SeriesRequest{}
+	err := parseJsonRequest(ctx, seriesRequest)
+	if err != nil {
+		return
+	}
+	err = setValidGenres(ctx, s.repositories, seriesRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, seriesRequest, []string{
+		request.SeriesFieldTitle,
+		request.SeriesFieldStartYear,
+		request.SeriesFieldGenres,
+	})
+	if err != nil {
+		return
+	}
+
+	series := seriesRequest.ToModel()
+	err = s.repositories.Series.Create(&series)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Location", path.Join("/v1/series", strconv.Itoa(series.Id)))
+	ctx.JSON(
+		http.StatusCreated,
+		response.SuccessResponse(
+			http.StatusCreated,
+			series.ToResponse(),
+		),
+	)
+}
+
+// Update replaces the given fields of the series with the given id, and returns the updated series.
+// An end year of 0 marks the series as still running.
+func (s seriesHandler) Update(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	// parse and validate the request body with all fields being optional
+	seriesRequest := &request.SeriesRequest{}
+	err = parseJsonRequest(ctx, seriesRequest)
+	if err != nil {
+		return
+	}
+	err = setValidGenres(ctx, s.repositories, seriesRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, seriesRequest, []string{})
+	if err != nil {
+		return
+	}
+
+	series, err := s.fetchSeries(ctx, id)
+	if err != nil {
+		return
+	}
+
+	// check the years against those of the series, as the request may only change one of them
+	if v := seriesRequest.CheckYears(series); !v.Valid() {
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+		return
+	}
+
+	seriesRequest.UpdateModel(&series)
+	err = s.repositories.Series.Update(&series)
+	if err != nil {
+		if errors.Is(err, repository.ErrEditConflict) {
+			responseErrors.NewErrorHandler().EditConflict(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			series.ToResponse(),
+		),
+	)
+}
+
+// Delete removes the series with the given id along with its seasons and episodes.
+func (s seriesHandler) Delete(ctx *gin.Context) {
+	// validate id
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	err = s.repositories.Series.Delete(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			map[string]string{"message": "series deleted successfully"},
+		),
+	)
+}
+
+// PutSeason creates or replaces the season of the series with the given id and number,
+// and returns the season with its episodes. The episodes of a replaced season are kept.
+func (s seriesHandler) PutSeason(ctx *gin.Context) {
+	// validate id and season number
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+	number, err := parseNumberParam(ctx, "season")
+	if err != nil {
+		return
+	}
+
+	// parse and validate the request body
+	seasonRequest := &request.SeasonRequest{}
+	err = parseJsonRequest(ctx, seasonRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, seasonRequest, []string{})
+	if err != nil {
+		return
+	}
+
+	// fetch the series for the episodes of the season being replaced
+	series, err := s.fetchSeries(ctx, id)
+	if err != nil {
+		return
+	}
+
+	season := seasonRequest.ToModel(id, number)
+	season.Episodes = []models.Episode{}
+	for _, existing := range series.Seasons {
+		if existing.Number == number {
+			season.Episodes = existing.Episodes
+		}
+	}
+
+	created, err := s.repositories.Series.UpsertSeason(&season)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRecordNotFound):
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		case errors.Is(err, repository.ErrAirDateOrder):
+			abortWithInvalidAirDate(ctx, "season", "must not be before the air date of an earlier season or after that of a later one")
+		default:
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		ctx.Header("Location", path.Join("/v1/series", strconv.Itoa(id), "seasons", strconv.Itoa(number)))
+	}
+	ctx.JSON(
+		status,
+		response.SuccessResponse(
+			status,
+			season.ToResponse(),
+		),
+	)
+}
+
+// DeleteSeason removes the season of the series with the given id and number along with its episodes.
+func (s seriesHandler) DeleteSeason(ctx *gin.Context) {
+	// validate id and season number
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+	number, err := parseNumberParam(ctx, "season")
+	if err != nil {
+		return
+	}
+
+	err = s.repositories.Series.DeleteSeason(id, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			map[string]string{"message": "season deleted successfully"},
+		),
+	)
+}
+
+// PutEpisode creates or replaces the episode with the given number in a season of the series with the given id.
+func (s seriesHandler) PutEpisode(ctx *gin.Context) {
+	// validate id, season number and episode number
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+	seasonNumber, err := parseNumberParam(ctx, "season")
+	if err != nil {
+		return
+	}
+	number, err := parseNumberParam(ctx, "episode")
+	if err != nil {
+		return
+	}
+
+	// parse and validate the request body
+	episodeRequest := &request.EpisodeRequest{}
+	err = parseJsonRequest(ctx, episodeRequest)
+	if err != nil {
+		return
+	}
+	err = validateJsonRequest(ctx, episodeRequest, []string{
+		request.EpisodeFieldTitle,
+		request.EpisodeFieldRuntime,
+	})
+	if err != nil {
+		return
+	}
+
+	episode := episodeRequest.ToModel(seasonNumber, number)
+	created, err := s.repositories.Series.UpsertEpisode(id, &episode)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRecordNotFound):
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		case errors.Is(err, repository.ErrAirDateOrder):
+			abortWithInvalidAirDate(ctx, "episode", "must not be before the air date of an earlier episode or after that of a later one")
+		default:
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		ctx.Header("Location", path.Join(
+			"/v1/series", strconv.Itoa(id), "seasons", strconv.Itoa(seasonNumber), "episodes", strconv.Itoa(number),
+		))
+	}
+	ctx.JSON(
+		status,
+		response.SuccessResponse(
+			status,
+			episode.ToResponse(),
+		),
+	)
+}
+
+// DeleteEpisode removes the episode with the given number from a season of the series with the given id.
+func (s seriesHandler) DeleteEpisode(ctx *gin.Context) {
+	// validate id, season number and episode number
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+	seasonNumber, err := parseNumberParam(ctx, "season")
+	if err != nil {
+		return
+	}
+	number, err := parseNumberParam(ctx, "episode")
+	if err != nil {
+		return
+	}
+
+	err = s.repositories.Series.DeleteEpisode(id, seasonNumber, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			map[string]string{"message": "episode deleted successfully"},
+		),
+	)
+}
+
+// fetchSeries returns the series with the given id from the repository.
+// A 404 error is returned if the series doesn't exist.
+func (s seriesHandler) fetchSeries(ctx *gin.Context, id int) (models.Series, error) {
+	series, err := s.repositories.Series.Get(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return models.Series{}, err
+	}
+	return series, nil
+}
+
+// parseNumberParam converts the parameter of a season or episode number to a positive integer.
+// A 404 response is returned if it isn't one.
+func parseNumberParam(ctx *gin.Context, key string) (int, error) {
+	number, err := strconv.Atoi(ctx.Param(key))
+	if err == nil && number <= 0 {
+		err = errors.New("non-positive number")
+	}
+	if err != nil {
+		responseErrors.NewErrorHandler().NotFound(ctx)
+		return 0, err
+	}
+	return number, nil
+}
+
+// abortWithInvalidAirDate aborts the request with a 422 error for an air date which is out of order.
+func abortWithInvalidAirDate(ctx *gin.Context, name string, message string) {
+	v := validator.New(name)
+	v.AddError(request.SeasonFieldAirDate, message)
+	ctx.AbortWithStatusJSON(
+		http.StatusUnprocessableEntity,
+		response.UnprocessableEntityError(v),
+	)
+}
@@ -0,0 +1,421 @@
+package handlers
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/infrastructure/mock"
+	"net/http"
+)
+
+// expected responses of the mock series and their seasons
+var (
+	airDate = func(date string) *string { return &date }
+
+	severanceSeasonOneResponse = response.SeasonResponse{
+		Number:  1,
+		AirDate: airDate("2022-02-18"),
+		Episodes: []response.EpisodeResponse{
+			{Number: 1, Title: "Good News About Hell", Runtime: 57, AirDate: airDate("2022-02-18")},
+			{Number: 2, Title: "Half Loop", Runtime: 53, AirDate: airDate("2022-02-18")},
+			{Number: 3, Title: "In Perpetuity", Runtime: 52, AirDate: airDate("2022-02-25")},
+		},
+	}
+
+	severanceSeasonTwoResponse = response.SeasonResponse{
+		Number:  2,
+		AirDate: airDate("2025-01-17"),
+		Episodes: []response.EpisodeResponse{
+			{Number: 1, Title: "Hello, Ms. Cobel", Runtime: 50, AirDate: airDate("2025-01-17")},
+		},
+	}
+
+	severanceResponse = response.SeriesResponse{
+		Id:           1,
+		Title:        "Severance",
+		StartYear:    2022,
+		Genres:       []string{"Drama", "Thriller"},
+		Synopsis:     "Office workers have their memories surgically divided between their work and personal lives.",
+		SeasonCount:  2,
+		EpisodeCount: 4,
+		Version:      1,
+		Created:      mock.MockDate,
+		Updated:      mock.MockDate,
+	}
+
+	arcaneEndYear = 2024
+
+	arcaneResponse = response.SeriesResponse{
+		Id:           2,
+		Title:        "Arcane",
+		StartYear:    2021,
+		EndYear:      &arcaneEndYear,
+		Genres:       []string{"Action", "Adventure", "Animation"},
+		Synopsis:     "Two sisters fight on opposite sides of a war between the cities of Piltover and Zaun.",
+		SeasonCount:  1,
+		EpisodeCount: 1,
+		Version:      1,
+		Created:      mock.MockDate,
+		Updated:      mock.MockDate,
+	}
+)
+
+// withSeasons returns a copy of the series response with the seasons.
+func withSeasons(series response.SeriesResponse, seasons ...response.SeasonResponse) response.SeriesResponse {
+	series.Seasons = seasons
+	return series
+}
+
+var listSeriesTestCases = map[string]struct {
+	requestUrl string
+	wantCode   int
+	wantBody   response.BaseResponse
+}{
+	"all series": {
+		requestUrl: "/v1/series/",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Data:     []response.SeriesResponse{severanceResponse, arcaneResponse},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 2},
+		},
+	},
+
+	"filtered by genre": {
+		requestUrl: "/v1/series/?genres=animation",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Data:     []response.SeriesResponse{arcaneResponse},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 1},
+		},
+	},
+
+	"sorted by start year": {
+		requestUrl: "/v1/series/?sort=start_year",
+		wantCode:   200,
+		wantBody: response.BaseResponse{
+			Success:  true,
+			Status:   200,
+			Data:     []response.SeriesResponse{arcaneResponse, severanceResponse},
+			Metadata: &response.Metadata{CurrentPage: 1, PageLimit: 20, LastPage: 1, TotalRecords: 2},
+		},
+	},
+
+	"invalid sort": {
+		requestUrl: "/v1/series/?sort=runtime",
+		wantCode:   422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "filter",
+			Data: map[string]string{
+				"sort": "invalid sort value",
+			},
+		}),
+	},
+}
+
+var getSeriesTestCases = map[string]struct {
+	requestId string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"with seasons and episodes": {
+		requestId: "1",
+		wantCode:  200,
+		wantBody:  response.SuccessResponse(200, withSeasons(severanceResponse, severanceSeasonOneResponse, severanceSeasonTwoResponse)),
+	},
+
+	"non-existent series": {
+		requestId: "99",
+		wantCode:  404,
+		wantBody:  notFoundResponse,
+	},
+}
+
+var createSeriesTestCases = map[string]struct {
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+	wantHeaders http.Header
+}{
+	"valid series with genre variants": {
+		requestBody: `{"title": " The Bear ", "start_year": 2022, "genres": ["comedy", "DRAMA"]}`,
+		wantCode:    201,
+		wantBody: response.SuccessResponse(201, response.SeriesResponse{
+			Id:        3,
+			Title:     "The Bear",
+			StartYear: 2022,
+			Genres:    []string{"Comedy", "Drama"},
+			Version:   1,
+			Created:   mock.MockDate,
+			Updated:   mock.MockDate,
+		}),
+		wantHeaders: map[string][]string{
+			"Location": {"/v1/series/3"},
+		},
+	},
+
+	"missing fields": {
+		requestBody: `{}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "series",
+			Data: map[string]string{
+				"title":      "must be provided",
+				"start_year": "must be provided",
+				"genres":     "must be provided",
+			},
+		}),
+	},
+
+	"invalid fields": {
+		requestBody: `{"title": " ", "start_year": 1900, "end_year": 1899, "genres": ["Sitcom"]}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "series",
+			Data: map[string]string{
+				"title":      "must not be blank",
+				"start_year": "must not be before 1928",
+				"end_year":   "must not be before the start year",
+				"genres":     `unknown genre "Sitcom"`,
+			},
+		}),
+	},
+}
+
+var updateSeriesTestCases = map[string]struct {
+	requestId   string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+}{
+	"end year": {
+		requestId:   "1",
+		requestBody: `{"end_year": 2025}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, func() response.SeriesResponse {
+			series := withSeasons(severanceResponse, severanceSeasonOneResponse, severanceSeasonTwoResponse)
+			endYear := 2025
+			series.EndYear = &endYear
+			series.Version = 2
+			return series
+		}()),
+	},
+
+	"end year before existing start year": {
+		requestId:   "2",
+		requestBody: `{"end_year": 2020}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "series",
+			Data: map[string]string{
+				"end_year": "must not be before the start year",
+			},
+		}),
+	},
+
+	"non-existent series": {
+		requestId:   "99",
+		requestBody: `{"title": "Andor"}`,
+		wantCode:    404,
+		wantBody:    notFoundResponse,
+	},
+}
+
+var deleteSeriesTestCases = map[string]struct {
+	requestId string
+	wantCode  int
+	wantBody  response.BaseResponse
+}{
+	"existing series": {
+		requestId: "2",
+		wantCode:  200,
+		wantBody: response.SuccessResponse(200, map[string]string{
+			"message": "series deleted successfully",
+		}),
+	},
+
+	"non-existent series": {
+		requestId: "99",
+		wantCode:  404,
+		wantBody:  notFoundResponse,
+	},
+}
+
+var putSeasonTestCases = map[string]struct {
+	requestUrl  string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+	wantHeaders http.Header
+}{
+	"new season": {
+		requestUrl:  "/v1/series/1/seasons/3",
+		requestBody: `{"air_date": "2026-01-16"}`,
+		wantCode:    201,
+		wantBody: response.SuccessResponse(201, response.SeasonResponse{
+			Number:   3,
+			AirDate:  airDate("2026-01-16"),
+			Episodes: []response.EpisodeResponse{},
+		}),
+		wantHeaders: map[string][]string{
+			"Location": {"/v1/series/1/seasons/3"},
+		},
+	},
+
+	"existing season keeps its episodes": {
+		requestUrl:  "/v1/series/1/seasons/1",
+		requestBody: `{"title": " Season One ", "air_date": "2022-02-18"}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, func() response.SeasonResponse {
+			season := severanceSeasonOneResponse
+			season.Title = "Season One"
+			return season
+		}()),
+	},
+
+	"air date before earlier season": {
+		requestUrl:  "/v1/series/1/seasons/3",
+		requestBody: `{"air_date": "2024-01-01"}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "season",
+			Data: map[string]string{
+				"air_date": "must not be before the air date of an earlier season or after that of a later one",
+			},
+		}),
+	},
+
+	"invalid air date": {
+		requestUrl:  "/v1/series/1/seasons/3",
+		requestBody: `{"air_date": "16/01/2026"}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "season",
+			Data: map[string]string{
+				"air_date": "must be a date in the format YYYY-MM-DD",
+			},
+		}),
+	},
+
+	"non-existent series": {
+		requestUrl:  "/v1/series/99/seasons/1",
+		requestBody: `{}`,
+		wantCode:    404,
+		wantBody:    notFoundResponse,
+	},
+
+	"non-positive number": {
+		requestUrl:  "/v1/series/1/seasons/0",
+		requestBody: `{}`,
+		wantCode:    404,
+		wantBody:    notFoundResponse,
+	},
+}
+
+var deleteSeasonTestCases = map[string]struct {
+	requestUrl string
+	wantCode   int
+	wantBody   response.BaseResponse
+}{
+	"existing season": {
+		requestUrl: "/v1/series/1/seasons/2",
+		wantCode:   200,
+		wantBody: response.SuccessResponse(200, map[string]string{
+			"message": "season deleted successfully",
+		}),
+	},
+
+	"non-existent season": {
+		requestUrl: "/v1/series/1/seasons/5",
+		wantCode:   404,
+		wantBody:   notFoundResponse,
+	},
+}
+
+var putEpisodeTestCases = map[string]struct {
+	requestUrl  string
+	requestBody string
+	wantCode    int
+	wantBody    response.BaseResponse
+	wantHeaders http.Header
+}{
+	"new episode": {
+		requestUrl:  "/v1/series/1/seasons/2/episodes/2",
+		requestBody: `{"title": "Goodbye, Mrs. Selvig", "runtime": 49, "air_date": "2025-01-24"}`,
+		wantCode:    201,
+		wantBody: response.SuccessResponse(201, response.EpisodeResponse{
+			Number:  2,
+			Title:   "Goodbye, Mrs. Selvig",
+			Runtime: 49,
+			AirDate: airDate("2025-01-24"),
+		}),
+		wantHeaders: map[string][]string{
+			"Location": {"/v1/series/1/seasons/2/episodes/2"},
+		},
+	},
+
+	"existing episode": {
+		requestUrl:  "/v1/series/1/seasons/1/episodes/3",
+		requestBody: `{"title": "In Perpetuity", "runtime": 52, "synopsis": " Mark visits the company museum. "}`,
+		wantCode:    200,
+		wantBody: response.SuccessResponse(200, response.EpisodeResponse{
+			Number:   3,
+			Title:    "In Perpetuity",
+			Runtime:  52,
+			Synopsis: "Mark visits the company museum.",
+		}),
+	},
+
+	"air date after later episode": {
+		requestUrl:  "/v1/series/1/seasons/1/episodes/2",
+		requestBody: `{"title": "Half Loop", "runtime": 53, "air_date": "2022-03-04"}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "episode",
+			Data: map[string]string{
+				"air_date": "must not be before the air date of an earlier episode or after that of a later one",
+			},
+		}),
+	},
+
+	"missing fields": {
+		requestUrl:  "/v1/series/1/seasons/1/episodes/4",
+		requestBody: `{"runtime": 0}`,
+		wantCode:    422,
+		wantBody: response.ErrorResponse(422, response.Error{
+			Type: "episode",
+			Data: map[string]string{
+				"title":   "must be provided",
+				"runtime": "must be a positive integer",
+			},
+		}),
+	},
+
+	"non-existent season": {
+		requestUrl:  "/v1/series/1/seasons/9/episodes/1",
+		requestBody: `{"title": "Pilot", "runtime": 45}`,
+		wantCode:    404,
+		wantBody:    notFoundResponse,
+	},
+}
+
+var deleteEpisodeTestCases = map[string]struct {
+	requestUrl string
+	wantCode   int
+	wantBody   response.BaseResponse
+}{
+	"existing episode": {
+		requestUrl: "/v1/series/1/seasons/1/episodes/2",
+		wantCode:   200,
+		wantBody: response.SuccessResponse(200, map[string]string{
+			"message": "episode deleted successfully",
+		}),
+	},
+
+	"non-existent episode": {
+		requestUrl: "/v1/series/1/seasons/1/episodes/9",
+		wantCode:   404,
+		wantBody:   notFoundResponse,
+	},
+}
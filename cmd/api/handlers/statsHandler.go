@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/cache"
+	"net/http"
+	"time"
+)
+
+// statsRecentMovies is the number of most recently added movies in the movie statistics.
+const statsRecentMovies = 5
+
+type statsHandler struct {
+	repositories repository.Repositories
+	movieStats   *cache.Value[models.MovieStats]
+}
+
+// NewStatsHandler returns a StatsHandler which caches the statistics for the duration set in the config,
+// so frequent requests share the same aggregates instead of each querying the repositories.
+func NewStatsHandler(config common.Config, repositories repository.Repositories) common.StatsHandler {
+	handler := &statsHandler{
+		repositories: repositories,
+	}
+	handler.movieStats = cache.NewValue(config.Stats.CacheTtl, handler.loadMovieStats)
+	return handler
+}
+
+// Movies returns the statistics of the movies outside the trash, which may be as old as the cache duration.
+func (s statsHandler) Movies(ctx *gin.Context) {
+	stats, err := s.movieStats.Get()
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			stats.ToResponse(),
+		),
+	)
+}
+
+// loadMovieStats computes the movie statistics from the aggregates of the movie repository.
+func (s statsHandler) loadMovieStats() (models.MovieStats, error) {
+	var err error
+	stats := models.MovieStats{Generated: time.Now()}
+
+	stats.Genres, err = s.repositories.Movies.CountByGenre()
+	if err != nil {
+		return models.MovieStats{}, err
+	}
+
+	stats.Years, err = s.repositories.Movies.CountByYear()
+	if err != nil {
+		return models.MovieStats{}, err
+	}
+
+	stats.Runtime, err = s.repositories.Movies.RuntimeStats()
+	if err != nil {
+		return models.MovieStats{}, err
+	}
+
+	stats.Recent, err = s.repositories.Movies.ListRecent(statsRecentMovies)
+	if err != nil {
+		return models.MovieStats{}, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatsHandler_Movies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+
+	getStats := func() (int, string) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/stats/movies", nil)
+		setBearerToken(req)
+
+		app.Router(testRouteHandlers).ServeHTTP(rr, req)
+		code, body, _ := parseResponse(t, rr.Result())
+		return code, body
+	}
+
+	code, body := getStats()
+	testhelpers.AssertEqual(t, code, http.StatusOK)
+
+	// the generation time is only known from the response
+	var got struct {
+		Data response.MovieStatsResponse `json:"data"`
+	}
+	err := json.Unmarshal([]byte(body), &got)
+	testhelpers.AssertFatalError(t, err)
+
+	// assert response body, with the trashed movie left out
+	wantBody, _ := json.Marshal(response.SuccessResponse(200, response.MovieStatsResponse{
+		Total: 2,
+		Genres: []response.GenreCountResponse{
+			{Genre: "Action", Count: 1},
+			{Genre: "Comedy", Count: 1},
+			{Genre: "Drama", Count: 1},
+			{Genre: "Musical", Count: 1},
+		},
+		Years: []response.YearCountResponse{
+			{Year: 2020, Count: 1},
+			{Year: 2022, Count: 1},
+		},
+		Decades: []response.DecadeCountResponse{
+			{Decade: 2020, Count: 2},
+		},
+		Runtime: response.RuntimeStatsResponse{
+			Total:  248,
+			Mean:   124,
+			Min:    108,
+			P25:    108,
+			Median: 108,
+			P75:    140,
+			P90:    140,
+			Max:    140,
+		},
+		Recent: []response.MovieResponse{
+			{
+				Id:               2,
+				Title:            "Hamilton",
+				Year:             2020,
+				Runtime:          140,
+				Genres:           []string{"Musical", "Drama"},
+				OriginalTitle:    "Hamilton",
+				OriginalLanguage: "en",
+				Countries:        []string{"US"},
+				ReleaseDates:     []response.ReleaseDateResponse{{Country: "US", Date: "2020-07-03"}},
+				Certifications:   []response.CertificationResponse{{Country: "US", Rating: "PG-13"}},
+				Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+				Tagline:          "An American musical.",
+				ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+				Version:          1,
+			},
+			{
+				Id:      1,
+				Title:   "Bullet Train",
+				Year:    2022,
+				Runtime: 108,
+				Genres:  []string{"Action", "Comedy"},
+				Version: 1,
+			},
+		},
+		Generated: got.Data.Generated,
+	}))
+	testhelpers.AssertEqual(t, body, string(wantBody))
+
+	// assert the cached statistics are returned within the cache duration
+	code, cachedBody := getStats()
+	testhelpers.AssertEqual(t, code, http.StatusOK)
+	testhelpers.AssertEqual(t, cachedBody, body)
+}
@@ -1,18 +1,29 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"github.com/rhodeon/moviescreen/cmd/api/common"
 	"github.com/rhodeon/moviescreen/cmd/api/internal"
+	"github.com/rhodeon/moviescreen/cmd/api/jobs"
 	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
 	"github.com/rhodeon/moviescreen/domain/repository"
 	"github.com/rhodeon/moviescreen/infrastructure/mock"
+	"github.com/rhodeon/moviescreen/internal/mailer"
 	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"reflect"
-	"sync"
+	"strings"
 	"testing"
+	"time"
 )
 
 const mockRequestToken = "2QRJK3S54HAIUNIHNXEF4WSZSI"
@@ -23,29 +34,90 @@ func newTestApp(t *testing.T) internal.Application {
 	return internal.Application{
 		Config:       testConfig,
 		Repositories: testRepos,
+		Mailer:       testMailer,
+		Unsubscriber: testUnsubscriber,
 	}
 }
 
-var testConfig = common.Config{
-	Env:     "testing",
-	Version: "v1.0.0",
-	Port:    4000,
-}
+var testConfig = func() common.Config {
+	config := common.Config{
+		Env:     "testing",
+		Version: "v1.0.0",
+		Port:    4000,
+	}
+	config.Import.MaxBytes = 1024
+	config.Import.BatchSize = 2
+	config.Images.MaxBytes = 64 * 1024
+	config.Stats.CacheTtl = time.Minute
+	config.Mail.WebhookSecret = testWebhookSecret
+	return config
+}()
 
 var testRepos = repository.Repositories{
-	Tokens:      mock.NewTokenController(),
-	Movies:      mock.NewMovieController(),
-	Users:       mock.NewUserController(),
-	Permissions: mock.NewPermissionController(),
+	Tokens:                  mock.NewTokenController(),
+	Movies:                  mock.NewMovieController(),
+	MovieRevisions:          mock.NewMovieRevisionController(),
+	MovieTranslations:       mock.NewMovieTranslationController(),
+	MovieSimilarities:       mock.NewMovieSimilarityController(),
+	Genres:                  mock.NewGenreController(),
+	Collections:             mock.NewCollectionController(),
+	Series:                  mock.NewSeriesController(),
+	Search:                  mock.NewSearchController(),
+	Users:                   mock.NewUserController(),
+	Permissions:             mock.NewPermissionController(),
+	NotificationPreferences: mock.NewNotificationPreferenceController(),
+	Outbox:                  testOutbox,
+	Suppressions:            mock.NewSuppressionController(),
+	Transactor:              testTransactor,
+}
+
+// testTransactor runs the units of work of the handlers on the test repositories,
+// which are set on initialisation as the repositories hold the transactor.
+var testTransactor = mock.NewTransactor(nil)
+
+func init() {
+	testTransactor.Repositories = &testRepos
 }
 
-var testWaitGroup = sync.WaitGroup{}
+// testWebhookSecret authenticates the requests to the mail webhook.
+const testWebhookSecret = "test-webhook-secret-0123456789abcdef"
+
+// testOutbox holds the emails queued by the handlers until they're sent to the test transport.
+var testOutbox = mock.NewOutboxController()
+
+// testTransport captures the emails sent through the test mailer.
+var testTransport = mailer.NewMemoryTransport()
+
+// testUnsubscriber signs the unsubscribe links of the emails sent through the test mailer.
+var testUnsubscriber = mailer.NewUnsubscriber("test-unsubscribe-secret-0123456789", "http://localhost:4000/v1/users/unsubscribe")
+
+var testMailer = func() *mailer.Mailer {
+	mail, err := mailer.New(testTransport, "Moviescreen <no-reply@moviescreen.net>", mailer.Branding{
+		Name:  "Moviescreen",
+		Color: "#e11d48",
+	}, testUnsubscriber)
+	if err != nil {
+		panic(err)
+	}
+
+	// expiry times are shown relative to the creation time of the mock tokens
+	mail.SetClock(func() time.Time { return mock.AuthenticationBaseDate })
+	return mail
+}()
+
+var testStorage = mock.NewBlobStorage()
 
 var testRouteHandlers = common.RouteHandlers{
-	Error:  responseErrors.NewErrorHandler(),
-	Misc:   NewMiscHandler(testConfig),
-	Movies: NewMovieHandler(testConfig, testRepos),
-	Users:  NewUserHandler(testConfig, testRepos, &testWaitGroup),
+	Error:       responseErrors.NewErrorHandler(),
+	Misc:        NewMiscHandler(testConfig),
+	Movies:      NewMovieHandler(testConfig, testRepos, testStorage),
+	Genres:      NewGenreHandler(testRepos),
+	Collections: NewCollectionHandler(testRepos),
+	Series:      NewSeriesHandler(testRepos),
+	Search:      NewSearchHandler(testRepos),
+	Stats:       NewStatsHandler(testConfig, testRepos),
+	Emails:      NewEmailHandler(testConfig, testRepos, testMailer),
+	Users:       NewUserHandler(testConfig, testRepos, testUnsubscriber),
 }
 
 // parseResponse parses a http response and returns the code, body and header.
@@ -58,6 +130,41 @@ func parseResponse(t *testing.T, result *http.Response) (int, string, http.Heade
 	return result.StatusCode, string(body), result.Header
 }
 
+// wantEmail holds the recipient, subject and part of the plain text body
+// of an email expected to be sent as a result of a request,
+// and its unsubscribe link which is only set for non-transactional emails.
+type wantEmail struct {
+	To                string
+	Subject           string
+	PlainBodyContains string
+	UnsubscribeUrl    string
+}
+
+// assertSentEmail sends the emails queued in the test outbox
+// and asserts that a single email matching the expected one was captured after the given number of sent emails,
+// or none if no email is expected.
+func assertSentEmail(t *testing.T, sentBefore int, want *wantEmail) {
+	t.Helper()
+
+	err := jobs.SendOutbox(testRepos, testMailer, 10, 1)()
+	testhelpers.AssertFatalError(t, err)
+
+	if want == nil {
+		testhelpers.AssertEqual(t, len(testTransport.Messages()), sentBefore)
+		return
+	}
+
+	testhelpers.AssertEqual(t, len(testTransport.Messages()), sentBefore+1)
+	if msg, sent := testTransport.Last(); sent {
+		testhelpers.AssertEqual(t, msg.To, want.To)
+		testhelpers.AssertEqual(t, msg.Subject, want.Subject)
+		testhelpers.AssertEqual(t, msg.UnsubscribeUrl, want.UnsubscribeUrl)
+		if !strings.Contains(msg.PlainBody, want.PlainBodyContains) {
+			t.Errorf("\nGot:\t%q\nWant to contain:\t%q", msg.PlainBody, want.PlainBodyContains)
+		}
+	}
+}
+
 func assertHeaders(t *testing.T, gotHeaders http.Header, wantHeaders http.Header) {
 	t.Helper()
 
@@ -75,3 +182,55 @@ func assertHeaders(t *testing.T, gotHeaders http.Header, wantHeaders http.Header
 func setBearerToken(req *http.Request) {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", mockRequestToken))
 }
+
+// newTestImage returns a gradient image with the given dimensions.
+func newTestImage(width int, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+// encodeTestPng returns a PNG encoding of a test image with the given dimensions.
+func encodeTestPng(width int, height int) []byte {
+	buf := &bytes.Buffer{}
+	_ = png.Encode(buf, newTestImage(width, height))
+	return buf.Bytes()
+}
+
+// encodeTestJpeg returns a JPEG encoding of a test image with the given dimensions.
+func encodeTestJpeg(width int, height int) []byte {
+	buf := &bytes.Buffer{}
+	_ = jpeg.Encode(buf, newTestImage(width, height), nil)
+	return buf.Bytes()
+}
+
+// encodeTestPngHeader returns the signature and header chunk of a PNG with the given dimensions,
+// which is enough to read its dimensions without any pixel data.
+func encodeTestPngHeader(width int, height int) []byte {
+	chunk := make([]byte, 17)
+	copy(chunk, "IHDR")
+	binary.BigEndian.PutUint32(chunk[4:], uint32(width))
+	binary.BigEndian.PutUint32(chunk[8:], uint32(height))
+	chunk[12] = 8 // bit depth
+	chunk[13] = 2 // truecolour
+
+	buf := bytes.NewBufferString("\x89PNG\r\n\x1a\n")
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(chunk)-4))
+	buf.Write(chunk)
+	_ = binary.Write(buf, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+	return buf.Bytes()
+}
+
+// newMultipartBody returns a multipart form body with the file in the given field, and its content type.
+func newMultipartBody(field string, file []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile(field, "upload")
+	_, _ = part.Write(file)
+	_ = writer.Close()
+	return body, writer.FormDataContentType()
+}
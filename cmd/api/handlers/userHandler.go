@@ -11,23 +11,29 @@ import (
 	"github.com/rhodeon/moviescreen/domain/repository"
 	"github.com/rhodeon/moviescreen/internal/mailer"
 	"github.com/rhodeon/moviescreen/internal/validator"
-	"github.com/rhodeon/prettylog"
 	"net/http"
-	"sync"
 	"time"
 )
 
+// Lifetimes of the tokens sent to users by email, which the sample data of email previews expire after as well.
+const (
+	registrationTokenLifetime  = 2 * 24 * time.Hour
+	activationTokenLifetime    = 15 * time.Minute
+	passwordResetTokenLifetime = 15 * time.Minute
+)
+
 type userHandler struct {
 	config       common.Config
 	repositories repository.Repositories
-	backgroundWg *sync.WaitGroup
+	unsubscriber *mailer.Unsubscriber
 }
 
-func NewUserHandler(config common.Config, repositories repository.Repositories, waitGroup *sync.WaitGroup) common.UserHandler {
+// NewUserHandler returns a UserHandler which verifies the tokens of unsubscribe links with the unsubscriber.
+func NewUserHandler(config common.Config, repositories repository.Repositories, unsubscriber *mailer.Unsubscriber) common.UserHandler {
 	return &userHandler{
 		config:       config,
 		repositories: repositories,
-		backgroundWg: waitGroup,
+		unsubscriber: unsubscriber,
 	}
 }
 
@@ -56,8 +62,32 @@ func (u userHandler) Register(ctx *gin.Context) {
 		return
 	}
 
-	// attempt to register user
-	err = u.repositories.Users.Register(&user)
+	// register the user with the "movies:read" permission and queue the welcome email in the outbox
+	// as a single unit of work, so the user isn't left without either and the email is only sent for registered users.
+	// The email is sent with an activation token with a lifetime of 2 days, which is created when it's sent
+	err = u.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		err := repositories.Users.Register(&user)
+		if err != nil {
+			return err
+		}
+
+		err = repositories.Permissions.AddForUser(user, models.PermissionMoviesRead)
+		if err != nil {
+			return err
+		}
+
+		return enqueueEmail(repositories, &models.OutboxEmail{
+			Recipient: user.Email,
+			Template:  mailer.TemplateUserWelcome,
+			Language:  user.Language,
+			Data: map[string]any{
+				"Username": user.Username,
+			},
+			UserId:        user.Id,
+			TokenScope:    models.ScopeActivation,
+			TokenLifetime: registrationTokenLifetime,
+		})
+	})
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrDuplicateUsername):
@@ -83,38 +113,6 @@ func (u userHandler) Register(ctx *gin.Context) {
 		return
 	}
 
-	// add "movies:read" permission for the new user
-	err = u.repositories.Permissions.AddForUser(user, models.PermissionMoviesRead)
-	if err != nil {
-		responseErrors.HandleInternalServerError(ctx, err)
-		return
-	}
-
-	// generate activation token with a lifetime of 2 days
-	token, err := u.repositories.Tokens.New(user.Id, models.ScopeActivation, 2*24*time.Hour)
-	if err != nil {
-		responseErrors.HandleInternalServerError(ctx, err)
-	}
-
-	// send welcome email to user in the background
-	common.Background(u.backgroundWg, func() {
-		smtp := u.config.Smtp
-		mail := mailer.New(smtp.Host, smtp.Port, smtp.User, smtp.Password, smtp.Sender)
-
-		err = mail.Send(user.Email, "user_welcome.gotmpl", struct {
-			Username        string
-			ActivationToken string
-		}{
-			Username:        user.Username,
-			ActivationToken: token.PlainText,
-		})
-
-		if err != nil {
-			prettylog.ErrorF("welcome mail: %v", err)
-			return
-		}
-	})
-
 	// return new user details
 	ctx.JSON(
 		http.StatusCreated,
@@ -161,8 +159,15 @@ func (u userHandler) Activate(ctx *gin.Context) {
 	// activate user
 	user.Activated = true
 
-	// save user activated status
-	err = u.repositories.Users.Update(&user)
+	// save user activated status and delete the used token together,
+	// so the token remains usable if the activation isn't saved
+	err = u.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		err := repositories.Users.Update(&user)
+		if err != nil {
+			return err
+		}
+		return repositories.Tokens.DeleteAllForUser(user.Id, models.ScopeActivation)
+	})
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrEditConflict):
@@ -175,13 +180,6 @@ func (u userHandler) Activate(ctx *gin.Context) {
 		return
 	}
 
-	// delete used token
-	err = u.repositories.Tokens.DeleteAllForUser(user.Id, models.ScopeActivation)
-	if err != nil {
-		responseErrors.HandleInternalServerError(ctx, err)
-		return
-	}
-
 	// return updated user response
 	ctx.JSON(
 		http.StatusOK,
@@ -299,32 +297,24 @@ func (u userHandler) CreateActivationToken(ctx *gin.Context) {
 		return
 	}
 
-	// generate new authentication token with a lifetime of 15 minutes
-	token, err := u.repositories.Tokens.New(user.Id, models.ScopeActivation, 15*time.Minute)
+	// queue the email containing a new activation token with a lifetime of 15 minutes,
+	// which is generated when the email is sent
+	err = enqueueEmail(u.repositories, &models.OutboxEmail{
+		Recipient: user.Email,
+		Template:  mailer.TemplateActivationToken,
+		Language:  user.Language,
+		Data: map[string]any{
+			"Username": user.Username,
+		},
+		UserId:        user.Id,
+		TokenScope:    models.ScopeActivation,
+		TokenLifetime: activationTokenLifetime,
+	})
 	if err != nil {
 		responseErrors.HandleInternalServerError(ctx, err)
 		return
 	}
 
-	// send email with password reset token
-	common.Background(u.backgroundWg, func() {
-		smtp := u.config.Smtp
-		mail := mailer.New(smtp.Host, smtp.Port, smtp.User, smtp.Password, smtp.Sender)
-
-		err = mail.Send(user.Email, "activation_token.gotmpl", struct {
-			Username        string
-			ActivationToken string
-		}{
-			Username:        user.Username,
-			ActivationToken: token.PlainText,
-		})
-
-		if err != nil {
-			prettylog.ErrorF("activation token mail: %v", err)
-			return
-		}
-	})
-
 	ctx.JSON(
 		http.StatusAccepted,
 		response.SuccessResponse(
@@ -371,31 +361,24 @@ func (u userHandler) CreatePasswordResetToken(ctx *gin.Context) {
 		return
 	}
 
-	token, err := u.repositories.Tokens.New(user.Id, models.ScopePasswordReset, 15*time.Minute)
+	// queue the email containing a new password reset token with a lifetime of 15 minutes,
+	// which is generated when the email is sent
+	err = enqueueEmail(u.repositories, &models.OutboxEmail{
+		Recipient: user.Email,
+		Template:  mailer.TemplateResetPassword,
+		Language:  user.Language,
+		Data: map[string]any{
+			"Username": user.Username,
+		},
+		UserId:        user.Id,
+		TokenScope:    models.ScopePasswordReset,
+		TokenLifetime: passwordResetTokenLifetime,
+	})
 	if err != nil {
 		responseErrors.HandleInternalServerError(ctx, err)
 		return
 	}
 
-	// send email with password reset token
-	common.Background(u.backgroundWg, func() {
-		smtp := u.config.Smtp
-		mail := mailer.New(smtp.Host, smtp.Port, smtp.User, smtp.Password, smtp.Sender)
-
-		err = mail.Send(user.Email, "reset_password.gotmpl", struct {
-			Username           string
-			PasswordResetToken string
-		}{
-			Username:           user.Username,
-			PasswordResetToken: token.PlainText,
-		})
-
-		if err != nil {
-			prettylog.ErrorF("password reset mail: %v", err)
-			return
-		}
-	})
-
 	ctx.JSON(
 		http.StatusAccepted,
 		response.SuccessResponse(
@@ -443,8 +426,30 @@ func (u userHandler) UpdatePassword(ctx *gin.Context) {
 		return
 	}
 
-	// save user with updated password
-	err = u.repositories.Users.Update(&user)
+	// save user with updated password and delete the used reset token together,
+	// so the token can't be reused once the password is changed,
+	// and alert the user of the change unless they've opted out of security alerts
+	err = u.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		err := repositories.Users.Update(&user)
+		if err != nil {
+			return err
+		}
+		err = repositories.Tokens.DeleteAllForUser(user.Id, models.ScopePasswordReset)
+		if err != nil {
+			return err
+		}
+
+		return enqueueEmail(repositories, &models.OutboxEmail{
+			Recipient: user.Email,
+			Template:  mailer.TemplatePasswordChanged,
+			Language:  user.Language,
+			Data: map[string]any{
+				"Username": user.Username,
+			},
+			UserId:   user.Id,
+			Category: models.NotificationSecurityAlerts,
+		})
+	})
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrEditConflict):
@@ -457,9 +462,41 @@ func (u userHandler) UpdatePassword(ctx *gin.Context) {
 		return
 	}
 
-	// delete used reset token
-	err = u.repositories.Tokens.DeleteAllForUser(user.Id, models.ScopePasswordReset)
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			map[string]string{"message": "your password was successfully reset"},
+		),
+	)
+}
+
+// GetById returns the user with the id for administrators,
+// along with the suppression of their email address if emails are no longer sent to it.
+func (u userHandler) GetById(ctx *gin.Context) {
+	id, err := parseIdParam(ctx)
+	if err != nil {
+		return
+	}
+
+	user, err := u.repositories.Users.Get(id)
 	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			responseErrors.NewErrorHandler().NotFound(ctx)
+		} else {
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+		return
+	}
+
+	userResponse := response.AdminUserResponse{UserResponse: user.ToResponse()}
+	suppression, err := u.repositories.Suppressions.Get(user.Email)
+	switch {
+	case err == nil:
+		suppressionResponse := suppression.ToResponse()
+		userResponse.Suppression = &suppressionResponse
+
+	case !errors.Is(err, repository.ErrRecordNotFound):
 		responseErrors.HandleInternalServerError(ctx, err)
 		return
 	}
@@ -468,7 +505,24 @@ func (u userHandler) UpdatePassword(ctx *gin.Context) {
 		http.StatusOK,
 		response.SuccessResponse(
 			http.StatusOK,
-			map[string]string{"message": "your password was successfully reset"},
+			userResponse,
 		),
 	)
 }
+
+// enqueueEmail adds the email to the outbox with the repositories, unless its recipient has opted out of its category.
+// Transactional emails, which have no category, are always enqueued.
+// Every email sent to users goes through it, so the preferences of the users apply wherever a category is chosen.
+func enqueueEmail(repositories repository.Repositories, email *models.OutboxEmail) error {
+	if email.Category != "" {
+		preferences, err := repositories.NotificationPreferences.Get(email.UserId)
+		if err != nil {
+			return err
+		}
+		if !preferences.Allows(email.Category) {
+			return nil
+		}
+	}
+
+	return repositories.Outbox.Enqueue(email)
+}
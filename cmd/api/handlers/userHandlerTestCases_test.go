@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
 	"github.com/rhodeon/moviescreen/infrastructure/mock"
+	"strings"
 	"time"
 )
 
@@ -10,6 +13,7 @@ var registerUserTestCases = map[string]struct {
 	RequestBody string
 	WantCode    int
 	WantBody    response.BaseResponse
+	WantEmail   *wantEmail
 }{
 	"valid request": {
 		RequestBody: `{
@@ -24,8 +28,57 @@ var registerUserTestCases = map[string]struct {
 			Email:     "person@mail.com",
 			Version:   1,
 			Activated: false,
+			Language:  "en",
 			Created:   mock.MockDate,
 		}),
+		WantEmail: &wantEmail{
+			To:                "person@mail.com",
+			Subject:           "Welcome to Moviescreen",
+			PlainBodyContains: "it will expire in 2 days",
+		},
+	},
+
+	"valid request (with language)": {
+		RequestBody: `{
+			"username": "personne",
+			"email": "personne@mail.com",
+			"password": "password",
+			"language": "fr"
+		}`,
+		WantCode: 201,
+		WantBody: response.SuccessResponse(201, response.UserResponse{
+			Id:        4,
+			Username:  "personne",
+			Email:     "personne@mail.com",
+			Version:   1,
+			Activated: false,
+			Language:  "fr",
+			Created:   mock.MockDate,
+		}),
+		WantEmail: &wantEmail{
+			To:                "personne@mail.com",
+			Subject:           "Bienvenue sur Moviescreen",
+			PlainBodyContains: "expirera dans 2 jours",
+		},
+	},
+
+	"invalid language": {
+		RequestBody: `{
+			"username": "person",
+			"email": "person@mail.com",
+			"password": "password",
+			"language": "french"
+		}`,
+		WantCode: 422,
+		WantBody: response.ErrorResponse(
+			422,
+			response.Error{
+				Type: "user",
+				Data: map[string]string{
+					"language": "must be an ISO 639-1 language code",
+				},
+			},
+		),
 	},
 
 	"missing username": {
@@ -131,6 +184,7 @@ var activateUserTestCases = map[string]struct {
 			Username:  "rhodeon",
 			Email:     "rhodeon@dev.mail",
 			Activated: true,
+			Language:  "en",
 			Version:   1,
 			Created:   mock.MockDate,
 		}),
@@ -278,6 +332,7 @@ var createActivationTokenTestCases = map[string]struct {
 	RequestBody string
 	WantCode    int
 	WantBody    response.BaseResponse
+	WantEmail   *wantEmail
 }{
 	"valid request": {
 		RequestBody: `{
@@ -289,6 +344,11 @@ var createActivationTokenTestCases = map[string]struct {
 			202,
 			map[string]string{"message": "an email will be sent to you containing activation instructions"},
 		),
+		WantEmail: &wantEmail{
+			To:                "ruona@mail.com",
+			Subject:           "Activate your account",
+			PlainBodyContains: "it will expire in 15 minutes",
+		},
 	},
 
 	"missing email": {
@@ -364,6 +424,7 @@ var createPasswordResetTokenTestCases = map[string]struct {
 	RequestBody string
 	WantCode    int
 	WantBody    response.BaseResponse
+	WantEmail   *wantEmail
 }{
 	"valid email": {
 		RequestBody: `{
@@ -374,6 +435,11 @@ var createPasswordResetTokenTestCases = map[string]struct {
 			202,
 			map[string]string{"message": "an email will be sent to you containing password reset instructions"},
 		),
+		WantEmail: &wantEmail{
+			To:                "rhodeon@dev.mail",
+			Subject:           "Reset your password",
+			PlainBodyContains: "it will expire in 15 minutes",
+		},
 	},
 
 	"missing email": {
@@ -422,6 +488,7 @@ var updatePasswordTestCases = map[string]struct {
 	RequestBody string
 	WantCode    int
 	WantBody    response.BaseResponse
+	WantEmail   *wantEmail
 }{
 	"valid request ": {
 		RequestBody: `{
@@ -433,6 +500,12 @@ var updatePasswordTestCases = map[string]struct {
 			200,
 			map[string]string{"message": "your password was successfully reset"},
 		),
+		WantEmail: &wantEmail{
+			To:                "rhodeon@dev.mail",
+			Subject:           "Your password was changed",
+			PlainBodyContains: "The password of your Moviescreen account was just changed.",
+			UnsubscribeUrl:    testUnsubscriber.Url(1, models.NotificationSecurityAlerts),
+		},
 	},
 
 	"missing password": {
@@ -484,3 +557,165 @@ var updatePasswordTestCases = map[string]struct {
 		),
 	},
 }
+
+var notificationsTestCases = map[string]struct {
+	Authenticated bool
+	WantCode      int
+	WantBody      response.BaseResponse
+}{
+	"default preferences": {
+		Authenticated: true,
+		WantCode:      200,
+		WantBody: response.SuccessResponse(200, response.NotificationPreferencesResponse{
+			SecurityAlerts:    true,
+			ProductUpdates:    false,
+			SavedSearchAlerts: true,
+		}),
+	},
+
+	"unauthenticated user": {
+		Authenticated: false,
+		WantCode:      401,
+		WantBody: response.ErrorResponse(401, response.Error{
+			Type: "generic",
+			Data: map[string]string{
+				"message": responseErrors.ErrMessageUnauthenticatedAccess,
+			},
+		}),
+	},
+}
+
+var updateNotificationsTestCases = map[string]struct {
+	RequestBody string
+	WantCode    int
+	WantBody    response.BaseResponse
+}{
+	"valid request": {
+		RequestBody: `{"security_alerts": true, "product_updates": true, "saved_search_alerts": false}`,
+		WantCode:    200,
+		WantBody: response.SuccessResponse(200, response.NotificationPreferencesResponse{
+			SecurityAlerts:    true,
+			ProductUpdates:    true,
+			SavedSearchAlerts: false,
+		}),
+	},
+
+	"no categories": {
+		RequestBody: `{}`,
+		WantCode:    422,
+		WantBody: response.ErrorResponse(422, response.Error{
+			Type: "notifications",
+			Data: map[string]string{
+				"notifications": "must change at least 1 category",
+			},
+		}),
+	},
+}
+
+var unsubscribeTestCases = map[string]struct {
+	Token           string
+	UserId          int
+	WantCode        int
+	WantBody        response.BaseResponse
+	WantPreferences models.NotificationPreferences
+}{
+	"valid token": {
+		Token:    testUnsubscriber.Token(2, models.NotificationSavedSearchAlerts),
+		UserId:   2,
+		WantCode: 200,
+		WantBody: response.SuccessResponse(200, map[string]string{"message": "you have been unsubscribed successfully"}),
+		WantPreferences: models.NotificationPreferences{
+			SecurityAlerts:    true,
+			ProductUpdates:    false,
+			SavedSearchAlerts: false,
+		},
+	},
+
+	"tampered token": {
+		Token:           strings.Replace(testUnsubscriber.Token(2, models.NotificationSecurityAlerts), "2.", "3.", 1),
+		UserId:          3,
+		WantCode:        422,
+		WantBody:        invalidUnsubscribeTokenResponse,
+		WantPreferences: models.NotificationPreferences{},
+	},
+
+	"unknown category": {
+		Token:           testUnsubscriber.Token(3, "newsletters"),
+		UserId:          3,
+		WantCode:        422,
+		WantBody:        invalidUnsubscribeTokenResponse,
+		WantPreferences: models.NotificationPreferences{},
+	},
+
+	"non-existent user": {
+		Token:           testUnsubscriber.Token(99, models.NotificationProductUpdates),
+		UserId:          99,
+		WantCode:        422,
+		WantBody:        invalidUnsubscribeTokenResponse,
+		WantPreferences: models.DefaultNotificationPreferences,
+	},
+
+	"missing token": {
+		WantCode:        422,
+		WantBody:        invalidUnsubscribeTokenResponse,
+		WantPreferences: models.DefaultNotificationPreferences,
+	},
+}
+
+var invalidUnsubscribeTokenResponse = response.ErrorResponse(422, response.Error{
+	Type: "unsubscribe",
+	Data: map[string]string{
+		"token": "invalid unsubscribe token",
+	},
+})
+
+var getUserTestCases = map[string]struct {
+	Id       string
+	WantCode int
+	WantBody response.BaseResponse
+}{
+	"user without suppression": {
+		Id:       "2",
+		WantCode: 200,
+		WantBody: response.SuccessResponse(200, response.AdminUserResponse{
+			UserResponse: response.UserResponse{
+				Id:       2,
+				Username: "ruona",
+				Email:    "ruona@mail.com",
+				Language: "en",
+				Created:  mock.MockDate,
+			},
+			Suppression: nil,
+		}),
+	},
+
+	"suppressed user": {
+		Id:       "3",
+		WantCode: 200,
+		WantBody: response.SuccessResponse(200, response.AdminUserResponse{
+			UserResponse: response.UserResponse{
+				Id:       3,
+				Username: "johndoe",
+				Email:    "johndoe@mail.com",
+				Language: "en",
+				Created:  mock.MockDate,
+			},
+			Suppression: &response.EmailSuppressionResponse{
+				Reason:  models.SuppressionReasonBounce,
+				Detail:  "550 5.1.1 mailbox unavailable",
+				Created: mock.MockDate,
+			},
+		}),
+	},
+
+	"non-existent user": {
+		Id:       "99",
+		WantCode: 404,
+		WantBody: response.ErrorResponse(404, response.Error{
+			Type: "generic",
+			Data: map[string]string{
+				"message": responseErrors.ErrMessageNotFound,
+			},
+		}),
+	},
+}
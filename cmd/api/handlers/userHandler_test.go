@@ -6,6 +6,7 @@ import (
 	"github.com/rhodeon/moviescreen/internal/testhelpers"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 )
@@ -18,6 +19,7 @@ func TestUserHandler_Register(t *testing.T) {
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			rr := httptest.NewRecorder()
+			sent := len(testTransport.Messages())
 			req := httptest.NewRequest(http.MethodPost, "/v1/users/", strings.NewReader(tc.RequestBody))
 			app.Router(testRouteHandlers).ServeHTTP(rr, req)
 
@@ -29,6 +31,9 @@ func TestUserHandler_Register(t *testing.T) {
 			// assert response body
 			wantBody, _ := json.Marshal(tc.WantBody)
 			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert sent email
+			assertSentEmail(t, sent, tc.WantEmail)
 		})
 	}
 }
@@ -87,6 +92,7 @@ func TestUserHandler_CreatePasswordResetToken(t *testing.T) {
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			rr := httptest.NewRecorder()
+			sent := len(testTransport.Messages())
 			req := httptest.NewRequest(http.MethodPost, "/v1/users/password-reset-token", strings.NewReader(tc.RequestBody))
 			app.Router(testRouteHandlers).ServeHTTP(rr, req)
 
@@ -98,6 +104,9 @@ func TestUserHandler_CreatePasswordResetToken(t *testing.T) {
 			// assert response body
 			wantBody, _ := json.Marshal(tc.WantBody)
 			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert sent email
+			assertSentEmail(t, sent, tc.WantEmail)
 		})
 	}
 }
@@ -110,6 +119,7 @@ func TestUserHandler_UpdatePassword(t *testing.T) {
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			rr := httptest.NewRecorder()
+			sent := len(testTransport.Messages())
 			req := httptest.NewRequest(http.MethodPut, "/v1/users/update-password", strings.NewReader(tc.RequestBody))
 			app.Router(testRouteHandlers).ServeHTTP(rr, req)
 
@@ -121,6 +131,9 @@ func TestUserHandler_UpdatePassword(t *testing.T) {
 			// assert response body
 			wantBody, _ := json.Marshal(tc.WantBody)
 			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert sent email
+			assertSentEmail(t, sent, tc.WantEmail)
 		})
 	}
 }
@@ -133,6 +146,7 @@ func TestUserHandler_CreateActivationToken(t *testing.T) {
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			rr := httptest.NewRecorder()
+			sent := len(testTransport.Messages())
 			req := httptest.NewRequest(http.MethodPost, "/v1/users/refresh-activation-token", strings.NewReader(tc.RequestBody))
 			app.Router(testRouteHandlers).ServeHTTP(rr, req)
 
@@ -144,6 +158,114 @@ func TestUserHandler_CreateActivationToken(t *testing.T) {
 			// assert response body
 			wantBody, _ := json.Marshal(tc.WantBody)
 			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert sent email
+			assertSentEmail(t, sent, tc.WantEmail)
+		})
+	}
+}
+
+func TestUserHandler_Notifications(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := notificationsTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/notifications", nil)
+			if tc.Authenticated {
+				setBearerToken(req)
+			}
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.WantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.WantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestUserHandler_UpdateNotifications(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := updateNotificationsTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, "/v1/users/notifications", strings.NewReader(tc.RequestBody))
+			setBearerToken(req)
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.WantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.WantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+		})
+	}
+}
+
+func TestUserHandler_Unsubscribe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := unsubscribeTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// mail clients post a one-click unsubscribe form without authentication
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/users/unsubscribe", strings.NewReader("List-Unsubscribe=One-Click"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.URL.RawQuery = url.Values{"token": {tc.Token}}.Encode()
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.WantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.WantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
+
+			// assert the preferences of the user
+			preferences, err := testRepos.NotificationPreferences.Get(tc.UserId)
+			testhelpers.AssertFatalError(t, err)
+			testhelpers.AssertStruct(t, preferences, tc.WantPreferences)
+		})
+	}
+}
+
+func TestUserHandler_GetById(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := newTestApp(t)
+	testCases := getUserTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/users/"+tc.Id, nil)
+			setBearerToken(req)
+			app.Router(testRouteHandlers).ServeHTTP(rr, req)
+
+			code, body, _ := parseResponse(t, rr.Result())
+
+			// assert status code
+			testhelpers.AssertEqual(t, code, tc.WantCode)
+
+			// assert response body
+			wantBody, _ := json.Marshal(tc.WantBody)
+			testhelpers.AssertEqual(t, body, string(wantBody))
 		})
 	}
 }
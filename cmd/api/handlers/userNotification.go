@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"net/http"
+)
+
+// Notifications returns the notification preferences of the authenticated user.
+func (u userHandler) Notifications(ctx *gin.Context) {
+	user := common.ContextGetUser(ctx)
+
+	preferences, err := u.repositories.NotificationPreferences.Get(user.Id)
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			preferences.ToResponse(),
+		),
+	)
+}
+
+// UpdateNotifications opts the authenticated user into or out of the categories in the request,
+// and returns the updated notification preferences.
+func (u userHandler) UpdateNotifications(ctx *gin.Context) {
+	req := &request.NotificationPreferencesRequest{}
+	err := parseJsonRequest(ctx, req)
+	if err != nil {
+		return
+	}
+
+	err = validateJsonRequest(ctx, req, nil)
+	if err != nil {
+		return
+	}
+
+	user := common.ContextGetUser(ctx)
+	preferences := models.NotificationPreferences{}
+	err = u.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		preferences, err = repositories.NotificationPreferences.Get(user.Id)
+		if err != nil {
+			return err
+		}
+
+		req.Apply(&preferences)
+		return repositories.NotificationPreferences.Update(user.Id, preferences)
+	})
+	if err != nil {
+		responseErrors.HandleInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			preferences.ToResponse(),
+		),
+	)
+}
+
+// Unsubscribe opts the user out of the category of emails signed into the token of the query,
+// which is linked in the List-Unsubscribe header of non-transactional emails.
+// Mail clients send the request without authentication, with a body which is ignored, as described in RFC 8058.
+func (u userHandler) Unsubscribe(ctx *gin.Context) {
+	abortWithInvalidToken := func() {
+		v := validator.New(request.UnsubscribeField)
+		v.AddError(request.UnsubscribeFieldToken, "invalid unsubscribe token")
+		ctx.AbortWithStatusJSON(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityError(v),
+		)
+	}
+
+	userId, category, err := u.unsubscriber.Verify(ctx.Query(request.UnsubscribeFieldToken))
+	if err != nil || !rules.In(category, models.NotificationCategories) {
+		abortWithInvalidToken()
+		return
+	}
+
+	err = u.repositories.WithinTx(ctx.Request.Context(), func(repositories repository.Repositories) error {
+		preferences, err := repositories.NotificationPreferences.Get(userId)
+		if err != nil {
+			return err
+		}
+
+		preferences.Set(category, false)
+		return repositories.NotificationPreferences.Update(userId, preferences)
+	})
+	if err != nil {
+		switch {
+		// the user was deleted after the email was sent
+		case errors.Is(err, repository.ErrRecordNotFound):
+			abortWithInvalidToken()
+
+		default:
+			responseErrors.HandleInternalServerError(ctx, err)
+		}
+
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		response.SuccessResponse(
+			http.StatusOK,
+			map[string]string{"message": "you have been unsubscribed successfully"},
+		),
+	)
+}
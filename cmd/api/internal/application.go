@@ -3,9 +3,13 @@ package internal
 import (
 	"github.com/rhodeon/moviescreen/cmd/api/common"
 	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/mailer"
 )
 
 type Application struct {
 	Config       common.Config
 	Repositories repository.Repositories
+	Storage      repository.BlobStorage
+	Mailer       *mailer.Mailer
+	Unsubscriber *mailer.Unsubscriber
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/rhodeon/moviescreen/cmd/api/middleware"
 	"github.com/rhodeon/moviescreen/domain/models"
 	"path"
+	"strings"
 )
 
 const (
@@ -25,14 +26,25 @@ func (app Application) Router(handlers common.RouteHandlers) *gin.Engine {
 	// set CORS behaviour
 	router.Use(cors.New(cors.Config{
 		AllowAllOrigins: true,
-		AllowHeaders:    []string{"Authorization", "Content-Type"},
+		AllowHeaders:    []string{"Authorization", "Content-Type", "If-Match", "If-None-Match", "Accept-Language"},
 		AllowMethods:    []string{"PUT", "PATCH", "DELETE"},
+		ExposeHeaders:   []string{"ETag", "Last-Modified", "Content-Language"},
 	}))
 
 	// set general middleware
 	router.Use(middleware.Metrics())
 	router.Use(middleware.RateLimit(app.Config))
-	router.Use(middleware.MaxSizeLimit())
+	router.Use(middleware.MaxSizeLimit(
+		middleware.DefaultMaxBytes,
+		withVersion("movies/import"),
+		withVersion("movies/:id/poster"),
+		withVersion("movies/:id/backdrop"),
+	))
+
+	// serve the stored files from the local storage directory if their base URL is a path on the API
+	if strings.HasPrefix(app.Config.Storage.Url, "/") && app.Config.Storage.Dir != "" {
+		router.Static(app.Config.Storage.Url, app.Config.Storage.Dir)
+	}
 
 	router.GET(withVersion("healthcheck"), handlers.Misc.HealthCheck)
 
@@ -46,14 +58,123 @@ func (app Application) Router(handlers common.RouteHandlers) *gin.Engine {
 		movies.Use(middleware.RequireActivatedUser())
 		requireRead := middleware.RequirePermission(models.PermissionMoviesRead, app.Repositories)
 		requireWrite := middleware.RequirePermission(models.PermissionMoviesWrite, app.Repositories)
+		requireExport := middleware.RequirePermission(models.PermissionMoviesExport, app.Repositories)
 
 		movies.GET("/", requireRead, handlers.Movies.List)
 		movies.POST("/", requireWrite, handlers.Movies.Create)
+		movies.POST("/import", requireWrite, middleware.ExtendDeadlines(app.Config.Import.Timeout), middleware.MaxSizeLimit(app.Config.Import.MaxBytes), handlers.Movies.Import)
+		movies.GET("/export", requireExport, middleware.ExtendDeadlines(0), handlers.Movies.Export)
+		movies.GET("/lookup", requireRead, handlers.Movies.Lookup)
 		movies.GET("/:id", requireRead, handlers.Movies.GetById)
 		movies.PATCH("/:id", requireWrite, handlers.Movies.Update)
 		movies.DELETE("/:id", requireWrite, handlers.Movies.Delete)
+		movies.GET("/trash", requireWrite, handlers.Movies.Trash)
+		movies.POST("/:id/restore", requireWrite, handlers.Movies.Restore)
+		movies.GET("/:id/revisions", requireRead, handlers.Movies.Revisions)
+		movies.GET("/:id/revisions/diff", requireRead, handlers.Movies.DiffRevisions)
+		movies.POST("/:id/revisions/:version/revert", requireWrite, handlers.Movies.RevertRevision)
+		movies.GET("/:id/similar", requireRead, handlers.Movies.Similar)
+		movies.GET("/:id/translations", requireRead, handlers.Movies.Translations)
+		movies.PUT("/:id/translations/:language", requireWrite, handlers.Movies.PutTranslation)
+		movies.DELETE("/:id/translations/:language", requireWrite, handlers.Movies.DeleteTranslation)
+		movies.PUT("/:id/poster", requireWrite, middleware.MaxSizeLimit(app.Config.Images.MaxBytes), handlers.Movies.PutPoster)
+		movies.DELETE("/:id/poster", requireWrite, handlers.Movies.DeletePoster)
+		movies.PUT("/:id/backdrop", requireWrite, middleware.MaxSizeLimit(app.Config.Images.MaxBytes), handlers.Movies.PutBackdrop)
+		movies.DELETE("/:id/backdrop", requireWrite, handlers.Movies.DeleteBackdrop)
 	}
 
+	genres := router.Group(withVersion("genres"))
+	{
+		// set middleware for activation and permission requirements
+		genres.Use(middleware.Authenticate(app.Repositories))
+		genres.Use(middleware.RequireActivatedUser())
+		requireRead := middleware.RequirePermission(models.PermissionMoviesRead, app.Repositories)
+		requireWrite := middleware.RequirePermission(models.PermissionGenresWrite, app.Repositories)
+
+		genres.GET("/", requireRead, handlers.Genres.List)
+		genres.POST("/", requireWrite, handlers.Genres.Create)
+		genres.PATCH("/:id", requireWrite, handlers.Genres.Rename)
+		genres.POST("/:id/merge", requireWrite, handlers.Genres.Merge)
+	}
+
+	collections := router.Group(withVersion("collections"))
+	{
+		// set middleware for activation and permission requirements,
+		// with ownership and permissions to modify each collection checked by the handlers
+		collections.Use(middleware.Authenticate(app.Repositories))
+		collections.Use(middleware.RequireActivatedUser())
+		collections.Use(middleware.RequirePermission(models.PermissionMoviesRead, app.Repositories))
+
+		collections.GET("/", handlers.Collections.List)
+		collections.POST("/", handlers.Collections.Create)
+		collections.GET("/:id", handlers.Collections.GetById)
+		collections.PATCH("/:id", handlers.Collections.Update)
+		collections.DELETE("/:id", handlers.Collections.Delete)
+		collections.POST("/:id/movies", handlers.Collections.AddMovie)
+		collections.DELETE("/:id/movies/:movie_id", handlers.Collections.RemoveMovie)
+		collections.PUT("/:id/order", handlers.Collections.Reorder)
+	}
+
+	series := router.Group(withVersion("series"))
+	{
+		// set middleware for activation and permission requirements
+		series.Use(middleware.Authenticate(app.Repositories))
+		series.Use(middleware.RequireActivatedUser())
+		requireRead := middleware.RequirePermission(models.PermissionMoviesRead, app.Repositories)
+		requireWrite := middleware.RequirePermission(models.PermissionMoviesWrite, app.Repositories)
+
+		series.GET("/", requireRead, handlers.Series.List)
+		series.POST("/", requireWrite, handlers.Series.Create)
+		series.GET("/:id", requireRead, handlers.Series.GetById)
+		series.PATCH("/:id", requireWrite, handlers.Series.Update)
+		series.DELETE("/:id", requireWrite, handlers.Series.Delete)
+		series.PUT("/:id/seasons/:season", requireWrite, handlers.Series.PutSeason)
+		series.DELETE("/:id/seasons/:season", requireWrite, handlers.Series.DeleteSeason)
+		series.PUT("/:id/seasons/:season/episodes/:episode", requireWrite, handlers.Series.PutEpisode)
+		series.DELETE("/:id/seasons/:season/episodes/:episode", requireWrite, handlers.Series.DeleteEpisode)
+	}
+
+	// search across movies and series with the same requirements as reading movies
+	search := router.Group(withVersion("search"))
+	{
+		search.Use(middleware.Authenticate(app.Repositories))
+		search.Use(middleware.RequireActivatedUser())
+		search.Use(middleware.RequirePermission(models.PermissionMoviesRead, app.Repositories))
+
+		search.GET("/", handlers.Search.Search)
+	}
+
+	// statistics of the catalogue with the same requirements as reading movies
+	stats := router.Group(withVersion("stats"))
+	{
+		stats.Use(middleware.Authenticate(app.Repositories))
+		stats.Use(middleware.RequireActivatedUser())
+		stats.Use(middleware.RequirePermission(models.PermissionMoviesRead, app.Repositories))
+
+		stats.GET("/movies", handlers.Stats.Movies)
+	}
+
+	// administration of the catalogue, which can remove movies and requires the "movies:merge" permission,
+	// of the email templates, which requires the "emails:manage" permission,
+	// and of the users, which requires the "users:read" permission
+	admin := router.Group(withVersion("admin"))
+	{
+		admin.Use(middleware.Authenticate(app.Repositories))
+		admin.Use(middleware.RequireActivatedUser())
+		requireMerge := middleware.RequirePermission(models.PermissionMoviesMerge, app.Repositories)
+		requireEmails := middleware.RequirePermission(models.PermissionEmailsManage, app.Repositories)
+		requireUsers := middleware.RequirePermission(models.PermissionUsersRead, app.Repositories)
+
+		admin.GET("/movies/duplicates", requireMerge, handlers.Movies.Duplicates)
+		admin.POST("/movies/merge", requireMerge, handlers.Movies.Merge)
+		admin.GET("/emails/:template/preview", requireEmails, handlers.Emails.Preview)
+		admin.POST("/emails/:template/test", requireEmails, handlers.Emails.TestSend)
+		admin.GET("/users/:id", requireUsers, handlers.Users.GetById)
+	}
+
+	// notifications of the mail provider, which authenticate with the webhook secret instead of user tokens
+	router.POST(withVersion("webhooks/mail"), handlers.Emails.Webhook)
+
 	users := router.Group(withVersion("users"))
 	{
 		users.POST("/", handlers.Users.Register)
@@ -62,6 +183,13 @@ func (app Application) Router(handlers common.RouteHandlers) *gin.Engine {
 		users.POST("/password-reset-token", handlers.Users.CreatePasswordResetToken)
 		users.PUT("/update-password", handlers.Users.UpdatePassword)
 		users.POST("/refresh-activation-token", handlers.Users.CreateActivationToken)
+		users.POST("/unsubscribe", handlers.Users.Unsubscribe)
+
+		// notification preferences of the authenticated user
+		authenticate := middleware.Authenticate(app.Repositories)
+		requireActivated := middleware.RequireActivatedUser()
+		users.GET("/notifications", authenticate, requireActivated, handlers.Users.Notifications)
+		users.PATCH("/notifications", authenticate, requireActivated, handlers.Users.UpdateNotifications)
 	}
 
 	return router
@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/prettylog"
+	"time"
+)
+
+// PurgeTrash returns a job which permanently removes the movies
+// which have been in the trash for longer than the retention period, along with their images in the storage.
+// The revisions of movies which no longer exist, whether purged or merged into another,
+// are removed once they haven't changed for longer than the revision retention period.
+func PurgeTrash(repositories repository.Repositories, storage repository.BlobStorage, retention time.Duration, revisionRetention time.Duration) Job {
+	return func() error {
+		purged, imageKeys, err := repositories.Movies.Purge(time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		if purged > 0 {
+			prettylog.InfoF("purged %d movies from the trash", purged)
+		}
+
+		// the movies are already gone, so a failure to delete their images doesn't stop the purge of revisions
+		if len(imageKeys) > 0 {
+			if err := storage.Delete(imageKeys...); err != nil {
+				prettylog.ErrorF("movie images: %s", err.Error())
+			}
+		}
+
+		purgedRevisions, err := repositories.MovieRevisions.PurgeRemoved(time.Now().Add(-revisionRetention))
+		if err != nil {
+			return err
+		}
+
+		if purgedRevisions > 0 {
+			prettylog.InfoF("purged %d revisions of removed movies", purgedRevisions)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,20 @@
+package jobs
+
+import (
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/prettylog"
+)
+
+// RefreshSimilarities returns a job which recomputes the similarities between movies
+// used to recommend similar movies.
+func RefreshSimilarities(repositories repository.Repositories) Job {
+	return func() error {
+		stored, err := repositories.MovieSimilarities.Refresh()
+		if err != nil {
+			return err
+		}
+
+		prettylog.InfoF("refreshed %d movie similarities", stored)
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+// Package jobs runs the periodic background jobs of the application.
+package jobs
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/prettylog"
+	"sync"
+	"time"
+)
+
+// Job is a unit of background work which is run periodically.
+type Job func() error
+
+// Scheduler runs jobs at fixed intervals in background goroutines until it is stopped.
+type Scheduler struct {
+	wg   *sync.WaitGroup
+	quit chan struct{}
+	once sync.Once
+}
+
+// NewScheduler creates a Scheduler whose jobs are tracked by the waitgroup,
+// so the application can wait for running jobs to complete before shutting down.
+func NewScheduler(wg *sync.WaitGroup) *Scheduler {
+	return &Scheduler{
+		wg:   wg,
+		quit: make(chan struct{}),
+	}
+}
+
+// Every runs the job after each interval until the scheduler is stopped.
+// Errors returned by the job are logged, and don't prevent it from running again.
+func (s *Scheduler) Every(interval time.Duration, name string, job Job) {
+	common.Background(s.wg, func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := job(); err != nil {
+					prettylog.ErrorF("job %q: %s", name, err.Error())
+				}
+
+			case <-s.quit:
+				return
+			}
+		}
+	})
+}
+
+// Now runs the job once in the background, for jobs whose results are needed before their first interval elapses.
+// Errors returned by the job are logged.
+func (s *Scheduler) Now(name string, job Job) {
+	common.Background(s.wg, func() {
+		if err := job(); err != nil {
+			prettylog.ErrorF("job %q: %s", name, err.Error())
+		}
+	})
+}
+
+// Stop signals all the jobs to stop after their current run.
+func (s *Scheduler) Stop() {
+	s.once.Do(func() {
+		close(s.quit)
+	})
+}
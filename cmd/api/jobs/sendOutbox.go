@@ -0,0 +1,169 @@
+package jobs
+
+import (
+	"errors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/mailer"
+	"github.com/rhodeon/prettylog"
+	"time"
+)
+
+const (
+	// outboxLease is how long a claimed email is hidden from other workers,
+	// after which it's claimed again if its worker stopped before recording the result.
+	outboxLease = 5 * time.Minute
+
+	// outboxBaseBackoff and outboxMaxBackoff bound the exponential delay before a failed email is retried.
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = time.Hour
+)
+
+// Sender sends batches of emails rendered from templates,
+// returning an error for each email with nil for sent ones.
+type Sender interface {
+	SendBatch(emails []mailer.Email) []error
+}
+
+// SendOutbox returns a job which claims a batch of pending emails from the outbox and sends them together.
+// Several of these jobs can run concurrently as a worker pool, as claimed emails are skipped by the others.
+// Failed emails are retried with an exponential back-off, and dead-lettered if the failure is permanent
+// or they have run out of attempts.
+// Emails to suppressed addresses aren't sent, and addresses which emails bounce from are suppressed.
+// The tokens of the emails are created as they're sent, so their plaintext is never stored in the outbox.
+// The result of every email is recorded even if recording others fails, with the errors returned together,
+// so sent emails aren't left claimed to be sent again after their lease.
+func SendOutbox(repositories repository.Repositories, sender Sender, batchSize int, maxAttempts int) Job {
+	return func() error {
+		emails, err := repositories.Outbox.Claim(batchSize, outboxLease)
+		if err != nil {
+			return err
+		}
+
+		if len(emails) == 0 {
+			return nil
+		}
+
+		recipients := make([]string, len(emails))
+		for i, email := range emails {
+			recipients[i] = email.Recipient
+		}
+		suppressions, err := repositories.Suppressions.GetForEmails(recipients)
+		if err != nil {
+			return err
+		}
+
+		var errs []error
+		sending := models.OutboxEmails{}
+		batch := []mailer.Email{}
+		for _, email := range emails {
+			if suppression, suppressed := suppressions[models.NormalizeEmail(email.Recipient)]; suppressed {
+				err = repositories.Outbox.MarkSuppressed(email.Id, "recipient suppressed after "+suppression.Reason)
+				if err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+
+			data, err := withToken(repositories, email)
+			if err != nil {
+				// the email is retried like a failed delivery
+				err = recordResult(repositories, email, err, maxAttempts)
+				if err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+
+			sending = append(sending, email)
+			batch = append(batch, mailer.Email{
+				Recipient: email.Recipient,
+				Template:  email.Template,
+				Language:  email.Language,
+				Data:      data,
+				UserId:    email.UserId,
+				Category:  email.Category,
+			})
+		}
+
+		if len(batch) > 0 {
+			for i, sendErr := range sender.SendBatch(batch) {
+				err = recordResult(repositories, sending[i], sendErr, maxAttempts)
+				if err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// outboxTokenFields maps the scopes of the tokens created for outbox emails to the template data fields holding them.
+var outboxTokenFields = map[string]string{
+	models.ScopeActivation:    "ActivationToken",
+	models.ScopePasswordReset: "PasswordResetToken",
+}
+
+// withToken returns the data of the email, with a new token of its scope for its user and the token's expiry
+// added to a copy of the data if the email has a token.
+func withToken(repositories repository.Repositories, email models.OutboxEmail) (map[string]any, error) {
+	if email.TokenScope == "" {
+		return email.Data, nil
+	}
+
+	token, err := repositories.Tokens.New(email.UserId, email.TokenScope, email.TokenLifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]any, len(email.Data)+2)
+	for key, value := range email.Data {
+		data[key] = value
+	}
+	data[outboxTokenFields[email.TokenScope]] = token.PlainText
+	data["Expires"] = token.Expires
+	return data, nil
+}
+
+// recordResult records the result of sending the email in the outbox,
+// suppressing its recipient if the email bounced.
+func recordResult(repositories repository.Repositories, email models.OutboxEmail, sendErr error, maxAttempts int) error {
+	outbox := repositories.Outbox
+
+	switch {
+	case sendErr == nil:
+		return outbox.MarkSent(email.Id)
+
+	case mailer.IsBounce(sendErr):
+		prettylog.ErrorF("outbox email %d bounced, suppressing %s: %s", email.Id, email.Recipient, sendErr.Error())
+		err := repositories.Suppressions.Suppress(&models.EmailSuppression{
+			Email:  email.Recipient,
+			Reason: models.SuppressionReasonBounce,
+			Detail: sendErr.Error(),
+		})
+		if err != nil {
+			return err
+		}
+		return outbox.DeadLetter(email.Id, sendErr.Error())
+
+	case mailer.IsPermanent(sendErr) || email.Attempts >= maxAttempts:
+		prettylog.ErrorF("outbox email %d dead-lettered after %d attempts: %s", email.Id, email.Attempts, sendErr.Error())
+		return outbox.DeadLetter(email.Id, sendErr.Error())
+
+	default:
+		return outbox.Retry(email.Id, sendErr.Error(), time.Now().Add(backoff(email.Attempts)))
+	}
+}
+
+// backoff returns the delay before the next attempt of an email which has been attempted the given number of times,
+// which doubles with each attempt up to the maximum.
+func backoff(attempts int) time.Duration {
+	delay := outboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+	return delay
+}
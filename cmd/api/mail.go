@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/internal/mailer"
+)
+
+// newMailTransport returns the transport emails are delivered through, as selected by the configuration.
+func newMailTransport(config common.Config) (mailer.Transport, error) {
+	switch config.Mail.Transport {
+	case mailer.TransportSmtp:
+		smtp := config.Smtp
+		return mailer.NewSmtpTransport(smtp.Host, smtp.Port, smtp.User, smtp.Password, smtp.PoolSize), nil
+
+	case mailer.TransportFile:
+		return mailer.NewFileTransport(config.Mail.Dir)
+
+	case mailer.TransportStdout:
+		return mailer.NewStdoutTransport(), nil
+
+	case mailer.TransportMemory:
+		return mailer.NewMemoryTransport(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q", config.Mail.Transport)
+	}
+}
@@ -6,8 +6,9 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/rhodeon/moviescreen/cmd/api/common"
 	"github.com/rhodeon/moviescreen/cmd/api/internal"
-	"github.com/rhodeon/moviescreen/domain/repository"
 	"github.com/rhodeon/moviescreen/infrastructure/database"
+	"github.com/rhodeon/moviescreen/infrastructure/storage"
+	"github.com/rhodeon/moviescreen/internal/mailer"
 	"github.com/rhodeon/prettylog"
 	"os"
 	"sync"
@@ -43,7 +44,24 @@ func main() {
 	defer db.Close()
 	prettylog.InfoF("database connection pool established")
 
-	setMetrics(config, db)
+	// set up the mailer shared by the application, with its templates parsed once
+	mailTransport, err := newMailTransport(config)
+	if err != nil {
+		prettylog.FatalError(err)
+	}
+	unsubscriber := mailer.NewUnsubscriber(config.Mail.UnsubscribeSecret, config.Mail.UnsubscribeUrl)
+	mail, err := mailer.New(mailTransport, config.Smtp.Sender, mailer.Branding{
+		Name:    config.Mail.BrandName,
+		Color:   config.Mail.BrandColor,
+		LogoUrl: config.Mail.BrandLogoUrl,
+	}, unsubscriber)
+	if err != nil {
+		prettylog.FatalError(err)
+	}
+	defer mail.Close()
+
+	repositories := database.NewRepositories(db)
+	setMetrics(config, db, repositories)
 
 	// set Gin to release mode on production
 	if config.Env == "production" {
@@ -51,13 +69,11 @@ func main() {
 	}
 
 	app := internal.Application{
-		Config: config,
-		Repositories: repository.Repositories{
-			Tokens:      database.TokenController{Db: db},
-			Movies:      database.MovieController{Db: db},
-			Users:       database.UserController{Db: db},
-			Permissions: database.PermissionController{Db: db},
-		},
+		Config:       config,
+		Repositories: repositories,
+		Storage:      storage.LocalStorage{Dir: config.Storage.Dir, BaseUrl: config.Storage.Url},
+		Mailer:       mail,
+		Unsubscriber: unsubscriber,
 	}
 
 	// establish waitgroup to ensure background tasks
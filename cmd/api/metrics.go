@@ -4,11 +4,13 @@ import (
 	"database/sql"
 	"expvar"
 	"github.com/rhodeon/moviescreen/cmd/api/common"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/prettylog"
 	"runtime"
 	"time"
 )
 
-func setMetrics(config common.Config, db *sql.DB) {
+func setMetrics(config common.Config, db *sql.DB, repositories repository.Repositories) {
 	expvar.NewString(common.MetricVersion).Set(config.Version)
 
 	expvar.Publish(common.MetricTimestamp, expvar.Func(func() any {
@@ -22,4 +24,13 @@ func setMetrics(config common.Config, db *sql.DB) {
 	expvar.Publish(common.MetricDatabase, expvar.Func(func() any {
 		return db.Stats()
 	}))
+
+	expvar.Publish(common.MetricOutbox, expvar.Func(func() any {
+		depth, err := repositories.Outbox.Depth()
+		if err != nil {
+			prettylog.ErrorF("outbox depth: %s", err.Error())
+			return nil
+		}
+		return depth
+	}))
 }
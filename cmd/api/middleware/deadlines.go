@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/prettylog"
+	"net/http"
+	"time"
+)
+
+// responseControllerKey is the context key of the controller of the server's response writer.
+type responseControllerKey struct{}
+
+// WithResponseController stores a controller of the server's response writer in the request context,
+// as the response writer of gin doesn't expose it to extend the deadlines of the connection.
+func WithResponseController(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), responseControllerKey{}, http.NewResponseController(w))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ExtendDeadlines replaces the read and write deadlines set by the server timeouts for requests to the route,
+// giving them the timeout from this point instead. A zero timeout removes the deadlines.
+// Requests served without a response controller are let through unchanged.
+func ExtendDeadlines(timeout time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		controller, exists := ctx.Request.Context().Value(responseControllerKey{}).(*http.ResponseController)
+		if !exists {
+			ctx.Next()
+			return
+		}
+
+		var deadline time.Time
+		if timeout > 0 {
+			deadline = time.Now().Add(timeout)
+		}
+
+		if err := controller.SetReadDeadline(deadline); err != nil {
+			prettylog.ErrorF("read deadline: %s", err.Error())
+		}
+		if err := controller.SetWriteDeadline(deadline); err != nil {
+			prettylog.ErrorF("write deadline: %s", err.Error())
+		}
+		ctx.Next()
+	}
+}
@@ -2,16 +2,24 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
 	"net/http"
 )
 
-// MaxSizeLimit restricts the size of requests to 1MB.
-func MaxSizeLimit() gin.HandlerFunc {
+// DefaultMaxBytes is the default maximum size of request bodies (1MB).
+const DefaultMaxBytes = 1_048_576
+
+// MaxSizeLimit restricts the size of requests to maxBytes.
+// Requests to the exempt routes are let through to be limited by their own route middleware.
+func MaxSizeLimit(maxBytes int64, exemptRoutes ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		maxBytes := 1_048_576 // max size of 1MB
+		if rules.In(ctx.FullPath(), exemptRoutes) {
+			ctx.Next()
+			return
+		}
 
-		// any future attempts to read a body greater than 1MB will return an error
-		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, int64(maxBytes))
+		// any future attempts to read a body greater than maxBytes will return an error
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBytes)
 		ctx.Next()
 	}
 }
@@ -0,0 +1,172 @@
+package request
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"strings"
+	"unicode/utf8"
+)
+
+type CollectionRequest struct {
+	Kind        *string `json:"kind"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Public      *bool   `json:"public"`
+}
+
+const (
+	CollectionFieldKind        = "kind"
+	CollectionFieldName        = "name"
+	CollectionFieldDescription = "description"
+	CollectionFieldPublic      = "public"
+)
+
+// ToModel creates a collection model from a request with a non-nil kind and name,
+// with its fields trimmed.
+// Franchises are always public, while lists are private unless stated otherwise.
+func (request *CollectionRequest) ToModel() models.Collection {
+	collection := models.Collection{Kind: *request.Kind}
+	request.Apply(&collection)
+	if collection.Kind == models.CollectionKindFranchise {
+		collection.Public = true
+	}
+	return collection
+}
+
+// Apply replaces the name, description and visibility of the collection with the non-nil fields of the request.
+func (request *CollectionRequest) Apply(collection *models.Collection) {
+	if request.Name != nil {
+		collection.Name = strings.TrimSpace(*request.Name)
+	}
+	if request.Description != nil {
+		collection.Description = strings.TrimSpace(*request.Description)
+	}
+	if request.Public != nil {
+		collection.Public = *request.Public
+	}
+}
+
+func (request *CollectionRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("collection")
+
+	for _, field := range required {
+		switch field {
+		case CollectionFieldKind:
+			v.Check(request.Kind != nil, CollectionFieldKind, "must be provided")
+		case CollectionFieldName:
+			v.Check(request.Name != nil, CollectionFieldName, "must be provided")
+		}
+	}
+
+	if request.Kind != nil {
+		v.Check(rules.In(*request.Kind, models.CollectionKinds), CollectionFieldKind, "must be one of "+strings.Join(models.CollectionKinds, ", "))
+	}
+
+	if request.Name != nil {
+		v.Check(strings.TrimSpace(*request.Name) != "", CollectionFieldName, "must not be blank")
+		v.Check(utf8.RuneCountInString(*request.Name) <= 200, CollectionFieldName, "must not have more than 200 characters")
+	}
+
+	if request.Description != nil {
+		v.Check(utf8.RuneCountInString(*request.Description) <= 2000, CollectionFieldDescription, "must not have more than 2000 characters")
+	}
+
+	// franchises are official, so they can't be hidden
+	if request.Kind != nil && *request.Kind == models.CollectionKindFranchise && request.Public != nil {
+		v.Check(*request.Public, CollectionFieldPublic, "must be true for a franchise")
+	}
+
+	return v
+}
+
+// CollectionMovieRequest holds a movie to add to a collection and its position.
+type CollectionMovieRequest struct {
+	MovieId  *int `json:"movie_id"`
+	Position *int `json:"position"`
+}
+
+const (
+	CollectionMovieFieldMovieId  = "movie_id"
+	CollectionMovieFieldPosition = "position"
+)
+
+func (request *CollectionMovieRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("collection")
+
+	for _, field := range required {
+		switch field {
+		case CollectionMovieFieldMovieId:
+			v.Check(request.MovieId != nil, CollectionMovieFieldMovieId, "must be provided")
+		}
+	}
+
+	if request.MovieId != nil {
+		v.Check(*request.MovieId > 0, CollectionMovieFieldMovieId, "must be a positive integer")
+	}
+
+	if request.Position != nil {
+		v.Check(*request.Position > 0, CollectionMovieFieldPosition, "must be a positive integer")
+	}
+
+	return v
+}
+
+// PositionValue returns the requested position, and 0 to append the movie if there is none.
+func (request *CollectionMovieRequest) PositionValue() int {
+	if request.Position == nil {
+		return 0
+	}
+	return *request.Position
+}
+
+// CollectionOrderRequest holds the ids of the movies of a collection in their new order.
+type CollectionOrderRequest struct {
+	MovieIds []int `json:"movie_ids"`
+}
+
+const CollectionOrderFieldMovieIds = "movie_ids"
+
+func (request *CollectionOrderRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("collection")
+
+	for _, field := range required {
+		switch field {
+		case CollectionOrderFieldMovieIds:
+			v.Check(len(request.MovieIds) > 0, CollectionOrderFieldMovieIds, "must contain at least 1 movie")
+		}
+	}
+
+	v.Check(len(request.MovieIds) <= 1000, CollectionOrderFieldMovieIds, "must not contain more than 1000 movies")
+	v.Check(rules.Unique(request.MovieIds), CollectionOrderFieldMovieIds, "must not contain duplicate movies")
+
+	return v
+}
+
+// CollectionQuery holds the search queries for listing collections.
+// Empty queries match all collections.
+type CollectionQuery struct {
+	Kind string
+
+	// UserId matches the lists of the user with the id, and is 0 to match collections of any owner.
+	UserId int
+}
+
+const (
+	CollectionQueryFieldKind   = "kind"
+	CollectionQueryFieldUserId = "user_id"
+)
+
+// Validate adds the errors of the queries to the validator of the filters they're used with.
+func (query CollectionQuery) Validate(v *validator.Validator) {
+	if query.Kind != "" {
+		v.Check(rules.In(query.Kind, models.CollectionKinds), CollectionQueryFieldKind, "must be one of "+strings.Join(models.CollectionKinds, ", "))
+	}
+	v.Check(query.UserId >= 0, CollectionQueryFieldUserId, "must be a positive integer")
+}
+
+const (
+	CollectionFilterSortId      = "id"
+	CollectionFilterSortName    = "name"
+	CollectionFilterSortUpdated = "updated_at"
+)
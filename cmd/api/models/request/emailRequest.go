@@ -0,0 +1,139 @@
+package request
+
+import (
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"strings"
+)
+
+// Formats of email previews.
+const (
+	EmailFormatHtml = "html"
+	EmailFormatText = "text"
+)
+
+// EmailPreviewQuery holds the queries for previewing an email template.
+type EmailPreviewQuery struct {
+	// Format is either the HTML body or the plain text body preceded by the subject.
+	Format string
+
+	// Locale is the ISO 639-1 code of the language to render the template in.
+	Locale string
+}
+
+const (
+	EmailPreviewFieldFormat = "format"
+	EmailPreviewFieldLocale = "locale"
+)
+
+func (query EmailPreviewQuery) Validate() *validator.Validator {
+	v := validator.New("preview")
+
+	v.Check(rules.In(query.Format, []string{EmailFormatHtml, EmailFormatText}), EmailPreviewFieldFormat, `must be "html" or "text"`)
+	v.Check(rules.LanguageCode(query.Locale), EmailPreviewFieldLocale, "must be an ISO 639-1 language code")
+
+	return v
+}
+
+// EmailTestRequest holds the recipient of a test email and the language to render it in.
+type EmailTestRequest struct {
+	Email  *string `json:"email"`
+	Locale *string `json:"locale"`
+}
+
+const (
+	EmailTestFieldEmail  = "email"
+	EmailTestFieldLocale = "locale"
+)
+
+func (request *EmailTestRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("email")
+
+	for _, field := range required {
+		switch field {
+		case EmailTestFieldEmail:
+			v.Check(request.Email != nil, field, "must be provided")
+		case EmailTestFieldLocale:
+			v.Check(request.Locale != nil, field, "must be provided")
+		}
+	}
+
+	if request.Email != nil {
+		v.Check(strings.TrimSpace(*request.Email) != "", EmailTestFieldEmail, "must not be blank")
+		v.Check(rules.MatchesPattern(*request.Email, validator.EmailRX), EmailTestFieldEmail, "must be a valid email address")
+	}
+
+	if request.Locale != nil {
+		v.Check(rules.LanguageCode(*request.Locale), EmailTestFieldLocale, "must be an ISO 639-1 language code")
+	}
+
+	return v
+}
+
+// Types of mail events reported to the webhook.
+const (
+	MailEventBounce    = "bounce"
+	MailEventComplaint = "complaint"
+)
+
+// Types of bounces, where only hard bounces are permanent.
+const (
+	BounceTypeHard = "hard"
+	BounceTypeSoft = "soft"
+)
+
+// MailEventRequest holds a bounce or complaint notification of the mail provider about an email address.
+type MailEventRequest struct {
+	Type  *string `json:"type"`
+	Email *string `json:"email"`
+
+	// BounceType is whether a bounce is permanent, defaulting to a hard bounce.
+	BounceType *string `json:"bounce_type"`
+
+	// Diagnostic is the explanation of the provider, such as the reply of the recipient's SMTP server.
+	Diagnostic *string `json:"diagnostic"`
+}
+
+const (
+	MailEventFieldType       = "type"
+	MailEventFieldEmail      = "email"
+	MailEventFieldBounceType = "bounce_type"
+	MailEventFieldDiagnostic = "diagnostic"
+)
+
+func (request *MailEventRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("mail_event")
+
+	for _, field := range required {
+		switch field {
+		case MailEventFieldType:
+			v.Check(request.Type != nil, field, "must be provided")
+		case MailEventFieldEmail:
+			v.Check(request.Email != nil, field, "must be provided")
+		}
+	}
+
+	if request.Type != nil {
+		v.Check(rules.In(*request.Type, []string{MailEventBounce, MailEventComplaint}), MailEventFieldType, `must be "bounce" or "complaint"`)
+	}
+
+	if request.Email != nil {
+		v.Check(rules.MatchesPattern(*request.Email, validator.EmailRX), MailEventFieldEmail, "must be a valid email address")
+	}
+
+	if request.BounceType != nil {
+		v.Check(rules.In(*request.BounceType, []string{BounceTypeHard, BounceTypeSoft}), MailEventFieldBounceType, `must be "hard" or "soft"`)
+	}
+
+	if request.Diagnostic != nil {
+		v.Check(len(*request.Diagnostic) <= 1000, MailEventFieldDiagnostic, "must not be more than 1000 bytes long")
+	}
+
+	return v
+}
+
+// Suppresses returns true if the event is a hard bounce or a complaint,
+// after which emails shouldn't be sent to the address again.
+func (request *MailEventRequest) Suppresses() bool {
+	return *request.Type == MailEventComplaint || request.BounceType == nil || *request.BounceType == BounceTypeHard
+}
@@ -0,0 +1,43 @@
+package request
+
+import (
+	"fmt"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+)
+
+// Fields represents the sparse fieldset and embedded relations
+// requested as query parameters in a request url.
+type Fields struct {
+	Selected      []string
+	Include       []string
+	ValidFields   []string
+	ValidIncludes []string
+}
+
+const (
+	FieldsFieldFields  = "fields"
+	FieldsFieldInclude = "include"
+)
+
+func (f Fields) Validate() *validator.Validator {
+	v := validator.New("filter")
+
+	// check that every requested field and relation is known
+	for _, field := range f.Selected {
+		v.Check(rules.In(field, f.ValidFields), FieldsFieldFields, fmt.Sprintf("unknown field %q", field))
+	}
+	v.Check(rules.Unique(f.Selected), FieldsFieldFields, "must have unique fields")
+
+	for _, include := range f.Include {
+		v.Check(rules.In(include, f.ValidIncludes), FieldsFieldInclude, fmt.Sprintf("unknown relation %q", include))
+	}
+	v.Check(rules.Unique(f.Include), FieldsFieldInclude, "must have unique relations")
+
+	return v
+}
+
+// Includes returns true if the relation was requested.
+func (f Fields) Includes(relation string) bool {
+	return rules.In(relation, f.Include)
+}
@@ -29,12 +29,23 @@ func (f Filters) Validate() *validator.Validator {
 	v.Check(f.Limit > 0, FilterFieldLimit, "must be greater than zero")
 	v.Check(f.Limit <= 100, FilterFieldLimit, "must be a maximum of 100")
 
-	// check that the sort parameter matches a value in the valid list
-	v.Check(rules.In(strings.TrimPrefix(f.Sort, "-"), f.ValidSorts), FilterFieldSort, "invalid sort value")
+	f.checkSort(v)
+	return v
+}
 
+// ValidateSort validates only the sort filter.
+// It is used by requests which aren't paginated.
+func (f Filters) ValidateSort() *validator.Validator {
+	v := validator.New("filter")
+	f.checkSort(v)
 	return v
 }
 
+// checkSort checks that the sort parameter matches a value in the valid list.
+func (f Filters) checkSort(v *validator.Validator) {
+	v.Check(rules.In(strings.TrimPrefix(f.Sort, "-"), f.ValidSorts), FilterFieldSort, "invalid sort value")
+}
+
 // SortColumn checks that the base form of the sort filter exists in the list of valid sorts,
 // and returns the base form if so.
 // This is to enable passing in a valid column name as an SQL order.
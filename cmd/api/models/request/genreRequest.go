@@ -0,0 +1,120 @@
+package request
+
+import (
+	"fmt"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"strings"
+	"unicode/utf8"
+)
+
+type GenreRequest struct {
+	Name *string `json:"name"`
+}
+
+const GenreFieldName = "name"
+
+// ToModel creates a genre model from a request with a non-nil name,
+// with the name trimmed and its slug set.
+func (request *GenreRequest) ToModel() models.Genre {
+	name := strings.TrimSpace(*request.Name)
+	return models.Genre{
+		Slug: models.GenreSlug(name),
+		Name: name,
+	}
+}
+
+func (request *GenreRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("genre")
+
+	for _, field := range required {
+		switch field {
+		case GenreFieldName:
+			v.Check(request.Name != nil, GenreFieldName, "must be provided")
+		}
+	}
+
+	if request.Name != nil {
+		v.Check(models.GenreSlug(*request.Name) != "", GenreFieldName, "must contain letters or digits")
+		v.Check(utf8.RuneCountInString(strings.TrimSpace(*request.Name)) <= 100, GenreFieldName, "must not have more than 100 characters")
+	}
+
+	return v
+}
+
+// GenreMergeRequest holds the id of the genre to merge another genre into.
+type GenreMergeRequest struct {
+	Into *int `json:"into"`
+}
+
+const GenreMergeFieldInto = "into"
+
+func (request *GenreMergeRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("genre")
+
+	for _, field := range required {
+		switch field {
+		case GenreMergeFieldInto:
+			v.Check(request.Into != nil, GenreMergeFieldInto, "must be provided")
+		}
+	}
+
+	if request.Into != nil {
+		v.Check(*request.Into > 0, GenreMergeFieldInto, "must be a positive integer")
+	}
+
+	return v
+}
+
+// GenreListRequest is a request with a list of genres, which must be amongst the known genres.
+// It is satisfied by the requests of movies and series.
+type GenreListRequest interface {
+	ClientRequest
+
+	// SetValidGenres sets the known genres for the validation of the request,
+	// and replaces its genres with their canonical names.
+	SetValidGenres(validGenres []string)
+}
+
+// canonicaliseGenres returns the genres with each replaced by the name of the valid genre sharing its slug,
+// so variants like "sci-fi" and "Sci Fi" are accepted for the genre "Sci-Fi".
+// Unknown genres are left unchanged to be reported by checkGenres.
+// A new slice is returned rather than modifying the genres, as they may be shared with a model.
+func canonicaliseGenres(genres []string, validGenres []string) []string {
+	if genres == nil {
+		return nil
+	}
+
+	canonicalNames := make(map[string]string, len(validGenres))
+	for _, name := range validGenres {
+		canonicalNames[models.GenreSlug(name)] = name
+	}
+
+	canonical := make([]string, len(genres))
+	for i, genre := range genres {
+		if name, exists := canonicalNames[models.GenreSlug(genre)]; exists {
+			genre = name
+		}
+		canonical[i] = genre
+	}
+	return canonical
+}
+
+// checkGenres adds an error to the field if the genres are empty, blank or repeated,
+// or if any isn't amongst the valid genres.
+// The genres aren't checked against the valid genres if they're nil.
+func checkGenres(v *validator.Validator, field string, genres []string, validGenres []string) {
+	v.Check(len(genres) >= 1, field, "must have at least 1 genre")
+	v.Check(rules.NotBlank(genres), field, "must not have any blank genres")
+	v.Check(rules.Unique(genres), field, "must have unique genres")
+
+	if validGenres != nil {
+		for _, genre := range genres {
+			if !rules.In(genre, validGenres) {
+				v.AddError(field, fmt.Sprintf("unknown genre %q", genre))
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package request
+
+import "github.com/rhodeon/moviescreen/internal/validator"
+
+// MovieDiffRequest holds the versions of a movie to compare.
+type MovieDiffRequest struct {
+	From int
+	To   int
+}
+
+const (
+	MovieDiffFieldFrom = "from"
+	MovieDiffFieldTo   = "to"
+)
+
+const MovieRevisionFilterSortVersion = "version"
+
+func (request MovieDiffRequest) Validate() *validator.Validator {
+	v := validator.New("diff")
+
+	v.Check(request.From > 0, MovieDiffFieldFrom, "must be a positive integer")
+	v.Check(request.To > 0, MovieDiffFieldTo, "must be a positive integer")
+
+	return v
+}
@@ -0,0 +1,183 @@
+package request
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MovieCsvGenreSeparator separates the genres in the genres column of a movie CSV.
+const MovieCsvGenreSeparator = "|"
+
+// MovieCsvHeader holds the columns of a movie CSV in their default order.
+var MovieCsvHeader = []string{MovieFieldTitle, MovieFieldYear, MovieFieldRuntime, MovieFieldGenres}
+
+// MovieCsvIgnoredColumns holds the columns of a movie CSV export which identify the exported movies.
+// They are accepted and ignored on import, as the imported movies are created anew.
+var MovieCsvIgnoredColumns = []string{MovieFieldId, MovieFieldVersion}
+
+// RecordError is returned when a record of an import stream cannot be parsed.
+// Reading can continue with the next record.
+type RecordError struct {
+	Field   string
+	Message string
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// MovieRecordReader reads movie requests one record at a time from an import stream.
+type MovieRecordReader interface {
+	// Read returns the movie request of the next record, or io.EOF when the stream is exhausted.
+	// A RecordError is returned for a malformed record.
+	Read() (*MovieRequest, error)
+}
+
+type movieCsvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// NewMovieCsvReader returns a reader of CSV records with a header row naming the movie fields.
+// Genres are separated by MovieCsvGenreSeparator.
+// Columns in MovieCsvIgnoredColumns are skipped, so exported CSVs can be imported.
+// An error is returned if the header is missing or contains unknown or duplicate columns.
+func NewMovieCsvReader(r io.Reader) (MovieRecordReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("CSV header is missing")
+		}
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if rules.In(column, MovieCsvIgnoredColumns) {
+			continue
+		}
+		if !rules.In(column, MovieCsvHeader) {
+			return nil, fmt.Errorf("unknown CSV column %q", column)
+		}
+		if _, exists := columns[column]; exists {
+			return nil, fmt.Errorf("duplicate CSV column %q", column)
+		}
+		columns[column] = i
+	}
+
+	return &movieCsvReader{reader: reader, columns: columns}, nil
+}
+
+func (m *movieCsvReader) Read() (*MovieRequest, error) {
+	record, err := m.reader.Read()
+	if err != nil {
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			return nil, &RecordError{Field: "record", Message: parseError.Err.Error()}
+		}
+		return nil, err
+	}
+
+	movieRequest := &MovieRequest{}
+
+	if i, exists := m.columns[MovieFieldTitle]; exists {
+		title := record[i]
+		movieRequest.Title = &title
+	}
+
+	if i, exists := m.columns[MovieFieldYear]; exists {
+		year, err := strconv.Atoi(strings.TrimSpace(record[i]))
+		if err != nil {
+			return nil, &RecordError{Field: MovieFieldYear, Message: "must be an integer"}
+		}
+		movieRequest.Year = &year
+	}
+
+	if i, exists := m.columns[MovieFieldRuntime]; exists {
+		runtime, err := strconv.Atoi(strings.TrimSpace(record[i]))
+		if err != nil {
+			return nil, &RecordError{Field: MovieFieldRuntime, Message: "must be an integer"}
+		}
+		movieRequest.Runtime = &runtime
+	}
+
+	if i, exists := m.columns[MovieFieldGenres]; exists {
+		movieRequest.Genres = []string{}
+		if strings.TrimSpace(record[i]) != "" {
+			for _, genre := range strings.Split(record[i], MovieCsvGenreSeparator) {
+				movieRequest.Genres = append(movieRequest.Genres, strings.TrimSpace(genre))
+			}
+		}
+	}
+
+	return movieRequest, nil
+}
+
+type movieNdjsonReader struct {
+	scanner *bufio.Scanner
+}
+
+// NewMovieNdjsonReader returns a reader of newline-delimited JSON objects of movie requests.
+// Blank lines are skipped, and each line is limited to 1MB.
+func NewMovieNdjsonReader(r io.Reader) MovieRecordReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1_048_576)
+	return &movieNdjsonReader{scanner: scanner}
+}
+
+func (m *movieNdjsonReader) Read() (*MovieRequest, error) {
+	for m.scanner.Scan() {
+		line := bytes.TrimSpace(m.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		// decode the line, rejecting unknown fields
+		movieRequest := &MovieRequest{}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(movieRequest); err != nil {
+			return nil, &RecordError{Field: "record", Message: ndjsonErrorMessage(err)}
+		}
+		return movieRequest, nil
+	}
+
+	if err := m.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// ndjsonErrorMessage returns a client-friendly message for the error of decoding a JSON line.
+func ndjsonErrorMessage(err error) string {
+	var syntaxError *json.SyntaxError
+	var unmarshalTypeError *json.UnmarshalTypeError
+	unknownFieldPrefix := "json: unknown field "
+
+	switch {
+	case errors.As(err, &syntaxError), errors.Is(err, io.ErrUnexpectedEOF):
+		return "malformed JSON"
+
+	case errors.As(err, &unmarshalTypeError):
+		return fmt.Sprintf("incorrect JSON type for field %q", unmarshalTypeError.Field)
+
+	case strings.HasPrefix(err.Error(), unknownFieldPrefix):
+		return fmt.Sprintf("unknown field: %s", strings.TrimPrefix(err.Error(), unknownFieldPrefix))
+
+	default:
+		return err.Error()
+	}
+}
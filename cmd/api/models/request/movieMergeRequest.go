@@ -0,0 +1,52 @@
+package request
+
+import (
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+)
+
+// MovieMergeRequest holds the id of the movie to keep and the ids of its duplicates to merge into it.
+type MovieMergeRequest struct {
+	SurvivorId   *int  `json:"survivor_id"`
+	DuplicateIds []int `json:"duplicate_ids"`
+}
+
+const (
+	MovieMergeFieldSurvivorId   = "survivor_id"
+	MovieMergeFieldDuplicateIds = "duplicate_ids"
+)
+
+// MaxMergedDuplicates is the maximum number of duplicates which can be merged into a movie at once.
+const MaxMergedDuplicates = 50
+
+func (request *MovieMergeRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("merge")
+
+	for _, field := range required {
+		switch field {
+		case MovieMergeFieldSurvivorId:
+			v.Check(request.SurvivorId != nil, MovieMergeFieldSurvivorId, "must be provided")
+		case MovieMergeFieldDuplicateIds:
+			v.Check(len(request.DuplicateIds) > 0, MovieMergeFieldDuplicateIds, "must contain at least 1 movie")
+		}
+	}
+
+	if request.SurvivorId != nil {
+		v.Check(*request.SurvivorId > 0, MovieMergeFieldSurvivorId, "must be a positive integer")
+	}
+
+	v.Check(len(request.DuplicateIds) <= MaxMergedDuplicates, MovieMergeFieldDuplicateIds, "must not contain more than 50 movies")
+	v.Check(rules.Unique(request.DuplicateIds), MovieMergeFieldDuplicateIds, "must not contain duplicate movies")
+	for _, id := range request.DuplicateIds {
+		if id <= 0 {
+			v.AddError(MovieMergeFieldDuplicateIds, "must only contain positive integers")
+			break
+		}
+		if request.SurvivorId != nil && id == *request.SurvivorId {
+			v.AddError(MovieMergeFieldDuplicateIds, "must not contain the survivor")
+			break
+		}
+	}
+
+	return v
+}
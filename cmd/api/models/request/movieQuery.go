@@ -0,0 +1,101 @@
+package request
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/types"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"strings"
+)
+
+// MovieQuery holds the search queries for listing movies.
+// Empty queries match all movies.
+type MovieQuery struct {
+	// Title supports partial searching.
+	Title string
+
+	// Genres and Countries match movies which have all of them.
+	Genres    []string
+	Countries []string
+
+	// Language is matched against the original language.
+	Language string
+
+	// Certification holds a country code and a rating of the country's certification system,
+	// separated by a colon, such as "US:PG-13".
+	Certification string
+
+	// ReleasedFrom and ReleasedTo are the bounds of the dates, formatted as YYYY-MM-DD,
+	// between which the movie was released in any country.
+	ReleasedFrom string
+	ReleasedTo   string
+}
+
+const (
+	MovieQueryFieldLanguage      = "language"
+	MovieQueryFieldCountries     = "countries"
+	MovieQueryFieldCertification = "certification"
+	MovieQueryFieldReleasedFrom  = "released_from"
+	MovieQueryFieldReleasedTo    = "released_to"
+)
+
+// Validate adds the errors of the queries to the validator of the filters they're used with.
+func (query MovieQuery) Validate(v *validator.Validator) {
+	if query.Language != "" {
+		v.Check(rules.LanguageCode(query.Language), MovieQueryFieldLanguage, "must be an ISO 639-1 language code")
+	}
+
+	for _, country := range query.Countries {
+		if !rules.CountryCode(country) {
+			v.AddError(MovieQueryFieldCountries, "must be ISO 3166-1 alpha-2 country codes")
+			break
+		}
+	}
+
+	if query.Certification != "" {
+		country, rating, found := strings.Cut(query.Certification, ":")
+		v.Check(found && rules.CountryCode(country) && rating != "", MovieQueryFieldCertification, "must be a country code and a rating separated by a colon")
+	}
+
+	checkDate := func(value string, field string) {
+		if value != "" {
+			_, err := types.ParseDate(value)
+			v.Check(err == nil, field, "must be a date in the format YYYY-MM-DD")
+		}
+	}
+	checkDate(query.ReleasedFrom, MovieQueryFieldReleasedFrom)
+	checkDate(query.ReleasedTo, MovieQueryFieldReleasedTo)
+}
+
+// CertificationFilter returns the certification to be matched, and nil if there is none.
+// It should only be used after validation.
+func (query MovieQuery) CertificationFilter() *models.Certification {
+	if query.Certification == "" {
+		return nil
+	}
+	country, rating, _ := strings.Cut(query.Certification, ":")
+	return &models.Certification{Country: country, Rating: rating}
+}
+
+// MovieLookupQuery holds the id of a movie in an external source.
+type MovieLookupQuery struct {
+	Source string
+	Id     string
+}
+
+const (
+	MovieLookupFieldSource = "source"
+	MovieLookupFieldId     = "id"
+)
+
+func (query MovieLookupQuery) Validate() *validator.Validator {
+	v := validator.New("lookup")
+
+	format, exists := MovieExternalIdFormats[query.Source]
+	v.Check(exists, MovieLookupFieldSource, "must be one of "+strings.Join(models.ExternalIdSources, ", "))
+	if exists {
+		v.Check(format.MatchString(query.Id), MovieLookupFieldId, "must be a valid "+query.Source+" id")
+	}
+
+	return v
+}
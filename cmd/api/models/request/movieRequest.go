@@ -1,9 +1,13 @@
 package request
 
 import (
+	"fmt"
 	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/types"
 	"github.com/rhodeon/moviescreen/internal/validator"
 	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -14,32 +18,159 @@ type MovieRequest struct {
 	Year    *int     `json:"year"`
 	Runtime *int     `json:"runtime"`
 	Genres  []string `json:"genres"`
+
+	OriginalTitle    *string                `json:"original_title"`
+	OriginalLanguage *string                `json:"original_language"`
+	Countries        []string               `json:"countries"`
+	ReleaseDates     []ReleaseDateRequest   `json:"release_dates"`
+	Certifications   []CertificationRequest `json:"certifications"`
+	Synopsis         *string                `json:"synopsis"`
+	Tagline          *string                `json:"tagline"`
+	ExternalIds      map[string]string      `json:"external_ids"`
+
+	// ValidGenres holds the names of the known genres, which the genres of the request must be amongst.
+	// The genres aren't checked if it's nil.
+	ValidGenres []string `json:"-"`
+}
+
+type ReleaseDateRequest struct {
+	Country string `json:"country"`
+	Date    string `json:"date"`
+}
+
+type CertificationRequest struct {
+	Country string `json:"country"`
+	Rating  string `json:"rating"`
+}
+
+// MovieCertificationRatings holds the ratings of the certification systems of countries.
+// Ratings of countries without a listed system are only checked for length.
+var MovieCertificationRatings = map[string][]string{
+	// Motion Picture Association
+	"US": {"G", "PG", "PG-13", "R", "NC-17"},
+	// British Board of Film Classification
+	"GB": {"U", "PG", "12A", "12", "15", "18", "R18"},
+	// Freiwillige Selbstkontrolle der Filmwirtschaft
+	"DE": {"0", "6", "12", "16", "18"},
+	// Centre national du cinéma et de l'image animée
+	"FR": {"TP", "10", "12", "16", "18"},
+	// Australian Classification Board
+	"AU": {"G", "PG", "M", "MA15+", "R18+", "X18+"},
+}
+
+// MovieExternalIdFormats holds the formats of the ids of each external source.
+var MovieExternalIdFormats = map[string]*regexp.Regexp{
+	// IMDb title ids, such as "tt0111161"
+	models.ExternalIdSourceImdb: regexp.MustCompile(`^tt[0-9]{7,10}$`),
+	// The Movie Database ids, such as "278"
+	models.ExternalIdSourceTmdb: regexp.MustCompile(`^[1-9][0-9]{0,9}$`),
+	// Wikidata item ids, such as "Q172241"
+	models.ExternalIdSourceWikidata: regexp.MustCompile(`^Q[1-9][0-9]*$`),
 }
 
 const (
+	MovieFieldId      = "id"
 	MovieFieldTitle   = "title"
 	MovieFieldYear    = "year"
 	MovieFieldRuntime = "runtime"
 	MovieFieldGenres  = "genres"
+	MovieFieldVersion = "version"
+
+	MovieFieldOriginalTitle    = "original_title"
+	MovieFieldOriginalLanguage = "original_language"
+	MovieFieldCountries        = "countries"
+	MovieFieldReleaseDates     = "release_dates"
+	MovieFieldCertifications   = "certifications"
+	MovieFieldSynopsis         = "synopsis"
+	MovieFieldTagline          = "tagline"
+	MovieFieldExternalIds      = "external_ids"
+	MovieFieldPoster           = "poster"
+	MovieFieldBackdrop         = "backdrop"
 )
 
+// MovieFields holds the JSON names of the movie fields which can be
+// selected with a sparse fieldset.
+var MovieFields = []string{
+	MovieFieldId,
+	MovieFieldTitle,
+	MovieFieldYear,
+	MovieFieldRuntime,
+	MovieFieldGenres,
+	MovieFieldOriginalTitle,
+	MovieFieldOriginalLanguage,
+	MovieFieldCountries,
+	MovieFieldReleaseDates,
+	MovieFieldCertifications,
+	MovieFieldSynopsis,
+	MovieFieldTagline,
+	MovieFieldExternalIds,
+	MovieFieldPoster,
+	MovieFieldBackdrop,
+	MovieFieldVersion,
+}
+
+// MovieIncludes holds the names of the relations which can be embedded in a movie response.
+var MovieIncludes = []string{MovieIncludeCollections}
+
+// MovieIncludeCollections embeds the collections a movie belongs to.
+const MovieIncludeCollections = "collections"
+
 const (
 	MovieFilterSortId      = "id"
 	MovieFilterSortTitle   = "title"
 	MovieFilterSortYear    = "year"
 	MovieFilterSortRuntime = "runtime"
+	MovieFilterSortDeleted = "deleted_at"
 )
 
-// ToModel creates a movie model from a request with all fields being non-nil.
+// ToModel creates a movie model from a request with the title, year, runtime and genres being non-nil.
 // An error occurs if a nil field is encountered.
-// This should only be used when all fields are required in the validation.
+// This should only be used when those fields are required in the validation.
+// The optional metadata fields are left empty if nil.
 func (request *MovieRequest) ToModel() models.Movie {
-	return models.Movie{
+	movie := models.Movie{
 		Title:   *request.Title,
 		Year:    *request.Year,
 		Runtime: *request.Runtime,
 		Genres:  request.Genres,
 	}
+	request.UpdateModel(&movie)
+	return movie
+}
+
+// NewMovieRequest creates a request with all fields set from the movie model,
+// with empty lists and maps in place of nil metadata lists and maps.
+// It serves as the document to which patches are applied.
+func NewMovieRequest(movie models.Movie) *MovieRequest {
+	movieRequest := &MovieRequest{
+		Title:            &movie.Title,
+		Year:             &movie.Year,
+		Runtime:          &movie.Runtime,
+		Genres:           movie.Genres,
+		OriginalTitle:    &movie.OriginalTitle,
+		OriginalLanguage: &movie.OriginalLanguage,
+		Countries:        append([]string{}, movie.Countries...),
+		ReleaseDates:     []ReleaseDateRequest{},
+		Certifications:   []CertificationRequest{},
+		Synopsis:         &movie.Synopsis,
+		Tagline:          &movie.Tagline,
+		ExternalIds:      map[string]string{},
+	}
+
+	for _, date := range movie.ReleaseDates {
+		movieRequest.ReleaseDates = append(movieRequest.ReleaseDates, ReleaseDateRequest{
+			Country: date.Country,
+			Date:    date.Date.String(),
+		})
+	}
+	for _, certification := range movie.Certifications {
+		movieRequest.Certifications = append(movieRequest.Certifications, CertificationRequest(certification))
+	}
+	for source, id := range movie.ExternalIds {
+		movieRequest.ExternalIds[source] = id
+	}
+
+	return movieRequest
 }
 
 // UpdateModel maps the request to an already existing movie model,
@@ -57,6 +188,51 @@ func (request *MovieRequest) UpdateModel(model *models.Movie) {
 	if request.Genres != nil {
 		model.Genres = request.Genres
 	}
+	if request.OriginalTitle != nil {
+		model.OriginalTitle = strings.TrimSpace(*request.OriginalTitle)
+	}
+	if request.OriginalLanguage != nil {
+		model.OriginalLanguage = *request.OriginalLanguage
+	}
+	if request.Countries != nil {
+		model.Countries = request.Countries
+	}
+	if request.ReleaseDates != nil {
+		model.ReleaseDates = []models.ReleaseDate{}
+		for _, date := range request.ReleaseDates {
+			// the date is valid after validation
+			parsed, _ := types.ParseDate(date.Date)
+			model.ReleaseDates = append(model.ReleaseDates, models.ReleaseDate{Country: date.Country, Date: parsed})
+		}
+	}
+	if request.Certifications != nil {
+		model.Certifications = []models.Certification{}
+		for _, certification := range request.Certifications {
+			model.Certifications = append(model.Certifications, models.Certification(certification))
+		}
+	}
+	if request.Synopsis != nil {
+		model.Synopsis = strings.TrimSpace(*request.Synopsis)
+	}
+	if request.Tagline != nil {
+		model.Tagline = strings.TrimSpace(*request.Tagline)
+	}
+	if request.ExternalIds != nil {
+		model.ExternalIds = request.ExternalIds
+	}
+}
+
+// CanonicaliseGenres replaces the genres of the request with the names of the valid genres
+// sharing their slugs, so variants like "sci-fi" and "Sci Fi" are accepted for the genre "Sci-Fi".
+// Unknown genres are left unchanged to be reported by Validate.
+func (request *MovieRequest) CanonicaliseGenres() {
+	request.Genres = canonicaliseGenres(request.Genres, request.ValidGenres)
+}
+
+// SetValidGenres sets the known genres of the request and canonicalises its genres.
+func (request *MovieRequest) SetValidGenres(validGenres []string) {
+	request.ValidGenres = validGenres
+	request.CanonicaliseGenres()
 }
 
 func (request *MovieRequest) Validate(required []string) *validator.Validator {
@@ -93,10 +269,102 @@ func (request *MovieRequest) Validate(required []string) *validator.Validator {
 	}
 
 	if request.Genres != nil {
-		v.Check(len(request.Genres) >= 1, MovieFieldGenres, "must have at least 1 genre")
-		v.Check(rules.NotBlank(request.Genres), MovieFieldGenres, "must not have any blank genres")
-		v.Check(rules.Unique(request.Genres), MovieFieldGenres, "must have unique genres")
+		checkGenres(v, MovieFieldGenres, request.Genres, request.ValidGenres)
+	}
+
+	if request.OriginalTitle != nil {
+		v.Check(utf8.RuneCountInString(*request.OriginalTitle) <= 500, MovieFieldOriginalTitle, "must not have more than 500 characters")
+	}
+
+	if request.OriginalLanguage != nil && *request.OriginalLanguage != "" {
+		v.Check(rules.LanguageCode(*request.OriginalLanguage), MovieFieldOriginalLanguage, "must be an ISO 639-1 language code")
+	}
+
+	if request.Countries != nil {
+		for _, country := range request.Countries {
+			if !rules.CountryCode(country) {
+				v.AddError(MovieFieldCountries, fmt.Sprintf("invalid ISO 3166-1 alpha-2 country code %q", country))
+				break
+			}
+		}
+		v.Check(rules.Unique(request.Countries), MovieFieldCountries, "must have unique countries")
+	}
+
+	if request.ReleaseDates != nil {
+		countries := []string{}
+		for _, date := range request.ReleaseDates {
+			countries = append(countries, date.Country)
+			if !rules.CountryCode(date.Country) {
+				v.AddError(MovieFieldReleaseDates, fmt.Sprintf("invalid ISO 3166-1 alpha-2 country code %q", date.Country))
+				break
+			}
+			parsed, err := types.ParseDate(date.Date)
+			if err != nil {
+				v.AddError(MovieFieldReleaseDates, fmt.Sprintf("invalid date %q, must be in the format YYYY-MM-DD", date.Date))
+				break
+			}
+			if parsed.Year() < 1888 {
+				v.AddError(MovieFieldReleaseDates, "must not be before 1888")
+				break
+			}
+		}
+		v.Check(rules.Unique(countries), MovieFieldReleaseDates, "must have one release date per country")
+	}
+
+	if request.Certifications != nil {
+		countries := []string{}
+		for _, certification := range request.Certifications {
+			countries = append(countries, certification.Country)
+			if !rules.CountryCode(certification.Country) {
+				v.AddError(MovieFieldCertifications, fmt.Sprintf("invalid ISO 3166-1 alpha-2 country code %q", certification.Country))
+				break
+			}
+			if !validCertificationRating(certification.Country, certification.Rating) {
+				v.AddError(MovieFieldCertifications, fmt.Sprintf("invalid rating %q for country %q", certification.Rating, certification.Country))
+				break
+			}
+		}
+		v.Check(rules.Unique(countries), MovieFieldCertifications, "must have one certification per country")
+	}
+
+	if request.Synopsis != nil {
+		v.Check(utf8.RuneCountInString(*request.Synopsis) <= 5000, MovieFieldSynopsis, "must not have more than 5000 characters")
+	}
+
+	if request.Tagline != nil {
+		v.Check(utf8.RuneCountInString(*request.Tagline) <= 300, MovieFieldTagline, "must not have more than 300 characters")
+	}
+
+	// check the sources in a fixed order, so the same error is reported for the same request
+	for _, source := range sortedKeys(request.ExternalIds) {
+		id := request.ExternalIds[source]
+		format, exists := MovieExternalIdFormats[source]
+		switch {
+		case !exists:
+			v.AddError(MovieFieldExternalIds, fmt.Sprintf("unknown source %q", source))
+		case !format.MatchString(id):
+			v.AddError(MovieFieldExternalIds, fmt.Sprintf("invalid %s id %q", source, id))
+		}
 	}
 
 	return v
 }
+
+// validCertificationRating returns true if the rating belongs to the certification system of the country,
+// or is a non-blank rating of at most 10 characters for countries without a listed system.
+func validCertificationRating(country string, rating string) bool {
+	if ratings, exists := MovieCertificationRatings[country]; exists {
+		return rules.In(rating, ratings)
+	}
+	return strings.TrimSpace(rating) != "" && utf8.RuneCountInString(rating) <= 10
+}
+
+// sortedKeys returns the keys of the map in ascending order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,64 @@
+package request
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"strings"
+	"unicode/utf8"
+)
+
+type MovieTranslationRequest struct {
+	Title    *string `json:"title"`
+	Synopsis *string `json:"synopsis"`
+	Tagline  *string `json:"tagline"`
+}
+
+const (
+	MovieTranslationFieldLanguage = "language"
+	MovieTranslationFieldTitle    = "title"
+	MovieTranslationFieldSynopsis = "synopsis"
+	MovieTranslationFieldTagline  = "tagline"
+)
+
+// ToModel creates a translation model of the movie in the language from a request with a non-nil title,
+// with its fields trimmed.
+func (request *MovieTranslationRequest) ToModel(movieId int, language string) models.MovieTranslation {
+	translation := models.MovieTranslation{
+		MovieId:  movieId,
+		Language: language,
+		Title:    strings.TrimSpace(*request.Title),
+	}
+	if request.Synopsis != nil {
+		translation.Synopsis = strings.TrimSpace(*request.Synopsis)
+	}
+	if request.Tagline != nil {
+		translation.Tagline = strings.TrimSpace(*request.Tagline)
+	}
+	return translation
+}
+
+func (request *MovieTranslationRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("translation")
+
+	for _, field := range required {
+		switch field {
+		case MovieTranslationFieldTitle:
+			v.Check(request.Title != nil, MovieTranslationFieldTitle, "must be provided")
+		}
+	}
+
+	if request.Title != nil {
+		v.Check(strings.TrimSpace(*request.Title) != "", MovieTranslationFieldTitle, "must not be blank")
+		v.Check(utf8.RuneCountInString(*request.Title) <= 500, MovieTranslationFieldTitle, "must not have more than 500 characters")
+	}
+
+	if request.Synopsis != nil {
+		v.Check(utf8.RuneCountInString(*request.Synopsis) <= 5000, MovieTranslationFieldSynopsis, "must not have more than 5000 characters")
+	}
+
+	if request.Tagline != nil {
+		v.Check(utf8.RuneCountInString(*request.Tagline) <= 300, MovieTranslationFieldTagline, "must not have more than 300 characters")
+	}
+
+	return v
+}
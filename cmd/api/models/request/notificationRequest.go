@@ -0,0 +1,46 @@
+package request
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/validator"
+)
+
+// NotificationPreferencesRequest holds the categories of non-transactional emails to opt into or out of,
+// with omitted categories left unchanged.
+type NotificationPreferencesRequest struct {
+	SecurityAlerts    *bool `json:"security_alerts"`
+	ProductUpdates    *bool `json:"product_updates"`
+	SavedSearchAlerts *bool `json:"saved_search_alerts"`
+}
+
+const NotificationField = "notifications"
+
+func (request *NotificationPreferencesRequest) Validate(_ []string) *validator.Validator {
+	v := validator.New(NotificationField)
+
+	v.Check(
+		request.SecurityAlerts != nil || request.ProductUpdates != nil || request.SavedSearchAlerts != nil,
+		NotificationField,
+		"must change at least 1 category",
+	)
+
+	return v
+}
+
+// Apply sets the provided categories in the preferences.
+func (request *NotificationPreferencesRequest) Apply(preferences *models.NotificationPreferences) {
+	for category, enabled := range map[string]*bool{
+		models.NotificationSecurityAlerts:    request.SecurityAlerts,
+		models.NotificationProductUpdates:    request.ProductUpdates,
+		models.NotificationSavedSearchAlerts: request.SavedSearchAlerts,
+	} {
+		if enabled != nil {
+			preferences.Set(category, *enabled)
+		}
+	}
+}
+
+const (
+	UnsubscribeField      = "unsubscribe"
+	UnsubscribeFieldToken = "token"
+)
@@ -0,0 +1,39 @@
+package request
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"strings"
+)
+
+// SearchQuery holds the queries for searching movies and series together.
+// Empty queries match all movies outside the trash and all series.
+type SearchQuery struct {
+	// Title supports partial searching.
+	Title string
+
+	// Type restricts the results to either movies or series.
+	Type string
+
+	// Genres match results which have all of them.
+	Genres []string
+}
+
+const (
+	SearchQueryFieldTitle  = "title"
+	SearchQueryFieldType   = "type"
+	SearchQueryFieldGenres = "genres"
+)
+
+const (
+	SearchFilterSortTitle = "title"
+	SearchFilterSortYear  = "year"
+)
+
+// Validate adds the errors of the queries to the validator of the filters they're used with.
+func (query SearchQuery) Validate(v *validator.Validator) {
+	if query.Type != "" {
+		v.Check(rules.In(query.Type, models.SearchTypes), SearchQueryFieldType, "must be one of "+strings.Join(models.SearchTypes, ", "))
+	}
+}
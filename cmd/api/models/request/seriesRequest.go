@@ -0,0 +1,260 @@
+package request
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/types"
+	"github.com/rhodeon/moviescreen/internal/validator"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type SeriesRequest struct {
+	Title     *string `json:"title"`
+	StartYear *int    `json:"start_year"`
+
+	// EndYear is 0 for series which are still running.
+	EndYear  *int     `json:"end_year"`
+	Genres   []string `json:"genres"`
+	Synopsis *string  `json:"synopsis"`
+
+	// ValidGenres holds the names of the known genres, which the genres of the request must be amongst.
+	// The genres aren't checked if it's nil.
+	ValidGenres []string `json:"-"`
+}
+
+const (
+	SeriesFieldTitle     = "title"
+	SeriesFieldStartYear = "start_year"
+	SeriesFieldEndYear   = "end_year"
+	SeriesFieldGenres    = "genres"
+	SeriesFieldSynopsis  = "synopsis"
+)
+
+// SeriesFirstYear is the earliest start year of a series, when regular television broadcasts started.
+const SeriesFirstYear = 1928
+
+const (
+	SeriesFilterSortId        = "id"
+	SeriesFilterSortTitle     = "title"
+	SeriesFilterSortStartYear = "start_year"
+)
+
+// ToModel creates a series model from a request with the title, start year and genres being non-nil.
+// This should only be used when those fields are required in the validation.
+func (request *SeriesRequest) ToModel() models.Series {
+	series := models.Series{}
+	request.UpdateModel(&series)
+	return series
+}
+
+// UpdateModel maps the request to an already existing series model,
+// replacing with the non-nil request values.
+func (request *SeriesRequest) UpdateModel(model *models.Series) {
+	if request.Title != nil {
+		model.Title = strings.TrimSpace(*request.Title)
+	}
+	if request.StartYear != nil {
+		model.StartYear = *request.StartYear
+	}
+	if request.EndYear != nil {
+		model.EndYear = *request.EndYear
+	}
+	if request.Genres != nil {
+		model.Genres = request.Genres
+	}
+	if request.Synopsis != nil {
+		model.Synopsis = strings.TrimSpace(*request.Synopsis)
+	}
+}
+
+// SetValidGenres sets the known genres of the request and canonicalises its genres.
+func (request *SeriesRequest) SetValidGenres(validGenres []string) {
+	request.ValidGenres = validGenres
+	request.Genres = canonicaliseGenres(request.Genres, validGenres)
+}
+
+func (request *SeriesRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("series")
+
+	for _, field := range required {
+		switch field {
+		case SeriesFieldTitle:
+			v.Check(request.Title != nil, SeriesFieldTitle, "must be provided")
+
+		case SeriesFieldStartYear:
+			v.Check(request.StartYear != nil, SeriesFieldStartYear, "must be provided")
+
+		case SeriesFieldGenres:
+			v.Check(request.Genres != nil, SeriesFieldGenres, "must be provided")
+		}
+	}
+
+	if request.Title != nil {
+		v.Check(strings.TrimSpace(*request.Title) != "", SeriesFieldTitle, "must not be blank")
+		v.Check(utf8.RuneCountInString(*request.Title) <= 500, SeriesFieldTitle, "must not have more than 500 characters")
+	}
+
+	if request.StartYear != nil {
+		v.Check(*request.StartYear >= SeriesFirstYear, SeriesFieldStartYear, "must not be before 1928")
+		v.Check(*request.StartYear <= time.Now().Year(), SeriesFieldStartYear, "must not be in the future")
+	}
+
+	// the order of the years is checked against the series by CheckYears if either is missing
+	if request.EndYear != nil && *request.EndYear != 0 {
+		v.Check(*request.EndYear <= time.Now().Year(), SeriesFieldEndYear, "must not be in the future")
+		if request.StartYear != nil {
+			v.Check(*request.EndYear >= *request.StartYear, SeriesFieldEndYear, "must not be before the start year")
+		}
+	}
+
+	if request.Genres != nil {
+		checkGenres(v, SeriesFieldGenres, request.Genres, request.ValidGenres)
+	}
+
+	if request.Synopsis != nil {
+		v.Check(utf8.RuneCountInString(*request.Synopsis) <= 5000, SeriesFieldSynopsis, "must not have more than 5000 characters")
+	}
+
+	return v
+}
+
+// CheckYears validates that the end year of the series with the request applied isn't before its start year.
+// It covers partial updates, which may only change one of the years.
+func (request *SeriesRequest) CheckYears(series models.Series) *validator.Validator {
+	v := validator.New("series")
+	request.UpdateModel(&series)
+	v.Check(series.EndYear == 0 || series.EndYear >= series.StartYear, SeriesFieldEndYear, "must not be before the start year")
+	return v
+}
+
+// SeasonRequest holds the details of a season, whose series and number are given by the path.
+type SeasonRequest struct {
+	Title   *string `json:"title"`
+	AirDate *string `json:"air_date"`
+}
+
+const (
+	SeasonFieldTitle   = "title"
+	SeasonFieldAirDate = "air_date"
+)
+
+// ToModel creates a season model of the series with the given number from the request,
+// leaving missing fields empty.
+func (request *SeasonRequest) ToModel(seriesId int, number int) models.Season {
+	season := models.Season{
+		SeriesId: seriesId,
+		Number:   number,
+		AirDate:  parseAirDate(request.AirDate),
+	}
+	if request.Title != nil {
+		season.Title = strings.TrimSpace(*request.Title)
+	}
+	return season
+}
+
+func (request *SeasonRequest) Validate(_ []string) *validator.Validator {
+	v := validator.New("season")
+
+	if request.Title != nil {
+		v.Check(utf8.RuneCountInString(*request.Title) <= 500, SeasonFieldTitle, "must not have more than 500 characters")
+	}
+
+	checkAirDate(v, SeasonFieldAirDate, request.AirDate)
+	return v
+}
+
+// EpisodeRequest holds the details of an episode, whose season and number are given by the path.
+type EpisodeRequest struct {
+	Title    *string `json:"title"`
+	Runtime  *int    `json:"runtime"`
+	AirDate  *string `json:"air_date"`
+	Synopsis *string `json:"synopsis"`
+}
+
+const (
+	EpisodeFieldTitle    = "title"
+	EpisodeFieldRuntime  = "runtime"
+	EpisodeFieldAirDate  = "air_date"
+	EpisodeFieldSynopsis = "synopsis"
+)
+
+// ToModel creates an episode model of the season with the given number from a request
+// with the title and runtime being non-nil, leaving the other missing fields empty.
+func (request *EpisodeRequest) ToModel(seasonNumber int, number int) models.Episode {
+	episode := models.Episode{
+		SeasonNumber: seasonNumber,
+		Number:       number,
+		Title:        strings.TrimSpace(*request.Title),
+		Runtime:      *request.Runtime,
+		AirDate:      parseAirDate(request.AirDate),
+	}
+	if request.Synopsis != nil {
+		episode.Synopsis = strings.TrimSpace(*request.Synopsis)
+	}
+	return episode
+}
+
+func (request *EpisodeRequest) Validate(required []string) *validator.Validator {
+	v := validator.New("episode")
+
+	for _, field := range required {
+		switch field {
+		case EpisodeFieldTitle:
+			v.Check(request.Title != nil, EpisodeFieldTitle, "must be provided")
+
+		case EpisodeFieldRuntime:
+			v.Check(request.Runtime != nil, EpisodeFieldRuntime, "must be provided")
+		}
+	}
+
+	if request.Title != nil {
+		v.Check(strings.TrimSpace(*request.Title) != "", EpisodeFieldTitle, "must not be blank")
+		v.Check(utf8.RuneCountInString(*request.Title) <= 500, EpisodeFieldTitle, "must not have more than 500 characters")
+	}
+
+	if request.Runtime != nil {
+		v.Check(*request.Runtime > 0, EpisodeFieldRuntime, "must be a positive integer")
+	}
+
+	checkAirDate(v, EpisodeFieldAirDate, request.AirDate)
+
+	if request.Synopsis != nil {
+		v.Check(utf8.RuneCountInString(*request.Synopsis) <= 5000, EpisodeFieldSynopsis, "must not have more than 5000 characters")
+	}
+
+	return v
+}
+
+// checkAirDate adds an error to the field if the air date is given in a format other than YYYY-MM-DD,
+// or is before the first television broadcasts.
+func checkAirDate(v *validator.Validator, field string, airDate *string) {
+	if airDate == nil {
+		return
+	}
+	parsed, err := types.ParseDate(*airDate)
+	if err != nil {
+		v.AddError(field, "must be a date in the format YYYY-MM-DD")
+		return
+	}
+	v.Check(parsed.Year() >= SeriesFirstYear, field, "must not be before 1928")
+}
+
+// parseAirDate returns the validated air date, and nil if there is none.
+func parseAirDate(airDate *string) *types.Date {
+	if airDate == nil {
+		return nil
+	}
+	parsed, _ := types.ParseDate(*airDate)
+	return &parsed
+}
+
+// SeriesQuery holds the search queries for listing series.
+// Empty queries match all series.
+type SeriesQuery struct {
+	// Title supports partial searching.
+	Title string
+
+	// Genres match series which have all of them.
+	Genres []string
+}
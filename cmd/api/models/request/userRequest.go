@@ -14,6 +14,7 @@ type UserRequest struct {
 	Email    *string `json:"email"`
 	Password *string `json:"password"`
 	Token    *string `json:"token"`
+	Language *string `json:"language"`
 }
 
 const (
@@ -22,6 +23,7 @@ const (
 	UserFieldEmail    = "email"
 	UserFieldPassword = "password"
 	UserFieldToken    = "token"
+	UserFieldLanguage = "language"
 )
 
 func (request *UserRequest) ToModel() (models.User, error) {
@@ -32,10 +34,17 @@ func (request *UserRequest) ToModel() (models.User, error) {
 		return models.User{}, err
 	}
 
+	// users who don't choose a language get emails in the default one
+	language := models.DefaultUserLanguage
+	if request.Language != nil {
+		language = *request.Language
+	}
+
 	return models.User{
 		Username: *request.Username,
 		Email:    *request.Email,
 		Password: *password,
+		Language: language,
 	}, nil
 }
 
@@ -75,6 +84,10 @@ func (request UserRequest) Validate(required []string) *validator.Validator {
 		v.Check(utf8.RuneCountInString(*request.Password) <= 72, UserFieldPassword, "must not have more than 72 characters")
 	}
 
+	if request.Language != nil {
+		v.Check(rules.LanguageCode(*request.Language), UserFieldLanguage, "must be an ISO 639-1 language code")
+	}
+
 	if request.Token != nil {
 		if utf8.RuneCountInString(*request.Token) != 26 {
 			v.AddError(UserFieldToken, "must have exactly 26 characters")
@@ -13,11 +13,11 @@ type BaseResponse struct {
 	Metadata *Metadata `json:"metadata,omitempty"`
 
 	// Data is the data of a success response.
-	// It is mutually exclusive to the Error.
+	// It is mutually exclusive to the Error, except in a PartialResponse.
 	Data SuccessData `json:"data,omitempty"`
 
 	// Error is the data of an error response.
-	// It is mutually exclusive to the Data.
+	// It is mutually exclusive to the Data, except in a PartialResponse.
 	Error *Error `json:"error,omitempty"`
 }
 
@@ -42,3 +42,14 @@ func SuccessResponse(code int, data SuccessData) BaseResponse {
 }
 
 type SuccessData any
+
+// PartialResponse is a constructor for an error response to a request which was partly carried out before the error,
+// such as an import stopped midway, with the data reporting the completed part.
+func PartialResponse(code int, data SuccessData, error Error) BaseResponse {
+	return BaseResponse{
+		Success: false,
+		Status:  code,
+		Data:    data,
+		Error:   &error,
+	}
+}
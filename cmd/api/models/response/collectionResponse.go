@@ -0,0 +1,35 @@
+package response
+
+import "time"
+
+type CollectionResponse struct {
+	Id          int    `json:"id"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+
+	// UserId is the id of the owner of a list, and nil for franchises.
+	UserId *int `json:"user_id"`
+
+	MovieCount int `json:"movie_count"`
+
+	// Entries is only set for a single collection.
+	Entries []CollectionEntryResponse `json:"entries,omitempty"`
+
+	Created time.Time `json:"created_at"`
+	Updated time.Time `json:"updated_at"`
+}
+
+type CollectionEntryResponse struct {
+	Position int           `json:"position"`
+	Movie    MovieResponse `json:"movie"`
+}
+
+// MovieCollectionResponse is a collection a movie belongs to, embedded in the movie's response.
+type MovieCollectionResponse struct {
+	Id       int    `json:"id"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
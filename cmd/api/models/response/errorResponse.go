@@ -99,13 +99,8 @@ func BadRequestError(err error) BaseResponse {
 func UnprocessableEntityError(v *validator.Validator) BaseResponse {
 	// extract the first error of each validation field
 	// to display in the error response
-	firstErrors := map[string]string{}
-	for field, errs := range v.Errors {
-		firstErrors[field] = errs[0]
-	}
-
 	return ErrorResponse(
 		http.StatusUnprocessableEntity,
-		NewError(v.Type, firstErrors),
+		NewError(v.Type, v.FirstErrors()),
 	)
 }
@@ -0,0 +1,8 @@
+package response
+
+type GenreResponse struct {
+	Id         int    `json:"id"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	MovieCount int    `json:"movie_count"`
+}
@@ -0,0 +1,61 @@
+package response
+
+// MovieImportResponse reports the outcome of a bulk movie import.
+type MovieImportResponse struct {
+	// DryRun is true if the records were only validated without being imported.
+	DryRun bool `json:"dry_run"`
+
+	// Total is the number of records read.
+	Total int `json:"total"`
+
+	// Valid is the number of records which passed validation.
+	Valid int `json:"valid"`
+
+	// Invalid is the number of records which were malformed or failed validation, and were skipped.
+	Invalid int `json:"invalid"`
+
+	// Imported is the number of movies created from the valid records.
+	Imported int `json:"imported"`
+
+	// Failed is the number of valid records which weren't imported, as their batch failed to be inserted.
+	Failed int `json:"failed"`
+
+	// Errors of the invalid and failed records, limited to the first MaxMovieImportErrors.
+	Errors []MovieImportError `json:"errors"`
+
+	// StoppedAt is the row at which an error stopped the import, with none of the records from it onward imported.
+	// It is 0 if the whole body was read.
+	StoppedAt int `json:"stopped_at,omitempty"`
+}
+
+// MovieImportError holds the errors of an invalid record.
+type MovieImportError struct {
+	// Row is the 1-based position of the record in the import, excluding any header.
+	Row int `json:"row"`
+
+	// Errors maps each invalid field to its first error.
+	Errors map[string]string `json:"errors"`
+}
+
+// MaxMovieImportErrors is the maximum number of record errors included in an import report.
+const MaxMovieImportErrors = 100
+
+// AddError counts the record as invalid, and adds its errors to the report
+// if the error limit hasn't been reached.
+func (r *MovieImportResponse) AddError(row int, errors map[string]string) {
+	r.Invalid++
+	if len(r.Errors) < MaxMovieImportErrors {
+		r.Errors = append(r.Errors, MovieImportError{Row: row, Errors: errors})
+	}
+}
+
+// AddFailure counts the valid records of a batch which failed to be inserted as failed,
+// and adds the error to the report for each of their rows if the error limit hasn't been reached.
+func (r *MovieImportResponse) AddFailure(rows []int, errors map[string]string) {
+	r.Failed += len(rows)
+	for _, row := range rows {
+		if len(r.Errors) < MaxMovieImportErrors {
+			r.Errors = append(r.Errors, MovieImportError{Row: row, Errors: errors})
+		}
+	}
+}
@@ -1,10 +1,71 @@
 package response
 
+import "time"
+
 type MovieResponse struct {
 	Id      int      `json:"id,omitempty"`
 	Title   string   `json:"title,omitempty"`
 	Year    int      `json:"year,omitempty"`
 	Runtime int      `json:"runtime,omitempty"`
 	Genres  []string `json:"genres,omitempty"`
-	Version int      `json:"version,omitempty"`
+
+	OriginalTitle    string                  `json:"original_title,omitempty"`
+	OriginalLanguage string                  `json:"original_language,omitempty"`
+	Countries        []string                `json:"countries,omitempty"`
+	ReleaseDates     []ReleaseDateResponse   `json:"release_dates,omitempty"`
+	Certifications   []CertificationResponse `json:"certifications,omitempty"`
+	Synopsis         string                  `json:"synopsis,omitempty"`
+	Tagline          string                  `json:"tagline,omitempty"`
+	ExternalIds      map[string]string       `json:"external_ids,omitempty"`
+	Poster           *MovieImageResponse     `json:"poster,omitempty"`
+	Backdrop         *MovieImageResponse     `json:"backdrop,omitempty"`
+
+	// Translation is the language of the translated title, synopsis and tagline, if any.
+	Translation string `json:"translation,omitempty"`
+
+	// Collections is only set if the movie's collections are embedded.
+	Collections []MovieCollectionResponse `json:"collections,omitempty"`
+
+	Version int `json:"version,omitempty"`
+
+	// Deleted is only set for movies in the trash.
+	Deleted *time.Time `json:"deleted_at,omitempty"`
+}
+
+type ReleaseDateResponse struct {
+	Country string `json:"country"`
+	Date    string `json:"date"`
+}
+
+type CertificationResponse struct {
+	Country string `json:"country"`
+	Rating  string `json:"rating"`
+}
+
+type MovieImageResponse struct {
+	Url         string                   `json:"url"`
+	ContentType string                   `json:"content_type"`
+	Width       int                      `json:"width"`
+	Height      int                      `json:"height"`
+	Thumbnails  []MovieThumbnailResponse `json:"thumbnails"`
+}
+
+type MovieThumbnailResponse struct {
+	Size   string `json:"size"`
+	Url    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// SimilarMovieResponse is a movie recommended as similar to another.
+type SimilarMovieResponse struct {
+	Score float64       `json:"score"`
+	Movie MovieResponse `json:"movie"`
+}
+
+// DuplicateCandidateResponse is a pair of movies which are likely to be duplicates of each other.
+type DuplicateCandidateResponse struct {
+	Reason     string          `json:"reason"`
+	Similarity float64         `json:"similarity"`
+	Movies     []MovieResponse `json:"movies"`
 }
@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+type MovieRevisionResponse struct {
+	Version int           `json:"version"`
+	Action  string        `json:"action"`
+	Movie   MovieResponse `json:"movie"`
+
+	// Changes made by the revision's action, which is omitted for actions without changes to the data.
+	Changes map[string]MovieChangeResponse `json:"changes,omitempty"`
+
+	// UserId is omitted if the acting user is unknown.
+	UserId  int       `json:"user_id,omitempty"`
+	Created time.Time `json:"created_at"`
+}
+
+type MovieChangeResponse struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// MovieDiffResponse holds the changes of a movie between two versions.
+type MovieDiffResponse struct {
+	From    int                            `json:"from"`
+	To      int                            `json:"to"`
+	Changes map[string]MovieChangeResponse `json:"changes"`
+}
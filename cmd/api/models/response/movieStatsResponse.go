@@ -0,0 +1,43 @@
+package response
+
+import "time"
+
+type MovieStatsResponse struct {
+	Total   int                   `json:"total"`
+	Genres  []GenreCountResponse  `json:"genres"`
+	Years   []YearCountResponse   `json:"years"`
+	Decades []DecadeCountResponse `json:"decades"`
+	Runtime RuntimeStatsResponse  `json:"runtime"`
+	Recent  []MovieResponse       `json:"recent"`
+
+	// Generated is the time the statistics were computed, which is earlier than the request if they are cached.
+	Generated time.Time `json:"generated_at"`
+}
+
+type GenreCountResponse struct {
+	Genre string `json:"genre"`
+	Count int    `json:"count"`
+}
+
+type YearCountResponse struct {
+	Year  int `json:"year"`
+	Count int `json:"count"`
+}
+
+// DecadeCountResponse is the count of a decade, which is given by its first year.
+type DecadeCountResponse struct {
+	Decade int `json:"decade"`
+	Count  int `json:"count"`
+}
+
+// RuntimeStatsResponse holds the total, mean and percentiles of runtimes in minutes.
+type RuntimeStatsResponse struct {
+	Total  int     `json:"total"`
+	Mean   float64 `json:"mean"`
+	Min    int     `json:"min"`
+	P25    int     `json:"p25"`
+	Median int     `json:"median"`
+	P75    int     `json:"p75"`
+	P90    int     `json:"p90"`
+	Max    int     `json:"max"`
+}
@@ -0,0 +1,11 @@
+package response
+
+import "time"
+
+type MovieTranslationResponse struct {
+	Language string    `json:"language"`
+	Title    string    `json:"title"`
+	Synopsis string    `json:"synopsis,omitempty"`
+	Tagline  string    `json:"tagline,omitempty"`
+	Updated  time.Time `json:"updated_at"`
+}
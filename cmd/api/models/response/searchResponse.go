@@ -0,0 +1,13 @@
+package response
+
+// SearchResultResponse is a movie or series matching a search.
+type SearchResultResponse struct {
+	Type  string `json:"type"`
+	Id    int    `json:"id"`
+	Title string `json:"title"`
+
+	// Year is the release year of a movie, and the start year of a series.
+	Year int `json:"year"`
+
+	Genres []string `json:"genres"`
+}
@@ -0,0 +1,39 @@
+package response
+
+import "time"
+
+type SeriesResponse struct {
+	Id        int    `json:"id"`
+	Title     string `json:"title"`
+	StartYear int    `json:"start_year"`
+
+	// EndYear is nil for series which are still running.
+	EndYear *int `json:"end_year"`
+
+	Genres       []string `json:"genres"`
+	Synopsis     string   `json:"synopsis"`
+	SeasonCount  int      `json:"season_count"`
+	EpisodeCount int      `json:"episode_count"`
+
+	// Seasons is only set for a single series.
+	Seasons []SeasonResponse `json:"seasons,omitempty"`
+
+	Version int       `json:"version"`
+	Created time.Time `json:"created_at"`
+	Updated time.Time `json:"updated_at"`
+}
+
+type SeasonResponse struct {
+	Number   int               `json:"number"`
+	Title    string            `json:"title"`
+	AirDate  *string           `json:"air_date"`
+	Episodes []EpisodeResponse `json:"episodes"`
+}
+
+type EpisodeResponse struct {
+	Number   int     `json:"number"`
+	Title    string  `json:"title"`
+	Runtime  int     `json:"runtime"`
+	AirDate  *string `json:"air_date"`
+	Synopsis string  `json:"synopsis"`
+}
@@ -8,5 +8,27 @@ type UserResponse struct {
 	Email     string    `json:"email,omitempty"`
 	Version   int       `json:"version,omitempty"`
 	Activated bool      `json:"activated,omitempty"`
+	Language  string    `json:"language,omitempty"`
 	Created   time.Time `json:"created,omitempty"`
 }
+
+// AdminUserResponse is the user as seen by administrators,
+// with the suppression of their email address, or null if emails are sent to it.
+type AdminUserResponse struct {
+	UserResponse
+	Suppression *EmailSuppressionResponse `json:"suppression"`
+}
+
+// EmailSuppressionResponse holds why emails are no longer sent to an address, and since when.
+type EmailSuppressionResponse struct {
+	Reason  string    `json:"reason"`
+	Detail  string    `json:"detail"`
+	Created time.Time `json:"created"`
+}
+
+// NotificationPreferencesResponse holds whether the user receives each category of non-transactional emails.
+type NotificationPreferencesResponse struct {
+	SecurityAlerts    bool `json:"security_alerts"`
+	ProductUpdates    bool `json:"product_updates"`
+	SavedSearchAlerts bool `json:"saved_search_alerts"`
+}
@@ -86,3 +86,27 @@ func (e errorHandler) NotPermitted(ctx *gin.Context) {
 		response.GenericError(ErrMessageNotPermitted),
 	)
 }
+
+func (e errorHandler) PreconditionFailed(ctx *gin.Context) {
+	SetStatusAndBody(
+		ctx,
+		http.StatusPreconditionFailed,
+		response.GenericError(ErrMessagePreconditionFailed),
+	)
+}
+
+func (e errorHandler) UnsupportedMediaType(ctx *gin.Context) {
+	SetStatusAndBody(
+		ctx,
+		http.StatusUnsupportedMediaType,
+		response.GenericError(ErrMessageUnsupportedMediaType),
+	)
+}
+
+func (e errorHandler) RequestTooLarge(ctx *gin.Context) {
+	SetStatusAndBody(
+		ctx,
+		http.StatusRequestEntityTooLarge,
+		response.GenericError(ErrMessageRequestTooLarge),
+	)
+}
@@ -11,4 +11,7 @@ const (
 	ErrMessageUnauthenticatedAccess = "you must be authenticated to access this resource"
 	ErrMessageUnactivatedAccess     = "your account must be activated to access this resource"
 	ErrMessageNotPermitted          = "your account doesn't have the necessary permissions to access this resource"
+	ErrMessagePreconditionFailed    = "the resource has been modified since it was last fetched, please fetch it again and retry"
+	ErrMessageUnsupportedMediaType  = "the content type of the request body is not supported"
+	ErrMessageRequestTooLarge       = "request body is too large"
 )
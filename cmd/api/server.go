@@ -7,6 +7,8 @@ import (
 	"github.com/rhodeon/moviescreen/cmd/api/common"
 	"github.com/rhodeon/moviescreen/cmd/api/handlers"
 	"github.com/rhodeon/moviescreen/cmd/api/internal"
+	"github.com/rhodeon/moviescreen/cmd/api/jobs"
+	"github.com/rhodeon/moviescreen/cmd/api/middleware"
 	"github.com/rhodeon/moviescreen/cmd/api/responseErrors"
 	"github.com/rhodeon/prettylog"
 	"net/http"
@@ -20,23 +22,45 @@ import (
 // serveApp starts up a server with the app data.
 func serveApp(app internal.Application, backgroundWaitGroup *sync.WaitGroup) error {
 	routeHandlers := common.RouteHandlers{
-		Error:  responseErrors.NewErrorHandler(),
-		Misc:   handlers.NewMiscHandler(app.Config),
-		Movies: handlers.NewMovieHandler(app.Config, app.Repositories),
-		Users:  handlers.NewUserHandler(app.Config, app.Repositories, backgroundWaitGroup),
+		Error:       responseErrors.NewErrorHandler(),
+		Misc:        handlers.NewMiscHandler(app.Config),
+		Movies:      handlers.NewMovieHandler(app.Config, app.Repositories, app.Storage),
+		Genres:      handlers.NewGenreHandler(app.Repositories),
+		Collections: handlers.NewCollectionHandler(app.Repositories),
+		Series:      handlers.NewSeriesHandler(app.Repositories),
+		Search:      handlers.NewSearchHandler(app.Repositories),
+		Stats:       handlers.NewStatsHandler(app.Config, app.Repositories),
+		Emails:      handlers.NewEmailHandler(app.Config, app.Repositories, app.Mailer),
+		Users:       handlers.NewUserHandler(app.Config, app.Repositories, app.Unsubscriber),
 	}
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", app.Config.Port),
-		Handler:      app.Router(routeHandlers),
+		Handler:      middleware.WithResponseController(app.Router(routeHandlers)),
 		IdleTimeout:  1 * time.Minute,
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
 
+	// start the periodic background jobs
+	scheduler := jobs.NewScheduler(backgroundWaitGroup)
+
+	// emails queued in the outbox are sent by a pool of workers sharing the mailer
+	sendOutbox := jobs.SendOutbox(app.Repositories, app.Mailer, app.Config.Outbox.BatchSize, app.Config.Outbox.MaxAttempts)
+	for i := 1; i <= app.Config.Outbox.Workers; i++ {
+		scheduler.Every(app.Config.Outbox.PollInterval, fmt.Sprintf("send outbox %d", i), sendOutbox)
+	}
+
+	scheduler.Every(app.Config.Trash.PurgeInterval, "purge trash", jobs.PurgeTrash(app.Repositories, app.Storage, app.Config.Trash.Retention, app.Config.Trash.RevisionRetention))
+
+	// similar movies are recommended from precomputed similarities, which are computed on startup as well
+	refreshSimilarities := jobs.RefreshSimilarities(app.Repositories)
+	scheduler.Now("refresh similarities", refreshSimilarities)
+	scheduler.Every(app.Config.Similarities.RefreshInterval, "refresh similarities", refreshSimilarities)
+
 	// start a background goroutine to intercept and handle shutdown events
 	shutdownError := make(chan error)
-	go handleShutdown(srv, shutdownError, backgroundWaitGroup)
+	go handleShutdown(srv, shutdownError, scheduler, backgroundWaitGroup)
 
 	// start and listen on server until an error occurs
 	prettylog.InfoF("starting %s server on %s", app.Config.Env, srv.Addr)
@@ -59,7 +83,7 @@ func serveApp(app internal.Application, backgroundWaitGroup *sync.WaitGroup) err
 // handleShutdown gracefully handles interruption and termination signals,
 // giving ongoing request a 20-second leeway before shutting down the server.
 // It should be run as a background goroutine.
-func handleShutdown(server *http.Server, shutdownErr chan error, backgroundWg *sync.WaitGroup) {
+func handleShutdown(server *http.Server, shutdownErr chan error, scheduler *jobs.Scheduler, backgroundWg *sync.WaitGroup) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
@@ -78,8 +102,10 @@ func handleShutdown(server *http.Server, shutdownErr chan error, backgroundWg *s
 		shutdownErr <- err
 	}
 
-	// wait for background tasks to complete before shutting down the application
+	// stop the periodic jobs and wait for background tasks to complete
+	// before shutting down the application
 	prettylog.Info("completing background tasks")
+	scheduler.Stop()
 	backgroundWg.Wait()
 	shutdownErr <- nil
 }
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"time"
+)
+
+// Kinds of collections.
+const (
+	// CollectionKindFranchise is an official series of movies, which is always public.
+	CollectionKindFranchise = "franchise"
+
+	// CollectionKindList is a list of movies curated by a user.
+	CollectionKindList = "list"
+)
+
+// CollectionKinds holds the kinds of collections.
+var CollectionKinds = []string{CollectionKindFranchise, CollectionKindList}
+
+// Collection is an ordered group of movies, being either a franchise or a user's list.
+type Collection struct {
+	Id          int
+	Kind        string
+	Name        string
+	Description string
+
+	// Public is always true for franchises, while lists are only visible to their owners unless public.
+	Public bool
+
+	// UserId is the id of the owner of a list, and 0 for franchises.
+	UserId int
+
+	// MovieCount is the number of movies outside the trash in the collection.
+	MovieCount int
+
+	// Entries holds the movies outside the trash in the collection ordered by position.
+	// It's only populated when a single collection is fetched.
+	Entries []CollectionEntry
+
+	Created time.Time
+	Updated time.Time
+}
+
+// VisibleTo returns true if the user with the given id can view the collection.
+func (collection Collection) VisibleTo(userId int) bool {
+	return collection.Public || collection.UserId == userId
+}
+
+// EditableBy returns true if the user with the given id and permissions can modify the collection.
+// Lists can only be modified by their owners, and franchises by users who can write movies.
+func (collection Collection) EditableBy(userId int, permissions Permissions) bool {
+	if collection.Kind == CollectionKindFranchise {
+		return permissions.Includes(PermissionMoviesWrite)
+	}
+	return collection.UserId == userId
+}
+
+func (collection Collection) ToResponse() response.CollectionResponse {
+	resp := response.CollectionResponse{
+		Id:          collection.Id,
+		Kind:        collection.Kind,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Public:      collection.Public,
+		MovieCount:  collection.MovieCount,
+		Created:     collection.Created,
+		Updated:     collection.Updated,
+	}
+	if collection.UserId != 0 {
+		resp.UserId = &collection.UserId
+	}
+
+	if collection.Entries != nil {
+		resp.Entries = []response.CollectionEntryResponse{}
+		for _, entry := range collection.Entries {
+			resp.Entries = append(resp.Entries, entry.ToResponse())
+		}
+	}
+	return resp
+}
+
+type Collections []Collection
+
+func (collections Collections) ToResponse() []response.CollectionResponse {
+	collectionsResponse := []response.CollectionResponse{}
+	for _, collection := range collections {
+		collectionsResponse = append(collectionsResponse, collection.ToResponse())
+	}
+	return collectionsResponse
+}
+
+// CollectionEntry is a movie at a position of a collection.
+// Positions start from 1, and the movie only has its id, title, year, runtime and genres populated.
+type CollectionEntry struct {
+	Position int
+	Movie    Movie
+}
+
+func (entry CollectionEntry) ToResponse() response.CollectionEntryResponse {
+	return response.CollectionEntryResponse{
+		Position: entry.Position,
+		Movie:    entry.Movie.ToResponse(),
+	}
+}
+
+// MovieCollection is a collection a movie belongs to, with the position of the movie in it.
+type MovieCollection struct {
+	Id       int
+	Kind     string
+	Name     string
+	Position int
+}
+
+type MovieCollections []MovieCollection
+
+func (collections MovieCollections) ToResponse() []response.MovieCollectionResponse {
+	if collections == nil {
+		return nil
+	}
+
+	collectionsResponse := []response.MovieCollectionResponse{}
+	for _, collection := range collections {
+		collectionsResponse = append(collectionsResponse, response.MovieCollectionResponse{
+			Id:       collection.Id,
+			Kind:     collection.Kind,
+			Name:     collection.Name,
+			Position: collection.Position,
+		})
+	}
+	return collectionsResponse
+}
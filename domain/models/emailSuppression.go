@@ -0,0 +1,41 @@
+package models
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"strings"
+	"time"
+)
+
+// Reasons email addresses are suppressed for.
+const (
+	// SuppressionReasonBounce is given to addresses which emails bounced from permanently,
+	// as reported by the SMTP server or a bounce notification.
+	SuppressionReasonBounce = "bounce"
+
+	// SuppressionReasonComplaint is given to addresses whose recipient marked an email as spam.
+	SuppressionReasonComplaint = "complaint"
+)
+
+// EmailSuppression is an email address which emails are no longer sent to,
+// as sending to it would harm the reputation of the sender.
+type EmailSuppression struct {
+	Email  string
+	Reason string
+	// Detail is the diagnostic of the bounce or complaint the address was suppressed for.
+	Detail  string
+	Created time.Time
+}
+
+// NormalizeEmail returns the email address in the form suppressions are keyed by,
+// as addresses are compared case-insensitively.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (s EmailSuppression) ToResponse() response.EmailSuppressionResponse {
+	return response.EmailSuppressionResponse{
+		Reason:  s.Reason,
+		Detail:  s.Detail,
+		Created: s.Created,
+	}
+}
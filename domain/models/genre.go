@@ -0,0 +1,46 @@
+package models
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"strings"
+)
+
+// Genre is a canonical movie genre. Movies hold the names of their genres,
+// while the slug identifies variants of the same genre, such as "Sci-Fi" and "sci fi".
+type Genre struct {
+	Id   int
+	Slug string
+	Name string
+
+	// MovieCount is the number of movies outside the trash with the genre.
+	MovieCount int
+}
+
+// GenreSlug returns the slug of a genre name, which is the lowercase name
+// with its words joined by hyphens.
+// It matches the slugs generated for existing genres by the genres migration.
+func GenreSlug(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "-")
+}
+
+func (genre Genre) ToResponse() response.GenreResponse {
+	return response.GenreResponse{
+		Id:         genre.Id,
+		Slug:       genre.Slug,
+		Name:       genre.Name,
+		MovieCount: genre.MovieCount,
+	}
+}
+
+type Genres []Genre
+
+func (genres Genres) ToResponse() []response.GenreResponse {
+	genresResponse := []response.GenreResponse{}
+	for _, genre := range genres {
+		genresResponse = append(genresResponse, genre.ToResponse())
+	}
+	return genresResponse
+}
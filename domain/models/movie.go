@@ -2,17 +2,66 @@ package models
 
 import (
 	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/internal/types"
 	"time"
 )
 
+// Sources of external movie ids.
+const (
+	ExternalIdSourceImdb     = "imdb"
+	ExternalIdSourceTmdb     = "tmdb"
+	ExternalIdSourceWikidata = "wikidata"
+)
+
+// ExternalIdSources holds the sources of external movie ids.
+var ExternalIdSources = []string{
+	ExternalIdSourceImdb,
+	ExternalIdSourceTmdb,
+	ExternalIdSourceWikidata,
+}
+
 type Movie struct {
 	Id      int
 	Title   string
 	Year    int
 	Runtime int
 	Genres  []string
+
+	// OriginalTitle is the title in the original language, and empty if it's unknown.
+	OriginalTitle string
+
+	// OriginalLanguage is the ISO 639-1 code of the original language, and empty if it's unknown.
+	OriginalLanguage string
+
+	// Countries holds the ISO 3166-1 alpha-2 codes of the production countries.
+	Countries []string
+
+	ReleaseDates   []ReleaseDate
+	Certifications []Certification
+	Synopsis       string
+	Tagline        string
+
+	// ExternalIds maps the sources in ExternalIdSources to the movie's ids in them.
+	ExternalIds map[string]string
+
+	// Poster and Backdrop are nil if the movie has no such image.
+	Poster   *MovieImage
+	Backdrop *MovieImage
+
+	// Translation is the language of the translation applied to the movie, and empty if there is none.
+	// It isn't stored with the movie.
+	Translation string
+
+	// Collections holds the collections the movie belongs to, and is nil unless they have been fetched.
+	// They aren't stored with the movie.
+	Collections MovieCollections
+
 	Version int
 	Created time.Time
+	Updated time.Time
+
+	// Deleted is the time the movie was moved to the trash, and nil if it hasn't been.
+	Deleted *time.Time
 }
 
 func (movie *Movie) ToResponse() response.MovieResponse {
@@ -24,9 +73,103 @@ func (movie *Movie) ToResponse() response.MovieResponse {
 		// runtime is in minutes
 		Runtime: movie.Runtime,
 
-		Genres:  movie.Genres,
-		Version: movie.Version,
+		Genres:           movie.Genres,
+		OriginalTitle:    movie.OriginalTitle,
+		OriginalLanguage: movie.OriginalLanguage,
+		Countries:        movie.Countries,
+		ReleaseDates:     ReleaseDates(movie.ReleaseDates).ToResponse(),
+		Certifications:   Certifications(movie.Certifications).ToResponse(),
+		Synopsis:         movie.Synopsis,
+		Tagline:          movie.Tagline,
+		ExternalIds:      movie.ExternalIds,
+		Poster:           movie.Poster.ToResponse(),
+		Backdrop:         movie.Backdrop.ToResponse(),
+		Translation:      movie.Translation,
+		Collections:      movie.Collections.ToResponse(),
+		Version:          movie.Version,
+		Deleted:          movie.Deleted,
+	}
+}
+
+// Translate replaces the title, synopsis and tagline of the movie with those of the translation,
+// keeping the original synopsis and tagline where the translation has none.
+func (movie *Movie) Translate(translation MovieTranslation) {
+	movie.Title = translation.Title
+	if translation.Synopsis != "" {
+		movie.Synopsis = translation.Synopsis
+	}
+	if translation.Tagline != "" {
+		movie.Tagline = translation.Tagline
+	}
+	movie.Translation = translation.Language
+}
+
+// Image returns the image of the given kind, and nil if the movie has none or the kind is unknown.
+func (movie *Movie) Image(kind string) *MovieImage {
+	switch kind {
+	case MovieImageKindPoster:
+		return movie.Poster
+	case MovieImageKindBackdrop:
+		return movie.Backdrop
+	}
+	return nil
+}
+
+// SetImage replaces the image of the given kind, with nil removing it.
+func (movie *Movie) SetImage(kind string, image *MovieImage) {
+	switch kind {
+	case MovieImageKindPoster:
+		movie.Poster = image
+	case MovieImageKindBackdrop:
+		movie.Backdrop = image
+	}
+}
+
+// ReleaseDate is the date a movie was released in a country.
+type ReleaseDate struct {
+	Country string     `json:"country"`
+	Date    types.Date `json:"date"`
+}
+
+type ReleaseDates []ReleaseDate
+
+func (dates ReleaseDates) ToResponse() []response.ReleaseDateResponse {
+	if dates == nil {
+		return nil
+	}
+
+	datesResponse := []response.ReleaseDateResponse{}
+	for _, date := range dates {
+		datesResponse = append(datesResponse, response.ReleaseDateResponse{
+			Country: date.Country,
+			Date:    date.Date.String(),
+		})
+	}
+	return datesResponse
+}
+
+// Certification is the age rating of a movie by the certification system of a country,
+// such as the MPAA rating in the United States or the BBFC rating in the United Kingdom.
+type Certification struct {
+	Country string `json:"country"`
+	Rating  string `json:"rating"`
+}
+
+type Certifications []Certification
+
+func (certifications Certifications) ToResponse() []response.CertificationResponse {
+	if certifications == nil {
+		return nil
 	}
+
+	certificationsResponse := []response.CertificationResponse{}
+	for _, certification := range certifications {
+		certificationsResponse = append(certificationsResponse, response.CertificationResponse{
+			Country: certification.Country,
+			Rating:  certification.Rating,
+		})
+	}
+	return certificationsResponse
 }
 
 type Movies []Movie
@@ -38,3 +181,14 @@ func (movies Movies) ToResponse() []response.MovieResponse {
 	}
 	return moviesResponse
 }
+
+// LastModified returns the latest modification time amongst the movies.
+func (movies Movies) LastModified() time.Time {
+	lastModified := time.Time{}
+	for _, movie := range movies {
+		if movie.Updated.After(lastModified) {
+			lastModified = movie.Updated
+		}
+	}
+	return lastModified
+}
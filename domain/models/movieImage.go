@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+)
+
+// Kinds of movie images.
+const (
+	MovieImageKindPoster   = "poster"
+	MovieImageKindBackdrop = "backdrop"
+)
+
+// MovieThumbnailWidths holds the widths of the thumbnails generated for each kind of movie image.
+// Thumbnails are only generated for widths smaller than that of the image.
+var MovieThumbnailWidths = map[string][]int{
+	MovieImageKindPoster:   {185, 342, 780},
+	MovieImageKindBackdrop: {300, 780, 1280},
+}
+
+// MovieImage is an image of a movie kept in blob storage, along with its thumbnails.
+type MovieImage struct {
+	// Key is the storage key of the image as uploaded.
+	Key         string           `json:"key"`
+	Url         string           `json:"url"`
+	ContentType string           `json:"content_type"`
+	Width       int              `json:"width"`
+	Height      int              `json:"height"`
+	Thumbnails  []MovieThumbnail `json:"thumbnails"`
+}
+
+// MovieThumbnail is a JPEG copy of a movie image scaled down to a fixed width.
+type MovieThumbnail struct {
+	// Size names the thumbnail by its width, such as "w185".
+	Size   string `json:"size"`
+	Key    string `json:"key"`
+	Url    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// ThumbnailSize returns the name of the thumbnail size with the given width.
+func ThumbnailSize(width int) string {
+	return fmt.Sprintf("w%d", width)
+}
+
+// Keys returns the storage keys of the image and its thumbnails.
+func (image *MovieImage) Keys() []string {
+	if image == nil {
+		return nil
+	}
+
+	keys := []string{image.Key}
+	for _, thumbnail := range image.Thumbnails {
+		keys = append(keys, thumbnail.Key)
+	}
+	return keys
+}
+
+func (image *MovieImage) ToResponse() *response.MovieImageResponse {
+	if image == nil {
+		return nil
+	}
+
+	thumbnails := []response.MovieThumbnailResponse{}
+	for _, thumbnail := range image.Thumbnails {
+		thumbnails = append(thumbnails, response.MovieThumbnailResponse{
+			Size:   thumbnail.Size,
+			Url:    thumbnail.Url,
+			Width:  thumbnail.Width,
+			Height: thumbnail.Height,
+		})
+	}
+
+	return &response.MovieImageResponse{
+		Url:         image.Url,
+		ContentType: image.ContentType,
+		Width:       image.Width,
+		Height:      image.Height,
+		Thumbnails:  thumbnails,
+	}
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+)
+
+// Reasons movies are reported as duplicate candidates.
+const (
+	// DuplicateReasonTitleYear is given to movies with the same normalised title and year.
+	DuplicateReasonTitleYear = "same_title_year"
+
+	// DuplicateReasonSimilarTitle is given to movies with similar titles and the same runtime.
+	DuplicateReasonSimilarTitle = "similar_title"
+)
+
+// DuplicateCandidate is a pair of movies which are likely to be duplicates of each other.
+type DuplicateCandidate struct {
+	Movies Movies
+	Reason string
+
+	// Similarity is the trigram similarity of the titles, between 0 and 1.
+	Similarity float64
+}
+
+func (candidate DuplicateCandidate) ToResponse() response.DuplicateCandidateResponse {
+	return response.DuplicateCandidateResponse{
+		Reason:     candidate.Reason,
+		Similarity: candidate.Similarity,
+		Movies:     candidate.Movies.ToResponse(),
+	}
+}
+
+type DuplicateCandidates []DuplicateCandidate
+
+func (candidates DuplicateCandidates) ToResponse() []response.DuplicateCandidateResponse {
+	candidatesResponse := []response.DuplicateCandidateResponse{}
+	for _, candidate := range candidates {
+		candidatesResponse = append(candidatesResponse, candidate.ToResponse())
+	}
+	return candidatesResponse
+}
+
+// Absorb consolidates the data of a duplicate into the movie.
+// The title, year and runtime of the movie are kept, while the genres, countries and external ids
+// of the duplicate are added to those of the movie.
+// Release dates and certifications are added for countries the movie has none for,
+// and the other metadata and images only replace those the movie doesn't have.
+func (movie *Movie) Absorb(duplicate Movie) {
+	movie.Genres = appendMissing(movie.Genres, duplicate.Genres)
+	movie.Countries = appendMissing(movie.Countries, duplicate.Countries)
+
+	// countries are compared before any additions, as the duplicate has at most one of each
+	releaseCountries := make([]string, len(movie.ReleaseDates))
+	for i, releaseDate := range movie.ReleaseDates {
+		releaseCountries[i] = releaseDate.Country
+	}
+	for _, releaseDate := range duplicate.ReleaseDates {
+		if !rules.In(releaseDate.Country, releaseCountries) {
+			movie.ReleaseDates = append(movie.ReleaseDates, releaseDate)
+		}
+	}
+
+	certificationCountries := make([]string, len(movie.Certifications))
+	for i, certification := range movie.Certifications {
+		certificationCountries[i] = certification.Country
+	}
+	for _, certification := range duplicate.Certifications {
+		if !rules.In(certification.Country, certificationCountries) {
+			movie.Certifications = append(movie.Certifications, certification)
+		}
+	}
+
+	// the external ids are copied so the map of a previous copy of the movie is left unchanged,
+	// with the movie's own ids taking precedence
+	if len(duplicate.ExternalIds) > 0 {
+		externalIds := map[string]string{}
+		for source, id := range duplicate.ExternalIds {
+			externalIds[source] = id
+		}
+		for source, id := range movie.ExternalIds {
+			externalIds[source] = id
+		}
+		movie.ExternalIds = externalIds
+	}
+
+	if movie.OriginalTitle == "" {
+		movie.OriginalTitle = duplicate.OriginalTitle
+	}
+	if movie.OriginalLanguage == "" {
+		movie.OriginalLanguage = duplicate.OriginalLanguage
+	}
+	if movie.Synopsis == "" {
+		movie.Synopsis = duplicate.Synopsis
+	}
+	if movie.Tagline == "" {
+		movie.Tagline = duplicate.Tagline
+	}
+	if movie.Poster == nil {
+		movie.Poster = duplicate.Poster
+	}
+	if movie.Backdrop == nil {
+		movie.Backdrop = duplicate.Backdrop
+	}
+}
+
+// appendMissing returns the values with the additions which aren't amongst them appended in order.
+func appendMissing(values []string, additions []string) []string {
+	for _, addition := range additions {
+		if !rules.In(addition, values) {
+			values = append(values, addition)
+		}
+	}
+	return values
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"reflect"
+	"time"
+)
+
+// Actions recorded by movie revisions.
+const (
+	RevisionActionCreate  = "create"
+	RevisionActionUpdate  = "update"
+	RevisionActionDelete  = "delete"
+	RevisionActionRestore = "restore"
+	RevisionActionMerge   = "merge"
+)
+
+// MovieRevision records the state of a movie after an action, along with the changes made
+// by the action and the user who performed it.
+type MovieRevision struct {
+	Id       int
+	MovieId  int
+	Version  int
+	Action   string
+	Snapshot MovieSnapshot
+	Diff     MovieDiff
+
+	// UserId is the id of the acting user, and 0 if the user is unknown or has been deleted.
+	UserId  int
+	Created time.Time
+}
+
+// MovieSnapshot holds the editable data of a movie at a revision.
+// Metadata fields are omitted when empty, so snapshots recorded before they existed decode unchanged.
+// Images are recorded by the storage keys of their uploads, which identify them without being restorable
+// as their files are deleted once replaced.
+type MovieSnapshot struct {
+	Title   string   `json:"title"`
+	Year    int      `json:"year"`
+	Runtime int      `json:"runtime"`
+	Genres  []string `json:"genres"`
+
+	OriginalTitle    string            `json:"original_title,omitempty"`
+	OriginalLanguage string            `json:"original_language,omitempty"`
+	Countries        []string          `json:"countries,omitempty"`
+	ReleaseDates     []ReleaseDate     `json:"release_dates,omitempty"`
+	Certifications   []Certification   `json:"certifications,omitempty"`
+	Synopsis         string            `json:"synopsis,omitempty"`
+	Tagline          string            `json:"tagline,omitempty"`
+	ExternalIds      map[string]string `json:"external_ids,omitempty"`
+	Poster           string            `json:"poster,omitempty"`
+	Backdrop         string            `json:"backdrop,omitempty"`
+}
+
+// MovieChange holds the previous and current values of a changed movie field.
+type MovieChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// MovieDiff maps the JSON names of changed movie fields to their changes.
+type MovieDiff map[string]MovieChange
+
+// NewMovieRevision creates a revision of the movie's current state for the action.
+// The diff is computed against the previous state of the movie if given.
+func NewMovieRevision(action string, movie Movie, previous *Movie) MovieRevision {
+	revision := MovieRevision{
+		MovieId:  movie.Id,
+		Version:  movie.Version,
+		Action:   action,
+		Snapshot: movie.Snapshot(),
+	}
+
+	if previous != nil {
+		revision.Diff = DiffMovieSnapshots(previous.Snapshot(), revision.Snapshot)
+	}
+	return revision
+}
+
+// Snapshot returns the editable data of the movie.
+func (movie Movie) Snapshot() MovieSnapshot {
+	return MovieSnapshot{
+		Title:            movie.Title,
+		Year:             movie.Year,
+		Runtime:          movie.Runtime,
+		Genres:           movie.Genres,
+		OriginalTitle:    movie.OriginalTitle,
+		OriginalLanguage: movie.OriginalLanguage,
+		Countries:        movie.Countries,
+		ReleaseDates:     movie.ReleaseDates,
+		Certifications:   movie.Certifications,
+		Synopsis:         movie.Synopsis,
+		Tagline:          movie.Tagline,
+		ExternalIds:      movie.ExternalIds,
+		Poster:           imageKey(movie.Poster),
+		Backdrop:         imageKey(movie.Backdrop),
+	}
+}
+
+// imageKey returns the storage key of the image, and an empty string if there is no image.
+func imageKey(image *MovieImage) string {
+	if image == nil {
+		return ""
+	}
+	return image.Key
+}
+
+// ToModel returns a movie with the data of the snapshot.
+// Images aren't restorable, so they're left out.
+func (snapshot MovieSnapshot) ToModel() Movie {
+	return Movie{
+		Title:            snapshot.Title,
+		Year:             snapshot.Year,
+		Runtime:          snapshot.Runtime,
+		Genres:           snapshot.Genres,
+		OriginalTitle:    snapshot.OriginalTitle,
+		OriginalLanguage: snapshot.OriginalLanguage,
+		Countries:        snapshot.Countries,
+		ReleaseDates:     snapshot.ReleaseDates,
+		Certifications:   snapshot.Certifications,
+		Synopsis:         snapshot.Synopsis,
+		Tagline:          snapshot.Tagline,
+		ExternalIds:      snapshot.ExternalIds,
+	}
+}
+
+// DiffMovieSnapshots returns the changes of the fields which differ between the snapshots.
+func DiffMovieSnapshots(from MovieSnapshot, to MovieSnapshot) MovieDiff {
+	diff := MovieDiff{}
+
+	if from.Title != to.Title {
+		diff["title"] = MovieChange{From: from.Title, To: to.Title}
+	}
+	if from.Year != to.Year {
+		diff["year"] = MovieChange{From: from.Year, To: to.Year}
+	}
+	if from.Runtime != to.Runtime {
+		diff["runtime"] = MovieChange{From: from.Runtime, To: to.Runtime}
+	}
+	if !reflect.DeepEqual(from.Genres, to.Genres) {
+		diff["genres"] = MovieChange{From: from.Genres, To: to.Genres}
+	}
+	if from.OriginalTitle != to.OriginalTitle {
+		diff["original_title"] = MovieChange{From: from.OriginalTitle, To: to.OriginalTitle}
+	}
+	if from.OriginalLanguage != to.OriginalLanguage {
+		diff["original_language"] = MovieChange{From: from.OriginalLanguage, To: to.OriginalLanguage}
+	}
+	if !equalOrEmpty(from.Countries, to.Countries) {
+		diff["countries"] = MovieChange{From: from.Countries, To: to.Countries}
+	}
+	if !equalOrEmpty(from.ReleaseDates, to.ReleaseDates) {
+		diff["release_dates"] = MovieChange{From: from.ReleaseDates, To: to.ReleaseDates}
+	}
+	if !equalOrEmpty(from.Certifications, to.Certifications) {
+		diff["certifications"] = MovieChange{From: from.Certifications, To: to.Certifications}
+	}
+	if from.Synopsis != to.Synopsis {
+		diff["synopsis"] = MovieChange{From: from.Synopsis, To: to.Synopsis}
+	}
+	if from.Tagline != to.Tagline {
+		diff["tagline"] = MovieChange{From: from.Tagline, To: to.Tagline}
+	}
+	if !(len(from.ExternalIds) == 0 && len(to.ExternalIds) == 0) && !reflect.DeepEqual(from.ExternalIds, to.ExternalIds) {
+		diff["external_ids"] = MovieChange{From: from.ExternalIds, To: to.ExternalIds}
+	}
+	if from.Poster != to.Poster {
+		diff["poster"] = MovieChange{From: from.Poster, To: to.Poster}
+	}
+	if from.Backdrop != to.Backdrop {
+		diff["backdrop"] = MovieChange{From: from.Backdrop, To: to.Backdrop}
+	}
+
+	return diff
+}
+
+// equalOrEmpty returns true if the slices are deeply equal, treating nil and empty slices as equal.
+func equalOrEmpty[T any](a []T, b []T) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func (diff MovieDiff) ToResponse() map[string]response.MovieChangeResponse {
+	if diff == nil {
+		return nil
+	}
+
+	changes := map[string]response.MovieChangeResponse{}
+	for field, change := range diff {
+		changes[field] = response.MovieChangeResponse{From: change.From, To: change.To}
+	}
+	return changes
+}
+
+func (revision MovieRevision) ToResponse() response.MovieRevisionResponse {
+	return response.MovieRevisionResponse{
+		Version: revision.Version,
+		Action:  revision.Action,
+		Movie:   revisionMovieResponse(revision),
+		Changes: revision.Diff.ToResponse(),
+		UserId:  revision.UserId,
+		Created: revision.Created,
+	}
+}
+
+// revisionMovieResponse returns the response of the movie as recorded by the revision.
+func revisionMovieResponse(revision MovieRevision) response.MovieResponse {
+	movie := revision.Snapshot.ToModel()
+	movie.Id = revision.MovieId
+	movie.Version = revision.Version
+	return movie.ToResponse()
+}
+
+type MovieRevisions []MovieRevision
+
+func (revisions MovieRevisions) ToResponse() []response.MovieRevisionResponse {
+	revisionsResponse := []response.MovieRevisionResponse{}
+	for _, revision := range revisions {
+		revisionsResponse = append(revisionsResponse, revision.ToResponse())
+	}
+	return revisionsResponse
+}
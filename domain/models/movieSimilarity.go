@@ -0,0 +1,26 @@
+package models
+
+import "github.com/rhodeon/moviescreen/cmd/api/models/response"
+
+// SimilarMovie is a movie recommended as similar to another, with a score between 0 and 1.
+type SimilarMovie struct {
+	Movie Movie
+	Score float64
+}
+
+func (similar SimilarMovie) ToResponse() response.SimilarMovieResponse {
+	return response.SimilarMovieResponse{
+		Score: similar.Score,
+		Movie: similar.Movie.ToResponse(),
+	}
+}
+
+type SimilarMovies []SimilarMovie
+
+func (similarMovies SimilarMovies) ToResponse() []response.SimilarMovieResponse {
+	similarMoviesResponse := []response.SimilarMovieResponse{}
+	for _, similar := range similarMovies {
+		similarMoviesResponse = append(similarMoviesResponse, similar.ToResponse())
+	}
+	return similarMoviesResponse
+}
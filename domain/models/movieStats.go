@@ -0,0 +1,132 @@
+package models
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"time"
+)
+
+// MovieStats holds aggregates of the movies outside the trash.
+type MovieStats struct {
+	Genres  GenreCounts
+	Years   YearCounts
+	Runtime RuntimeStats
+
+	// Recent holds the most recently added movies, latest first.
+	Recent Movies
+
+	// Generated is the time the aggregates were computed.
+	Generated time.Time
+}
+
+func (stats MovieStats) ToResponse() response.MovieStatsResponse {
+	return response.MovieStatsResponse{
+		Total:     stats.Years.Total(),
+		Genres:    stats.Genres.ToResponse(),
+		Years:     stats.Years.ToResponse(),
+		Decades:   stats.Years.ByDecade().ToDecadesResponse(),
+		Runtime:   stats.Runtime.ToResponse(),
+		Recent:    stats.Recent.ToResponse(),
+		Generated: stats.Generated,
+	}
+}
+
+// GenreCount is the number of movies with a genre.
+type GenreCount struct {
+	Genre string
+	Count int
+}
+
+type GenreCounts []GenreCount
+
+func (counts GenreCounts) ToResponse() []response.GenreCountResponse {
+	countsResponse := []response.GenreCountResponse{}
+	for _, count := range counts {
+		countsResponse = append(countsResponse, response.GenreCountResponse{
+			Genre: count.Genre,
+			Count: count.Count,
+		})
+	}
+	return countsResponse
+}
+
+// YearCount is the number of movies released in a year, or in a decade starting with the year.
+type YearCount struct {
+	Year  int
+	Count int
+}
+
+// YearCounts is ordered by year.
+type YearCounts []YearCount
+
+// Total returns the sum of the counts, which is the number of movies as each has a single year.
+func (counts YearCounts) Total() int {
+	total := 0
+	for _, count := range counts {
+		total += count.Count
+	}
+	return total
+}
+
+// ByDecade returns the counts summed by decade, with each decade given by its first year.
+func (counts YearCounts) ByDecade() YearCounts {
+	decades := YearCounts{}
+	for _, count := range counts {
+		decade := count.Year - count.Year%10
+		if len(decades) > 0 && decades[len(decades)-1].Year == decade {
+			decades[len(decades)-1].Count += count.Count
+		} else {
+			decades = append(decades, YearCount{Year: decade, Count: count.Count})
+		}
+	}
+	return decades
+}
+
+func (counts YearCounts) ToResponse() []response.YearCountResponse {
+	countsResponse := []response.YearCountResponse{}
+	for _, count := range counts {
+		countsResponse = append(countsResponse, response.YearCountResponse{
+			Year:  count.Year,
+			Count: count.Count,
+		})
+	}
+	return countsResponse
+}
+
+// ToDecadesResponse returns the counts of decades, which are given by their first years.
+func (counts YearCounts) ToDecadesResponse() []response.DecadeCountResponse {
+	countsResponse := []response.DecadeCountResponse{}
+	for _, count := range counts {
+		countsResponse = append(countsResponse, response.DecadeCountResponse{
+			Decade: count.Year,
+			Count:  count.Count,
+		})
+	}
+	return countsResponse
+}
+
+// RuntimeStats describes the distribution of runtimes in minutes.
+// The percentiles are runtimes of actual movies rather than interpolated values,
+// and all the fields are 0 if there are no movies.
+type RuntimeStats struct {
+	Total  int
+	Mean   float64
+	Min    int
+	P25    int
+	Median int
+	P75    int
+	P90    int
+	Max    int
+}
+
+func (stats RuntimeStats) ToResponse() response.RuntimeStatsResponse {
+	return response.RuntimeStatsResponse{
+		Total:  stats.Total,
+		Mean:   stats.Mean,
+		Min:    stats.Min,
+		P25:    stats.P25,
+		Median: stats.Median,
+		P75:    stats.P75,
+		P90:    stats.P90,
+		Max:    stats.Max,
+	}
+}
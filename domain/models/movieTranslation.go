@@ -0,0 +1,41 @@
+package models
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"time"
+)
+
+// MovieTranslation holds the title, synopsis and tagline of a movie in a language other than the original.
+type MovieTranslation struct {
+	MovieId int
+
+	// Language is the canonical BCP 47 tag of the translation's language.
+	Language string
+
+	Title    string
+	Synopsis string
+	Tagline  string
+
+	Created time.Time
+	Updated time.Time
+}
+
+func (translation MovieTranslation) ToResponse() response.MovieTranslationResponse {
+	return response.MovieTranslationResponse{
+		Language: translation.Language,
+		Title:    translation.Title,
+		Synopsis: translation.Synopsis,
+		Tagline:  translation.Tagline,
+		Updated:  translation.Updated,
+	}
+}
+
+type MovieTranslations []MovieTranslation
+
+func (translations MovieTranslations) ToResponse() []response.MovieTranslationResponse {
+	translationsResponse := []response.MovieTranslationResponse{}
+	for _, translation := range translations {
+		translationsResponse = append(translationsResponse, translation.ToResponse())
+	}
+	return translationsResponse
+}
@@ -0,0 +1,68 @@
+package models
+
+import "github.com/rhodeon/moviescreen/cmd/api/models/response"
+
+// Categories of non-transactional emails, which users can opt out of.
+// Transactional emails, like activation and password reset emails, have no category and are always sent.
+const (
+	NotificationSecurityAlerts    = "security_alerts"
+	NotificationProductUpdates    = "product_updates"
+	NotificationSavedSearchAlerts = "saved_search_alerts"
+)
+
+// NotificationCategories lists the categories of non-transactional emails.
+var NotificationCategories = []string{
+	NotificationSecurityAlerts,
+	NotificationProductUpdates,
+	NotificationSavedSearchAlerts,
+}
+
+// NotificationPreferences holds whether a user receives each category of non-transactional emails.
+type NotificationPreferences struct {
+	SecurityAlerts    bool
+	ProductUpdates    bool
+	SavedSearchAlerts bool
+}
+
+// DefaultNotificationPreferences are the preferences of users who haven't changed them,
+// who receive alerts but have to opt into product updates.
+var DefaultNotificationPreferences = NotificationPreferences{
+	SecurityAlerts:    true,
+	ProductUpdates:    false,
+	SavedSearchAlerts: true,
+}
+
+// Allows returns true if emails of the category are received,
+// which is always the case for transactional emails without a category.
+func (p NotificationPreferences) Allows(category string) bool {
+	switch category {
+	case NotificationSecurityAlerts:
+		return p.SecurityAlerts
+	case NotificationProductUpdates:
+		return p.ProductUpdates
+	case NotificationSavedSearchAlerts:
+		return p.SavedSearchAlerts
+	default:
+		return category == ""
+	}
+}
+
+// Set sets whether emails of the category are received, ignoring unknown categories.
+func (p *NotificationPreferences) Set(category string, enabled bool) {
+	switch category {
+	case NotificationSecurityAlerts:
+		p.SecurityAlerts = enabled
+	case NotificationProductUpdates:
+		p.ProductUpdates = enabled
+	case NotificationSavedSearchAlerts:
+		p.SavedSearchAlerts = enabled
+	}
+}
+
+func (p NotificationPreferences) ToResponse() response.NotificationPreferencesResponse {
+	return response.NotificationPreferencesResponse{
+		SecurityAlerts:    p.SecurityAlerts,
+		ProductUpdates:    p.ProductUpdates,
+		SavedSearchAlerts: p.SavedSearchAlerts,
+	}
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Statuses of emails in the outbox.
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+
+	// OutboxStatusDead is given to emails which failed permanently or ran out of attempts.
+	// They are kept in the outbox for inspection, and aren't sent again.
+	OutboxStatusDead = "dead"
+
+	// OutboxStatusSuppressed is given to emails which weren't sent as their recipient is suppressed.
+	OutboxStatusSuppressed = "suppressed"
+)
+
+// OutboxEmail is an email queued to be sent in the background,
+// rendered from its template with its data when it is sent.
+type OutboxEmail struct {
+	Id        int
+	Recipient string
+	Template  string
+	// Language is the ISO 639-1 code of the language the template is rendered in.
+	Language string
+	Data     map[string]any
+	Status   string
+
+	// UserId is the id of the user the email is sent to, or 0 if the recipient isn't a user.
+	UserId int
+	// Category is the category of notifications the email belongs to,
+	// which is empty for transactional emails as they can't be unsubscribed from.
+	Category string
+
+	// TokenScope is the scope of the token created for the user when the email is sent, if it has one,
+	// so the plaintext of the token isn't stored in the outbox.
+	TokenScope string
+	// TokenLifetime is the lifetime of the token from when the email is sent.
+	TokenLifetime time.Duration
+
+	// Attempts is the number of times the email has been claimed for sending.
+	Attempts  int
+	LastError string
+
+	// Available is the time from which the email can be claimed,
+	// which is pushed back while it is being sent and after each failure.
+	Available time.Time
+	Created   time.Time
+	Sent      *time.Time
+}
+
+type OutboxEmails []OutboxEmail
+
+// OutboxDepth holds the numbers of emails waiting to be sent and dead-lettered in the outbox.
+type OutboxDepth struct {
+	Pending int `json:"pending"`
+	Dead    int `json:"dead"`
+}
@@ -3,8 +3,13 @@ package models
 type Permissions []string
 
 const (
-	PermissionMoviesRead  = "movies:read"
-	PermissionMoviesWrite = "movies:write"
+	PermissionMoviesRead   = "movies:read"
+	PermissionMoviesWrite  = "movies:write"
+	PermissionMoviesExport = "movies:export"
+	PermissionGenresWrite  = "genres:write"
+	PermissionMoviesMerge  = "movies:merge"
+	PermissionEmailsManage = "emails:manage"
+	PermissionUsersRead    = "users:read"
 )
 
 // Includes returns true if the specified code is amongst the permissions,
@@ -0,0 +1,44 @@
+package models
+
+import "github.com/rhodeon/moviescreen/cmd/api/models/response"
+
+// Types of search results.
+const (
+	SearchTypeMovie  = "movie"
+	SearchTypeSeries = "series"
+)
+
+// SearchTypes holds the types of search results.
+var SearchTypes = []string{SearchTypeMovie, SearchTypeSeries}
+
+// SearchResult is a movie or series matching a search, with the details shared by both.
+type SearchResult struct {
+	Type  string
+	Id    int
+	Title string
+
+	// Year is the release year of a movie, and the start year of a series.
+	Year int
+
+	Genres []string
+}
+
+func (result SearchResult) ToResponse() response.SearchResultResponse {
+	return response.SearchResultResponse{
+		Type:   result.Type,
+		Id:     result.Id,
+		Title:  result.Title,
+		Year:   result.Year,
+		Genres: result.Genres,
+	}
+}
+
+type SearchResults []SearchResult
+
+func (results SearchResults) ToResponse() []response.SearchResultResponse {
+	resultsResponse := []response.SearchResultResponse{}
+	for _, result := range results {
+		resultsResponse = append(resultsResponse, result.ToResponse())
+	}
+	return resultsResponse
+}
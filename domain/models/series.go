@@ -0,0 +1,136 @@
+package models
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/internal/types"
+	"time"
+)
+
+// Series is a TV series, made up of numbered seasons of numbered episodes.
+type Series struct {
+	Id        int
+	Title     string
+	StartYear int
+
+	// EndYear is 0 for series which are still running.
+	EndYear int
+
+	Genres   []string
+	Synopsis string
+
+	// SeasonCount and EpisodeCount are the numbers of seasons and episodes of the series.
+	SeasonCount  int
+	EpisodeCount int
+
+	// Seasons holds the seasons of the series with their episodes, ordered by number.
+	// It's only populated when a single series is fetched.
+	Seasons []Season
+
+	Version int
+	Created time.Time
+	Updated time.Time
+}
+
+func (series Series) ToResponse() response.SeriesResponse {
+	resp := response.SeriesResponse{
+		Id:           series.Id,
+		Title:        series.Title,
+		StartYear:    series.StartYear,
+		Genres:       series.Genres,
+		Synopsis:     series.Synopsis,
+		SeasonCount:  series.SeasonCount,
+		EpisodeCount: series.EpisodeCount,
+		Version:      series.Version,
+		Created:      series.Created,
+		Updated:      series.Updated,
+	}
+	if series.EndYear != 0 {
+		resp.EndYear = &series.EndYear
+	}
+
+	if series.Seasons != nil {
+		resp.Seasons = []response.SeasonResponse{}
+		for _, season := range series.Seasons {
+			resp.Seasons = append(resp.Seasons, season.ToResponse())
+		}
+	}
+	return resp
+}
+
+type SeriesList []Series
+
+func (seriesList SeriesList) ToResponse() []response.SeriesResponse {
+	seriesResponse := []response.SeriesResponse{}
+	for _, series := range seriesList {
+		seriesResponse = append(seriesResponse, series.ToResponse())
+	}
+	return seriesResponse
+}
+
+// Season is a numbered season of a series.
+// Seasons are ordered by number, and their air dates must follow that order.
+type Season struct {
+	Id       int
+	SeriesId int
+	Number   int
+	Title    string
+
+	// AirDate is the premiere date of the season, and nil if unknown.
+	AirDate *types.Date
+
+	// Episodes holds the episodes of the season ordered by number.
+	Episodes []Episode
+
+	Created time.Time
+	Updated time.Time
+}
+
+func (season Season) ToResponse() response.SeasonResponse {
+	resp := response.SeasonResponse{
+		Number:   season.Number,
+		Title:    season.Title,
+		AirDate:  airDateResponse(season.AirDate),
+		Episodes: []response.EpisodeResponse{},
+	}
+	for _, episode := range season.Episodes {
+		resp.Episodes = append(resp.Episodes, episode.ToResponse())
+	}
+	return resp
+}
+
+// Episode is a numbered episode of a season of a series.
+// Episodes are ordered by number within their season, and their air dates must follow that order.
+type Episode struct {
+	Id           int
+	SeasonNumber int
+	Number       int
+	Title        string
+	Runtime      int
+
+	// AirDate is the date of the episode's first broadcast, and nil if unknown.
+	AirDate *types.Date
+
+	Synopsis string
+
+	Created time.Time
+	Updated time.Time
+}
+
+func (episode Episode) ToResponse() response.EpisodeResponse {
+	return response.EpisodeResponse{
+		Number:   episode.Number,
+		Title:    episode.Title,
+		Runtime:  episode.Runtime,
+		AirDate:  airDateResponse(episode.AirDate),
+		Synopsis: episode.Synopsis,
+	}
+}
+
+// airDateResponse returns the air date formatted as YYYY-MM-DD, and nil if it is unknown.
+func airDateResponse(date *types.Date) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.String()
+	return &formatted
+}
@@ -13,10 +13,15 @@ type User struct {
 	Email     string
 	Password  types.Password
 	Activated bool
-	Version   int
-	Created   time.Time
+	// Language is the ISO 639-1 code of the language emails are sent to the user in.
+	Language string
+	Version  int
+	Created  time.Time
 }
 
+// DefaultUserLanguage is the language of users who haven't chosen one.
+const DefaultUserLanguage = "en"
+
 // AnonymousUser is the user model to be used if no authentication
 // token is sent as part of a request.
 var AnonymousUser = User{}
@@ -28,6 +33,7 @@ func (user User) ToResponse() response.UserResponse {
 		Email:     user.Email,
 		Version:   user.Version,
 		Activated: user.Activated,
+		Language:  user.Language,
 		Created:   user.Created,
 	}
 }
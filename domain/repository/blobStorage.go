@@ -0,0 +1,16 @@
+package repository
+
+import "io"
+
+// BlobStorage stores binary objects, such as uploaded images, under slash-separated keys.
+type BlobStorage interface {
+	// Put stores the content under the key with its content type, replacing any existing object.
+	Put(key string, contentType string, content io.Reader) error
+
+	// Delete removes the objects stored under the keys.
+	// Keys without objects are ignored.
+	Delete(keys ...string) error
+
+	// Url returns the URL the object stored under the key is served from.
+	Url(key string) string
+}
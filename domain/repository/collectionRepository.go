@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+)
+
+type CollectionRepository interface {
+	// List returns the collections visible to the user with the given id, being franchises, public lists
+	// and the user's own lists, matching the query and paginated and sorted by the filters.
+	// The number of movies in each collection is set, but not their entries.
+	List(query request.CollectionQuery, filters request.Filters, userId int) (models.Collections, response.Metadata, error)
+
+	// Get returns the collection with the given id with its entries, leaving out movies in the trash.
+	Get(id int) (models.Collection, error)
+
+	// ListForMovies returns the collections visible to the user with the given id which contain the movies
+	// with the given ids, grouped by movie id and ordered by kind and name.
+	// Movies without collections are left out of the map.
+	ListForMovies(movieIds []int, userId int) (map[int]models.MovieCollections, error)
+
+	// Create inserts the collection, setting its id and timestamps.
+	Create(collection *models.Collection) error
+
+	// Update saves the name, description and visibility of the collection, and sets its modification time.
+	Update(collection *models.Collection) error
+
+	// Delete removes the collection with the given id, along with its entries.
+	Delete(id int) error
+
+	// AddMovie inserts the movie into the collection at the given position, shifting the movies
+	// at and after it back. Positions of 0 or after the last entry append the movie.
+	// ErrRecordNotFound is returned if the movie doesn't exist outside the trash,
+	// and ErrDuplicateCollectionMovie if it's already in the collection.
+	AddMovie(collectionId int, movieId int, position int) error
+
+	// RemoveMovie removes the movie from the collection, shifting the movies after it forward.
+	// ErrRecordNotFound is returned if the movie isn't in the collection.
+	RemoveMovie(collectionId int, movieId int) error
+
+	// Reorder moves the movies with the given ids to the start of the collection in their given order,
+	// followed by the remaining movies in their current order.
+	// ErrRecordNotFound is returned if any of the movies isn't in the collection.
+	Reorder(collectionId int, movieIds []int) error
+}
@@ -7,4 +7,13 @@ var (
 	ErrEditConflict      = errors.New("edit conflict")
 	ErrDuplicateUsername = errors.New("username already exists")
 	ErrDuplicateEmail    = errors.New("email already exists")
+	ErrDuplicateGenre    = errors.New("genre already exists")
+
+	ErrDuplicateExternalId = errors.New("external id already registered")
+
+	ErrDuplicateCollectionMovie = errors.New("movie already in collection")
+
+	ErrAirDateOrder = errors.New("air date out of order")
+
+	ErrMissingDuplicate = errors.New("duplicate movie not found")
 )
@@ -0,0 +1,31 @@
+package repository
+
+import "github.com/rhodeon/moviescreen/domain/models"
+
+type GenreRepository interface {
+	// List returns all the genres ordered by name, with the number of movies in each.
+	List() (models.Genres, error)
+
+	// Names returns the names of all the genres.
+	Names() ([]string, error)
+
+	// Get returns the genre with the given id, without its movie count.
+	Get(id int) (models.Genre, error)
+
+	// Create inserts the genre, setting its id.
+	// ErrDuplicateGenre is returned if a genre with the same name or slug exists.
+	Create(genre *models.Genre) error
+
+	// Rename changes the name and slug of the genre, and replaces the genre in the movies and series which have it.
+	// The movie count of the genre is set after the movies are updated.
+	// The movies' versions are incremented and their revisions recorded with the given user as the actor,
+	// while the series' versions are incremented without revisions.
+	// ErrDuplicateGenre is returned if another genre with the same name or slug exists.
+	Rename(genre *models.Genre, userId int) error
+
+	// Merge moves the movies and series of the source genre to the target genre and deletes the source genre.
+	// The movies' versions are incremented and their revisions recorded with the given user as the actor,
+	// while the series' versions are incremented without revisions.
+	// The target genre is returned with its updated movie count.
+	Merge(sourceId int, targetId int, userId int) (models.Genre, error)
+}
@@ -4,12 +4,96 @@ import (
 	"github.com/rhodeon/moviescreen/cmd/api/models/request"
 	"github.com/rhodeon/moviescreen/cmd/api/models/response"
 	"github.com/rhodeon/moviescreen/domain/models"
+	"time"
 )
 
 type MovieRepository interface {
+	// Create inserts the movie, setting its id, timestamps and version.
+	// ErrDuplicateExternalId is returned if any of its external ids is registered to another movie.
 	Create(movie *models.Movie) error
-	Get(id int) (models.Movie, error)
-	List(title string, genres []string, filters request.Filters) (models.Movies, response.Metadata, error)
+
+	// BulkCreate inserts all the movies at once, setting their ids, timestamps and versions.
+	// None of the movies are inserted if any fails.
+	// ErrDuplicateExternalId is returned if an external id is registered to another movie.
+	BulkCreate(movies models.Movies) error
+
+	// Get returns the movie with the given id.
+	// Only the given fields are populated if any are specified.
+	Get(id int, fields ...string) (models.Movie, error)
+
+	// List returns the movies matching the query, paginated and sorted by the filters.
+	// Only the given fields are populated if any are specified.
+	List(query request.MovieQuery, filters request.Filters, fields ...string) (models.Movies, response.Metadata, error)
+
+	// Iterate returns an iterator over all the movies matching the query, sorted by the filters.
+	// Pagination is ignored, and movies are fetched lazily as the iterator advances.
+	Iterate(query request.MovieQuery, filters request.Filters) MovieIterator
+
+	// Update saves the movie if its version is current, incrementing the version.
+	// ErrEditConflict is returned if the version is outdated,
+	// and ErrDuplicateExternalId if an external id is registered to another movie.
 	Update(movie *models.Movie) error
-	Delete(id int) error
+
+	// Lookup returns the movie outside the trash with the given id in the external source.
+	Lookup(source string, externalId string) (models.Movie, error)
+
+	// FindByExternalIds returns the movies with any of the given ids in the external source,
+	// including those in the trash as their external ids stay registered to them.
+	FindByExternalIds(source string, externalIds []string) (models.Movies, error)
+
+	// Delete moves the movie with the given id and version to the trash.
+	// An "edit conflict" error is returned if the movie was changed or deleted since that version.
+	Delete(id int, version int) error
+
+	// ListDeleted returns the movies in the trash, paginated and sorted by the filters.
+	ListDeleted(filters request.Filters) (models.Movies, response.Metadata, error)
+
+	// Restore moves the movie with the given id out of the trash and returns it.
+	Restore(id int) (models.Movie, error)
+
+	// Purge permanently removes the movies moved to the trash before the given time,
+	// and returns the number removed with the storage keys of their images,
+	// which are no longer referenced by any movie.
+	Purge(deletedBefore time.Time) (int64, []string, error)
+
+	// CountByGenre returns the number of movies outside the trash with each genre,
+	// ordered by descending count and then by genre.
+	CountByGenre() (models.GenreCounts, error)
+
+	// CountByYear returns the number of movies outside the trash released in each year, ordered by year.
+	// Years without movies are left out.
+	CountByYear() (models.YearCounts, error)
+
+	// RuntimeStats returns the distribution of the runtimes of the movies outside the trash.
+	RuntimeStats() (models.RuntimeStats, error)
+
+	// ListRecent returns up to limit movies outside the trash which were added most recently, latest first.
+	ListRecent(limit int) (models.Movies, error)
+
+	// ListDuplicates returns the pairs of movies outside the trash which are likely to be duplicates,
+	// ordered by descending similarity and paginated by the filters.
+	ListDuplicates(filters request.Filters) (models.DuplicateCandidates, response.Metadata, error)
+
+	// Merge consolidates the duplicates into the survivor and returns the merged survivor.
+	// The duplicates are removed, and their ids are redirected to the survivor.
+	// A merge revision of the survivor is recorded with the user as the actor.
+	// ErrRecordNotFound is returned if the survivor isn't found outside the trash,
+	// and ErrMissingDuplicate if any of the duplicates isn't.
+	Merge(survivorId int, duplicateIds []int, userId int) (models.Movie, error)
+
+	// Redirect returns the id of the movie which the movie with the given id was merged into.
+	Redirect(oldId int) (int, error)
+}
+
+// MovieIterator iterates over the movies of a repository one at a time.
+type MovieIterator interface {
+	// Next advances the iterator to the next movie, returning false
+	// when there are no more movies or an error occurs.
+	Next() bool
+
+	// Movie returns the current movie of the iterator.
+	Movie() models.Movie
+
+	// Err returns the error which stopped the iteration, if any.
+	Err() error
 }
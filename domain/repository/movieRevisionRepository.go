@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"time"
+)
+
+type MovieRevisionRepository interface {
+	// Insert records the revisions, setting their ids and creation times.
+	Insert(revisions ...*models.MovieRevision) error
+
+	// Get returns the revision with the state of the movie at the given version.
+	// Deletions are excluded as they don't change the state of the movie.
+	Get(movieId int, version int) (models.MovieRevision, error)
+
+	// List returns the revisions of the movie, paginated and sorted by the filters.
+	List(movieId int, filters request.Filters) (models.MovieRevisions, response.Metadata, error)
+
+	// PurgeRemoved permanently removes the revisions of movies which no longer exist
+	// and were last changed before the given time, and returns the number removed.
+	PurgeRemoved(changedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/rhodeon/moviescreen/domain/models"
+
+// MaxSimilarMovies is the number of most similar movies kept for each movie.
+const MaxSimilarMovies = 20
+
+type MovieSimilarityRepository interface {
+	// List returns up to limit movies outside the trash which are most similar to the movie with the given id
+	// as of the last refresh, ordered by descending score.
+	List(movieId int, limit int) (models.SimilarMovies, error)
+
+	// Refresh recomputes the similarities between the movies outside the trash, replacing the previous ones
+	// with the MaxSimilarMovies highest scores of each movie.
+	// Only movies sharing a genre or with similar titles are compared.
+	// It returns the number of similarities stored.
+	Refresh() (int, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/rhodeon/moviescreen/domain/models"
+
+type MovieTranslationRepository interface {
+	// List returns the translations of the movie with the given id, ordered by language.
+	List(movieId int) (models.MovieTranslations, error)
+
+	// ListForMovies returns the translations of the movies with the given ids, grouped by movie id.
+	// Movies without translations are left out of the map.
+	ListForMovies(movieIds []int) (map[int]models.MovieTranslations, error)
+
+	// Upsert inserts the translation, or replaces the movie's existing translation in the same language,
+	// and sets its creation and modification times.
+	// It returns true if the translation was inserted.
+	// ErrRecordNotFound is returned if the movie doesn't exist outside the trash.
+	Upsert(translation *models.MovieTranslation) (bool, error)
+
+	// Delete removes the translation of the movie in the given language.
+	Delete(movieId int, language string) error
+}
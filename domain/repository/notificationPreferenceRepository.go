@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/rhodeon/moviescreen/domain/models"
+
+type NotificationPreferenceRepository interface {
+	// Get returns the notification preferences of the user,
+	// which are the default preferences if the user hasn't changed them.
+	Get(userId int) (models.NotificationPreferences, error)
+
+	// Update saves the notification preferences of the user,
+	// returning a "record not found" error if the user doesn't exist.
+	Update(userId int, preferences models.NotificationPreferences) error
+}
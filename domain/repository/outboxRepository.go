@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"time"
+)
+
+type OutboxRepository interface {
+	// Enqueue adds the email to the outbox to be sent as soon as possible,
+	// setting its id, status, availability and creation times.
+	Enqueue(email *models.OutboxEmail) error
+
+	// Claim returns up to limit pending emails which are available, oldest first, and increments their attempts.
+	// The claimed emails are hidden from other claims for the lease, so concurrent workers don't send them twice,
+	// and are claimed again once it expires if they aren't marked, as their worker may have stopped.
+	Claim(limit int, lease time.Duration) (models.OutboxEmails, error)
+
+	// MarkSent marks the email as sent.
+	MarkSent(id int) error
+
+	// Retry records the failure of the email and makes it available to be claimed again at the given time.
+	Retry(id int, reason string, availableAt time.Time) error
+
+	// DeadLetter records the failure of the email and stops it from being sent again.
+	DeadLetter(id int, reason string) error
+
+	// MarkSuppressed records why the email wasn't sent to its suppressed recipient and stops it from being sent again.
+	MarkSuppressed(id int, reason string) error
+
+	// Depth returns the numbers of pending and dead-lettered emails.
+	Depth() (models.OutboxDepth, error)
+}
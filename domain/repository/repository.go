@@ -2,10 +2,33 @@
 // (like mocks and the database).
 package repository
 
+import (
+	"context"
+)
+
 // Repositories encapsulates all available repositories for easy reuse.
 type Repositories struct {
-	Tokens      TokenRepository
-	Movies      MovieRepository
-	Users       UserRepository
-	Permissions PermissionRepository
+	Tokens                  TokenRepository
+	Movies                  MovieRepository
+	MovieRevisions          MovieRevisionRepository
+	MovieTranslations       MovieTranslationRepository
+	MovieSimilarities       MovieSimilarityRepository
+	Genres                  GenreRepository
+	Collections             CollectionRepository
+	Series                  SeriesRepository
+	Search                  SearchRepository
+	Users                   UserRepository
+	Permissions             PermissionRepository
+	NotificationPreferences NotificationPreferenceRepository
+	Outbox                  OutboxRepository
+	Suppressions            SuppressionRepository
+	Transactor              Transactor
+}
+
+// WithinTx runs fn as a single unit of work over the repositories passed to it,
+// saving all their changes if fn returns nil and discarding them all otherwise.
+// fn should return the errors of the repositories rather than recover from them,
+// as a failed change may leave the unit of work unusable.
+func (r Repositories) WithinTx(ctx context.Context, fn func(repositories Repositories) error) error {
+	return r.Transactor.WithinTx(ctx, fn)
 }
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+)
+
+type SearchRepository interface {
+	// Search returns the movies outside the trash and the series matching the query,
+	// paginated and sorted by the filters, with ties ordered by type and id.
+	Search(query request.SearchQuery, filters request.Filters) (models.SearchResults, response.Metadata, error)
+}
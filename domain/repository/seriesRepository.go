@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+)
+
+type SeriesRepository interface {
+	// Create inserts the series, setting its id, timestamps and version.
+	Create(series *models.Series) error
+
+	// Get returns the series with the given id with its seasons and episodes.
+	Get(id int) (models.Series, error)
+
+	// List returns the series matching the query, paginated and sorted by the filters.
+	// The numbers of seasons and episodes are set, but not the seasons themselves.
+	List(query request.SeriesQuery, filters request.Filters) (models.SeriesList, response.Metadata, error)
+
+	// Update saves the series if its version is current, incrementing the version.
+	// ErrEditConflict is returned if the version is outdated.
+	Update(series *models.Series) error
+
+	// Delete removes the series with the given id, along with its seasons and episodes.
+	Delete(id int) error
+
+	// UpsertSeason inserts the season, or replaces the series' existing season with the same number
+	// while keeping its episodes, and sets its id and timestamps.
+	// It returns true if the season was inserted.
+	// ErrRecordNotFound is returned if the series doesn't exist,
+	// and ErrAirDateOrder if the season would air before an earlier season or after a later one.
+	UpsertSeason(season *models.Season) (bool, error)
+
+	// DeleteSeason removes the season of the series with the given number, along with its episodes.
+	DeleteSeason(seriesId int, number int) error
+
+	// UpsertEpisode inserts the episode into the season of the series with its season number,
+	// or replaces the season's existing episode with the same number, and sets its id and timestamps.
+	// It returns true if the episode was inserted.
+	// ErrRecordNotFound is returned if the season doesn't exist,
+	// and ErrAirDateOrder if the episode would air before an earlier episode of the season or after a later one.
+	UpsertEpisode(seriesId int, episode *models.Episode) (bool, error)
+
+	// DeleteEpisode removes the episode with the given number from the season of the series.
+	DeleteEpisode(seriesId int, seasonNumber int, number int) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import "github.com/rhodeon/moviescreen/domain/models"
+
+type SuppressionRepository interface {
+	// Suppress stops emails from being sent to the address of the suppression,
+	// replacing the reason and detail if the address is already suppressed.
+	Suppress(suppression *models.EmailSuppression) error
+
+	// Get returns the suppression of the email address,
+	// or a "record not found" error if emails are sent to it.
+	Get(email string) (models.EmailSuppression, error)
+
+	// GetForEmails returns the suppressions of the email addresses which are suppressed,
+	// keyed by their normalized address.
+	GetForEmails(emails []string) (map[string]models.EmailSuppression, error)
+}
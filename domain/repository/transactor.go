@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+// Transactor runs units of work spanning several repositories within transactions.
+type Transactor interface {
+	// WithinTx calls fn with repositories whose changes are saved together if fn returns nil,
+	// and are all discarded if it returns an error, which is then returned.
+	// The repositories join the current transaction if the transactor is already within one,
+	// leaving the changes to be saved or discarded with it.
+	WithinTx(ctx context.Context, fn func(repositories Repositories) error) error
+}
@@ -4,6 +4,7 @@ import "github.com/rhodeon/moviescreen/domain/models"
 
 type UserRepository interface {
 	Register(user *models.User) error
+	Get(id int) (models.User, error)
 	GetByEmail(email string) (models.User, error)
 	Update(user *models.User) error
 	GetByToken(plainTextToken string, scope string) (models.User, error)
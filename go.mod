@@ -1,6 +1,6 @@
 module github.com/rhodeon/moviescreen
 
-go 1.18
+go 1.20
 
 require (
 	github.com/gin-contrib/cors v1.3.1
@@ -11,6 +11,7 @@ require (
 	github.com/rhodeon/prettylog v1.0.3
 	github.com/tomasen/realip v0.0.0-20180522021738-f0c99a92ddce
 	golang.org/x/crypto v0.0.0-20220408190544-5352b0902921
+	golang.org/x/text v0.3.7
 	golang.org/x/time v0.0.0-20220224211638-0e9765cccd65
 )
 
@@ -27,7 +28,6 @@ require (
 	github.com/modern-go/reflect2 v0.0.0-20180701023420-4b7aa43c6742 // indirect
 	github.com/ugorji/go/codec v1.1.7 // indirect
 	golang.org/x/sys v0.0.0-20220319134239-a9b59b0215f8 // indirect
-	golang.org/x/text v0.3.7 // indirect
 	gopkg.in/alexcesaro/quotedprintable.v3 v3.0.0-20150716171945-2caba252f4dc // indirect
 	gopkg.in/mail.v2 v2.3.1 // indirect
 	gopkg.in/yaml.v2 v2.2.8 // indirect
@@ -0,0 +1,422 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"strings"
+	"time"
+)
+
+type CollectionController struct {
+	Db Conn
+}
+
+// collectionColumns selects the columns of a collection aliased as c,
+// counting its movies outside the trash.
+const collectionColumns = `c.id, c.kind, c.name, c.description, c.public, coalesce(c.user_id, 0), c.created_at, c.updated_at,
+	(SELECT count(*) FROM collection_movies cm
+	JOIN movies m ON m.id = cm.movie_id
+	WHERE cm.collection_id = c.id AND m.deleted_at IS NULL)`
+
+// touchCollectionStmt sets the modification time of a collection whose entries have changed.
+const touchCollectionStmt = `UPDATE collections SET updated_at = now() WHERE id = $1`
+
+// List fetches the collections visible to the user,
+// with the sort column and direction interpolated as they can't be parameterized.
+func (c CollectionController) List(query request.CollectionQuery, filters request.Filters, userId int) (models.Collections, response.Metadata, error) {
+	stmt := fmt.Sprintf(`SELECT count(*) OVER(), %s
+	FROM collections c
+	WHERE (c.public OR c.user_id = $1)
+	AND (c.kind = $2 OR $2 = '')
+	AND (c.user_id = $3 OR $3 = 0)
+	ORDER BY c.%s %s, c.id ASC
+	LIMIT $4 OFFSET $5`, collectionColumns, filters.SortColumn(request.CollectionFilterSortId), filters.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := c.Db.QueryContext(ctx, stmt, userId, query.Kind, query.UserId, filters.Limit, filters.Offset())
+	if err != nil {
+		return nil, response.Metadata{}, err
+	}
+	defer rows.Close()
+
+	collections := models.Collections{}
+	var totalRecords int
+
+	for rows.Next() {
+		collection := models.Collection{}
+		err := rows.Scan(
+			&totalRecords,
+			&collection.Id,
+			&collection.Kind,
+			&collection.Name,
+			&collection.Description,
+			&collection.Public,
+			&collection.UserId,
+			&collection.Created,
+			&collection.Updated,
+			&collection.MovieCount,
+		)
+		if err != nil {
+			return nil, response.Metadata{}, err
+		}
+		collections = append(collections, collection)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, response.Metadata{}, err
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, totalRecords)
+	return collections, metadata, nil
+}
+
+func (c CollectionController) Get(id int) (models.Collection, error) {
+	stmt := `SELECT ` + collectionColumns + `
+	FROM collections c
+	WHERE c.id = $1`
+
+	entriesStmt := `SELECT cm.position, m.id, m.title, m.year, m.runtime, m.genres
+	FROM collection_movies cm
+	JOIN movies m ON m.id = cm.movie_id
+	WHERE cm.collection_id = $1 AND m.deleted_at IS NULL
+	ORDER BY cm.position`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	collection := models.Collection{}
+	err := c.Db.QueryRowContext(ctx, stmt, id).Scan(
+		&collection.Id,
+		&collection.Kind,
+		&collection.Name,
+		&collection.Description,
+		&collection.Public,
+		&collection.UserId,
+		&collection.Created,
+		&collection.Updated,
+		&collection.MovieCount,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Collection{}, repository.ErrRecordNotFound
+		}
+		return models.Collection{}, err
+	}
+
+	rows, err := c.Db.QueryContext(ctx, entriesStmt, id)
+	if err != nil {
+		return models.Collection{}, err
+	}
+	defer rows.Close()
+
+	collection.Entries = []models.CollectionEntry{}
+	for rows.Next() {
+		entry := models.CollectionEntry{}
+		err := rows.Scan(
+			&entry.Position,
+			&entry.Movie.Id,
+			&entry.Movie.Title,
+			&entry.Movie.Year,
+			&entry.Movie.Runtime,
+			pq.Array(&entry.Movie.Genres),
+		)
+		if err != nil {
+			return models.Collection{}, err
+		}
+		collection.Entries = append(collection.Entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return models.Collection{}, err
+	}
+
+	return collection, nil
+}
+
+func (c CollectionController) ListForMovies(movieIds []int, userId int) (map[int]models.MovieCollections, error) {
+	stmt := `SELECT cm.movie_id, c.id, c.kind, c.name, cm.position
+	FROM collection_movies cm
+	JOIN collections c ON c.id = cm.collection_id
+	WHERE cm.movie_id = ANY($1) AND (c.public OR c.user_id = $2)
+	ORDER BY cm.movie_id, c.kind, c.name, c.id`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := c.Db.QueryContext(ctx, stmt, pq.Array(movieIds), userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := map[int]models.MovieCollections{}
+	for rows.Next() {
+		var movieId int
+		collection := models.MovieCollection{}
+		err := rows.Scan(&movieId, &collection.Id, &collection.Kind, &collection.Name, &collection.Position)
+		if err != nil {
+			return nil, err
+		}
+		collections[movieId] = append(collections[movieId], collection)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+func (c CollectionController) Create(collection *models.Collection) error {
+	stmt := `INSERT INTO collections (kind, name, description, public, user_id)
+	VALUES ($1, $2, $3, $4, NULLIF($5, 0))
+	RETURNING id, created_at, updated_at`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return c.Db.QueryRowContext(
+		ctx,
+		stmt,
+		collection.Kind,
+		collection.Name,
+		collection.Description,
+		collection.Public,
+		collection.UserId,
+	).Scan(&collection.Id, &collection.Created, &collection.Updated)
+}
+
+func (c CollectionController) Update(collection *models.Collection) error {
+	stmt := `UPDATE collections
+	SET name = $1, description = $2, public = $3, updated_at = now()
+	WHERE id = $4
+	RETURNING updated_at`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := c.Db.QueryRowContext(
+		ctx,
+		stmt,
+		collection.Name,
+		collection.Description,
+		collection.Public,
+		collection.Id,
+	).Scan(&collection.Updated)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrRecordNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (c CollectionController) Delete(id int) error {
+	stmt := `DELETE FROM collections WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := c.Db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// AddMovie shifts the entries and inserts the movie in a single transaction,
+// with the collection locked so concurrent changes to its entries are serialised.
+func (c CollectionController) AddMovie(collectionId int, movieId int, position int) error {
+	shiftStmt := `UPDATE collection_movies
+	SET position = position + 1
+	WHERE collection_id = $1 AND position >= $2`
+
+	insertStmt := `INSERT INTO collection_movies (collection_id, movie_id, position)
+	VALUES ($1, $2, $3)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, c.Db)
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	err = lockCollection(ctx, tx, collectionId)
+	if err != nil {
+		return err
+	}
+
+	var movieExists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM movies WHERE id = $1 AND deleted_at IS NULL)`, movieId).
+		Scan(&movieExists)
+	if err != nil {
+		return err
+	}
+	if !movieExists {
+		return repository.ErrRecordNotFound
+	}
+
+	// positions after the last entry append the movie
+	var count int
+	err = tx.QueryRowContext(ctx, `SELECT count(*) FROM collection_movies WHERE collection_id = $1`, collectionId).
+		Scan(&count)
+	if err != nil {
+		return err
+	}
+	if position == 0 || position > count+1 {
+		position = count + 1
+	}
+
+	_, err = tx.ExecContext(ctx, shiftStmt, collectionId, position)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, insertStmt, collectionId, movieId, position)
+	if err != nil {
+		return collectionError(err)
+	}
+	_, err = tx.ExecContext(ctx, touchCollectionStmt, collectionId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RemoveMovie deletes the entry and closes the gap it leaves in a single transaction.
+func (c CollectionController) RemoveMovie(collectionId int, movieId int) error {
+	deleteStmt := `DELETE FROM collection_movies
+	WHERE collection_id = $1 AND movie_id = $2
+	RETURNING position`
+
+	shiftStmt := `UPDATE collection_movies
+	SET position = position - 1
+	WHERE collection_id = $1 AND position > $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, c.Db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockCollection(ctx, tx, collectionId)
+	if err != nil {
+		return err
+	}
+
+	var position int
+	err = tx.QueryRowContext(ctx, deleteStmt, collectionId, movieId).Scan(&position)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrRecordNotFound
+		}
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, shiftStmt, collectionId, position)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, touchCollectionStmt, collectionId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Reorder renumbers all the entries of the collection in a single statement,
+// ranking them by their index in the given ids before their current position.
+func (c CollectionController) Reorder(collectionId int, movieIds []int) error {
+	countStmt := `SELECT count(*) FROM collection_movies
+	WHERE collection_id = $1 AND movie_id = ANY($2)`
+
+	reorderStmt := `UPDATE collection_movies cm
+	SET position = ranked.position
+	FROM (
+		SELECT movie_id,
+		row_number() OVER (ORDER BY array_position($2::bigint[], movie_id) NULLS LAST, position) AS position
+		FROM collection_movies
+		WHERE collection_id = $1
+	) ranked
+	WHERE cm.collection_id = $1 AND cm.movie_id = ranked.movie_id`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, c.Db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockCollection(ctx, tx, collectionId)
+	if err != nil {
+		return err
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx, countStmt, collectionId, pq.Array(movieIds)).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count != len(movieIds) {
+		return repository.ErrRecordNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, reorderStmt, collectionId, pq.Array(movieIds))
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, touchCollectionStmt, collectionId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockCollection locks the row of the collection until the end of the transaction.
+// ErrRecordNotFound is returned if the collection doesn't exist.
+func lockCollection(ctx context.Context, tx Conn, collectionId int) error {
+	var id int
+	err := tx.QueryRowContext(ctx, `SELECT id FROM collections WHERE id = $1 FOR UPDATE`, collectionId).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrRecordNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// collectionError maps the errors of constraint violations to repository errors.
+func collectionError(err error) error {
+	if strings.Contains(err.Error(), "collection_movies_pkey") {
+		return repository.ErrDuplicateCollectionMovie
+	}
+	return err
+}
@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+)
+
+func TestCollectionController_List(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	testCases := map[string]struct {
+		query   request.CollectionQuery
+		userId  int
+		wantIds []int
+	}{
+		"visible to owner of private list": {
+			query:   request.CollectionQuery{},
+			userId:  1,
+			wantIds: []int{1, 2, 3},
+		},
+
+		"visible to owner of other private list": {
+			query:   request.CollectionQuery{},
+			userId:  2,
+			wantIds: []int{1, 3, 4},
+		},
+
+		"filtered by kind and owner": {
+			query:   request.CollectionQuery{Kind: models.CollectionKindList, UserId: 2},
+			userId:  1,
+			wantIds: []int{3},
+		},
+	}
+
+	db, teardown := newTestDb(t)
+	collectionController := CollectionController{Db: db}
+	defer teardown()
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			filters := request.Filters{
+				Page:       1,
+				Limit:      20,
+				Sort:       request.CollectionFilterSortId,
+				ValidSorts: []string{request.CollectionFilterSortId},
+			}
+			collections, _, err := collectionController.List(tc.query, filters, tc.userId)
+			testhelpers.AssertError(t, err, nil)
+
+			ids := []int{}
+			for _, collection := range collections {
+				ids = append(ids, collection.Id)
+			}
+			testhelpers.AssertStruct(t, ids, tc.wantIds)
+		})
+	}
+}
+
+func TestCollectionController_ListForMovies(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	collectionController := CollectionController{Db: db}
+	defer teardown()
+
+	collections, err := collectionController.ListForMovies([]int{1, 2, 3}, 1)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertStruct(t, collections, map[int]models.MovieCollections{
+		1: {
+			{Id: 3, Kind: models.CollectionKindList, Name: "Summer blockbusters", Position: 1},
+			{Id: 2, Kind: models.CollectionKindList, Name: "Weekend picks", Position: 1},
+		},
+		2: {
+			{Id: 1, Kind: models.CollectionKindFranchise, Name: "Lin-Manuel Miranda Musicals", Position: 1},
+			{Id: 2, Kind: models.CollectionKindList, Name: "Weekend picks", Position: 3},
+		},
+		3: {
+			{Id: 2, Kind: models.CollectionKindList, Name: "Weekend picks", Position: 2},
+		},
+	})
+}
+
+func TestCollectionController_Entries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	testCases := map[string]struct {
+		collectionId int
+		change       func(c CollectionController) error
+		wantErr      error
+		wantEntries  []int
+	}{
+		"add movie at position": {
+			collectionId: 2,
+			change: func(c CollectionController) error {
+				_, err := c.Db.ExecContext(context.Background(), `DELETE FROM collection_movies WHERE collection_id = 2 AND movie_id = 2`)
+				if err != nil {
+					return err
+				}
+				return c.AddMovie(2, 2, 1)
+			},
+			wantErr:     nil,
+			wantEntries: []int{2, 1, 3},
+		},
+
+		"append movie": {
+			collectionId: 1,
+			change:       func(c CollectionController) error { return c.AddMovie(1, 1, 0) },
+			wantErr:      nil,
+			wantEntries:  []int{2, 1},
+		},
+
+		"add movie already in collection": {
+			collectionId: 2,
+			change:       func(c CollectionController) error { return c.AddMovie(2, 1, 1) },
+			wantErr:      repository.ErrDuplicateCollectionMovie,
+			wantEntries:  []int{1, 3, 2},
+		},
+
+		"add non-existent movie": {
+			collectionId: 2,
+			change:       func(c CollectionController) error { return c.AddMovie(2, 99, 0) },
+			wantErr:      repository.ErrRecordNotFound,
+			wantEntries:  []int{1, 3, 2},
+		},
+
+		"remove movie": {
+			collectionId: 2,
+			change:       func(c CollectionController) error { return c.RemoveMovie(2, 1) },
+			wantErr:      nil,
+			wantEntries:  []int{3, 2},
+		},
+
+		"reorder some movies": {
+			collectionId: 2,
+			change:       func(c CollectionController) error { return c.Reorder(2, []int{2}) },
+			wantErr:      nil,
+			wantEntries:  []int{2, 1, 3},
+		},
+
+		"reorder movie not in collection": {
+			collectionId: 1,
+			change:       func(c CollectionController) error { return c.Reorder(1, []int{2, 1}) },
+			wantErr:      repository.ErrRecordNotFound,
+			wantEntries:  []int{2},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			collectionController := CollectionController{Db: db}
+			defer teardown()
+
+			err := tc.change(collectionController)
+			testhelpers.AssertError(t, err, tc.wantErr)
+
+			collection, err := collectionController.Get(tc.collectionId)
+			testhelpers.AssertError(t, err, nil)
+
+			// entries are numbered from 1 without gaps
+			movieIds := []int{}
+			for i, entry := range collection.Entries {
+				testhelpers.AssertEqual(t, entry.Position, i+1)
+				movieIds = append(movieIds, entry.Movie.Id)
+			}
+			testhelpers.AssertStruct(t, movieIds, tc.wantEntries)
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"strings"
+	"time"
+)
+
+type GenreController struct {
+	Db Conn
+}
+
+// genreMovieCountStmt counts the movies outside the trash with the genre of the given name.
+const genreMovieCountStmt = `SELECT count(*) FROM movies
+WHERE genres @> ARRAY[$1::text] AND deleted_at IS NULL`
+
+// List fetches all genres from the database ordered by name,
+// counting the movies outside the trash which have each genre.
+func (g GenreController) List() (models.Genres, error) {
+	stmt := `SELECT g.id, g.slug, g.name, count(m.id)
+	FROM genres g
+	LEFT JOIN movies m ON m.genres @> ARRAY[g.name] AND m.deleted_at IS NULL
+	GROUP BY g.id
+	ORDER BY g.name`
+
+	// create context for database operation with a 3-second timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := g.Db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	genres := models.Genres{}
+	for rows.Next() {
+		genre := models.Genre{}
+		if err := rows.Scan(&genre.Id, &genre.Slug, &genre.Name, &genre.MovieCount); err != nil {
+			return nil, err
+		}
+		genres = append(genres, genre)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+func (g GenreController) Names() ([]string, error) {
+	stmt := `SELECT name FROM genres`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := g.Db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (g GenreController) Get(id int) (models.Genre, error) {
+	stmt := `SELECT id, slug, name FROM genres
+	WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	genre := models.Genre{}
+	err := g.Db.QueryRowContext(ctx, stmt, id).Scan(&genre.Id, &genre.Slug, &genre.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Genre{}, repository.ErrRecordNotFound
+		} else {
+			return models.Genre{}, err
+		}
+	}
+
+	return genre, nil
+}
+
+func (g GenreController) Create(genre *models.Genre) error {
+	stmt := `INSERT INTO genres (slug, name)
+	VALUES ($1, $2)
+	RETURNING id`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := g.Db.QueryRowContext(ctx, stmt, genre.Slug, genre.Name).Scan(&genre.Id)
+	if err != nil {
+		return genreError(err)
+	}
+
+	return nil
+}
+
+// Rename updates the genre and the movies and series with it in a single transaction.
+func (g GenreController) Rename(genre *models.Genre, userId int) error {
+	stmt := `UPDATE genres g
+	SET slug = $1, name = $2
+	FROM genres previous
+	WHERE g.id = $3 AND previous.id = g.id
+	RETURNING previous.name`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, g.Db)
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	var previousName string
+	err = tx.QueryRowContext(ctx, stmt, genre.Slug, genre.Name, genre.Id).Scan(&previousName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrRecordNotFound
+		}
+		return genreError(err)
+	}
+
+	if previousName != genre.Name {
+		err = replaceMovieGenre(ctx, tx, previousName, genre.Name, userId)
+		if err != nil {
+			return err
+		}
+		err = replaceSeriesGenre(ctx, tx, previousName, genre.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, genreMovieCountStmt, genre.Name).Scan(&genre.MovieCount)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Merge updates the movies and series of the source genre and deletes it in a single transaction.
+func (g GenreController) Merge(sourceId int, targetId int, userId int) (models.Genre, error) {
+	deleteStmt := `DELETE FROM genres
+	WHERE id = $1
+	RETURNING name`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, g.Db)
+	if err != nil {
+		return models.Genre{}, err
+	}
+	defer tx.Rollback()
+
+	target := models.Genre{}
+	err = tx.QueryRowContext(ctx, `SELECT id, slug, name FROM genres WHERE id = $1`, targetId).
+		Scan(&target.Id, &target.Slug, &target.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Genre{}, repository.ErrRecordNotFound
+		}
+		return models.Genre{}, err
+	}
+
+	var sourceName string
+	err = tx.QueryRowContext(ctx, deleteStmt, sourceId).Scan(&sourceName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Genre{}, repository.ErrRecordNotFound
+		}
+		return models.Genre{}, err
+	}
+
+	err = replaceMovieGenre(ctx, tx, sourceName, target.Name, userId)
+	if err != nil {
+		return models.Genre{}, err
+	}
+	err = replaceSeriesGenre(ctx, tx, sourceName, target.Name)
+	if err != nil {
+		return models.Genre{}, err
+	}
+
+	err = tx.QueryRowContext(ctx, genreMovieCountStmt, target.Name).Scan(&target.MovieCount)
+	if err != nil {
+		return models.Genre{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.Genre{}, err
+	}
+
+	return target, nil
+}
+
+// replaceMovieGenre replaces the genre named from with the genre named to in all movies, including those
+// in the trash, removing it instead from movies which already have the replacement.
+// The version of each changed movie is incremented, and an update revision is recorded with its genre change.
+// The user id of the revisions is stored as null if it is 0.
+func replaceMovieGenre(ctx context.Context, tx Conn, from string, to string, userId int) error {
+	stmt := `WITH previous AS (
+		SELECT id, genres FROM movies
+		WHERE genres @> ARRAY[$1::text]
+		FOR UPDATE
+	), updated AS (
+		UPDATE movies m
+		SET genres = CASE
+				WHEN m.genres @> ARRAY[$2::text] THEN array_remove(m.genres, $1)
+				ELSE array_replace(m.genres, $1, $2)
+			END,
+			version = m.version + 1,
+			updated_at = now()
+		FROM previous p
+		WHERE m.id = p.id
+		RETURNING m.id, m.title, m.year, m.runtime, m.genres, m.version,
+			m.original_title, m.original_language, m.countries, m.release_dates, m.certifications, m.synopsis, m.tagline, m.external_ids,
+			m.poster ->> 'key' AS poster, m.backdrop ->> 'key' AS backdrop,
+			p.genres AS previous_genres
+	)
+	INSERT INTO movie_revisions (movie_id, version, action, snapshot, diff, user_id)
+	SELECT id,
+		version,
+		$3,
+		jsonb_build_object(
+			'title', title, 'year', year, 'runtime', runtime, 'genres', genres,
+			'original_title', original_title, 'original_language', original_language,
+			'countries', countries, 'release_dates', release_dates, 'certifications', certifications,
+			'synopsis', synopsis, 'tagline', tagline, 'external_ids', external_ids,
+			'poster', poster, 'backdrop', backdrop
+		),
+		jsonb_build_object('genres', jsonb_build_object('from', previous_genres, 'to', genres)),
+		$4
+	FROM updated`
+
+	nullableUserId := sql.NullInt64{Int64: int64(userId), Valid: userId != 0}
+	_, err := tx.ExecContext(ctx, stmt, from, to, models.RevisionActionUpdate, nullableUserId)
+	return err
+}
+
+// genreError maps unique constraint violations of the genres table to ErrDuplicateGenre.
+func genreError(err error) error {
+	if strings.Contains(err.Error(), "genres_slug_key") || strings.Contains(err.Error(), "genres_name_key") {
+		return repository.ErrDuplicateGenre
+	}
+	return err
+}
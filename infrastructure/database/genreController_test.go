@@ -0,0 +1,98 @@
+package database
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+)
+
+func TestGenreController_List(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	genreController := GenreController{Db: db}
+	defer teardown()
+
+	genres, err := genreController.List()
+	testhelpers.AssertError(t, err, nil)
+
+	counts := map[string]int{}
+	for _, genre := range genres {
+		counts[genre.Name] = genre.MovieCount
+	}
+	testhelpers.AssertStruct(t, counts, map[string]int{
+		"Action":    1,
+		"Adventure": 1,
+		"Comedy":    1,
+		"Drama":     1,
+		"Family":    1,
+		"Musical":   1,
+	})
+}
+
+func TestGenreController_Create(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	testCases := map[string]struct {
+		genre   models.Genre
+		wantErr error
+	}{
+		"new genre": {
+			genre:   models.Genre{Slug: "thriller", Name: "Thriller"},
+			wantErr: nil,
+		},
+
+		"existing slug": {
+			genre:   models.Genre{Slug: "action", Name: "ACTION"},
+			wantErr: repository.ErrDuplicateGenre,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			genreController := GenreController{Db: db}
+			defer teardown()
+
+			err := genreController.Create(&tc.genre)
+			testhelpers.AssertError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestGenreController_Merge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	genreController := GenreController{Db: db}
+	movieController := MovieController{Db: db}
+	revisionController := MovieRevisionController{Db: db}
+	defer teardown()
+
+	// merge "Musical" into "Drama", which Hamilton already has
+	target, err := genreController.Merge(6, 4, 1)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, target.MovieCount, 1)
+
+	_, err = genreController.Get(6)
+	testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+
+	movie, err := movieController.Get(2)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertStruct(t, movie.Genres, []string{"Drama"})
+	testhelpers.AssertEqual(t, movie.Version, 2)
+
+	revision, err := revisionController.Get(2, 2)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, revision.UserId, 1)
+	testhelpers.AssertStruct(t, revision.Diff, models.MovieDiff{
+		"genres": {From: []any{"Musical", "Drama"}, To: []any{"Drama"}},
+	})
+}
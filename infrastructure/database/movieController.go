@@ -3,6 +3,8 @@ package database
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/lib/pq"
@@ -10,44 +12,240 @@ import (
 	"github.com/rhodeon/moviescreen/cmd/api/models/response"
 	"github.com/rhodeon/moviescreen/domain/models"
 	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"strings"
 	"time"
 )
 
 type MovieController struct {
-	Db *sql.DB
+	Db Conn
 }
 
+// movieColumn associates a movie field with its database column
+// and the destination the column is scanned into.
+// Columns which are always set are selected regardless of the requested fields.
+type movieColumn struct {
+	field  string
+	name   string
+	always bool
+	dest   func(movie *models.Movie) any
+}
+
+// movieColumns holds the selectable columns of the movies table in their default order.
+// The creation and deletion times have no associated fields and are only selected with the full set of columns.
+var movieColumns = []movieColumn{
+	{request.MovieFieldId, "id", true, func(movie *models.Movie) any { return &movie.Id }},
+	{request.MovieFieldTitle, "title", false, func(movie *models.Movie) any { return &movie.Title }},
+	{request.MovieFieldYear, "year", false, func(movie *models.Movie) any { return &movie.Year }},
+	{request.MovieFieldRuntime, "runtime", false, func(movie *models.Movie) any { return &movie.Runtime }},
+	{request.MovieFieldGenres, "genres", false, func(movie *models.Movie) any { return pq.Array(&movie.Genres) }},
+	{request.MovieFieldOriginalTitle, "original_title", false, func(movie *models.Movie) any { return &movie.OriginalTitle }},
+	{request.MovieFieldOriginalLanguage, "original_language", false, func(movie *models.Movie) any { return &movie.OriginalLanguage }},
+	{request.MovieFieldCountries, "countries", false, func(movie *models.Movie) any { return pq.Array(&movie.Countries) }},
+	{request.MovieFieldReleaseDates, "release_dates", false, func(movie *models.Movie) any { return jsonArray{&movie.ReleaseDates} }},
+	{request.MovieFieldCertifications, "certifications", false, func(movie *models.Movie) any { return jsonArray{&movie.Certifications} }},
+	{request.MovieFieldSynopsis, "synopsis", false, func(movie *models.Movie) any { return &movie.Synopsis }},
+	{request.MovieFieldTagline, "tagline", false, func(movie *models.Movie) any { return &movie.Tagline }},
+	{request.MovieFieldExternalIds, "external_ids", false, func(movie *models.Movie) any { return jsonObject{&movie.ExternalIds} }},
+	{request.MovieFieldPoster, "poster", false, func(movie *models.Movie) any { return nullableJson{&movie.Poster} }},
+	{request.MovieFieldBackdrop, "backdrop", false, func(movie *models.Movie) any { return nullableJson{&movie.Backdrop} }},
+	{"", "created_at", false, func(movie *models.Movie) any { return &movie.Created }},
+	{"", "updated_at", true, func(movie *models.Movie) any { return &movie.Updated }},
+	{request.MovieFieldVersion, "version", true, func(movie *models.Movie) any { return &movie.Version }},
+	{"", "deleted_at", false, func(movie *models.Movie) any { return &movie.Deleted }},
+}
+
+// selectMovieColumns returns the comma-separated columns to be selected for the given fields,
+// along with the destinations in the movie to scan them into.
+// All columns are selected if no field is specified.
+// Otherwise, the id, modification time and version are always selected
+// as they identify the movie and its state.
+func selectMovieColumns(movie *models.Movie, fields []string) (string, []any) {
+	var columns []string
+	var dests []any
+
+	for _, column := range movieColumns {
+		selected := len(fields) == 0 ||
+			column.always ||
+			(column.field != "" && rules.In(column.field, fields))
+
+		if selected {
+			columns = append(columns, column.name)
+			dests = append(dests, column.dest(movie))
+		}
+	}
+
+	return strings.Join(columns, ", "), dests
+}
+
+// jsonArray encodes the slice pointed to by its value as a JSON array column, and decodes the column into it.
+// Nil slices are encoded as empty arrays.
+type jsonArray struct {
+	value any
+}
+
+func (a jsonArray) Value() (driver.Value, error) {
+	encoded, err := json.Marshal(a.value)
+	if err != nil {
+		return nil, err
+	}
+	if string(encoded) == "null" {
+		return "[]", nil
+	}
+	return string(encoded), nil
+}
+
+func (a jsonArray) Scan(src any) error {
+	encoded, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("jsonArray: unsupported source type %T", src)
+	}
+	return json.Unmarshal(encoded, a.value)
+}
+
+// jsonObject encodes the map pointed to by its value as a JSON object column, and decodes the column into it.
+// Nil maps are encoded as empty objects.
+type jsonObject struct {
+	value any
+}
+
+func (o jsonObject) Value() (driver.Value, error) {
+	encoded, err := json.Marshal(o.value)
+	if err != nil {
+		return nil, err
+	}
+	if string(encoded) == "null" {
+		return "{}", nil
+	}
+	return string(encoded), nil
+}
+
+func (o jsonObject) Scan(src any) error {
+	return jsonArray(o).Scan(src)
+}
+
+// nullableJson encodes the value pointed to by its value as a nullable JSON column, and decodes the column into it.
+// Nil pointers are encoded as nulls, and nulls are decoded as nil pointers.
+type nullableJson struct {
+	value any
+}
+
+func (n nullableJson) Value() (driver.Value, error) {
+	encoded, err := json.Marshal(n.value)
+	if err != nil {
+		return nil, err
+	}
+	if string(encoded) == "null" {
+		return nil, nil
+	}
+	return string(encoded), nil
+}
+
+func (n nullableJson) Scan(src any) error {
+	if src == nil {
+		return nil
+	}
+	return jsonArray(n).Scan(src)
+}
+
+// movieValues returns the values of the columns which are set on the creation and update of a movie,
+// in the order of movieValueColumns.
+func movieValues(movie *models.Movie) []any {
+	return []any{
+		movie.Title,
+		movie.Year,
+		movie.Runtime,
+		pq.Array(movie.Genres),
+		movie.OriginalTitle,
+		movie.OriginalLanguage,
+		pq.Array(movie.Countries),
+		jsonArray{movie.ReleaseDates},
+		jsonArray{movie.Certifications},
+		movie.Synopsis,
+		movie.Tagline,
+		jsonObject{movie.ExternalIds},
+		nullableJson{movie.Poster},
+		nullableJson{movie.Backdrop},
+	}
+}
+
+const movieValueColumns = `title, year, runtime, genres, original_title, original_language,
+	countries, release_dates, certifications, synopsis, tagline, external_ids, poster, backdrop`
+
 // Create inserts the existing values of the Movie pointer into the database,
-// and updates the values of the pointer's id, creation time and version.
-// An error is returned if the operation fails.
+// and updates the values of the pointer's id, creation and modification times, and version.
+// An error is returned if the operation fails, which is ErrDuplicateExternalId if an external id
+// of the movie is registered to another movie.
 func (m MovieController) Create(movie *models.Movie) error {
-	stmt := `INSERT INTO movies (title, year, runtime, genres)
-	VALUES ($1, $2, $3, $4)
-	RETURNING id, created_at, version`
+	stmt := `INSERT INTO movies (` + movieValueColumns + `)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	RETURNING id, created_at, updated_at, version`
 
 	// create context for database operation with a 3-second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	row := m.Db.QueryRowContext(ctx, stmt, movie.Title, movie.Year, movie.Runtime, pq.Array(movie.Genres))
-	return row.Scan(&movie.Id, &movie.Created, &movie.Version)
+	row := m.Db.QueryRowContext(ctx, stmt, movieValues(movie)...)
+	err := row.Scan(&movie.Id, &movie.Created, &movie.Updated, &movie.Version)
+	if err != nil {
+		return movieError(err)
+	}
+	return nil
+}
+
+// BulkCreate inserts the movies into the database in a single transaction,
+// and updates the ids, creation and modification times, and versions of the slice elements.
+// The transaction is rolled back if any insertion fails.
+func (m MovieController) BulkCreate(movies models.Movies) error {
+	stmt := `INSERT INTO movies (` + movieValueColumns + `)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	RETURNING id, created_at, updated_at, version`
+
+	// create context for database operation with a 10-second timeout to accommodate the batch
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, m.Db)
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	prepared, err := tx.PrepareContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	defer prepared.Close()
+
+	for i := range movies {
+		movie := &movies[i]
+		row := prepared.QueryRowContext(ctx, movieValues(movie)...)
+		if err := row.Scan(&movie.Id, &movie.Created, &movie.Updated, &movie.Version); err != nil {
+			return movieError(err)
+		}
+	}
+
+	return tx.Commit()
 }
 
 // Get returns the movie with the given ID from the database.
-// A "record not found" error is returned if the ID doesn't belong to any movie.
-func (m MovieController) Get(id int) (models.Movie, error) {
-	stmt := `SELECT id, title, year, runtime, genres, created_at, version 
+// Only the columns of the given fields are selected if any are specified.
+// A "record not found" error is returned if the ID doesn't belong to any movie outside the trash.
+func (m MovieController) Get(id int, fields ...string) (models.Movie, error) {
+	movie := models.Movie{}
+	columns, dests := selectMovieColumns(&movie, fields)
+
+	stmt := fmt.Sprintf(`SELECT %s
 	FROM movies
-	WHERE id = $1`
+	WHERE id = $1 AND deleted_at IS NULL`, columns)
 
 	// create context for database operation with a 3-second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
 	row := m.Db.QueryRowContext(ctx, stmt, id)
-	movie := models.Movie{}
-	err := row.Scan(&movie.Id, &movie.Title, &movie.Year, &movie.Runtime,
-		pq.Array(&movie.Genres), &movie.Created, &movie.Version)
+	err := row.Scan(dests...)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -60,26 +258,65 @@ func (m MovieController) Get(id int) (models.Movie, error) {
 	return movie, nil
 }
 
+// movieQueryConditions matches the movies outside the trash which satisfy a movie query,
+// with the arguments from movieQueryArgs as the first parameters of the statement.
+// The title is searched for in both the original title and the translated titles.
+// Release dates are compared as text, which follows their chronological order in the YYYY-MM-DD format.
+const movieQueryConditions = `($1 = ''
+		OR to_tsvector('simple', title) @@ plainto_tsquery('simple', $1)
+		OR EXISTS (
+			SELECT 1 FROM movie_translations AS translation
+			WHERE translation.movie_id = movies.id
+				AND to_tsvector('simple', translation.title) @@ plainto_tsquery('simple', $1)
+		))
+	AND (genres @> $2 OR $2 = '{}')
+	AND (original_language = $3 OR $3 = '')
+	AND (countries @> $4 OR $4 = '{}')
+	AND (certifications @> $5 OR $5 IS NULL)
+	AND (($6 = '' AND $7 = '') OR EXISTS (
+		SELECT 1 FROM jsonb_array_elements(release_dates) AS release
+		WHERE ($6 = '' OR release->>'date' >= $6) AND ($7 = '' OR release->>'date' <= $7)
+	))
+	AND deleted_at IS NULL`
+
+// movieQueryArgs returns the arguments of movieQueryConditions for the query.
+func movieQueryArgs(query request.MovieQuery) []any {
+	var certification any
+	if filter := query.CertificationFilter(); filter != nil {
+		certification = jsonArray{[]models.Certification{*filter}}
+	}
+
+	return []any{
+		query.Title,
+		pq.Array(query.Genres),
+		query.Language,
+		pq.Array(query.Countries),
+		certification,
+		query.ReleasedFrom,
+		query.ReleasedTo,
+	}
+}
+
 // List fetches a list of movies from the database.
 // The movies are fetched based on the query and filter parameters.
-//
-// title supports partial searching.
+// Movies in the trash are excluded.
+// Only the columns of the given fields are selected if any are specified.
 // The metadata for the query is also returned.
-func (m MovieController) List(title string, genres []string, filters request.Filters) (models.Movies, response.Metadata, error) {
-	// interpolate the sort column and direction into the SQL query
-	// as keywords cannot be parameterized
+func (m MovieController) List(query request.MovieQuery, filters request.Filters, fields ...string) (models.Movies, response.Metadata, error) {
+	// interpolate the selected columns, sort column and direction into the SQL query
+	// as identifiers and keywords cannot be parameterized
+	columns, _ := selectMovieColumns(&models.Movie{}, fields)
 	stmt := fmt.Sprintf(
-		`SELECT count(*) OVER(), id, title, year, runtime, genres, created_at, version
+		`SELECT count(*) OVER(), %s
 	FROM movies
-	WHERE (to_tsvector('simple', title) @@ plainto_tsquery('simple', $1) OR $1 = '')
-	AND (genres @> $2 OR $2 = '{}')
+	WHERE %s
 	ORDER BY %s %s, id ASC
-	LIMIT $3 OFFSET $4`, filters.SortColumn(request.MovieFilterSortId), filters.SortDirection())
+	LIMIT $8 OFFSET $9`, columns, movieQueryConditions, filters.SortColumn(request.MovieFilterSortId), filters.SortDirection())
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	rows, err := m.Db.QueryContext(ctx, stmt, title, pq.Array(genres), filters.Limit, filters.Offset())
+	rows, err := m.Db.QueryContext(ctx, stmt, append(movieQueryArgs(query), filters.Limit, filters.Offset())...)
 	if err != nil {
 		return nil, response.Metadata{}, err
 	}
@@ -90,8 +327,8 @@ func (m MovieController) List(title string, genres []string, filters request.Fil
 
 	for rows.Next() {
 		movie := &models.Movie{}
-		_ = rows.Scan(&totalRecords, &movie.Id, &movie.Title, &movie.Year, &movie.Runtime,
-			pq.Array(&movie.Genres), &movie.Created, &movie.Version)
+		_, dests := selectMovieColumns(movie, fields)
+		_ = rows.Scan(append([]any{&totalRecords}, dests...)...)
 
 		movies = append(movies, *movie)
 	}
@@ -103,55 +340,802 @@ func (m MovieController) List(title string, genres []string, filters request.Fil
 	return movies, metadata, nil
 }
 
+// movieIteratorPageSize is the number of movies fetched by each query of a movie iterator.
+const movieIteratorPageSize = 500
+
+// Iterate returns an iterator over the movies matching the query, sorted by the filters.
+// Movies are fetched in pages with keyset pagination on the sort column and id,
+// so each query is bounded regardless of the number of movies.
+func (m MovieController) Iterate(query request.MovieQuery, filters request.Filters) repository.MovieIterator {
+	return &movieIterator{
+		controller: m,
+		query:      query,
+		filters:    filters,
+		pageSize:   movieIteratorPageSize,
+	}
+}
+
+// movieIterator is a cursor over movies which fetches a page of movies
+// whenever the current one is exhausted.
+type movieIterator struct {
+	controller MovieController
+	query      request.MovieQuery
+	filters    request.Filters
+	pageSize   int
+
+	page      models.Movies
+	index     int
+	current   models.Movie
+	exhausted bool
+	err       error
+}
+
+func (i *movieIterator) Next() bool {
+	if i.index >= len(i.page) {
+		if i.exhausted || i.err != nil {
+			return false
+		}
+
+		// fetch the page following the last movie, if any
+		var cursor *models.Movie
+		if len(i.page) > 0 {
+			cursor = &i.page[len(i.page)-1]
+		}
+
+		i.page, i.err = i.controller.listAfter(i.query, i.filters, cursor, i.pageSize)
+		i.index = 0
+		if i.err != nil {
+			return false
+		}
+		i.exhausted = len(i.page) < i.pageSize
+
+		if len(i.page) == 0 {
+			return false
+		}
+	}
+
+	i.current = i.page[i.index]
+	i.index++
+	return true
+}
+
+func (i *movieIterator) Movie() models.Movie {
+	return i.current
+}
+
+func (i *movieIterator) Err() error {
+	return i.err
+}
+
+// listAfter fetches up to limit movies matching the query which follow the cursor movie
+// in the order of the filters, excluding movies in the trash. The first movies are fetched if the cursor is nil.
+func (m MovieController) listAfter(query request.MovieQuery, filters request.Filters, cursor *models.Movie, limit int) (models.Movies, error) {
+	sortColumn := filters.SortColumn(request.MovieFilterSortId)
+	sortDirection := filters.SortDirection()
+
+	// movies follow the cursor if their sort value comes after it in the sort direction,
+	// or if the sort values are equal and the id is greater as ties are ordered by ascending ids
+	comparison := ">"
+	if sortDirection == "DESC" {
+		comparison = "<"
+	}
+
+	columns, _ := selectMovieColumns(&models.Movie{}, nil)
+	stmt := fmt.Sprintf(
+		`SELECT %[1]s
+	FROM movies
+	WHERE %[5]s
+	AND ($8 OR %[2]s %[4]s $9 OR (%[2]s = $9 AND id > $10))
+	ORDER BY %[2]s %[3]s, id ASC
+	LIMIT $11`, columns, sortColumn, sortDirection, comparison, movieQueryConditions)
+
+	var cursorValue any
+	cursorId := 0
+	if cursor != nil {
+		cursorValue = movieSortValue(*cursor, sortColumn)
+		cursorId = cursor.Id
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, append(movieQueryArgs(query), cursor == nil, cursorValue, cursorId, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := models.Movies{}
+	for rows.Next() {
+		movie := models.Movie{}
+		_, dests := selectMovieColumns(&movie, nil)
+		if err := rows.Scan(dests...); err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+// movieSortValue returns the value of the movie in the given sort column.
+func movieSortValue(movie models.Movie, sortColumn string) any {
+	switch sortColumn {
+	case request.MovieFilterSortTitle:
+		return movie.Title
+	case request.MovieFilterSortYear:
+		return movie.Year
+	case request.MovieFilterSortRuntime:
+		return movie.Runtime
+	default:
+		return movie.Id
+	}
+}
+
 // Update replaces the data of the movie in the database with those in the passed-in movie.
 // An "edit conflict" error is returned if the version of the movie in the database does not
 // match that in the parameter. This is done to prevent data races.
 func (m MovieController) Update(movie *models.Movie) error {
 	stmt := `UPDATE movies 
-	SET title = $1, year = $2, runtime = $3, genres = $4, version = version + 1
-	WHERE id = $5 AND version = $6
-	RETURNING version`
+	SET (` + movieValueColumns + `) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14),
+		version = version + 1, updated_at = now()
+	WHERE id = $15 AND version = $16 AND deleted_at IS NULL
+	RETURNING version, updated_at`
 
 	// create context for database operation with a 3-second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	row := m.Db.QueryRowContext(ctx, stmt, movie.Title, movie.Year, movie.Runtime, pq.Array(movie.Genres), movie.Id, movie.Version)
-	err := row.Scan(&movie.Version)
+	row := m.Db.QueryRowContext(ctx, stmt, append(movieValues(movie), movie.Id, movie.Version)...)
+	err := row.Scan(&movie.Version, &movie.Updated)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return repository.ErrEditConflict
 		} else {
-			return err
+			return movieError(err)
 		}
 	}
 	return nil
 }
 
-// Delete removes the movie with the given id from the database.
-// An error is returned if no movie with the id is found.
-func (m MovieController) Delete(id int) error {
-	stmt := `DELETE FROM movies 
-	WHERE id = $1`
+// Lookup returns the movie outside the trash with the given id in the external source.
+// A "record not found" error is returned if no movie has the id, or the source is unknown.
+func (m MovieController) Lookup(source string, externalId string) (models.Movie, error) {
+	// the source is interpolated for the lookup to use its unique index,
+	// so only known sources are accepted
+	if !rules.In(source, models.ExternalIdSources) {
+		return models.Movie{}, repository.ErrRecordNotFound
+	}
+
+	movie := models.Movie{}
+	columns, dests := selectMovieColumns(&movie, nil)
+	stmt := fmt.Sprintf(`SELECT %s
+	FROM movies
+	WHERE external_ids ->> '%s' = $1 AND deleted_at IS NULL`, columns, source)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.Db.QueryRowContext(ctx, stmt, externalId).Scan(dests...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Movie{}, repository.ErrRecordNotFound
+		}
+		return models.Movie{}, err
+	}
+
+	return movie, nil
+}
+
+// FindByExternalIds returns the movies with any of the given ids in the external source in a single query,
+// including the movies in the trash, which are covered by the unique indexes of the external ids.
+// No movies are returned if the source is unknown.
+func (m MovieController) FindByExternalIds(source string, externalIds []string) (models.Movies, error) {
+	// the source is interpolated for the query to use its unique index,
+	// so only known sources are accepted
+	if !rules.In(source, models.ExternalIdSources) {
+		return models.Movies{}, nil
+	}
+
+	columns, _ := selectMovieColumns(&models.Movie{}, nil)
+	stmt := fmt.Sprintf(`SELECT %s
+	FROM movies
+	WHERE external_ids ->> '%s' = ANY($1)`, columns, source)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, pq.Array(externalIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := models.Movies{}
+	for rows.Next() {
+		movie := &models.Movie{}
+		_, dests := selectMovieColumns(movie, nil)
+		if err := rows.Scan(dests...); err != nil {
+			return nil, err
+		}
+		movies = append(movies, *movie)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+// movieError maps unique constraint violations of the external ids of movies to ErrDuplicateExternalId.
+func movieError(err error) error {
+	if strings.Contains(err.Error(), "movies_external_ids_") {
+		return repository.ErrDuplicateExternalId
+	}
+	return err
+}
+
+// Delete moves the movie with the given id to the trash by setting its deletion time.
+// The movie is excluded from other queries until it is restored, or permanently removed by a purge.
+// An error is returned if no movie with the id is found outside the trash.
+func (m MovieController) Delete(id int, version int) error {
+	stmt := `UPDATE movies
+	SET deleted_at = now()
+	WHERE id = $1 AND version = $2 AND deleted_at IS NULL`
 
 	// create context for database operation with a 3-second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	result, err := m.Db.ExecContext(ctx, stmt, id)
+	result, err := m.Db.ExecContext(ctx, stmt, id, version)
 	if err != nil {
 		return err
 	}
 
-	// check if no row was deleted and return a "record not found" error
+	// check if no row was deleted and return an "edit conflict" error,
+	// as the movie was changed or deleted since the version was read
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
 
 	if rowsAffected == 0 {
-		return repository.ErrRecordNotFound
+		return repository.ErrEditConflict
 	}
 
 	return nil
 }
+
+// ListDeleted fetches a list of the movies in the trash, sorted by the filters and paginated.
+// The metadata for the query is also returned.
+func (m MovieController) ListDeleted(filters request.Filters) (models.Movies, response.Metadata, error) {
+	columns, _ := selectMovieColumns(&models.Movie{}, nil)
+	stmt := fmt.Sprintf(
+		`SELECT count(*) OVER(), %s
+	FROM movies
+	WHERE deleted_at IS NOT NULL
+	ORDER BY %s %s, id ASC
+	LIMIT $1 OFFSET $2`, columns, filters.SortColumn(request.MovieFilterSortDeleted), filters.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, filters.Limit, filters.Offset())
+	if err != nil {
+		return nil, response.Metadata{}, err
+	}
+	defer rows.Close()
+
+	movies := models.Movies{}
+	var totalRecords int
+
+	for rows.Next() {
+		movie := &models.Movie{}
+		_, dests := selectMovieColumns(movie, nil)
+		if err := rows.Scan(append([]any{&totalRecords}, dests...)...); err != nil {
+			return nil, response.Metadata{}, err
+		}
+
+		movies = append(movies, *movie)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, response.Metadata{}, err
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, totalRecords)
+	return movies, metadata, nil
+}
+
+// Restore moves the movie with the given id out of the trash and returns it.
+// A "record not found" error is returned if the movie isn't in the trash.
+func (m MovieController) Restore(id int) (models.Movie, error) {
+	movie := models.Movie{}
+	columns, dests := selectMovieColumns(&movie, nil)
+
+	stmt := fmt.Sprintf(`UPDATE movies
+	SET deleted_at = NULL
+	WHERE id = $1 AND deleted_at IS NOT NULL
+	RETURNING %s`, columns)
+
+	// create context for database operation with a 3-second timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := m.Db.QueryRowContext(ctx, stmt, id).Scan(dests...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Movie{}, repository.ErrRecordNotFound
+		} else {
+			return models.Movie{}, err
+		}
+	}
+
+	return movie, nil
+}
+
+// Purge permanently removes the movies which were moved to the trash before the given time,
+// and returns the number of removed movies along with the storage keys of their images and thumbnails.
+func (m MovieController) Purge(deletedBefore time.Time) (int64, []string, error) {
+	stmt := `DELETE FROM movies
+	WHERE deleted_at < $1
+	RETURNING poster, backdrop`
+
+	// create context for database operation with a 10-second timeout to accommodate large purges
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, deletedBefore)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	var purged int64
+	var imageKeys []string
+
+	for rows.Next() {
+		var poster, backdrop *models.MovieImage
+		if err := rows.Scan(nullableJson{&poster}, nullableJson{&backdrop}); err != nil {
+			return 0, nil, err
+		}
+
+		purged++
+		imageKeys = append(imageKeys, poster.Keys()...)
+		imageKeys = append(imageKeys, backdrop.Keys()...)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, nil, err
+	}
+
+	return purged, imageKeys, nil
+}
+
+func (m MovieController) CountByGenre() (models.GenreCounts, error) {
+	stmt := `SELECT genre, count(*)
+	FROM movies, unnest(genres) AS genre
+	WHERE deleted_at IS NULL
+	GROUP BY genre
+	ORDER BY count(*) DESC, genre ASC`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := models.GenreCounts{}
+	for rows.Next() {
+		count := models.GenreCount{}
+		err = rows.Scan(&count.Genre, &count.Count)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (m MovieController) CountByYear() (models.YearCounts, error) {
+	stmt := `SELECT year, count(*)
+	FROM movies
+	WHERE deleted_at IS NULL
+	GROUP BY year
+	ORDER BY year ASC`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := models.YearCounts{}
+	for rows.Next() {
+		count := models.YearCount{}
+		err = rows.Scan(&count.Year, &count.Count)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// RuntimeStats uses discrete percentiles, which are runtimes of actual movies.
+// The aggregates are null without movies, so they are coalesced to 0.
+func (m MovieController) RuntimeStats() (models.RuntimeStats, error) {
+	stmt := `SELECT coalesce(sum(runtime), 0), coalesce(avg(runtime), 0), coalesce(min(runtime), 0),
+		coalesce(percentile_disc(ARRAY[0.25, 0.5, 0.75, 0.9]) WITHIN GROUP (ORDER BY runtime), '{0, 0, 0, 0}'),
+		coalesce(max(runtime), 0)
+	FROM movies
+	WHERE deleted_at IS NULL`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stats := models.RuntimeStats{}
+	var percentiles []int64
+	err := m.Db.QueryRowContext(ctx, stmt).
+		Scan(&stats.Total, &stats.Mean, &stats.Min, pq.Array(&percentiles), &stats.Max)
+	if err != nil {
+		return models.RuntimeStats{}, err
+	}
+	if len(percentiles) != 4 {
+		return models.RuntimeStats{}, fmt.Errorf("runtime stats: expected 4 percentiles, got %d", len(percentiles))
+	}
+
+	stats.P25 = int(percentiles[0])
+	stats.Median = int(percentiles[1])
+	stats.P75 = int(percentiles[2])
+	stats.P90 = int(percentiles[3])
+	return stats, nil
+}
+
+func (m MovieController) ListRecent(limit int) (models.Movies, error) {
+	columns, _ := selectMovieColumns(&models.Movie{}, nil)
+	stmt := fmt.Sprintf(`SELECT %s
+	FROM movies
+	WHERE deleted_at IS NULL
+	ORDER BY created_at DESC, id DESC
+	LIMIT $1`, columns)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := models.Movies{}
+	for rows.Next() {
+		movie := &models.Movie{}
+		_, dests := selectMovieColumns(movie, nil)
+		err = rows.Scan(dests...)
+		if err != nil {
+			return nil, err
+		}
+		movies = append(movies, *movie)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+// duplicateTitleSimilarity is the minimum trigram similarity of the titles of movies
+// with the same runtime for them to be reported as duplicates.
+const duplicateTitleSimilarity = 0.6
+
+// ListDuplicates fetches the pairs of movies outside the trash which are likely to be duplicates,
+// either having the same title and year once the titles are lowercased and stripped of non-alphanumeric characters,
+// or having similar titles and the same runtime.
+// The pairs are ordered by descending similarity and paginated by the filters,
+// and the movies of each pair are ordered by id.
+func (m MovieController) ListDuplicates(filters request.Filters) (models.DuplicateCandidates, response.Metadata, error) {
+	pairsStmt := `SELECT count(*) OVER(), first_id, second_id, reason, similarity
+	FROM (
+		SELECT a.id AS first_id,
+			b.id AS second_id,
+			CASE
+				WHEN regexp_replace(lower(a.title), '[^[:alnum:]]+', '', 'g') = regexp_replace(lower(b.title), '[^[:alnum:]]+', '', 'g')
+					AND a.year = b.year THEN $1
+				ELSE $2
+			END AS reason,
+			similarity(a.title, b.title) AS similarity
+		FROM movies AS a
+		JOIN movies AS b ON a.id < b.id
+		WHERE a.deleted_at IS NULL AND b.deleted_at IS NULL
+			AND ((regexp_replace(lower(a.title), '[^[:alnum:]]+', '', 'g') = regexp_replace(lower(b.title), '[^[:alnum:]]+', '', 'g')
+					AND a.year = b.year)
+				OR (a.title % b.title AND similarity(a.title, b.title) >= $3 AND a.runtime = b.runtime))
+	) AS candidates
+	ORDER BY similarity DESC, first_id ASC, second_id ASC
+	LIMIT $4 OFFSET $5`
+
+	columns, _ := selectMovieColumns(&models.Movie{}, nil)
+	moviesStmt := fmt.Sprintf(`SELECT %s
+	FROM movies
+	WHERE id = ANY($1)`, columns)
+
+	// create context for database operation with a 10-second timeout as every pair of movies is compared
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(
+		ctx,
+		pairsStmt,
+		models.DuplicateReasonTitleYear,
+		models.DuplicateReasonSimilarTitle,
+		duplicateTitleSimilarity,
+		filters.Limit,
+		filters.Offset(),
+	)
+	if err != nil {
+		return nil, response.Metadata{}, err
+	}
+	defer rows.Close()
+
+	// the ids of each pair are collected before the movies are fetched at once
+	var totalRecords int
+	var pairs [][2]int
+	var ids []int64
+	candidates := models.DuplicateCandidates{}
+	for rows.Next() {
+		var pair [2]int
+		candidate := models.DuplicateCandidate{}
+		err = rows.Scan(&totalRecords, &pair[0], &pair[1], &candidate.Reason, &candidate.Similarity)
+		if err != nil {
+			return nil, response.Metadata{}, err
+		}
+		pairs = append(pairs, pair)
+		ids = append(ids, int64(pair[0]), int64(pair[1]))
+		candidates = append(candidates, candidate)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, response.Metadata{}, err
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, totalRecords)
+	if len(candidates) == 0 {
+		return candidates, metadata, nil
+	}
+
+	movieRows, err := m.Db.QueryContext(ctx, moviesStmt, pq.Array(ids))
+	if err != nil {
+		return nil, response.Metadata{}, err
+	}
+	defer movieRows.Close()
+
+	moviesById := map[int]models.Movie{}
+	for movieRows.Next() {
+		movie := &models.Movie{}
+		_, dests := selectMovieColumns(movie, nil)
+		if err = movieRows.Scan(dests...); err != nil {
+			return nil, response.Metadata{}, err
+		}
+		moviesById[movie.Id] = *movie
+	}
+	if err = movieRows.Err(); err != nil {
+		return nil, response.Metadata{}, err
+	}
+
+	for i, pair := range pairs {
+		candidates[i].Movies = models.Movies{moviesById[pair[0]], moviesById[pair[1]]}
+	}
+
+	return candidates, metadata, nil
+}
+
+// Merge consolidates the duplicates into the survivor in a single transaction and returns the merged survivor.
+// The duplicates are absorbed by the survivor in order of their ids, and their collection entries and translations
+// are moved to the survivor where it has none of its own in the same collection or language.
+// The duplicates are then permanently removed, leaving redirects from their ids to the survivor,
+// which also replace the existing redirects to the duplicates.
+// A merge revision is recorded with the changes to the survivor, with the user id stored as null if it is 0.
+// A "record not found" error is returned if the survivor isn't found outside the trash,
+// and ErrMissingDuplicate if any of the duplicates isn't.
+func (m MovieController) Merge(survivorId int, duplicateIds []int, userId int) (models.Movie, error) {
+	survivor := models.Movie{}
+	columns, dests := selectMovieColumns(&survivor, nil)
+
+	survivorStmt := fmt.Sprintf(`SELECT %s
+	FROM movies
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE`, columns)
+
+	duplicatesStmt := fmt.Sprintf(`SELECT %s
+	FROM movies
+	WHERE id = ANY($1) AND deleted_at IS NULL
+	ORDER BY id
+	FOR UPDATE`, columns)
+
+	moveEntriesStmt := `UPDATE collection_movies
+	SET movie_id = $1
+	WHERE movie_id = $2
+		AND collection_id NOT IN (SELECT collection_id FROM collection_movies WHERE movie_id = $1)`
+
+	deleteEntriesStmt := `DELETE FROM collection_movies
+	WHERE movie_id = ANY($1)
+	RETURNING collection_id`
+
+	// positions are renumbered to close the gaps left by the deleted entries
+	renumberStmt := `UPDATE collection_movies AS entry
+	SET position = ranked.position
+	FROM (
+		SELECT collection_id, movie_id, row_number() OVER (PARTITION BY collection_id ORDER BY position) AS position
+		FROM collection_movies
+		WHERE collection_id = ANY($1)
+	) AS ranked
+	WHERE entry.collection_id = ranked.collection_id
+		AND entry.movie_id = ranked.movie_id
+		AND entry.position <> ranked.position`
+
+	moveTranslationsStmt := `UPDATE movie_translations
+	SET movie_id = $1, updated_at = now()
+	WHERE movie_id = $2
+		AND language NOT IN (SELECT language FROM movie_translations WHERE movie_id = $1)`
+
+	updateRedirectsStmt := `UPDATE movie_redirects
+	SET movie_id = $1
+	WHERE movie_id = ANY($2)`
+
+	insertRedirectsStmt := `INSERT INTO movie_redirects (old_id, movie_id)
+	SELECT unnest($2::bigint[]), $1`
+
+	deleteDuplicatesStmt := `DELETE FROM movies
+	WHERE id = ANY($1)`
+
+	updateSurvivorStmt := `UPDATE movies
+	SET (` + movieValueColumns + `) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14),
+		version = version + 1, updated_at = now()
+	WHERE id = $15
+	RETURNING version, updated_at`
+
+	// create context for database operation with a 10-second timeout to accommodate the related records
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, m.Db)
+	if err != nil {
+		return models.Movie{}, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, survivorStmt, survivorId).Scan(dests...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Movie{}, repository.ErrRecordNotFound
+		}
+		return models.Movie{}, err
+	}
+
+	ids := pq.Array(duplicateIds)
+	rows, err := tx.QueryContext(ctx, duplicatesStmt, ids)
+	if err != nil {
+		return models.Movie{}, err
+	}
+	defer rows.Close()
+
+	duplicates := models.Movies{}
+	for rows.Next() {
+		duplicate := &models.Movie{}
+		_, dests := selectMovieColumns(duplicate, nil)
+		if err = rows.Scan(dests...); err != nil {
+			return models.Movie{}, err
+		}
+		duplicates = append(duplicates, *duplicate)
+	}
+	if err = rows.Err(); err != nil {
+		return models.Movie{}, err
+	}
+	if len(duplicates) != len(duplicateIds) {
+		return models.Movie{}, repository.ErrMissingDuplicate
+	}
+
+	previous := survivor
+
+	for _, duplicate := range duplicates {
+		survivor.Absorb(duplicate)
+
+		if _, err = tx.ExecContext(ctx, moveEntriesStmt, survivor.Id, duplicate.Id); err != nil {
+			return models.Movie{}, err
+		}
+		if _, err = tx.ExecContext(ctx, moveTranslationsStmt, survivor.Id, duplicate.Id); err != nil {
+			return models.Movie{}, err
+		}
+	}
+
+	// remove the entries which weren't moved as the survivor was already in their collections
+	entryRows, err := tx.QueryContext(ctx, deleteEntriesStmt, ids)
+	if err != nil {
+		return models.Movie{}, err
+	}
+	defer entryRows.Close()
+
+	var collectionIds []int64
+	for entryRows.Next() {
+		var collectionId int64
+		if err = entryRows.Scan(&collectionId); err != nil {
+			return models.Movie{}, err
+		}
+		collectionIds = append(collectionIds, collectionId)
+	}
+	if err = entryRows.Err(); err != nil {
+		return models.Movie{}, err
+	}
+
+	if len(collectionIds) > 0 {
+		if _, err = tx.ExecContext(ctx, renumberStmt, pq.Array(collectionIds)); err != nil {
+			return models.Movie{}, err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, updateRedirectsStmt, survivor.Id, ids); err != nil {
+		return models.Movie{}, err
+	}
+	if _, err = tx.ExecContext(ctx, insertRedirectsStmt, survivor.Id, ids); err != nil {
+		return models.Movie{}, err
+	}
+
+	// the duplicates are removed before the survivor is updated, as it may take over their external ids
+	if _, err = tx.ExecContext(ctx, deleteDuplicatesStmt, ids); err != nil {
+		return models.Movie{}, err
+	}
+
+	row := tx.QueryRowContext(ctx, updateSurvivorStmt, append(movieValues(&survivor), survivor.Id)...)
+	if err = row.Scan(&survivor.Version, &survivor.Updated); err != nil {
+		return models.Movie{}, movieError(err)
+	}
+
+	revision := models.NewMovieRevision(models.RevisionActionMerge, survivor, &previous)
+	revision.UserId = userId
+	if err = insertMovieRevision(ctx, tx, &revision); err != nil {
+		return models.Movie{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.Movie{}, err
+	}
+	return survivor, nil
+}
+
+// Redirect returns the id of the movie which the movie with the given id was merged into.
+// A "record not found" error is returned if the id wasn't merged into another movie.
+func (m MovieController) Redirect(oldId int) (int, error) {
+	stmt := `SELECT movie_id
+	FROM movie_redirects
+	WHERE old_id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var movieId int
+	err := m.Db.QueryRowContext(ctx, stmt, oldId).Scan(&movieId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repository.ErrRecordNotFound
+		}
+		return 0, err
+	}
+	return movieId, nil
+}
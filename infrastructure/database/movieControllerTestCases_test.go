@@ -1,10 +1,22 @@
 package database
 
 import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
 	"github.com/rhodeon/moviescreen/domain/models"
 	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/types"
+	"time"
 )
 
+// mustParseDate parses a date which is known to be valid.
+func mustParseDate(value string) types.Date {
+	date, err := types.ParseDate(value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
 var createMovieTestCases = map[string]struct {
 	movie        models.Movie
 	wantNewMovie models.Movie
@@ -27,21 +39,78 @@ var createMovieTestCases = map[string]struct {
 		},
 		wantErr: nil,
 	},
+
+	"registered external id": {
+		movie: models.Movie{
+			Title:       "Hamilton",
+			Year:        2020,
+			Runtime:     160,
+			Genres:      []string{"Musical"},
+			ExternalIds: map[string]string{"imdb": "tt8503618"},
+		},
+		wantNewMovie: models.Movie{
+			Title:       "Hamilton",
+			Year:        2020,
+			Runtime:     160,
+			Genres:      []string{"Musical"},
+			ExternalIds: map[string]string{"imdb": "tt8503618"},
+		},
+		wantErr: repository.ErrDuplicateExternalId,
+	},
+}
+
+var bulkCreateMovieTestCases = map[string]struct {
+	movies        models.Movies
+	wantNewMovies models.Movies
+	wantErr       error
+}{
+	"valid movies": {
+		movies: models.Movies{
+			{Title: "The Godfather", Year: 1972, Runtime: 175, Genres: []string{"Crime", "Drama"}},
+			{Title: "Parasite", Year: 2019, Runtime: 132, Genres: []string{"Thriller", "Drama"}},
+		},
+		wantNewMovies: models.Movies{
+			{Id: 4, Title: "The Godfather", Year: 1972, Runtime: 175, Genres: []string{"Crime", "Drama"}, Version: 1},
+			{Id: 5, Title: "Parasite", Year: 2019, Runtime: 132, Genres: []string{"Thriller", "Drama"}, Version: 1},
+		},
+		wantErr: nil,
+	},
 }
 
 var getMovieTestCases = map[string]struct {
 	id        int
+	fields    []string
 	wantMovie models.Movie
 	wantErr   error
 }{
 	"valid id": {
 		id: 2,
+		wantMovie: models.Movie{
+			Id:               2,
+			Title:            "Hamilton",
+			Year:             2020,
+			Runtime:          140,
+			Genres:           []string{"Musical", "Drama"},
+			OriginalTitle:    "Hamilton",
+			OriginalLanguage: "en",
+			Countries:        []string{"US"},
+			ReleaseDates:     []models.ReleaseDate{{Country: "US", Date: mustParseDate("2020-07-03")}},
+			Certifications:   []models.Certification{{Country: "US", Rating: "PG-13"}},
+			Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+			Tagline:          "An American musical.",
+			ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+			Version:          1,
+		},
+		wantErr: nil,
+	},
+
+	"valid id (with fields)": {
+		id:     2,
+		fields: []string{"title", "year"},
 		wantMovie: models.Movie{
 			Id:      2,
 			Title:   "Hamilton",
 			Year:    2020,
-			Runtime: 140,
-			Genres:  []string{"Musical", "Drama"},
 			Version: 1,
 		},
 		wantErr: nil,
@@ -54,6 +123,88 @@ var getMovieTestCases = map[string]struct {
 	},
 }
 
+var iterateMoviesTestCases = map[string]struct {
+	query    request.MovieQuery
+	filters  request.Filters
+	pageSize int
+	wantIds  []int
+}{
+	"all movies in a single page": {
+		filters:  request.Filters{Sort: "id", ValidSorts: []string{"id", "title", "year", "runtime"}},
+		pageSize: 10,
+		wantIds:  []int{1, 2, 3},
+	},
+
+	"all movies across pages": {
+		filters:  request.Filters{Sort: "id", ValidSorts: []string{"id", "title", "year", "runtime"}},
+		pageSize: 1,
+		wantIds:  []int{1, 2, 3},
+	},
+
+	"descending year across pages": {
+		filters:  request.Filters{Sort: "-year", ValidSorts: []string{"id", "title", "year", "runtime"}},
+		pageSize: 2,
+		wantIds:  []int{1, 3, 2},
+	},
+
+	"ascending title across pages": {
+		filters:  request.Filters{Sort: "title", ValidSorts: []string{"id", "title", "year", "runtime"}},
+		pageSize: 1,
+		wantIds:  []int{1, 2, 3},
+	},
+
+	"filtered by genre": {
+		query:    request.MovieQuery{Genres: []string{"Drama"}},
+		filters:  request.Filters{Sort: "id", ValidSorts: []string{"id", "title", "year", "runtime"}},
+		pageSize: 1,
+		wantIds:  []int{2},
+	},
+
+	"filtered by metadata": {
+		query: request.MovieQuery{
+			Language:      "en",
+			Countries:     []string{"US"},
+			Certification: "US:PG-13",
+			ReleasedFrom:  "2020-07-01",
+			ReleasedTo:    "2020-07-31",
+		},
+		filters:  request.Filters{Sort: "id", ValidSorts: []string{"id", "title", "year", "runtime"}},
+		pageSize: 1,
+		wantIds:  []int{2},
+	},
+
+	"release date out of range": {
+		query:    request.MovieQuery{ReleasedFrom: "2021-01-01", ReleasedTo: "2021-12-31"},
+		filters:  request.Filters{Sort: "id", ValidSorts: []string{"id", "title", "year", "runtime"}},
+		pageSize: 1,
+		wantIds:  []int{},
+	},
+
+	"no match": {
+		query:    request.MovieQuery{Title: "Godfather"},
+		filters:  request.Filters{Sort: "id", ValidSorts: []string{"id", "title", "year", "runtime"}},
+		pageSize: 1,
+		wantIds:  []int{},
+	},
+}
+
+var testPoster = &models.MovieImage{
+	Key:         "movies/3/poster/0123456789abcdef/original.png",
+	Url:         "/media/movies/3/poster/0123456789abcdef/original.png",
+	ContentType: "image/png",
+	Width:       400,
+	Height:      600,
+	Thumbnails: []models.MovieThumbnail{
+		{
+			Size:   "w185",
+			Key:    "movies/3/poster/0123456789abcdef/w185.jpg",
+			Url:    "/media/movies/3/poster/0123456789abcdef/w185.jpg",
+			Width:  185,
+			Height: 278,
+		},
+	},
+}
+
 var updateMovieTestCases = map[string]struct {
 	id               int
 	movie            models.Movie
@@ -81,6 +232,29 @@ var updateMovieTestCases = map[string]struct {
 		wantErr: nil,
 	},
 
+	"valid data with poster": {
+		id: 3,
+		movie: models.Movie{
+			Id:      3,
+			Title:   "Luca",
+			Year:    2021,
+			Runtime: 100,
+			Genres:  []string{"Adventure", "Family"},
+			Poster:  testPoster,
+			Version: 1,
+		},
+		wantUpdatedMovie: models.Movie{
+			Id:      3,
+			Title:   "Luca",
+			Year:    2021,
+			Runtime: 100,
+			Genres:  []string{"Adventure", "Family"},
+			Poster:  testPoster,
+			Version: 2,
+		},
+		wantErr: nil,
+	},
+
 	"wrong version number (data race)": {
 		id: 99,
 		movie: models.Movie{
@@ -104,16 +278,144 @@ var updateMovieTestCases = map[string]struct {
 }
 
 var deleteMovieTestCases = map[string]struct {
-	id      int
-	wantErr error
+	id       int
+	version  int
+	wantErr  error
+	wantKept bool
 }{
 	"valid id": {
 		id:      1,
+		version: 1,
 		wantErr: nil,
 	},
 
+	"stale version": {
+		id:       1,
+		version:  0,
+		wantErr:  repository.ErrEditConflict,
+		wantKept: true,
+	},
+
 	"non-existent": {
 		id:      99,
+		version: 1,
+		wantErr: repository.ErrEditConflict,
+	},
+}
+
+var restoreMovieTestCases = map[string]struct {
+	deleteId  int
+	id        int
+	wantMovie models.Movie
+	wantErr   error
+}{
+	"deleted id": {
+		deleteId: 2,
+		id:       2,
+		wantMovie: models.Movie{
+			Id:               2,
+			Title:            "Hamilton",
+			Year:             2020,
+			Runtime:          140,
+			Genres:           []string{"Musical", "Drama"},
+			OriginalTitle:    "Hamilton",
+			OriginalLanguage: "en",
+			Countries:        []string{"US"},
+			ReleaseDates:     []models.ReleaseDate{{Country: "US", Date: mustParseDate("2020-07-03")}},
+			Certifications:   []models.Certification{{Country: "US", Rating: "PG-13"}},
+			Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+			Tagline:          "An American musical.",
+			ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+			Version:          1,
+		},
+		wantErr: nil,
+	},
+
+	"id not in trash": {
+		deleteId:  2,
+		id:        1,
+		wantMovie: models.Movie{},
+		wantErr:   repository.ErrRecordNotFound,
+	},
+}
+
+var lookupMovieTestCases = map[string]struct {
+	source    string
+	id        string
+	deleteId  int
+	wantTitle string
+	wantErr   error
+}{
+	"registered id": {
+		source:    "tmdb",
+		id:        "556574",
+		wantTitle: "Hamilton",
+		wantErr:   nil,
+	},
+
+	"unregistered id": {
+		source:  "imdb",
+		id:      "tt0068646",
 		wantErr: repository.ErrRecordNotFound,
 	},
+
+	"movie in trash": {
+		source:   "imdb",
+		id:       "tt8503618",
+		deleteId: 2,
+		wantErr:  repository.ErrRecordNotFound,
+	},
+
+	"unknown source": {
+		source:  "letterboxd",
+		id:      "hamilton",
+		wantErr: repository.ErrRecordNotFound,
+	},
+}
+
+var findMoviesByExternalIdsTestCases = map[string]struct {
+	source   string
+	ids      []string
+	deleteId int
+	wantIds  []int
+}{
+	"registered and unregistered ids": {
+		source:  "imdb",
+		ids:     []string{"tt0068646", "tt8503618"},
+		wantIds: []int{2},
+	},
+
+	"movie in trash": {
+		source:   "imdb",
+		ids:      []string{"tt8503618"},
+		deleteId: 2,
+		wantIds:  []int{2},
+	},
+
+	"unknown source": {
+		source:  "letterboxd",
+		ids:     []string{"hamilton"},
+		wantIds: []int{},
+	},
+}
+
+var purgeMoviesTestCases = map[string]struct {
+	deleteIds     []int
+	deletedBefore time.Duration
+	wantPurged    int64
+	wantImageKeys []string
+}{
+	"deleted before cutoff": {
+		deleteIds:     []int{1, 3},
+		deletedBefore: time.Minute,
+		wantPurged:    2,
+		wantImageKeys: []string{"movies/1/poster.jpg", "movies/1/poster-w185.jpg"},
+	},
+
+	"deleted after cutoff": {
+		deleteIds:     []int{1, 3},
+		deletedBefore: -time.Minute,
+		wantPurged:    0,
+		wantImageKeys: nil,
+	},
 }
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/domain/models"
 	"github.com/rhodeon/moviescreen/domain/repository"
 	"github.com/rhodeon/moviescreen/internal/testhelpers"
 	"testing"
@@ -23,13 +25,39 @@ func TestMovieController_Create(t *testing.T) {
 
 			testhelpers.AssertError(t, err, tc.wantErr)
 
-			// reset new movie creation time as it can't be tested with the current implementation
+			// reset new movie creation and modification times as they can't be tested with the current implementation
 			tc.movie.Created = time.Time{}
+			tc.movie.Updated = time.Time{}
 			testhelpers.AssertStruct(t, tc.movie, tc.wantNewMovie)
 		})
 	}
 }
 
+func TestMovieController_BulkCreate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+	testCases := bulkCreateMovieTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			movieController := MovieController{Db: db}
+			defer teardown()
+
+			err := movieController.BulkCreate(tc.movies)
+
+			testhelpers.AssertError(t, err, tc.wantErr)
+
+			for i := range tc.movies {
+				tc.movies[i].Created = time.Time{}
+				tc.movies[i].Updated = time.Time{}
+			}
+			testhelpers.AssertStruct(t, tc.movies, tc.wantNewMovies)
+		})
+	}
+}
+
 func TestMovieController_Get(t *testing.T) {
 	if testing.Short() {
 		t.Skip("postgres: skipping integration test")
@@ -42,16 +70,97 @@ func TestMovieController_Get(t *testing.T) {
 			movieController := MovieController{Db: db}
 			defer teardown()
 
-			movie, err := movieController.Get(tc.id)
+			movie, err := movieController.Get(tc.id, tc.fields...)
 
 			testhelpers.AssertError(t, err, tc.wantErr)
 
 			movie.Created = time.Time{}
+			movie.Updated = time.Time{}
 			testhelpers.AssertStruct(t, movie, tc.wantMovie)
 		})
 	}
 }
 
+func TestMovieController_Lookup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+	testCases := lookupMovieTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			movieController := MovieController{Db: db}
+			defer teardown()
+
+			if tc.deleteId != 0 {
+				err := movieController.Delete(tc.deleteId, 1)
+				testhelpers.AssertFatalError(t, err)
+			}
+
+			movie, err := movieController.Lookup(tc.source, tc.id)
+
+			testhelpers.AssertError(t, err, tc.wantErr)
+			testhelpers.AssertEqual(t, movie.Title, tc.wantTitle)
+		})
+	}
+}
+
+func TestMovieController_FindByExternalIds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+	testCases := findMoviesByExternalIdsTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			movieController := MovieController{Db: db}
+			defer teardown()
+
+			if tc.deleteId != 0 {
+				err := movieController.Delete(tc.deleteId, 1)
+				testhelpers.AssertFatalError(t, err)
+			}
+
+			movies, err := movieController.FindByExternalIds(tc.source, tc.ids)
+			testhelpers.AssertError(t, err, nil)
+
+			ids := []int{}
+			for _, movie := range movies {
+				ids = append(ids, movie.Id)
+			}
+			testhelpers.AssertStruct(t, ids, tc.wantIds)
+		})
+	}
+}
+
+func TestMovieController_Iterate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+	testCases := iterateMoviesTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			movieController := MovieController{Db: db}
+			defer teardown()
+
+			iterator := movieController.Iterate(tc.query, tc.filters).(*movieIterator)
+			iterator.pageSize = tc.pageSize
+
+			ids := []int{}
+			for iterator.Next() {
+				ids = append(ids, iterator.Movie().Id)
+			}
+
+			testhelpers.AssertError(t, iterator.Err(), nil)
+			testhelpers.AssertStruct(t, ids, tc.wantIds)
+		})
+	}
+}
+
 func TestMovieController_Update(t *testing.T) {
 	if testing.Short() {
 		t.Skip("postgres: skipping integration test")
@@ -68,7 +177,16 @@ func TestMovieController_Update(t *testing.T) {
 			testhelpers.AssertError(t, err, tc.wantErr)
 
 			tc.movie.Created = time.Time{}
+			tc.movie.Updated = time.Time{}
 			testhelpers.AssertStruct(t, tc.movie, tc.wantUpdatedMovie)
+
+			// check database to ensure the images are stored as given
+			if tc.wantErr == nil {
+				movie, err := movieController.Get(tc.id)
+				testhelpers.AssertFatalError(t, err)
+				testhelpers.AssertStruct(t, movie.Poster, tc.wantUpdatedMovie.Poster)
+				testhelpers.AssertStruct(t, movie.Backdrop, tc.wantUpdatedMovie.Backdrop)
+			}
 		})
 	}
 }
@@ -85,14 +203,225 @@ func TestMovieController_Delete(t *testing.T) {
 			movieController := MovieController{Db: db}
 			defer teardown()
 
-			err := movieController.Delete(tc.id)
+			err := movieController.Delete(tc.id, tc.version)
 			testhelpers.AssertError(t, err, tc.wantErr)
 
-			// check database to ensure the movie was deleted
+			// check database to ensure the movie was deleted, or kept if the version was stale
 			_, err = movieController.Get(tc.id)
-			if err != repository.ErrRecordNotFound {
+			if tc.wantKept {
+				testhelpers.AssertError(t, err, nil)
+			} else if err != repository.ErrRecordNotFound {
 				t.Errorf("movie with id %d still exists in the database", tc.id)
 			}
 		})
 	}
 }
+
+func TestMovieController_ListDeleted(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	movieController := MovieController{Db: db}
+	defer teardown()
+
+	for _, id := range []int{3, 1} {
+		err := movieController.Delete(id, 1)
+		testhelpers.AssertFatalError(t, err)
+	}
+
+	movies, metadata, err := movieController.ListDeleted(request.Filters{
+		Page:       1,
+		Limit:      20,
+		Sort:       "id",
+		ValidSorts: []string{"id", "title", "deleted_at"},
+	})
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, metadata.TotalRecords, 2)
+
+	ids := []int{}
+	for _, movie := range movies {
+		if movie.Deleted == nil {
+			t.Errorf("movie with id %d has no deletion time", movie.Id)
+		}
+		ids = append(ids, movie.Id)
+	}
+	testhelpers.AssertStruct(t, ids, []int{1, 3})
+}
+
+func TestMovieController_Restore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+	testCases := restoreMovieTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			movieController := MovieController{Db: db}
+			defer teardown()
+
+			err := movieController.Delete(tc.deleteId, 1)
+			testhelpers.AssertFatalError(t, err)
+
+			movie, err := movieController.Restore(tc.id)
+			testhelpers.AssertError(t, err, tc.wantErr)
+
+			movie.Created = time.Time{}
+			movie.Updated = time.Time{}
+			testhelpers.AssertStruct(t, movie, tc.wantMovie)
+
+			// check database to ensure the restored movie is accessible again
+			if tc.wantErr == nil {
+				_, err = movieController.Get(tc.id)
+				testhelpers.AssertError(t, err, nil)
+			}
+		})
+	}
+}
+
+func TestMovieController_Purge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+	testCases := purgeMoviesTestCases
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			movieController := MovieController{Db: db}
+			defer teardown()
+
+			// give Bullet Train a poster to be removed from the storage with it
+			movie, err := movieController.Get(1)
+			testhelpers.AssertFatalError(t, err)
+			movie.Poster = &models.MovieImage{
+				Key:        "movies/1/poster.jpg",
+				Thumbnails: []models.MovieThumbnail{{Size: "w185", Key: "movies/1/poster-w185.jpg"}},
+			}
+			err = movieController.Update(&movie)
+			testhelpers.AssertFatalError(t, err)
+
+			for _, id := range tc.deleteIds {
+				movie, err := movieController.Get(id)
+				testhelpers.AssertFatalError(t, err)
+				err = movieController.Delete(id, movie.Version)
+				testhelpers.AssertFatalError(t, err)
+			}
+
+			purged, imageKeys, err := movieController.Purge(time.Now().Add(tc.deletedBefore))
+			testhelpers.AssertError(t, err, nil)
+			testhelpers.AssertEqual(t, purged, tc.wantPurged)
+			testhelpers.AssertStruct(t, imageKeys, tc.wantImageKeys)
+		})
+	}
+}
+
+func TestMovieController_Stats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	movieController := MovieController{Db: db}
+	defer teardown()
+
+	// Bullet Train is left out of the aggregates once it is in the trash
+	err := movieController.Delete(1, 1)
+	testhelpers.AssertFatalError(t, err)
+
+	genres, err := movieController.CountByGenre()
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertStruct(t, genres, models.GenreCounts{
+		{Genre: "Adventure", Count: 1},
+		{Genre: "Drama", Count: 1},
+		{Genre: "Family", Count: 1},
+		{Genre: "Musical", Count: 1},
+	})
+
+	years, err := movieController.CountByYear()
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertStruct(t, years, models.YearCounts{
+		{Year: 2020, Count: 1},
+		{Year: 2021, Count: 1},
+	})
+
+	runtime, err := movieController.RuntimeStats()
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertStruct(t, runtime, models.RuntimeStats{
+		Total:  240,
+		Mean:   120,
+		Min:    100,
+		P25:    100,
+		Median: 100,
+		P75:    140,
+		P90:    140,
+		Max:    140,
+	})
+
+	// the movies are added together, so the latest ids are the most recent
+	recent, err := movieController.ListRecent(1)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, len(recent), 1)
+	testhelpers.AssertEqual(t, recent[0].Title, "Luca")
+}
+
+func TestMovieController_Merge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	movieController := MovieController{Db: db}
+	revisionController := MovieRevisionController{Db: db}
+	defer teardown()
+
+	// the duplicate has the same title as Hamilton once normalised
+	duplicate := models.Movie{
+		Title:    "HAMILTON!",
+		Year:     2020,
+		Runtime:  160,
+		Genres:   []string{"Musical", "History"},
+		Synopsis: "A filmed stage performance.",
+	}
+	err := movieController.Create(&duplicate)
+	testhelpers.AssertFatalError(t, err)
+
+	candidates, _, err := movieController.ListDuplicates(request.Filters{Page: 1, Limit: 20})
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, len(candidates), 1)
+	testhelpers.AssertEqual(t, candidates[0].Reason, models.DuplicateReasonTitleYear)
+	testhelpers.AssertEqual(t, candidates[0].Movies[0].Id, 2)
+	testhelpers.AssertEqual(t, candidates[0].Movies[1].Id, duplicate.Id)
+
+	_, err = movieController.Merge(2, []int{duplicate.Id, 99}, 1)
+	testhelpers.AssertError(t, err, repository.ErrMissingDuplicate)
+
+	_, err = movieController.Merge(99, []int{duplicate.Id}, 1)
+	testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+
+	// the survivor keeps its own metadata and gains the genres of the duplicate
+	merged, err := movieController.Merge(2, []int{duplicate.Id}, 1)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertStruct(t, merged.Genres, []string{"Musical", "Drama", "History"})
+	testhelpers.AssertEqual(t, merged.Runtime, 140)
+	testhelpers.AssertEqual(t, merged.Synopsis, "The story of Alexander Hamilton, told through hip-hop.")
+	testhelpers.AssertEqual(t, merged.Version, 2)
+
+	_, err = movieController.Get(duplicate.Id)
+	testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+
+	survivorId, err := movieController.Redirect(duplicate.Id)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, survivorId, 2)
+
+	_, err = movieController.Redirect(2)
+	testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+
+	revision, err := revisionController.Get(2, 2)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, revision.Action, models.RevisionActionMerge)
+	testhelpers.AssertEqual(t, revision.UserId, 1)
+	testhelpers.AssertStruct(t, revision.Diff["genres"].To, []any{"Musical", "Drama", "History"})
+}
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"time"
+)
+
+type MovieRevisionController struct {
+	Db Conn
+}
+
+// Insert adds the revisions to the database in a single transaction,
+// and updates the ids and creation times of the revision pointers.
+// The user id of a revision is stored as null if it is 0.
+func (m MovieRevisionController) Insert(revisions ...*models.MovieRevision) error {
+	// create context for database operation with a 3-second timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, m.Db)
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	for _, revision := range revisions {
+		if err := insertMovieRevision(ctx, tx, revision); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// insertMovieRevision adds the revision to the database within the transaction,
+// and updates the id and creation time of the revision pointer.
+// The user id of the revision is stored as null if it is 0.
+func insertMovieRevision(ctx context.Context, tx Conn, revision *models.MovieRevision) error {
+	stmt := `INSERT INTO movie_revisions (movie_id, version, action, snapshot, diff, user_id)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at`
+
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	// store a null diff for revisions without one,
+	// as a nil byte slice would be sent as an empty value
+	var diff any
+	if revision.Diff != nil {
+		diff, err = json.Marshal(revision.Diff)
+		if err != nil {
+			return err
+		}
+	}
+
+	userId := sql.NullInt64{Int64: int64(revision.UserId), Valid: revision.UserId != 0}
+
+	row := tx.QueryRowContext(ctx, stmt, revision.MovieId, revision.Version, revision.Action, snapshot, diff, userId)
+	return row.Scan(&revision.Id, &revision.Created)
+}
+
+// Get returns the latest revision of the movie with the given version, excluding deletions.
+// A "record not found" error is returned if the movie has no such revision.
+func (m MovieRevisionController) Get(movieId int, version int) (models.MovieRevision, error) {
+	stmt := `SELECT id, movie_id, version, action, snapshot, diff, user_id, created_at
+	FROM movie_revisions
+	WHERE movie_id = $1 AND version = $2 AND action <> $3
+	ORDER BY id DESC
+	LIMIT 1`
+
+	// create context for database operation with a 3-second timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	row := m.Db.QueryRowContext(ctx, stmt, movieId, version, models.RevisionActionDelete)
+	revision, err := scanMovieRevision(row.Scan)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.MovieRevision{}, repository.ErrRecordNotFound
+		} else {
+			return models.MovieRevision{}, err
+		}
+	}
+
+	return revision, nil
+}
+
+// List fetches the revisions of the movie with the given id from the database,
+// sorted by version and paginated by the filters.
+// Revisions of the same version are ordered by their creation.
+// The metadata for the query is also returned.
+func (m MovieRevisionController) List(movieId int, filters request.Filters) (models.MovieRevisions, response.Metadata, error) {
+	// interpolate the sort direction into the SQL query as keywords cannot be parameterized
+	stmt := fmt.Sprintf(`SELECT count(*) OVER(), id, movie_id, version, action, snapshot, diff, user_id, created_at
+	FROM movie_revisions
+	WHERE movie_id = $1
+	ORDER BY version %[1]s, id %[1]s
+	LIMIT $2 OFFSET $3`, filters.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, movieId, filters.Limit, filters.Offset())
+	if err != nil {
+		return nil, response.Metadata{}, err
+	}
+	defer rows.Close()
+
+	revisions := models.MovieRevisions{}
+	var totalRecords int
+
+	for rows.Next() {
+		revision, err := scanMovieRevision(func(dests ...any) error {
+			return rows.Scan(append([]any{&totalRecords}, dests...)...)
+		})
+		if err != nil {
+			return nil, response.Metadata{}, err
+		}
+		revisions = append(revisions, revision)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, response.Metadata{}, err
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, totalRecords)
+	return revisions, metadata, nil
+}
+
+// PurgeRemoved deletes the revisions of movies which are no longer in the database,
+// once the latest revision of the movie was created before the given time.
+// The number of deleted revisions is returned.
+func (m MovieRevisionController) PurgeRemoved(changedBefore time.Time) (int64, error) {
+	stmt := `DELETE FROM movie_revisions
+	WHERE movie_id IN (
+		SELECT movie_id
+		FROM movie_revisions AS revision
+		WHERE NOT EXISTS (SELECT 1 FROM movies WHERE movies.id = revision.movie_id)
+		GROUP BY movie_id
+		HAVING max(created_at) < $1
+	)`
+
+	// create context for database operation with a 10-second timeout to accommodate large purges
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := m.Db.ExecContext(ctx, stmt, changedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// scanMovieRevision scans the columns of a revision with the scan function
+// and decodes its snapshot and diff.
+func scanMovieRevision(scan func(dests ...any) error) (models.MovieRevision, error) {
+	revision := models.MovieRevision{}
+	var snapshot, diff []byte
+	var userId sql.NullInt64
+
+	err := scan(&revision.Id, &revision.MovieId, &revision.Version, &revision.Action, &snapshot, &diff, &userId, &revision.Created)
+	if err != nil {
+		return models.MovieRevision{}, err
+	}
+
+	if err := json.Unmarshal(snapshot, &revision.Snapshot); err != nil {
+		return models.MovieRevision{}, err
+	}
+	if diff != nil {
+		if err := json.Unmarshal(diff, &revision.Diff); err != nil {
+			return models.MovieRevision{}, err
+		}
+	}
+	revision.UserId = int(userId.Int64)
+
+	return revision, nil
+}
@@ -0,0 +1,180 @@
+package database
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+	"time"
+)
+
+// insertTestRevisions records a creation and an update of the movie with id 2,
+// followed by its deletion, and returns the revisions.
+func insertTestRevisions(t *testing.T, revisionController MovieRevisionController) []*models.MovieRevision {
+	t.Helper()
+
+	original := models.Movie{Id: 2, Title: "Hamilton", Year: 2020, Runtime: 140, Genres: []string{"Musical", "Drama"}, Version: 1}
+	updated := models.Movie{Id: 2, Title: "Hamilton", Year: 2020, Runtime: 160, Genres: []string{"Musical"}, Version: 2}
+
+	create := models.NewMovieRevision(models.RevisionActionCreate, original, nil)
+	update := models.NewMovieRevision(models.RevisionActionUpdate, updated, &original)
+	update.UserId = 1
+	deletion := models.NewMovieRevision(models.RevisionActionDelete, updated, nil)
+
+	revisions := []*models.MovieRevision{&create, &update, &deletion}
+	err := revisionController.Insert(revisions...)
+	testhelpers.AssertFatalError(t, err)
+
+	return revisions
+}
+
+func TestMovieRevisionController_Get(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	testCases := map[string]struct {
+		movieId      int
+		version      int
+		wantRevision models.MovieRevision
+		wantErr      error
+	}{
+		"update of version": {
+			movieId: 2,
+			version: 2,
+			wantRevision: models.MovieRevision{
+				MovieId:  2,
+				Version:  2,
+				Action:   models.RevisionActionUpdate,
+				Snapshot: models.MovieSnapshot{Title: "Hamilton", Year: 2020, Runtime: 160, Genres: []string{"Musical"}},
+				Diff: models.MovieDiff{
+					"runtime": {From: float64(140), To: float64(160)},
+					"genres":  {From: []any{"Musical", "Drama"}, To: []any{"Musical"}},
+				},
+				UserId: 1,
+			},
+			wantErr: nil,
+		},
+
+		"creation without diff": {
+			movieId: 2,
+			version: 1,
+			wantRevision: models.MovieRevision{
+				MovieId:  2,
+				Version:  1,
+				Action:   models.RevisionActionCreate,
+				Snapshot: models.MovieSnapshot{Title: "Hamilton", Year: 2020, Runtime: 140, Genres: []string{"Musical", "Drama"}},
+			},
+			wantErr: nil,
+		},
+
+		"non-existent version": {
+			movieId:      2,
+			version:      3,
+			wantRevision: models.MovieRevision{},
+			wantErr:      repository.ErrRecordNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			revisionController := MovieRevisionController{Db: db}
+			defer teardown()
+
+			insertTestRevisions(t, revisionController)
+
+			revision, err := revisionController.Get(tc.movieId, tc.version)
+			testhelpers.AssertError(t, err, tc.wantErr)
+
+			// reset the id and creation time as they can't be tested with the current implementation
+			revision.Id = 0
+			revision.Created = time.Time{}
+			testhelpers.AssertStruct(t, revision, tc.wantRevision)
+		})
+	}
+}
+
+func TestMovieRevisionController_List(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	revisionController := MovieRevisionController{Db: db}
+	defer teardown()
+
+	inserted := insertTestRevisions(t, revisionController)
+
+	revisions, metadata, err := revisionController.List(2, request.Filters{
+		Page:       1,
+		Limit:      2,
+		Sort:       "-version",
+		ValidSorts: []string{"version"},
+	})
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, metadata.TotalRecords, 3)
+
+	// the deletion and update of version 2 are on the first page, with the latest first
+	ids := []int{}
+	for _, revision := range revisions {
+		ids = append(ids, revision.Id)
+	}
+	testhelpers.AssertStruct(t, ids, []int{inserted[2].Id, inserted[1].Id})
+}
+
+func TestMovieRevisionController_PurgeRemoved(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	testCases := map[string]struct {
+		changedBefore time.Duration
+		wantPurged    int64
+		wantRemaining int
+	}{
+		"within retention": {
+			changedBefore: -time.Hour,
+			wantPurged:    0,
+			wantRemaining: 3,
+		},
+
+		"past retention": {
+			changedBefore: time.Hour,
+			wantPurged:    3,
+			wantRemaining: 0,
+		},
+	}
+
+	filters := request.Filters{Page: 1, Limit: 10, Sort: "version", ValidSorts: []string{"version"}}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			movieController := MovieController{Db: db}
+			revisionController := MovieRevisionController{Db: db}
+			defer teardown()
+
+			insertTestRevisions(t, revisionController)
+
+			// the revisions of Hamilton survive it being purged from the trash
+			err := movieController.Delete(2, 1)
+			testhelpers.AssertFatalError(t, err)
+			_, _, err = movieController.Purge(time.Now().Add(time.Hour))
+			testhelpers.AssertFatalError(t, err)
+
+			_, metadata, err := revisionController.List(2, filters)
+			testhelpers.AssertFatalError(t, err)
+			testhelpers.AssertEqual(t, metadata.TotalRecords, 3)
+
+			purged, err := revisionController.PurgeRemoved(time.Now().Add(tc.changedBefore))
+			testhelpers.AssertError(t, err, nil)
+			testhelpers.AssertEqual(t, purged, tc.wantPurged)
+
+			_, metadata, err = revisionController.List(2, filters)
+			testhelpers.AssertFatalError(t, err)
+			testhelpers.AssertEqual(t, metadata.TotalRecords, tc.wantRemaining)
+		})
+	}
+}
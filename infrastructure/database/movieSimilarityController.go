@@ -0,0 +1,195 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"time"
+)
+
+type MovieSimilarityController struct {
+	Db Conn
+}
+
+// Weights of the components of the similarity score, which add up to 1.
+const (
+	similarityWeightGenres  = 0.4
+	similarityWeightDecade  = 0.2
+	similarityWeightRuntime = 0.2
+	similarityWeightTitle   = 0.2
+)
+
+func (m MovieSimilarityController) List(movieId int, limit int) (models.SimilarMovies, error) {
+	columns, _ := selectMovieColumns(&models.Movie{}, nil)
+	stmt := fmt.Sprintf(`SELECT similarity.score, %s
+	FROM movie_similarities AS similarity
+	JOIN movies ON movies.id = similarity.similar_movie_id
+	WHERE similarity.movie_id = $1 AND movies.deleted_at IS NULL
+	ORDER BY similarity.score DESC, movies.id ASC
+	LIMIT $2`, columns)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, movieId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	similarMovies := models.SimilarMovies{}
+	for rows.Next() {
+		similar := models.SimilarMovie{}
+		_, dests := selectMovieColumns(&similar.Movie, nil)
+		err = rows.Scan(append([]any{&similar.Score}, dests...)...)
+		if err != nil {
+			return nil, err
+		}
+		similarMovies = append(similarMovies, similar)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return similarMovies, nil
+}
+
+// Refresh scores each movie outside the trash against the candidates which share a genre with it
+// or have a similar title, by the weighted sum of:
+//   - the Jaccard index of their genres,
+//   - the proximity of their decades, which is 0 for movies 5 or more decades apart,
+//   - the similarity of their runtimes, relative to the longer one,
+//   - the trigram similarity of their titles.
+//
+// The candidates are found through the indexes of the genres and titles,
+// and the movies are refreshed in chunks of similarityRefreshChunkSize,
+// each replacing the previous similarities of its movies in its own transaction.
+func (m MovieSimilarityController) Refresh() (int, error) {
+	stored := 0
+	for lastId := 0; ; {
+		movieIds, err := m.nextRefreshChunk(lastId)
+		if err != nil {
+			return stored, err
+		}
+		if len(movieIds) == 0 {
+			break
+		}
+
+		chunkStored, err := m.refreshChunk(movieIds)
+		if err != nil {
+			return stored, err
+		}
+		stored += chunkStored
+		lastId = movieIds[len(movieIds)-1]
+	}
+
+	// clear the similarities of the movies moved to the trash since the last refresh
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.Db.ExecContext(ctx, `DELETE FROM movie_similarities
+	WHERE movie_id IN (SELECT id FROM movies WHERE deleted_at IS NOT NULL)`)
+	if err != nil {
+		return stored, err
+	}
+
+	return stored, nil
+}
+
+// similarityRefreshChunkSize is the number of movies whose similarities are refreshed per transaction.
+const similarityRefreshChunkSize = 50
+
+// nextRefreshChunk returns the ids of the next chunk of movies outside the trash after the given id.
+func (m MovieSimilarityController) nextRefreshChunk(lastId int) ([]int, error) {
+	stmt := `SELECT id
+	FROM movies
+	WHERE deleted_at IS NULL AND id > $1
+	ORDER BY id
+	LIMIT $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, lastId, similarityRefreshChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movieIds []int
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		movieIds = append(movieIds, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movieIds, nil
+}
+
+// refreshChunk replaces the similarities of the given movies with the MaxSimilarMovies highest scores
+// of each of them, returning the number of similarities stored.
+func (m MovieSimilarityController) refreshChunk(movieIds []int) (int, error) {
+	stmt := `WITH scores AS (
+		SELECT movie.id AS movie_id, other.id AS similar_movie_id,
+			$1 * cardinality(ARRAY(SELECT unnest(movie.genres) INTERSECT SELECT unnest(other.genres)))::float
+				/ cardinality(ARRAY(SELECT unnest(movie.genres) UNION SELECT unnest(other.genres)))
+			+ $2 * greatest(0, 1 - abs(movie.year / 10 - other.year / 10) / 5.0)
+			+ $3 * (1 - abs(movie.runtime - other.runtime)::float / greatest(movie.runtime, other.runtime))
+			+ $4 * similarity(movie.title, other.title) AS score
+		FROM movies AS movie
+		JOIN movies AS other ON other.id <> movie.id
+			AND (other.genres && movie.genres OR other.title % movie.title)
+		WHERE movie.id = ANY($6) AND other.deleted_at IS NULL
+	), ranked AS (
+		SELECT movie_id, similar_movie_id, score,
+			row_number() OVER (PARTITION BY movie_id ORDER BY score DESC, similar_movie_id ASC) AS rank
+		FROM scores
+		WHERE score > 0
+	)
+	INSERT INTO movie_similarities (movie_id, similar_movie_id, score)
+	SELECT movie_id, similar_movie_id, least(score, 1)
+	FROM ranked
+	WHERE rank <= $5`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, m.Db)
+	if err != nil {
+		return 0, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM movie_similarities WHERE movie_id = ANY($1)`, pq.Array(movieIds))
+	if err != nil {
+		return 0, err
+	}
+
+	args := []any{
+		similarityWeightGenres,
+		similarityWeightDecade,
+		similarityWeightRuntime,
+		similarityWeightTitle,
+		repository.MaxSimilarMovies,
+		pq.Array(movieIds),
+	}
+	result, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	stored, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(stored), tx.Commit()
+}
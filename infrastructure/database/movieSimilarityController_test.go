@@ -0,0 +1,63 @@
+package database
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+)
+
+func TestMovieSimilarityController_Refresh(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	movieSimilarityController := MovieSimilarityController{Db: db}
+	movieController := MovieController{Db: db}
+	defer teardown()
+
+	// only movies sharing a genre or with similar titles are compared,
+	// which none of the 3 movies do
+	stored, err := movieSimilarityController.Refresh()
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, stored, 0)
+
+	// The Fall Guy shares the genres of Bullet Train, and Turning Red shares a genre of Luca
+	for _, movie := range []models.Movie{
+		{Title: "The Fall Guy", Year: 2024, Runtime: 126, Genres: []string{"Action", "Comedy"}},
+		{Title: "Turning Red", Year: 2022, Runtime: 100, Genres: []string{"Animation", "Family"}},
+	} {
+		err = movieController.Create(&movie)
+		testhelpers.AssertFatalError(t, err)
+	}
+
+	stored, err = movieSimilarityController.Refresh()
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, stored, 4)
+
+	similarMovies, err := movieSimilarityController.List(1, 20)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, len(similarMovies), 1)
+	testhelpers.AssertEqual(t, similarMovies[0].Movie.Title, "The Fall Guy")
+
+	similarMovies, err = movieSimilarityController.List(2, 20)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, len(similarMovies), 0)
+
+	// movies in the trash are left out before the next refresh, and of the refresh itself
+	err = movieController.Delete(5, 1)
+	testhelpers.AssertError(t, err, nil)
+
+	similarMovies, err = movieSimilarityController.List(3, 20)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, len(similarMovies), 0)
+
+	stored, err = movieSimilarityController.Refresh()
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, stored, 2)
+
+	// the similarities of movies in the trash are cleared by the refresh
+	similarMovies, err = movieSimilarityController.List(5, 20)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, len(similarMovies), 0)
+}
@@ -0,0 +1,151 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"time"
+)
+
+type MovieTranslationController struct {
+	Db Conn
+}
+
+const movieTranslationColumns = `movie_id, language, title, synopsis, tagline, created_at, updated_at`
+
+// List returns the translations of the movie with the given id, ordered by language.
+func (m MovieTranslationController) List(movieId int) (models.MovieTranslations, error) {
+	stmt := `SELECT ` + movieTranslationColumns + `
+	FROM movie_translations
+	WHERE movie_id = $1
+	ORDER BY language`
+
+	// create context for database operation with a 3-second timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, movieId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := models.MovieTranslations{}
+	for rows.Next() {
+		translation, err := scanMovieTranslation(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, translation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// ListForMovies returns the translations of the movies with the given ids, grouped by movie id
+// and ordered by language.
+func (m MovieTranslationController) ListForMovies(movieIds []int) (map[int]models.MovieTranslations, error) {
+	stmt := `SELECT ` + movieTranslationColumns + `
+	FROM movie_translations
+	WHERE movie_id = ANY($1)
+	ORDER BY movie_id, language`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx, stmt, pq.Array(movieIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := map[int]models.MovieTranslations{}
+	for rows.Next() {
+		translation, err := scanMovieTranslation(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		translations[translation.MovieId] = append(translations[translation.MovieId], translation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// Upsert inserts the translation or replaces the existing one in its language.
+// Nothing is inserted if the movie doesn't exist or is in the trash, in which case a "record not found" error is returned.
+// The system column xmax is 0 for freshly inserted rows, which distinguishes them from updated ones.
+func (m MovieTranslationController) Upsert(translation *models.MovieTranslation) (bool, error) {
+	stmt := `INSERT INTO movie_translations (movie_id, language, title, synopsis, tagline)
+	SELECT id, $2, $3, $4, $5
+	FROM movies
+	WHERE id = $1 AND deleted_at IS NULL
+	ON CONFLICT (movie_id, language) DO UPDATE
+	SET title = excluded.title, synopsis = excluded.synopsis, tagline = excluded.tagline, updated_at = now()
+	RETURNING created_at, updated_at, xmax = 0`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	args := []any{translation.MovieId, translation.Language, translation.Title, translation.Synopsis, translation.Tagline}
+	var inserted bool
+	err := m.Db.QueryRowContext(ctx, stmt, args...).Scan(&translation.Created, &translation.Updated, &inserted)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, repository.ErrRecordNotFound
+		}
+		return false, err
+	}
+
+	return inserted, nil
+}
+
+// Delete removes the translation of the movie in the given language.
+// A "record not found" error is returned if the movie has no translation in the language.
+func (m MovieTranslationController) Delete(movieId int, language string) error {
+	stmt := `DELETE FROM movie_translations
+	WHERE movie_id = $1 AND language = $2`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.Db.ExecContext(ctx, stmt, movieId, language)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// scanMovieTranslation scans the columns of movieTranslationColumns from a row into a translation.
+func scanMovieTranslation(scan func(dest ...any) error) (models.MovieTranslation, error) {
+	translation := models.MovieTranslation{}
+	err := scan(
+		&translation.MovieId,
+		&translation.Language,
+		&translation.Title,
+		&translation.Synopsis,
+		&translation.Tagline,
+		&translation.Created,
+		&translation.Updated,
+	)
+	return translation, err
+}
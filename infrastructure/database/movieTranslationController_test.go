@@ -0,0 +1,92 @@
+package database
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+)
+
+func TestMovieTranslationController_ListForMovies(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	translationController := MovieTranslationController{Db: db}
+	defer teardown()
+
+	translations, err := translationController.ListForMovies([]int{1, 2, 3})
+	testhelpers.AssertError(t, err, nil)
+
+	titles := map[int][]string{}
+	for movieId, movieTranslations := range translations {
+		for _, translation := range movieTranslations {
+			titles[movieId] = append(titles[movieId], translation.Language+": "+translation.Title)
+		}
+	}
+	testhelpers.AssertStruct(t, titles, map[int][]string{
+		1: {"ja: ブレット・トレイン"},
+		2: {"fr: Hamilton : la comédie musicale"},
+	})
+}
+
+func TestMovieTranslationController_Upsert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	testCases := map[string]struct {
+		translation  models.MovieTranslation
+		wantInserted bool
+		wantErr      error
+	}{
+		"new translation": {
+			translation:  models.MovieTranslation{MovieId: 3, Language: "it", Title: "Luca"},
+			wantInserted: true,
+			wantErr:      nil,
+		},
+
+		"existing translation": {
+			translation:  models.MovieTranslation{MovieId: 1, Language: "ja", Title: "ブレット・トレイン"},
+			wantInserted: false,
+			wantErr:      nil,
+		},
+
+		"non-existent movie": {
+			translation:  models.MovieTranslation{MovieId: 99, Language: "it", Title: "Luca"},
+			wantInserted: false,
+			wantErr:      repository.ErrRecordNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			translationController := MovieTranslationController{Db: db}
+			defer teardown()
+
+			inserted, err := translationController.Upsert(&tc.translation)
+			testhelpers.AssertError(t, err, tc.wantErr)
+			testhelpers.AssertEqual(t, inserted, tc.wantInserted)
+		})
+	}
+}
+
+func TestMovieController_ListByTranslatedTitle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	movieController := MovieController{Db: db}
+	defer teardown()
+
+	filters := request.Filters{Page: 1, Limit: 20, Sort: "id", ValidSorts: []string{"id"}}
+	movies, _, err := movieController.List(request.MovieQuery{Title: "comédie"}, filters, request.MovieFieldTitle)
+	testhelpers.AssertError(t, err, nil)
+
+	testhelpers.AssertEqual(t, len(movies), 1)
+	testhelpers.AssertEqual(t, movies[0].Title, "Hamilton")
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"time"
+)
+
+type NotificationPreferenceController struct {
+	Db Conn
+}
+
+// Get returns the saved notification preferences of the user,
+// or the default preferences if none have been saved.
+func (n NotificationPreferenceController) Get(userId int) (models.NotificationPreferences, error) {
+	stmt := `SELECT security_alerts, product_updates, saved_search_alerts
+	FROM notification_preferences
+	WHERE user_id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	preferences := models.NotificationPreferences{}
+	err := n.Db.QueryRowContext(ctx, stmt, userId).Scan(
+		&preferences.SecurityAlerts,
+		&preferences.ProductUpdates,
+		&preferences.SavedSearchAlerts,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultNotificationPreferences, nil
+		}
+		return models.NotificationPreferences{}, err
+	}
+	return preferences, nil
+}
+
+// Update inserts or replaces the notification preferences of the user.
+// The preferences are only inserted if the user exists, and a "record not found" error is returned otherwise.
+func (n NotificationPreferenceController) Update(userId int, preferences models.NotificationPreferences) error {
+	stmt := `INSERT INTO notification_preferences (user_id, security_alerts, product_updates, saved_search_alerts)
+	SELECT id, $2, $3, $4 FROM users WHERE id = $1
+	ON CONFLICT (user_id) DO UPDATE
+	SET security_alerts = EXCLUDED.security_alerts,
+		product_updates = EXCLUDED.product_updates,
+		saved_search_alerts = EXCLUDED.saved_search_alerts,
+		updated_at = now()`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := n.Db.ExecContext(ctx, stmt, userId, preferences.SecurityAlerts, preferences.ProductUpdates, preferences.SavedSearchAlerts)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+)
+
+func TestNotificationPreferenceController(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	defer teardown()
+	n := NotificationPreferenceController{Db: db}
+
+	// users who haven't saved their preferences have the defaults
+	preferences, err := n.Get(1)
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertStruct(t, preferences, models.DefaultNotificationPreferences)
+
+	// saved preferences are replaced by later updates
+	err = n.Update(1, models.NotificationPreferences{ProductUpdates: true})
+	testhelpers.AssertFatalError(t, err)
+	err = n.Update(1, models.NotificationPreferences{SecurityAlerts: true, ProductUpdates: true})
+	testhelpers.AssertFatalError(t, err)
+
+	preferences, err = n.Get(1)
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertStruct(t, preferences, models.NotificationPreferences{SecurityAlerts: true, ProductUpdates: true})
+
+	// preferences of other users are unaffected
+	preferences, err = n.Get(2)
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertStruct(t, preferences, models.DefaultNotificationPreferences)
+
+	err = n.Update(99, models.DefaultNotificationPreferences)
+	testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+}
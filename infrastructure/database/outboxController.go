@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"time"
+)
+
+type OutboxController struct {
+	Db Conn
+}
+
+// Enqueue inserts the email into the outbox as pending and available immediately,
+// and updates the id, status, availability and creation times of the email pointer.
+func (o OutboxController) Enqueue(email *models.OutboxEmail) error {
+	stmt := `INSERT INTO outbox (recipient, template, language, data, user_id, category, token_scope, token_lifetime)
+	VALUES ($1, $2, $3, $4, NULLIF($5, 0), $6, $7, make_interval(secs => $8))
+	RETURNING id, status, available_at, created_at`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	args := []any{
+		email.Recipient,
+		email.Template,
+		email.Language,
+		jsonObject{email.Data},
+		email.UserId,
+		email.Category,
+		email.TokenScope,
+		email.TokenLifetime.Seconds(),
+	}
+	row := o.Db.QueryRowContext(ctx, stmt, args...)
+	return row.Scan(&email.Id, &email.Status, &email.Available, &email.Created)
+}
+
+// Claim leases up to limit available pending emails by pushing their availability back by the lease,
+// incrementing their attempts in the same statement.
+// Rows locked by concurrent claims are skipped rather than waited for, so workers claim distinct emails.
+func (o OutboxController) Claim(limit int, lease time.Duration) (models.OutboxEmails, error) {
+	stmt := `UPDATE outbox
+	SET attempts = attempts + 1, available_at = now() + make_interval(secs => $3)
+	WHERE id IN (
+		SELECT id FROM outbox
+		WHERE status = $1 AND available_at <= now()
+		ORDER BY available_at, id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, recipient, template, language, data, COALESCE(user_id, 0), category,
+		token_scope, extract(epoch FROM token_lifetime), status, attempts, last_error, available_at, created_at, sent_at`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := o.Db.QueryContext(ctx, stmt, models.OutboxStatusPending, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	emails := models.OutboxEmails{}
+	for rows.Next() {
+		email := models.OutboxEmail{}
+		var tokenLifetime float64
+		err = rows.Scan(
+			&email.Id,
+			&email.Recipient,
+			&email.Template,
+			&email.Language,
+			jsonObject{&email.Data},
+			&email.UserId,
+			&email.Category,
+			&email.TokenScope,
+			&tokenLifetime,
+			&email.Status,
+			&email.Attempts,
+			&email.LastError,
+			&email.Available,
+			&email.Created,
+			&email.Sent,
+		)
+		if err != nil {
+			return nil, err
+		}
+		email.TokenLifetime = time.Duration(tokenLifetime * float64(time.Second))
+		emails = append(emails, email)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+// MarkSent marks the pending email as sent and clears its data,
+// which isn't needed after the delivery.
+// A "record not found" error is returned if the email isn't pending.
+func (o OutboxController) MarkSent(id int) error {
+	stmt := `UPDATE outbox
+	SET status = $2, data = '{}', last_error = '', sent_at = now()
+	WHERE id = $1 AND status = $3`
+
+	return o.updatePending(stmt, id, models.OutboxStatusSent, models.OutboxStatusPending)
+}
+
+// Retry records the failure of the pending email and sets the time it becomes available again.
+// A "record not found" error is returned if the email isn't pending.
+func (o OutboxController) Retry(id int, reason string, availableAt time.Time) error {
+	stmt := `UPDATE outbox
+	SET last_error = $2, available_at = $3
+	WHERE id = $1 AND status = $4`
+
+	return o.updatePending(stmt, id, reason, availableAt, models.OutboxStatusPending)
+}
+
+// DeadLetter records the failure of the pending email, marks it as dead and clears its data,
+// as it's only kept for inspection.
+// A "record not found" error is returned if the email isn't pending.
+func (o OutboxController) DeadLetter(id int, reason string) error {
+	stmt := `UPDATE outbox
+	SET status = $2, data = '{}', last_error = $3
+	WHERE id = $1 AND status = $4`
+
+	return o.updatePending(stmt, id, models.OutboxStatusDead, reason, models.OutboxStatusPending)
+}
+
+// MarkSuppressed records why the pending email wasn't sent, marks it as suppressed and clears its data.
+// A "record not found" error is returned if the email isn't pending.
+func (o OutboxController) MarkSuppressed(id int, reason string) error {
+	stmt := `UPDATE outbox
+	SET status = $2, data = '{}', last_error = $3
+	WHERE id = $1 AND status = $4`
+
+	return o.updatePending(stmt, id, models.OutboxStatusSuppressed, reason, models.OutboxStatusPending)
+}
+
+// updatePending executes the update of a pending email,
+// returning a "record not found" error if no email is updated.
+func (o OutboxController) updatePending(stmt string, id int, args ...any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := o.Db.ExecContext(ctx, stmt, append([]any{id}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (o OutboxController) Depth() (models.OutboxDepth, error) {
+	stmt := `SELECT count(*) FILTER (WHERE status = $1), count(*) FILTER (WHERE status = $2)
+	FROM outbox`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	depth := models.OutboxDepth{}
+	err := o.Db.QueryRowContext(ctx, stmt, models.OutboxStatusPending, models.OutboxStatusDead).
+		Scan(&depth.Pending, &depth.Dead)
+	if err != nil {
+		return models.OutboxDepth{}, err
+	}
+	return depth, nil
+}
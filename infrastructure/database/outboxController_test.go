@@ -0,0 +1,97 @@
+package database
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+	"time"
+)
+
+func TestOutboxController(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	defer teardown()
+	o := OutboxController{Db: db}
+
+	for _, recipient := range []string{"first@mail.com", "second@mail.com", "third@mail.com"} {
+		err := o.Enqueue(&models.OutboxEmail{
+			Recipient:     recipient,
+			Template:      "user_welcome.gotmpl",
+			Language:      "en",
+			Data:          map[string]any{"Username": "person"},
+			TokenScope:    models.ScopeActivation,
+			TokenLifetime: 48 * time.Hour,
+		})
+		testhelpers.AssertFatalError(t, err)
+	}
+
+	// claimed emails are leased, so the next claim only gets the remaining email
+	claimed, err := o.Claim(2, time.Minute)
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertEqual(t, len(claimed), 2)
+	testhelpers.AssertEqual(t, claimed[0].Recipient, "first@mail.com")
+	testhelpers.AssertEqual(t, claimed[0].Attempts, 1)
+	testhelpers.AssertStruct(t, claimed[0].Data, map[string]any{"Username": "person"})
+	testhelpers.AssertEqual(t, claimed[0].UserId, 0)
+	testhelpers.AssertEqual(t, claimed[0].Category, "")
+	testhelpers.AssertEqual(t, claimed[0].TokenScope, models.ScopeActivation)
+	testhelpers.AssertEqual(t, claimed[0].TokenLifetime, 48*time.Hour)
+
+	remaining, err := o.Claim(2, time.Minute)
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertEqual(t, len(remaining), 1)
+	testhelpers.AssertEqual(t, remaining[0].Recipient, "third@mail.com")
+
+	// a retried email is claimed again once it's available
+	err = o.Retry(claimed[0].Id, "connection refused", time.Now().Add(-time.Second))
+	testhelpers.AssertFatalError(t, err)
+
+	retried, err := o.Claim(2, time.Minute)
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertEqual(t, len(retried), 1)
+	testhelpers.AssertEqual(t, retried[0].Id, claimed[0].Id)
+	testhelpers.AssertEqual(t, retried[0].Attempts, 2)
+	testhelpers.AssertEqual(t, retried[0].LastError, "connection refused")
+
+	err = o.MarkSent(claimed[0].Id)
+	testhelpers.AssertFatalError(t, err)
+	err = o.DeadLetter(claimed[1].Id, "mailbox unavailable")
+	testhelpers.AssertFatalError(t, err)
+
+	// only pending emails can be updated
+	err = o.MarkSent(claimed[1].Id)
+	testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+
+	// non-transactional emails keep their user and category
+	alert := &models.OutboxEmail{
+		Recipient: "rhodeon@dev.mail",
+		Template:  "password_changed.gotmpl",
+		Language:  "en",
+		UserId:    1,
+		Category:  models.NotificationSecurityAlerts,
+	}
+	err = o.Enqueue(alert)
+	testhelpers.AssertFatalError(t, err)
+
+	alerts, err := o.Claim(2, time.Minute)
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertEqual(t, len(alerts), 1)
+	testhelpers.AssertEqual(t, alerts[0].UserId, 1)
+	testhelpers.AssertEqual(t, alerts[0].Category, models.NotificationSecurityAlerts)
+
+	depth, err := o.Depth()
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertStruct(t, depth, models.OutboxDepth{Pending: 2, Dead: 1})
+
+	// suppressed emails are neither pending nor dead
+	err = o.MarkSuppressed(alerts[0].Id, "recipient suppressed after complaint")
+	testhelpers.AssertFatalError(t, err)
+
+	depth, err = o.Depth()
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertStruct(t, depth, models.OutboxDepth{Pending: 1, Dead: 1})
+}
@@ -2,14 +2,13 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"github.com/lib/pq"
 	"github.com/rhodeon/moviescreen/domain/models"
 	"time"
 )
 
 type PermissionController struct {
-	Db *sql.DB
+	Db Conn
 }
 
 func (p PermissionController) AddForUser(user models.User, codes ...string) error {
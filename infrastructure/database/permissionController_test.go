@@ -29,7 +29,7 @@ func TestPermissionController_AddForUser(t *testing.T) {
 	WHERE users.id = $1`
 
 	var code string
-	err = permissionController.Db.QueryRow(stmt, userId).Scan(&code)
+	err = db.QueryRow(stmt, userId).Scan(&code)
 	if err != nil {
 		testhelpers.AssertFatalError(t, err)
 	}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"time"
+)
+
+type SearchController struct {
+	Db Conn
+}
+
+// Search fetches the movies and series matching the query as a single list,
+// with the sort column and direction interpolated as they can't be parameterized.
+// The title of movies is searched for in their translations as well, as in the listing of movies.
+// Each type is only queried if it isn't excluded by the type filter.
+func (s SearchController) Search(query request.SearchQuery, filters request.Filters) (models.SearchResults, response.Metadata, error) {
+	stmt := fmt.Sprintf(`SELECT count(*) OVER(), type, id, title, year, genres
+	FROM (
+		SELECT $4 AS type, id, title, year, genres
+		FROM movies
+		WHERE ($1 = '' OR $1 = $4)
+			AND ($2 = ''
+				OR to_tsvector('simple', title) @@ plainto_tsquery('simple', $2)
+				OR EXISTS (
+					SELECT 1 FROM movie_translations AS translation
+					WHERE translation.movie_id = movies.id
+						AND to_tsvector('simple', translation.title) @@ plainto_tsquery('simple', $2)
+				))
+			AND (genres @> $3 OR $3 = '{}')
+			AND deleted_at IS NULL
+		UNION ALL
+		SELECT $5, id, title, start_year, genres
+		FROM series
+		WHERE ($1 = '' OR $1 = $5)
+			AND ($2 = '' OR to_tsvector('simple', title) @@ plainto_tsquery('simple', $2))
+			AND (genres @> $3 OR $3 = '{}')
+	) AS results
+	ORDER BY %s %s, type ASC, id ASC
+	LIMIT $6 OFFSET $7`, filters.SortColumn(request.SearchFilterSortTitle), filters.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	args := []any{
+		query.Type,
+		query.Title,
+		pq.Array(query.Genres),
+		models.SearchTypeMovie,
+		models.SearchTypeSeries,
+		filters.Limit,
+		filters.Offset(),
+	}
+	rows, err := s.Db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, response.Metadata{}, err
+	}
+	defer rows.Close()
+
+	results := models.SearchResults{}
+	var totalRecords int
+	for rows.Next() {
+		result := models.SearchResult{}
+		err = rows.Scan(&totalRecords, &result.Type, &result.Id, &result.Title, &result.Year, pq.Array(&result.Genres))
+		if err != nil {
+			return nil, response.Metadata{}, err
+		}
+		results = append(results, result)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, response.Metadata{}, err
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, totalRecords)
+	return results, metadata, nil
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"fmt"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+)
+
+func TestSearchController_Search(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	testCases := map[string]struct {
+		query       request.SearchQuery
+		deleteId    int
+		wantResults []string
+	}{
+		"all types": {
+			query:       request.SearchQuery{},
+			wantResults: []string{"series 2", "movie 1", "movie 2", "movie 3", "series 1"},
+		},
+
+		"movies only": {
+			query:       request.SearchQuery{Type: models.SearchTypeMovie},
+			wantResults: []string{"movie 1", "movie 2", "movie 3"},
+		},
+
+		"series only": {
+			query:       request.SearchQuery{Type: models.SearchTypeSeries},
+			wantResults: []string{"series 2", "series 1"},
+		},
+
+		"translated title": {
+			query:       request.SearchQuery{Title: "musicale"},
+			wantResults: []string{"movie 2"},
+		},
+
+		"genres of both types": {
+			query:       request.SearchQuery{Genres: []string{"Action"}},
+			wantResults: []string{"series 2", "movie 1"},
+		},
+
+		"movie in trash": {
+			query:       request.SearchQuery{},
+			deleteId:    1,
+			wantResults: []string{"series 2", "movie 2", "movie 3", "series 1"},
+		},
+
+		"translated title of movie in trash": {
+			query:       request.SearchQuery{Title: "ブレット・トレイン"},
+			deleteId:    1,
+			wantResults: []string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			searchController := SearchController{Db: db}
+			defer teardown()
+
+			if tc.deleteId != 0 {
+				movieController := MovieController{Db: db}
+				err := movieController.Delete(tc.deleteId, 1)
+				testhelpers.AssertFatalError(t, err)
+			}
+
+			// the search handler defaults the genres to an empty list
+			if tc.query.Genres == nil {
+				tc.query.Genres = []string{}
+			}
+
+			results, metadata, err := searchController.Search(tc.query, request.Filters{
+				Page:       1,
+				Limit:      20,
+				Sort:       request.SearchFilterSortTitle,
+				ValidSorts: []string{request.SearchFilterSortTitle, request.SearchFilterSortYear},
+			})
+			testhelpers.AssertError(t, err, nil)
+			testhelpers.AssertEqual(t, metadata.TotalRecords, len(tc.wantResults))
+
+			// identify the results by their type and id
+			gotResults := []string{}
+			for _, result := range results {
+				gotResults = append(gotResults, fmt.Sprintf("%s %d", result.Type, result.Id))
+			}
+			testhelpers.AssertStruct(t, gotResults, tc.wantResults)
+		})
+	}
+}
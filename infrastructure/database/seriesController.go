@@ -0,0 +1,486 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/types"
+	"time"
+)
+
+type SeriesController struct {
+	Db Conn
+}
+
+// seriesColumns selects the columns of a series aliased as s,
+// counting its seasons and episodes.
+const seriesColumns = `s.id, s.title, s.start_year, coalesce(s.end_year, 0), s.genres, s.synopsis,
+	(SELECT count(*) FROM seasons WHERE series_id = s.id),
+	(SELECT count(*) FROM episodes e JOIN seasons ss ON ss.id = e.season_id WHERE ss.series_id = s.id),
+	s.version, s.created_at, s.updated_at`
+
+// touchSeriesStmt sets the modification time of a series whose seasons or episodes have changed.
+const touchSeriesStmt = `UPDATE series SET updated_at = now() WHERE id = $1`
+
+// scanSeries scans a row of seriesColumns into a series.
+func scanSeries(scan func(dest ...any) error) (models.Series, error) {
+	series := models.Series{}
+	err := scan(
+		&series.Id,
+		&series.Title,
+		&series.StartYear,
+		&series.EndYear,
+		pq.Array(&series.Genres),
+		&series.Synopsis,
+		&series.SeasonCount,
+		&series.EpisodeCount,
+		&series.Version,
+		&series.Created,
+		&series.Updated,
+	)
+	return series, err
+}
+
+// seriesValues returns the values of the columns which are set on the creation and update of a series,
+// with the end year stored as null for series which are still running.
+func seriesValues(series *models.Series) []any {
+	return []any{
+		series.Title,
+		series.StartYear,
+		sql.NullInt64{Int64: int64(series.EndYear), Valid: series.EndYear != 0},
+		pq.Array(series.Genres),
+		series.Synopsis,
+	}
+}
+
+// Create inserts the series and sets its id, creation and modification times, and version.
+func (s SeriesController) Create(series *models.Series) error {
+	stmt := `INSERT INTO series (title, start_year, end_year, genres, synopsis)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, created_at, updated_at, version`
+
+	// create context for database operation with a 3-second timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return s.Db.QueryRowContext(ctx, stmt, seriesValues(series)...).
+		Scan(&series.Id, &series.Created, &series.Updated, &series.Version)
+}
+
+// Get returns the series with the given id, with its seasons and their episodes ordered by number.
+// A "record not found" error is returned if the id doesn't belong to any series.
+func (s SeriesController) Get(id int) (models.Series, error) {
+	stmt := `SELECT ` + seriesColumns + `
+	FROM series s
+	WHERE s.id = $1`
+
+	seasonsStmt := `SELECT id, number, title, air_date, created_at, updated_at
+	FROM seasons
+	WHERE series_id = $1
+	ORDER BY number`
+
+	episodesStmt := `SELECT e.id, s.number, e.number, e.title, e.runtime, e.air_date, e.synopsis, e.created_at, e.updated_at
+	FROM episodes e
+	JOIN seasons s ON s.id = e.season_id
+	WHERE s.series_id = $1
+	ORDER BY s.number, e.number`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	series, err := scanSeries(s.Db.QueryRowContext(ctx, stmt, id).Scan)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Series{}, repository.ErrRecordNotFound
+		}
+		return models.Series{}, err
+	}
+
+	// fetch the seasons, indexing them by number to attach their episodes
+	rows, err := s.Db.QueryContext(ctx, seasonsStmt, id)
+	if err != nil {
+		return models.Series{}, err
+	}
+	defer rows.Close()
+
+	series.Seasons = []models.Season{}
+	seasonIndexes := map[int]int{}
+	for rows.Next() {
+		season := models.Season{SeriesId: id, Episodes: []models.Episode{}}
+		var airDate sql.NullTime
+		err = rows.Scan(&season.Id, &season.Number, &season.Title, &airDate, &season.Created, &season.Updated)
+		if err != nil {
+			return models.Series{}, err
+		}
+		season.AirDate = scanAirDate(airDate)
+		seasonIndexes[season.Number] = len(series.Seasons)
+		series.Seasons = append(series.Seasons, season)
+	}
+	if err = rows.Err(); err != nil {
+		return models.Series{}, err
+	}
+
+	rows, err = s.Db.QueryContext(ctx, episodesStmt, id)
+	if err != nil {
+		return models.Series{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		episode := models.Episode{}
+		var airDate sql.NullTime
+		err = rows.Scan(
+			&episode.Id,
+			&episode.SeasonNumber,
+			&episode.Number,
+			&episode.Title,
+			&episode.Runtime,
+			&airDate,
+			&episode.Synopsis,
+			&episode.Created,
+			&episode.Updated,
+		)
+		if err != nil {
+			return models.Series{}, err
+		}
+		episode.AirDate = scanAirDate(airDate)
+		season := &series.Seasons[seasonIndexes[episode.SeasonNumber]]
+		season.Episodes = append(season.Episodes, episode)
+	}
+	if err = rows.Err(); err != nil {
+		return models.Series{}, err
+	}
+
+	return series, nil
+}
+
+// List fetches the series matching the query,
+// with the sort column and direction interpolated as they can't be parameterized.
+func (s SeriesController) List(query request.SeriesQuery, filters request.Filters) (models.SeriesList, response.Metadata, error) {
+	stmt := fmt.Sprintf(`SELECT count(*) OVER(), %s
+	FROM series s
+	WHERE (to_tsvector('simple', s.title) @@ plainto_tsquery('simple', $1) OR $1 = '')
+	AND (s.genres @> $2 OR $2 = '{}')
+	ORDER BY s.%s %s, s.id ASC
+	LIMIT $3 OFFSET $4`, seriesColumns, filters.SortColumn(request.SeriesFilterSortId), filters.SortDirection())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, stmt, query.Title, pq.Array(query.Genres), filters.Limit, filters.Offset())
+	if err != nil {
+		return nil, response.Metadata{}, err
+	}
+	defer rows.Close()
+
+	seriesList := models.SeriesList{}
+	var totalRecords int
+	for rows.Next() {
+		series, err := scanSeries(func(dest ...any) error {
+			return rows.Scan(append([]any{&totalRecords}, dest...)...)
+		})
+		if err != nil {
+			return nil, response.Metadata{}, err
+		}
+		seriesList = append(seriesList, series)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, response.Metadata{}, err
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, totalRecords)
+	return seriesList, metadata, nil
+}
+
+// Update saves the series if its version matches the one in the database,
+// and sets its new version and modification time.
+// An edit conflict error is returned if the series has been modified or deleted since it was fetched.
+func (s SeriesController) Update(series *models.Series) error {
+	stmt := `UPDATE series
+	SET (title, start_year, end_year, genres, synopsis) = ($1, $2, $3, $4, $5),
+		version = version + 1, updated_at = now()
+	WHERE id = $6 AND version = $7
+	RETURNING version, updated_at`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	args := append(seriesValues(series), series.Id, series.Version)
+	err := s.Db.QueryRowContext(ctx, stmt, args...).Scan(&series.Version, &series.Updated)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrEditConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes the series with the given id, with its seasons and episodes removed by cascade.
+// A "record not found" error is returned if no series has the id.
+func (s SeriesController) Delete(id int) error {
+	stmt := `DELETE FROM series
+	WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := s.Db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpsertSeason saves the season and checks the order of the air dates in a single transaction,
+// with the series locked so concurrent changes to its seasons are checked against each other.
+// The system column xmax is 0 for freshly inserted rows, which distinguishes them from updated ones.
+func (s SeriesController) UpsertSeason(season *models.Season) (bool, error) {
+	stmt := `INSERT INTO seasons (series_id, number, title, air_date)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (series_id, number) DO UPDATE
+	SET title = excluded.title, air_date = excluded.air_date, updated_at = now()
+	RETURNING id, created_at, updated_at, xmax = 0`
+
+	// seasons with unknown air dates are left out of the comparison
+	orderStmt := `SELECT EXISTS(
+		SELECT 1 FROM seasons earlier
+		JOIN seasons later ON later.series_id = earlier.series_id AND later.number > earlier.number
+		WHERE earlier.series_id = $1 AND $2 IN (earlier.number, later.number)
+		AND later.air_date < earlier.air_date
+	)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, s.Db)
+	if err != nil {
+		return false, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	err = lockSeries(ctx, tx, season.SeriesId)
+	if err != nil {
+		return false, err
+	}
+
+	var inserted bool
+	args := []any{season.SeriesId, season.Number, season.Title, nullableDate(season.AirDate)}
+	err = tx.QueryRowContext(ctx, stmt, args...).Scan(&season.Id, &season.Created, &season.Updated, &inserted)
+	if err != nil {
+		return false, err
+	}
+
+	err = checkAirDateOrder(ctx, tx, orderStmt, season.SeriesId, season.Number)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, touchSeriesStmt, season.SeriesId)
+	if err != nil {
+		return false, err
+	}
+
+	return inserted, tx.Commit()
+}
+
+// DeleteSeason removes the season, with its episodes removed by cascade,
+// and sets the modification time of its series.
+// A "record not found" error is returned if the series has no season with the number.
+func (s SeriesController) DeleteSeason(seriesId int, number int) error {
+	stmt := `WITH deleted AS (
+		DELETE FROM seasons
+		WHERE series_id = $1 AND number = $2
+		RETURNING series_id
+	)
+	UPDATE series SET updated_at = now()
+	WHERE id IN (SELECT series_id FROM deleted)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := s.Db.ExecContext(ctx, stmt, seriesId, number)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpsertEpisode saves the episode and checks the order of the air dates in a single transaction,
+// with the series locked so concurrent changes to its episodes are checked against each other.
+func (s SeriesController) UpsertEpisode(seriesId int, episode *models.Episode) (bool, error) {
+	stmt := `INSERT INTO episodes (season_id, number, title, runtime, air_date, synopsis)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (season_id, number) DO UPDATE
+	SET title = excluded.title, runtime = excluded.runtime, air_date = excluded.air_date,
+		synopsis = excluded.synopsis, updated_at = now()
+	RETURNING id, created_at, updated_at, xmax = 0`
+
+	// episodes with unknown air dates are left out of the comparison
+	orderStmt := `SELECT EXISTS(
+		SELECT 1 FROM episodes earlier
+		JOIN episodes later ON later.season_id = earlier.season_id AND later.number > earlier.number
+		WHERE earlier.season_id = $1 AND $2 IN (earlier.number, later.number)
+		AND later.air_date < earlier.air_date
+	)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := beginTx(ctx, s.Db)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	err = lockSeries(ctx, tx, seriesId)
+	if err != nil {
+		return false, err
+	}
+
+	var seasonId int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM seasons WHERE series_id = $1 AND number = $2`, seriesId, episode.SeasonNumber).
+		Scan(&seasonId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, repository.ErrRecordNotFound
+		}
+		return false, err
+	}
+
+	var inserted bool
+	args := []any{seasonId, episode.Number, episode.Title, episode.Runtime, nullableDate(episode.AirDate), episode.Synopsis}
+	err = tx.QueryRowContext(ctx, stmt, args...).Scan(&episode.Id, &episode.Created, &episode.Updated, &inserted)
+	if err != nil {
+		return false, err
+	}
+
+	err = checkAirDateOrder(ctx, tx, orderStmt, seasonId, episode.Number)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, touchSeriesStmt, seriesId)
+	if err != nil {
+		return false, err
+	}
+
+	return inserted, tx.Commit()
+}
+
+// DeleteEpisode removes the episode and sets the modification time of its series.
+// A "record not found" error is returned if the season of the series has no episode with the number.
+func (s SeriesController) DeleteEpisode(seriesId int, seasonNumber int, number int) error {
+	stmt := `WITH deleted AS (
+		DELETE FROM episodes e
+		USING seasons s
+		WHERE e.season_id = s.id AND s.series_id = $1 AND s.number = $2 AND e.number = $3
+		RETURNING s.series_id
+	)
+	UPDATE series SET updated_at = now()
+	WHERE id IN (SELECT series_id FROM deleted)`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := s.Db.ExecContext(ctx, stmt, seriesId, seasonNumber, number)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// lockSeries locks the row of the series until the end of the transaction.
+// ErrRecordNotFound is returned if the series doesn't exist.
+func lockSeries(ctx context.Context, tx Conn, seriesId int) error {
+	var id int
+	err := tx.QueryRowContext(ctx, `SELECT id FROM series WHERE id = $1 FOR UPDATE`, seriesId).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrRecordNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// checkAirDateOrder runs the statement, which reports whether the item with the given number
+// airs before an earlier item of its parent or after a later one, returning ErrAirDateOrder if so.
+func checkAirDateOrder(ctx context.Context, tx Conn, stmt string, parentId int, number int) error {
+	var outOfOrder bool
+	err := tx.QueryRowContext(ctx, stmt, parentId, number).Scan(&outOfOrder)
+	if err != nil {
+		return err
+	}
+	if outOfOrder {
+		return repository.ErrAirDateOrder
+	}
+	return nil
+}
+
+// replaceSeriesGenre replaces the genre named from with the genre named to in all series,
+// removing it instead from series which already have the replacement.
+// The version of each changed series is incremented.
+func replaceSeriesGenre(ctx context.Context, tx Conn, from string, to string) error {
+	stmt := `UPDATE series
+	SET genres = CASE
+			WHEN genres @> ARRAY[$2::text] THEN array_remove(genres, $1)
+			ELSE array_replace(genres, $1, $2)
+		END,
+		version = version + 1,
+		updated_at = now()
+	WHERE genres @> ARRAY[$1::text]`
+
+	_, err := tx.ExecContext(ctx, stmt, from, to)
+	return err
+}
+
+// nullableDate returns the value of a date column, being null if the date is unknown.
+func nullableDate(date *types.Date) any {
+	if date == nil {
+		return nil
+	}
+	return date.Time
+}
+
+// scanAirDate returns the date of a nullable date column, and nil if it is null.
+func scanAirDate(date sql.NullTime) *types.Date {
+	if !date.Valid {
+		return nil
+	}
+	return &types.Date{Time: date.Time}
+}
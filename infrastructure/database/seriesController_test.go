@@ -0,0 +1,143 @@
+package database
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"github.com/rhodeon/moviescreen/internal/types"
+	"testing"
+)
+
+func TestSeriesController_UpsertSeason(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	testCases := map[string]struct {
+		season       models.Season
+		wantInserted bool
+		wantErr      error
+	}{
+		"new season after the last": {
+			season:       models.Season{SeriesId: 1, Number: 3, AirDate: parseTestDate(t, "2026-01-16")},
+			wantInserted: true,
+		},
+
+		"existing season": {
+			season:       models.Season{SeriesId: 1, Number: 1, Title: "Season One", AirDate: parseTestDate(t, "2022-02-18")},
+			wantInserted: false,
+		},
+
+		"new season without air date": {
+			season:       models.Season{SeriesId: 2, Number: 2},
+			wantInserted: true,
+		},
+
+		"air date before an earlier season": {
+			season:  models.Season{SeriesId: 1, Number: 3, AirDate: parseTestDate(t, "2024-01-01")},
+			wantErr: repository.ErrAirDateOrder,
+		},
+
+		"air date after a later season": {
+			season:  models.Season{SeriesId: 1, Number: 1, AirDate: parseTestDate(t, "2025-02-01")},
+			wantErr: repository.ErrAirDateOrder,
+		},
+
+		"non-existent series": {
+			season:  models.Season{SeriesId: 99, Number: 1},
+			wantErr: repository.ErrRecordNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			seriesController := SeriesController{Db: db}
+			defer teardown()
+
+			inserted, err := seriesController.UpsertSeason(&tc.season)
+			testhelpers.AssertError(t, err, tc.wantErr)
+			testhelpers.AssertEqual(t, inserted, tc.wantInserted)
+		})
+	}
+}
+
+func TestSeriesController_UpsertEpisode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	testCases := map[string]struct {
+		seriesId     int
+		episode      models.Episode
+		wantInserted bool
+		wantErr      error
+	}{
+		"new episode": {
+			seriesId:     1,
+			episode:      models.Episode{SeasonNumber: 2, Number: 2, Title: "Goodbye, Mrs. Selvig", Runtime: 49, AirDate: parseTestDate(t, "2025-01-24")},
+			wantInserted: true,
+		},
+
+		"existing episode": {
+			seriesId:     1,
+			episode:      models.Episode{SeasonNumber: 1, Number: 3, Title: "In Perpetuity", Runtime: 52},
+			wantInserted: false,
+		},
+
+		"air date after a later episode": {
+			seriesId: 1,
+			episode:  models.Episode{SeasonNumber: 1, Number: 2, Title: "Half Loop", Runtime: 53, AirDate: parseTestDate(t, "2022-03-04")},
+			wantErr:  repository.ErrAirDateOrder,
+		},
+
+		"non-existent season": {
+			seriesId: 1,
+			episode:  models.Episode{SeasonNumber: 9, Number: 1, Title: "Pilot", Runtime: 45},
+			wantErr:  repository.ErrRecordNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			seriesController := SeriesController{Db: db}
+			defer teardown()
+
+			inserted, err := seriesController.UpsertEpisode(tc.seriesId, &tc.episode)
+			testhelpers.AssertError(t, err, tc.wantErr)
+			testhelpers.AssertEqual(t, inserted, tc.wantInserted)
+		})
+	}
+}
+
+func TestSeriesController_DeleteSeason(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	seriesController := SeriesController{Db: db}
+	defer teardown()
+
+	err := seriesController.DeleteSeason(1, 1)
+	testhelpers.AssertError(t, err, nil)
+
+	// the episodes of the season are removed with it
+	series, err := seriesController.Get(1)
+	testhelpers.AssertError(t, err, nil)
+	testhelpers.AssertEqual(t, series.SeasonCount, 1)
+	testhelpers.AssertEqual(t, series.EpisodeCount, 1)
+
+	err = seriesController.DeleteSeason(1, 1)
+	testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+}
+
+// parseTestDate parses the date, failing the test if it is invalid.
+func parseTestDate(t *testing.T, value string) *types.Date {
+	date, err := types.ParseDate(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &date
+}
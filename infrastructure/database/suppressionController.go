@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"time"
+)
+
+type SuppressionController struct {
+	Db Conn
+}
+
+// Suppress inserts the suppression of the normalized email address, or updates its reason and detail
+// if the address is already suppressed, and updates the email and creation time of the suppression pointer.
+// The creation time of existing suppressions is kept.
+func (s SuppressionController) Suppress(suppression *models.EmailSuppression) error {
+	stmt := `INSERT INTO email_suppressions (email, reason, detail)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (email) DO UPDATE SET reason = excluded.reason, detail = excluded.detail
+	RETURNING email, created_at`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	row := s.Db.QueryRowContext(ctx, stmt, models.NormalizeEmail(suppression.Email), suppression.Reason, suppression.Detail)
+	return row.Scan(&suppression.Email, &suppression.Created)
+}
+
+func (s SuppressionController) Get(email string) (models.EmailSuppression, error) {
+	stmt := `SELECT email, reason, detail, created_at
+	FROM email_suppressions
+	WHERE email = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	suppression := models.EmailSuppression{}
+	err := s.Db.QueryRowContext(ctx, stmt, models.NormalizeEmail(email)).Scan(
+		&suppression.Email,
+		&suppression.Reason,
+		&suppression.Detail,
+		&suppression.Created,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.EmailSuppression{}, repository.ErrRecordNotFound
+		}
+		return models.EmailSuppression{}, err
+	}
+	return suppression, nil
+}
+
+func (s SuppressionController) GetForEmails(emails []string) (map[string]models.EmailSuppression, error) {
+	stmt := `SELECT email, reason, detail, created_at
+	FROM email_suppressions
+	WHERE email = ANY($1)`
+
+	normalized := make([]string, len(emails))
+	for i, email := range emails {
+		normalized[i] = models.NormalizeEmail(email)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := s.Db.QueryContext(ctx, stmt, pq.Array(normalized))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppressions := map[string]models.EmailSuppression{}
+	for rows.Next() {
+		suppression := models.EmailSuppression{}
+		err = rows.Scan(&suppression.Email, &suppression.Reason, &suppression.Detail, &suppression.Created)
+		if err != nil {
+			return nil, err
+		}
+		suppressions[suppression.Email] = suppression
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suppressions, nil
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+)
+
+func TestSuppressionController(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	db, teardown := newTestDb(t)
+	defer teardown()
+	s := SuppressionController{Db: db}
+
+	_, err := s.Get("rhodeon@dev.mail")
+	testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+
+	// addresses are suppressed in lowercase
+	bounce := &models.EmailSuppression{
+		Email:  "Rhodeon@Dev.Mail",
+		Reason: models.SuppressionReasonBounce,
+		Detail: "550 5.1.1 user unknown",
+	}
+	err = s.Suppress(bounce)
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertEqual(t, bounce.Email, "rhodeon@dev.mail")
+
+	// suppressing an address again replaces its reason and keeps its creation time
+	complaint := &models.EmailSuppression{
+		Email:  "rhodeon@dev.mail",
+		Reason: models.SuppressionReasonComplaint,
+	}
+	err = s.Suppress(complaint)
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertEqual(t, complaint.Created, bounce.Created)
+
+	suppression, err := s.Get("RHODEON@dev.mail")
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertStruct(t, suppression, *complaint)
+
+	suppressions, err := s.GetForEmails([]string{"Rhodeon@dev.mail", "ruona@mail.com"})
+	testhelpers.AssertFatalError(t, err)
+	testhelpers.AssertStruct(t, suppressions, map[string]models.EmailSuppression{"rhodeon@dev.mail": *complaint})
+}
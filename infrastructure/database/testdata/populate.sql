@@ -4,6 +4,31 @@ VALUES ('Bullet Train', 2022, 108, '{"Action", "Comedy"}'),
        ('Hamilton', 2020, 140, '{"Musical", "Drama"}'),
        ('Luca', 2021, 100, '{"Adventure", "Family"}');
 
+UPDATE movies
+SET original_title    = 'Hamilton',
+    original_language = 'en',
+    countries         = '{"US"}',
+    release_dates     = '[{"country": "US", "date": "2020-07-03"}]',
+    certifications    = '[{"country": "US", "rating": "PG-13"}]',
+    synopsis          = 'The story of Alexander Hamilton, told through hip-hop.',
+    tagline           = 'An American musical.',
+    external_ids      = '{"imdb": "tt8503618", "tmdb": "556574"}'
+WHERE title = 'Hamilton';
+
+-- movie_translations
+INSERT INTO movie_translations(movie_id, language, title, synopsis)
+VALUES (1, 'ja', 'ブレット・トレイン', ''),
+       (2, 'fr', 'Hamilton : la comédie musicale', 'L''histoire d''Alexander Hamilton, racontée en hip-hop.');
+
+-- genres
+INSERT INTO genres(slug, name)
+VALUES ('action', 'Action'),
+       ('adventure', 'Adventure'),
+       ('comedy', 'Comedy'),
+       ('drama', 'Drama'),
+       ('family', 'Family'),
+       ('musical', 'Musical');
+
 -- users
 INSERT INTO users(created_at, username, email, password_hash, activated, version)
 VALUES (now(), 'rhodeon', 'rhodeon@dev.mail', '$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm',
@@ -28,4 +53,39 @@ VALUES ('movies:read'),
 -- users_permissions
 INSERT INTO users_permissions(user_id, permission_id)
 VALUES (1, 1),
-       (1, 2);
\ No newline at end of file
+       (1, 2);
+
+-- collections
+INSERT INTO collections(kind, name, description, public, user_id)
+VALUES ('franchise', 'Lin-Manuel Miranda Musicals', '', true, NULL),
+       ('list', 'Weekend picks', '', false, 1),
+       ('list', 'Summer blockbusters', '', true, 2),
+       ('list', 'Guilty pleasures', '', false, 2);
+
+-- collection_movies
+INSERT INTO collection_movies(collection_id, movie_id, position)
+VALUES (1, 2, 1),
+       (2, 1, 1),
+       (2, 3, 2),
+       (2, 2, 3),
+       (3, 1, 1),
+       (4, 1, 1);
+
+-- series
+INSERT INTO series(title, start_year, end_year, genres)
+VALUES ('Severance', 2022, NULL, '{"Drama", "Thriller"}'),
+       ('Arcane', 2021, 2024, '{"Action", "Adventure", "Animation"}');
+
+-- seasons
+INSERT INTO seasons(series_id, number, air_date)
+VALUES (1, 1, '2022-02-18'),
+       (1, 2, '2025-01-17'),
+       (2, 1, '2021-11-06');
+
+-- episodes
+INSERT INTO episodes(season_id, number, title, runtime, air_date)
+VALUES (1, 1, 'Good News About Hell', 57, '2022-02-18'),
+       (1, 2, 'Half Loop', 53, '2022-02-18'),
+       (1, 3, 'In Perpetuity', 52, '2022-02-25'),
+       (2, 1, 'Hello, Ms. Cobel', 50, '2025-01-17'),
+       (3, 1, 'Welcome to the Playground', 43, '2021-11-06');
@@ -2,13 +2,12 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"github.com/rhodeon/moviescreen/domain/models"
 	"time"
 )
 
 type TokenController struct {
-	Db *sql.DB
+	Db Conn
 }
 
 // New is a shortcut to insert a new token with the given user ID, token scope and lifetime.
@@ -68,7 +68,7 @@ func TestTokenController_DeleteAllForUser(t *testing.T) {
 	WHERE (user_id = $1 AND scope = $2) OR expires < now()`
 
 	fetchedToken := models.Token{}
-	row := tokenController.Db.QueryRow(stmt, userId, models.ScopeActivation)
+	row := db.QueryRow(stmt, userId, models.ScopeActivation)
 
 	err = row.Scan(&fetchedToken.Hash, &fetchedToken.UserId, &fetchedToken.Scope, &fetchedToken.Expires)
 	if err != sql.ErrNoRows {
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/rhodeon/moviescreen/domain/repository"
+)
+
+// Conn runs statements on the database, and is satisfied by both *sql.DB and *sql.Tx.
+// Controllers are given a transaction as their connection to take part in a unit of work spanning several controllers.
+type Conn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// txConn is a connection which saves or discards the changes made through it together.
+type txConn interface {
+	Conn
+	Commit() error
+	Rollback() error
+}
+
+// joinedTx is a transaction joined by a controller which doesn't own it.
+// Committing and rolling back are left to the owner, so they are no-ops.
+type joinedTx struct {
+	*sql.Tx
+}
+
+func (joinedTx) Commit() error {
+	return nil
+}
+
+func (joinedTx) Rollback() error {
+	return nil
+}
+
+// beginTx starts a transaction on the connection,
+// or joins the transaction the connection is already within.
+func beginTx(ctx context.Context, conn Conn) (txConn, error) {
+	switch conn := conn.(type) {
+	case *sql.DB:
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	case *sql.Tx:
+		return joinedTx{conn}, nil
+	case joinedTx:
+		return conn, nil
+	default:
+		return nil, fmt.Errorf("database: transactions are unsupported by %T", conn)
+	}
+}
+
+// NewRepositories returns the repositories of the database controllers, all running their statements on the connection.
+func NewRepositories(conn Conn) repository.Repositories {
+	return repository.Repositories{
+		Tokens:                  TokenController{Db: conn},
+		Movies:                  MovieController{Db: conn},
+		MovieRevisions:          MovieRevisionController{Db: conn},
+		MovieTranslations:       MovieTranslationController{Db: conn},
+		MovieSimilarities:       MovieSimilarityController{Db: conn},
+		Genres:                  GenreController{Db: conn},
+		Collections:             CollectionController{Db: conn},
+		Series:                  SeriesController{Db: conn},
+		Search:                  SearchController{Db: conn},
+		Users:                   UserController{Db: conn},
+		Permissions:             PermissionController{Db: conn},
+		NotificationPreferences: NotificationPreferenceController{Db: conn},
+		Outbox:                  OutboxController{Db: conn},
+		Suppressions:            SuppressionController{Db: conn},
+		Transactor:              Transactor{Db: conn},
+	}
+}
+
+type Transactor struct {
+	Db Conn
+}
+
+// WithinTx calls fn with the database repositories running their statements within a single transaction,
+// which is committed if fn returns nil and rolled back otherwise.
+func (t Transactor) WithinTx(ctx context.Context, fn func(repositories repository.Repositories) error) error {
+	tx, err := beginTx(ctx, t.Db)
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	if err = fn(NewRepositories(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"github.com/rhodeon/moviescreen/internal/types"
+	"testing"
+	"time"
+)
+
+func TestTransactor_WithinTx(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+
+	errAbort := errors.New("abort")
+
+	testCases := map[string]struct {
+		// nested runs the unit of work within another, which joins the outer transaction
+		nested   bool
+		fnErr    error
+		wantErr  error
+		wantUser bool
+	}{
+		"commit":        {fnErr: nil, wantErr: nil, wantUser: true},
+		"rollback":      {fnErr: errAbort, wantErr: errAbort, wantUser: false},
+		"nested commit": {nested: true, fnErr: nil, wantErr: nil, wantUser: true},
+		"nested rollback": {
+			nested:   true,
+			fnErr:    errAbort,
+			wantErr:  errAbort,
+			wantUser: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			repositories := NewRepositories(db)
+			defer teardown()
+
+			user := models.User{
+				Username: "janedoe",
+				Email:    "janedoe@mail.com",
+				Password: types.Password{
+					Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
+				},
+			}
+			register := func(repositories repository.Repositories) error {
+				err := repositories.Users.Register(&user)
+				if err != nil {
+					return err
+				}
+
+				err = repositories.Permissions.AddForUser(user, models.PermissionMoviesRead)
+				if err != nil {
+					return err
+				}
+
+				_, err = repositories.Tokens.New(user.Id, models.ScopeActivation, time.Hour)
+				if err != nil {
+					return err
+				}
+				return tc.fnErr
+			}
+
+			var err error
+			if tc.nested {
+				err = repositories.WithinTx(context.Background(), func(repositories repository.Repositories) error {
+					return repositories.WithinTx(context.Background(), register)
+				})
+			} else {
+				err = repositories.WithinTx(context.Background(), register)
+			}
+			testhelpers.AssertError(t, err, tc.wantErr)
+
+			_, err = repositories.Users.GetByEmail(user.Email)
+			if tc.wantUser {
+				testhelpers.AssertError(t, err, nil)
+			} else {
+				testhelpers.AssertError(t, err, repository.ErrRecordNotFound)
+			}
+		})
+	}
+}
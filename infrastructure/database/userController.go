@@ -12,19 +12,19 @@ import (
 )
 
 type UserController struct {
-	Db *sql.DB
+	Db Conn
 }
 
 // Register creates a new user updating the details of the inputted user pointer.
 func (u UserController) Register(user *models.User) error {
-	stmt := `INSERT INTO users (username, email, password_hash) 
-	VALUES ($1, $2, $3)
+	stmt := `INSERT INTO users (username, email, password_hash, language) 
+	VALUES ($1, $2, $3, $4)
 	RETURNING id, version, created_at`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	row := u.Db.QueryRowContext(ctx, stmt, user.Username, user.Email, user.Password.Hash)
+	row := u.Db.QueryRowContext(ctx, stmt, user.Username, user.Email, user.Password.Hash, user.Language)
 	err := row.Scan(&user.Id, &user.Version, &user.Created)
 
 	if err != nil {
@@ -40,8 +40,40 @@ func (u UserController) Register(user *models.User) error {
 	return nil
 }
 
+func (u UserController) Get(id int) (models.User, error) {
+	stmt := `SELECT id, username, email, password_hash, activated, language, version, created_at FROM users
+	WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	user := models.User{}
+
+	err := u.Db.QueryRowContext(ctx, stmt, id).Scan(
+		&user.Id,
+		&user.Username,
+		&user.Email,
+		&user.Password.Hash,
+		&user.Activated,
+		&user.Language,
+		&user.Version,
+		&user.Created,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return models.User{}, repository.ErrRecordNotFound
+
+		default:
+			return models.User{}, err
+		}
+	}
+
+	return user, nil
+}
+
 func (u UserController) GetByEmail(email string) (models.User, error) {
-	stmt := `SELECT id, username, email, password_hash, activated, version, created_at FROM users
+	stmt := `SELECT id, username, email, password_hash, activated, language, version, created_at FROM users
 	WHERE email = $1`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -55,6 +87,7 @@ func (u UserController) GetByEmail(email string) (models.User, error) {
 		&user.Email,
 		&user.Password.Hash,
 		&user.Activated,
+		&user.Language,
 		&user.Version,
 		&user.Created,
 	)
@@ -76,14 +109,14 @@ func (u UserController) GetByEmail(email string) (models.User, error) {
 // match that in the parameter. This is done to prevent data races.
 func (u UserController) Update(user *models.User) error {
 	stmt := `UPDATE users 
-	SET username = $1, email = $2, password_hash = $3, activated = $4, version = version + 1 
-	WHERE id = $5 AND version = $6
+	SET username = $1, email = $2, password_hash = $3, activated = $4, language = $5, version = version + 1 
+	WHERE id = $6 AND version = $7
 	RETURNING version, created_at`
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	err := u.Db.QueryRowContext(ctx, stmt, user.Username, user.Email, user.Password.Hash, user.Activated, user.Id, user.Version).Scan(
+	err := u.Db.QueryRowContext(ctx, stmt, user.Username, user.Email, user.Password.Hash, user.Activated, user.Language, user.Id, user.Version).Scan(
 		&user.Version,
 		&user.Created,
 	)
@@ -111,7 +144,7 @@ func (u UserController) Update(user *models.User) error {
 // GetByToken returns the user satisfying both the plain text token and the scope
 func (u UserController) GetByToken(plainTextToken string, scope string) (models.User, error) {
 	// join user and token tables to check users against the tokens and scopes
-	stmt := `SELECT users.id, users.username, users.email, users.password_hash, users.activated, users.version, users.created_at
+	stmt := `SELECT users.id, users.username, users.email, users.password_hash, users.activated, users.language, users.version, users.created_at
 	FROM users
 	INNER JOIN tokens ON users.id = tokens.user_id
 	WHERE tokens.hash = $1 AND tokens.scope = $2 AND tokens.expires > $3`
@@ -128,6 +161,7 @@ func (u UserController) GetByToken(plainTextToken string, scope string) (models.
 		&user.Email,
 		&user.Password.Hash,
 		&user.Activated,
+		&user.Language,
 		&user.Version,
 		&user.Created,
 	)
@@ -19,6 +19,7 @@ var registerUserTestCases = map[string]struct {
 			Password: types.Password{
 				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 			},
+			Language: "en",
 		},
 		registeredUser: models.User{
 			Id:       4,
@@ -28,6 +29,7 @@ var registerUserTestCases = map[string]struct {
 				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 			},
 			Activated: false,
+			Language:  "en",
 			Version:   1,
 			Created:   time.Time{},
 		},
@@ -59,6 +61,35 @@ var registerUserTestCases = map[string]struct {
 	},
 }
 
+var getUserTestCases = map[string]struct {
+	id       int
+	wantUser models.User
+	wantErr  error
+}{
+	"valid id": {
+		id: 1,
+		wantUser: models.User{
+			Id:       1,
+			Username: "rhodeon",
+			Email:    "rhodeon@dev.mail",
+			Password: types.Password{
+				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
+			},
+			Activated: true,
+			Language:  "en",
+			Version:   1,
+			Created:   time.Time{},
+		},
+		wantErr: nil,
+	},
+
+	"non-existent id": {
+		id:       99,
+		wantUser: models.User{},
+		wantErr:  repository.ErrRecordNotFound,
+	},
+}
+
 var getUserByEmailTestCases = map[string]struct {
 	email    string
 	wantUser models.User
@@ -74,6 +105,7 @@ var getUserByEmailTestCases = map[string]struct {
 				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 			},
 			Activated: true,
+			Language:  "en",
 			Version:   1,
 			Created:   time.Time{},
 		},
@@ -101,6 +133,7 @@ var updateUserTestCases = map[string]struct {
 				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.skdjd"),
 			},
 			Activated: true,
+			Language:  "en",
 			Version:   1,
 			Created:   time.Time{},
 		},
@@ -112,6 +145,7 @@ var updateUserTestCases = map[string]struct {
 				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.skdjd"),
 			},
 			Activated: true,
+			Language:  "en",
 			Version:   2,
 			Created:   time.Time{},
 		},
@@ -127,6 +161,7 @@ var updateUserTestCases = map[string]struct {
 				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 			},
 			Activated: true,
+			Language:  "en",
 			Version:   1,
 			Created:   time.Time{},
 		},
@@ -143,6 +178,7 @@ var updateUserTestCases = map[string]struct {
 				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 			},
 			Activated: true,
+			Language:  "en",
 			Version:   1,
 			Created:   time.Time{},
 		},
@@ -159,6 +195,7 @@ var updateUserTestCases = map[string]struct {
 				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 			},
 			Activated: true,
+			Language:  "en",
 			Version:   2,
 			Created:   time.Time{},
 		},
@@ -184,6 +221,7 @@ var getUserByTokenTestCases = map[string]struct {
 				Hash: []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 			},
 			Activated: true,
+			Language:  "en",
 			Version:   1,
 			Created:   time.Time{},
 		},
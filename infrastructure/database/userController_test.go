@@ -32,6 +32,28 @@ func TestUserController_Register(t *testing.T) {
 	}
 }
 
+func TestUserController_Get(t *testing.T) {
+	if testing.Short() {
+		t.Skip("postgres: skipping integration test")
+	}
+	testcases := getUserTestCases
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := newTestDb(t)
+			userController := UserController{Db: db}
+			defer teardown()
+
+			user, err := userController.Get(tc.id)
+
+			testhelpers.AssertError(t, err, tc.wantErr)
+
+			user.Created = time.Time{}
+			testhelpers.AssertStruct(t, user, tc.wantUser)
+		})
+	}
+}
+
 func TestUserController_GetByEmail(t *testing.T) {
 	if testing.Short() {
 		t.Skip("postgres: skipping integration test")
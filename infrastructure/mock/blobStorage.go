@@ -0,0 +1,58 @@
+package mock
+
+import (
+	"io"
+	"sync"
+)
+
+// MockStorageUrl is the base URL of the objects in a BlobStorage.
+const MockStorageUrl = "https://media.moviescreen.test"
+
+// BlobStorage keeps objects in memory, allowing tests to inspect what was stored.
+type BlobStorage struct {
+	mu      sync.Mutex
+	objects map[string]blob
+}
+
+type blob struct {
+	contentType string
+	content     []byte
+}
+
+func NewBlobStorage() *BlobStorage {
+	return &BlobStorage{objects: map[string]blob{}}
+}
+
+func (s *BlobStorage) Put(key string, contentType string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = blob{contentType: contentType, content: data}
+	return nil
+}
+
+func (s *BlobStorage) Delete(keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.objects, key)
+	}
+	return nil
+}
+
+func (s *BlobStorage) Url(key string) string {
+	return MockStorageUrl + "/" + key
+}
+
+// Get returns the content type and content of the object stored under the key,
+// and false if there is none.
+func (s *BlobStorage) Get(key string) (string, []byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, exists := s.objects[key]
+	return object.contentType, object.content, exists
+}
@@ -0,0 +1,244 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"sort"
+)
+
+type CollectionController struct {
+	Data models.Collections
+}
+
+// NewCollectionController creates a CollectionController pointer with the data being
+// a copy of the collections slice to avoid persistent modification across tests.
+func NewCollectionController() *CollectionController {
+	newCollections := make(models.Collections, len(collections))
+	copy(newCollections, collections)
+	return &CollectionController{Data: newCollections}
+}
+
+var collections = models.Collections{
+	{
+		Id:          1,
+		Kind:        models.CollectionKindFranchise,
+		Name:        "Lin-Manuel Miranda Musicals",
+		Description: "Stage musicals by Lin-Manuel Miranda brought to the screen.",
+		Public:      true,
+		Created:     MockDate,
+		Updated:     MockDate,
+	},
+	{
+		Id:          2,
+		Kind:        models.CollectionKindList,
+		Name:        "Weekend picks",
+		Description: "Movies to watch this weekend.",
+		UserId:      1,
+		Created:     MockDate,
+		Updated:     MockDate,
+	},
+	{
+		Id:      3,
+		Kind:    models.CollectionKindList,
+		Name:    "Summer blockbusters",
+		Public:  true,
+		UserId:  2,
+		Created: MockDate,
+		Updated: MockDate,
+	},
+	{
+		Id:      4,
+		Kind:    models.CollectionKindList,
+		Name:    "Guilty pleasures",
+		UserId:  2,
+		Created: MockDate,
+		Updated: MockDate,
+	},
+}
+
+type collectionMovie struct {
+	collectionId int
+	movieId      int
+	position     int
+}
+
+// collectionMovies is ordered by collection id and position.
+var collectionMovies = []collectionMovie{
+	{collectionId: 1, movieId: 2, position: 1},
+	{collectionId: 2, movieId: 1, position: 1},
+	{collectionId: 2, movieId: 4, position: 2},
+	{collectionId: 2, movieId: 2, position: 3},
+	{collectionId: 3, movieId: 1, position: 1},
+	{collectionId: 4, movieId: 1, position: 1},
+}
+
+func (c CollectionController) List(query request.CollectionQuery, filters request.Filters, userId int) (models.Collections, response.Metadata, error) {
+	collectionList := models.Collections{}
+	for _, collection := range c.Data {
+		if !collection.VisibleTo(userId) ||
+			query.Kind != "" && collection.Kind != query.Kind ||
+			query.UserId != 0 && collection.UserId != query.UserId {
+			continue
+		}
+		collection.MovieCount = len(collectionEntries(collection.Id))
+		collectionList = append(collectionList, collection)
+	}
+
+	sort.SliceStable(collectionList, func(i, j int) bool {
+		var less bool
+		switch filters.SortColumn(request.CollectionFilterSortId) {
+		case request.CollectionFilterSortName:
+			less = collectionList[i].Name < collectionList[j].Name
+		case request.CollectionFilterSortUpdated:
+			less = collectionList[i].Updated.Before(collectionList[j].Updated)
+		default:
+			less = collectionList[i].Id < collectionList[j].Id
+		}
+		if filters.SortDirection() == "DESC" {
+			return !less
+		}
+		return less
+	})
+
+	// determine ending index based on page limit
+	stop := filters.Offset() + filters.Limit
+	if stop > len(collectionList) {
+		stop = len(collectionList)
+	}
+
+	start := filters.Offset()
+	if start > stop {
+		start = stop
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, len(collectionList))
+	return collectionList[start:stop], metadata, nil
+}
+
+func (c CollectionController) Get(id int) (models.Collection, error) {
+	for _, collection := range c.Data {
+		if collection.Id == id {
+			collection.Entries = collectionEntries(id)
+			collection.MovieCount = len(collection.Entries)
+			return collection, nil
+		}
+	}
+	return models.Collection{}, repository.ErrRecordNotFound
+}
+
+func (c CollectionController) ListForMovies(movieIds []int, userId int) (map[int]models.MovieCollections, error) {
+	movieCollections := map[int]models.MovieCollections{}
+	for _, movieId := range movieIds {
+		for _, collection := range c.Data {
+			if !collection.VisibleTo(userId) {
+				continue
+			}
+			if entry, found := findCollectionMovie(collection.Id, movieId); found {
+				movieCollections[movieId] = append(movieCollections[movieId], models.MovieCollection{
+					Id:       collection.Id,
+					Kind:     collection.Kind,
+					Name:     collection.Name,
+					Position: entry.position,
+				})
+			}
+		}
+
+		sort.SliceStable(movieCollections[movieId], func(i, j int) bool {
+			a, b := movieCollections[movieId][i], movieCollections[movieId][j]
+			if a.Kind != b.Kind {
+				return a.Kind < b.Kind
+			}
+			return a.Name < b.Name
+		})
+	}
+	return movieCollections, nil
+}
+
+// Create sets the timestamps of the collection to MockDate for predictable responses.
+func (c CollectionController) Create(collection *models.Collection) error {
+	collection.Id = len(c.Data) + 1
+	collection.Created = MockDate
+	collection.Updated = MockDate
+	return nil
+}
+
+// Update sets the modification time of the collection to MockDate for predictable responses.
+func (c CollectionController) Update(collection *models.Collection) error {
+	if _, err := c.Get(collection.Id); err != nil {
+		return err
+	}
+	collection.Updated = MockDate
+	return nil
+}
+
+func (c CollectionController) Delete(id int) error {
+	_, err := c.Get(id)
+	return err
+}
+
+// AddMovie only checks the collection and movie, without changing anything as mock data is not persistent.
+func (c CollectionController) AddMovie(collectionId int, movieId int, _ int) error {
+	if _, err := c.Get(collectionId); err != nil {
+		return err
+	}
+	if _, err := (MovieController{}).Get(movieId); err != nil {
+		return err
+	}
+	if _, found := findCollectionMovie(collectionId, movieId); found {
+		return repository.ErrDuplicateCollectionMovie
+	}
+	return nil
+}
+
+func (c CollectionController) RemoveMovie(collectionId int, movieId int) error {
+	if _, found := findCollectionMovie(collectionId, movieId); !found {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (c CollectionController) Reorder(collectionId int, movieIds []int) error {
+	for _, movieId := range movieIds {
+		if _, found := findCollectionMovie(collectionId, movieId); !found {
+			return repository.ErrRecordNotFound
+		}
+	}
+	return nil
+}
+
+// collectionEntries returns the entries of the movies outside the trash in the collection, ordered by position.
+func collectionEntries(collectionId int) []models.CollectionEntry {
+	entries := []models.CollectionEntry{}
+	for _, entry := range collectionMovies {
+		if entry.collectionId != collectionId {
+			continue
+		}
+		movie, err := (MovieController{}).Get(entry.movieId)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, models.CollectionEntry{
+			Position: entry.position,
+			Movie: models.Movie{
+				Id:      movie.Id,
+				Title:   movie.Title,
+				Year:    movie.Year,
+				Runtime: movie.Runtime,
+				Genres:  movie.Genres,
+			},
+		})
+	}
+	return entries
+}
+
+// findCollectionMovie returns the entry of the movie in the collection, and false if there is none.
+func findCollectionMovie(collectionId int, movieId int) (collectionMovie, bool) {
+	for _, entry := range collectionMovies {
+		if entry.collectionId == collectionId && entry.movieId == movieId {
+			return entry, true
+		}
+	}
+	return collectionMovie{}, false
+}
@@ -0,0 +1,119 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+)
+
+type GenreController struct {
+	Data models.Genres
+}
+
+// NewGenreController creates a GenreController pointer with the data being
+// a copy of the genres slice to avoid persistent modification across tests.
+func NewGenreController() *GenreController {
+	newGenres := make(models.Genres, len(genres))
+	copy(newGenres, genres)
+	return &GenreController{Data: newGenres}
+}
+
+var genres = models.Genres{
+	{Id: 1, Slug: "action", Name: "Action"},
+	{Id: 2, Slug: "adventure", Name: "Adventure"},
+	{Id: 3, Slug: "animation", Name: "Animation"},
+	{Id: 4, Slug: "comedy", Name: "Comedy"},
+	{Id: 5, Slug: "crime", Name: "Crime"},
+	{Id: 6, Slug: "drama", Name: "Drama"},
+	{Id: 7, Slug: "family", Name: "Family"},
+	{Id: 8, Slug: "fantasy", Name: "Fantasy"},
+	{Id: 9, Slug: "history", Name: "History"},
+	{Id: 10, Slug: "musical", Name: "Musical"},
+	{Id: 11, Slug: "thriller", Name: "Thriller"},
+}
+
+// List counts the movies outside the trash with each genre.
+func (g GenreController) List() (models.Genres, error) {
+	genreList := models.Genres{}
+	for _, genre := range genres {
+		genre.MovieCount = countGenreMovies(genre.Name)
+		genreList = append(genreList, genre)
+	}
+	return genreList, nil
+}
+
+func (g GenreController) Names() ([]string, error) {
+	names := []string{}
+	for _, genre := range genres {
+		names = append(names, genre.Name)
+	}
+	return names, nil
+}
+
+func (g GenreController) Get(id int) (models.Genre, error) {
+	for _, genre := range genres {
+		if genre.Id == id {
+			return genre, nil
+		}
+	}
+	return models.Genre{}, repository.ErrRecordNotFound
+}
+
+func (g GenreController) Create(genre *models.Genre) error {
+	for _, existing := range genres {
+		if existing.Slug == genre.Slug || existing.Name == genre.Name {
+			return repository.ErrDuplicateGenre
+		}
+	}
+	genre.Id = len(genres) + 1
+	return nil
+}
+
+// Rename counts the movies with the previous name of the genre,
+// without changing anything as mock data is not persistent.
+func (g GenreController) Rename(genre *models.Genre, _ int) error {
+	found := false
+	for _, existing := range genres {
+		if existing.Id == genre.Id {
+			found = true
+			genre.MovieCount = countGenreMovies(existing.Name)
+		} else if existing.Slug == genre.Slug || existing.Name == genre.Name {
+			return repository.ErrDuplicateGenre
+		}
+	}
+	if !found {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Merge returns the target genre with the movies of both genres counted,
+// without changing anything as mock data is not persistent.
+func (g GenreController) Merge(sourceId int, targetId int, _ int) (models.Genre, error) {
+	source, err := g.Get(sourceId)
+	if err != nil {
+		return models.Genre{}, err
+	}
+	target, err := g.Get(targetId)
+	if err != nil {
+		return models.Genre{}, err
+	}
+
+	for _, movie := range movies {
+		if movie.Deleted == nil && (rules.In(source.Name, movie.Genres) || rules.In(target.Name, movie.Genres)) {
+			target.MovieCount++
+		}
+	}
+	return target, nil
+}
+
+// countGenreMovies returns the number of movies outside the trash with the genre.
+func countGenreMovies(name string) int {
+	count := 0
+	for _, movie := range movies {
+		if movie.Deleted == nil && rules.In(name, movie.Genres) {
+			count++
+		}
+	}
+	return count
+}
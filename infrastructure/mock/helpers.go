@@ -1,11 +1,131 @@
 package mock
 
 import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
 	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
 	"sort"
 	"strings"
 )
 
+// selectMovieFields returns a copy of the movie with only the given fields populated,
+// mimicking the column selection of the database.
+// The id, modification time and version are always populated.
+func selectMovieFields(movie models.Movie, fields []string) models.Movie {
+	if len(fields) == 0 {
+		return movie
+	}
+
+	selected := models.Movie{
+		Id:      movie.Id,
+		Version: movie.Version,
+		Updated: movie.Updated,
+	}
+	if rules.In(request.MovieFieldTitle, fields) {
+		selected.Title = movie.Title
+	}
+	if rules.In(request.MovieFieldYear, fields) {
+		selected.Year = movie.Year
+	}
+	if rules.In(request.MovieFieldRuntime, fields) {
+		selected.Runtime = movie.Runtime
+	}
+	if rules.In(request.MovieFieldGenres, fields) {
+		selected.Genres = movie.Genres
+	}
+	if rules.In(request.MovieFieldOriginalTitle, fields) {
+		selected.OriginalTitle = movie.OriginalTitle
+	}
+	if rules.In(request.MovieFieldOriginalLanguage, fields) {
+		selected.OriginalLanguage = movie.OriginalLanguage
+	}
+	if rules.In(request.MovieFieldCountries, fields) {
+		selected.Countries = movie.Countries
+	}
+	if rules.In(request.MovieFieldReleaseDates, fields) {
+		selected.ReleaseDates = movie.ReleaseDates
+	}
+	if rules.In(request.MovieFieldCertifications, fields) {
+		selected.Certifications = movie.Certifications
+	}
+	if rules.In(request.MovieFieldSynopsis, fields) {
+		selected.Synopsis = movie.Synopsis
+	}
+	if rules.In(request.MovieFieldTagline, fields) {
+		selected.Tagline = movie.Tagline
+	}
+	if rules.In(request.MovieFieldExternalIds, fields) {
+		selected.ExternalIds = movie.ExternalIds
+	}
+	if rules.In(request.MovieFieldPoster, fields) {
+		selected.Poster = movie.Poster
+	}
+	if rules.In(request.MovieFieldBackdrop, fields) {
+		selected.Backdrop = movie.Backdrop
+	}
+	return selected
+}
+
+// externalIdRegistered returns true if any external id of the movie belongs to another movie,
+// including those in the trash.
+func externalIdRegistered(movie models.Movie) bool {
+	for _, existing := range movies {
+		if existing.Id == movie.Id {
+			continue
+		}
+		for source, id := range movie.ExternalIds {
+			if existing.ExternalIds[source] == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesMovieTitle returns true if the title of the movie or any of its translations contains the search.
+func matchesMovieTitle(movie models.Movie, search string) bool {
+	if strings.Contains(movie.Title, search) {
+		return true
+	}
+	for _, translation := range movieTranslations {
+		if translation.MovieId == movie.Id && strings.Contains(translation.Title, search) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMovieQuery returns true if the movie is outside the trash and satisfies the query.
+func matchesMovieQuery(movie models.Movie, query request.MovieQuery) bool {
+	if movie.Deleted != nil ||
+		!matchesMovieTitle(movie, query.Title) ||
+		!caseInsensitiveSubslice(query.Genres, movie.Genres) ||
+		(query.Language != "" && movie.OriginalLanguage != query.Language) {
+		return false
+	}
+
+	for _, country := range query.Countries {
+		if !rules.In(country, movie.Countries) {
+			return false
+		}
+	}
+
+	if certification := query.CertificationFilter(); certification != nil && !rules.In(*certification, movie.Certifications) {
+		return false
+	}
+
+	if query.ReleasedFrom == "" && query.ReleasedTo == "" {
+		return true
+	}
+	for _, release := range movie.ReleaseDates {
+		date := release.Date.String()
+		if (query.ReleasedFrom == "" || date >= query.ReleasedFrom) && (query.ReleasedTo == "" || date <= query.ReleasedTo) {
+			return true
+		}
+	}
+	return false
+}
+
 // caseInsensitiveSubslice checks if the target slice contains the data slice.
 func caseInsensitiveSubslice(data []string, target []string) bool {
 	if len(data) > len(target) {
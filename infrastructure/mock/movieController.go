@@ -5,8 +5,13 @@ import (
 	"github.com/rhodeon/moviescreen/cmd/api/models/response"
 	"github.com/rhodeon/moviescreen/domain/models"
 	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/types"
+	"github.com/rhodeon/moviescreen/internal/validator/rules"
+	"math"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 )
 
 type MovieController struct {
@@ -30,6 +35,7 @@ var movies = models.Movies{
 		Genres:  []string{"Action", "Comedy"},
 		Version: 1,
 		Created: time.Now(),
+		Updated: MockDate,
 	},
 	{
 		Id:      2,
@@ -37,35 +43,78 @@ var movies = models.Movies{
 		Year:    2020,
 		Runtime: 140,
 		Genres:  []string{"Musical", "Drama"},
+
+		OriginalTitle:    "Hamilton",
+		OriginalLanguage: "en",
+		Countries:        []string{"US"},
+		ReleaseDates:     []models.ReleaseDate{{Country: "US", Date: types.Date{Time: time.Date(2020, 7, 3, 0, 0, 0, 0, time.UTC)}}},
+		Certifications:   []models.Certification{{Country: "US", Rating: "PG-13"}},
+		Synopsis:         "The story of Alexander Hamilton, told through hip-hop.",
+		Tagline:          "An American musical.",
+		ExternalIds:      map[string]string{"imdb": "tt8503618", "tmdb": "556574"},
+
 		Version: 1,
 		Created: time.Now(),
+		Updated: MockDate,
+	},
+	{
+		Id:          4,
+		Title:       "Luca",
+		Year:        2021,
+		Runtime:     100,
+		Genres:      []string{"Adventure", "Family"},
+		ExternalIds: map[string]string{"imdb": "tt12801262"},
+		Version:     1,
+		Created:     time.Now(),
+		Updated:     MockDate,
+		Deleted:     &MockDate,
 	},
 }
 
 func (m MovieController) Create(movie *models.Movie) error {
+	if externalIdRegistered(*movie) {
+		return repository.ErrDuplicateExternalId
+	}
 	movie.Id = 3
 	movie.Version = 1
 	movie.Created = time.Now()
+	movie.Updated = movie.Created
+	return nil
+}
+
+// BulkCreate assigns ids following the existing movies to the new movies.
+func (m MovieController) BulkCreate(newMovies models.Movies) error {
+	for _, movie := range newMovies {
+		if externalIdRegistered(movie) {
+			return repository.ErrDuplicateExternalId
+		}
+	}
+	for i := range newMovies {
+		newMovies[i].Id = len(movies) + i + 1
+		newMovies[i].Version = 1
+		newMovies[i].Created = time.Now()
+		newMovies[i].Updated = newMovies[i].Created
+	}
 	return nil
 }
 
-func (m MovieController) Get(id int) (models.Movie, error) {
+func (m MovieController) Get(id int, fields ...string) (models.Movie, error) {
 	for _, movie := range movies {
-		if movie.Id == id {
-			return movie, nil
+		if movie.Id == id && movie.Deleted == nil {
+			return selectMovieFields(movie, fields), nil
 		}
 	}
 
 	return models.Movie{}, repository.ErrRecordNotFound
 }
 
-func (m MovieController) List(title string, genres []string, filters request.Filters) (models.Movies, response.Metadata, error) {
+func (m MovieController) List(query request.MovieQuery, filters request.Filters, fields ...string) (models.Movies, response.Metadata, error) {
 	movieList := models.Movies{}
 
-	// add movies which match both the title and the genres
+	// add movies which match the query
 	for _, movie := range movies {
-		if strings.Contains(movie.Title, title) && caseInsensitiveSubslice(genres, movie.Genres) {
-			movieList = append(movieList, movie)
+		if matchesMovieQuery(movie, query) {
+			movieList = append(movieList, selectMovieFields(movie, fields))
 		}
 	}
 
@@ -107,9 +156,57 @@ func (m MovieController) List(title string, genres []string, filters request.Fil
 	return movieList, metadata, nil
 }
 
+func (m MovieController) Iterate(query request.MovieQuery, filters request.Filters) repository.MovieIterator {
+	movieList := models.Movies{}
+
+	// add movies which match the query
+	for _, movie := range movies {
+		if matchesMovieQuery(movie, query) {
+			movieList = append(movieList, movie)
+		}
+	}
+
+	// sort based on the filter
+	ascending := !strings.HasPrefix(filters.Sort, "-")
+	switch strings.TrimPrefix(filters.Sort, "-") {
+	case "id":
+		sortMoviesById(movieList, ascending)
+	case "title":
+		sortMoviesByTitle(movieList, ascending)
+	case "year":
+		sortMoviesByYear(movieList, ascending)
+	case "runtime":
+		sortMoviesByRuntime(movieList, ascending)
+	}
+
+	return &movieIterator{movies: movieList, index: -1}
+}
+
+// movieIterator iterates over a slice of movies.
+type movieIterator struct {
+	movies models.Movies
+	index  int
+}
+
+func (i *movieIterator) Next() bool {
+	i.index++
+	return i.index < len(i.movies)
+}
+
+func (i *movieIterator) Movie() models.Movie {
+	return i.movies[i.index]
+}
+
+func (i *movieIterator) Err() error {
+	return nil
+}
+
 func (m MovieController) Update(movie *models.Movie) error {
+	if externalIdRegistered(*movie) {
+		return repository.ErrDuplicateExternalId
+	}
 	for _, mov := range movies {
-		if mov.Id == movie.Id {
+		if mov.Id == movie.Id && mov.Deleted == nil {
 			movie.Version = mov.Version + 1
 			return nil
 		}
@@ -117,12 +214,260 @@ func (m MovieController) Update(movie *models.Movie) error {
 	return repository.ErrRecordNotFound
 }
 
-func (m MovieController) Delete(id int) error {
+func (m MovieController) Lookup(source string, externalId string) (models.Movie, error) {
 	for _, movie := range movies {
-		if movie.Id == id {
+		if movie.Deleted == nil && movie.ExternalIds[source] == externalId && externalId != "" {
+			return movie, nil
+		}
+	}
+	return models.Movie{}, repository.ErrRecordNotFound
+}
+
+func (m MovieController) FindByExternalIds(source string, externalIds []string) (models.Movies, error) {
+	found := models.Movies{}
+	for _, movie := range movies {
+		if rules.In(movie.ExternalIds[source], externalIds) {
+			found = append(found, movie)
+		}
+	}
+	return found, nil
+}
+
+func (m MovieController) Delete(id int, version int) error {
+	for _, movie := range movies {
+		if movie.Id == id && movie.Version == version && movie.Deleted == nil {
 			// delete nothing as mock data is not persistent
 			return nil
 		}
 	}
-	return repository.ErrRecordNotFound
+	return repository.ErrEditConflict
+}
+
+func (m MovieController) ListDeleted(filters request.Filters) (models.Movies, response.Metadata, error) {
+	movieList := models.Movies{}
+	for _, movie := range movies {
+		if movie.Deleted != nil {
+			movieList = append(movieList, movie)
+		}
+	}
+
+	// determine ending index based on page limit
+	stop := filters.Offset() + filters.Limit
+	if stop > len(movieList) {
+		stop = len(movieList)
+	}
+
+	start := filters.Offset()
+	if start > stop {
+		start = stop
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, len(movieList))
+	return movieList[start:stop], metadata, nil
+}
+
+func (m MovieController) Restore(id int) (models.Movie, error) {
+	for _, movie := range movies {
+		if movie.Id == id && movie.Deleted != nil {
+			movie.Deleted = nil
+			return movie, nil
+		}
+	}
+	return models.Movie{}, repository.ErrRecordNotFound
+}
+
+func (m MovieController) Purge(deletedBefore time.Time) (int64, []string, error) {
+	var purged int64
+	var imageKeys []string
+	for _, movie := range movies {
+		if movie.Deleted != nil && movie.Deleted.Before(deletedBefore) {
+			purged++
+			imageKeys = append(imageKeys, movie.Poster.Keys()...)
+			imageKeys = append(imageKeys, movie.Backdrop.Keys()...)
+		}
+	}
+	return purged, imageKeys, nil
+}
+
+func (m MovieController) CountByGenre() (models.GenreCounts, error) {
+	counts := models.GenreCounts{}
+	for _, movie := range activeMovies() {
+		for _, genre := range movie.Genres {
+			found := false
+			for i := range counts {
+				if counts[i].Genre == genre {
+					counts[i].Count++
+					found = true
+				}
+			}
+			if !found {
+				counts = append(counts, models.GenreCount{Genre: genre, Count: 1})
+			}
+		}
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Genre < counts[j].Genre
+	})
+	return counts, nil
+}
+
+func (m MovieController) CountByYear() (models.YearCounts, error) {
+	counts := models.YearCounts{}
+	for _, movie := range activeMovies() {
+		found := false
+		for i := range counts {
+			if counts[i].Year == movie.Year {
+				counts[i].Count++
+				found = true
+			}
+		}
+		if !found {
+			counts = append(counts, models.YearCount{Year: movie.Year, Count: 1})
+		}
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool {
+		return counts[i].Year < counts[j].Year
+	})
+	return counts, nil
+}
+
+// RuntimeStats picks the percentiles as the smallest runtimes with at least the percentage of runtimes
+// at or below them, as the discrete percentiles of the database do.
+func (m MovieController) RuntimeStats() (models.RuntimeStats, error) {
+	runtimes := []int{}
+	for _, movie := range activeMovies() {
+		runtimes = append(runtimes, movie.Runtime)
+	}
+	if len(runtimes) == 0 {
+		return models.RuntimeStats{}, nil
+	}
+	sort.Ints(runtimes)
+
+	percentile := func(fraction float64) int {
+		index := int(math.Ceil(fraction*float64(len(runtimes)))) - 1
+		if index < 0 {
+			index = 0
+		}
+		return runtimes[index]
+	}
+
+	stats := models.RuntimeStats{
+		Min:    runtimes[0],
+		P25:    percentile(0.25),
+		Median: percentile(0.5),
+		P75:    percentile(0.75),
+		P90:    percentile(0.9),
+		Max:    runtimes[len(runtimes)-1],
+	}
+	for _, runtime := range runtimes {
+		stats.Total += runtime
+	}
+	stats.Mean = float64(stats.Total) / float64(len(runtimes))
+	return stats, nil
+}
+
+func (m MovieController) ListRecent(limit int) (models.Movies, error) {
+	recent := activeMovies()
+	sort.SliceStable(recent, func(i, j int) bool {
+		if !recent[i].Created.Equal(recent[j].Created) {
+			return recent[i].Created.After(recent[j].Created)
+		}
+		return recent[i].Id > recent[j].Id
+	})
+
+	if len(recent) > limit {
+		recent = recent[:limit]
+	}
+	return recent, nil
+}
+
+// activeMovies returns the movies outside the trash.
+func activeMovies() models.Movies {
+	active := models.Movies{}
+	for _, movie := range movies {
+		if movie.Deleted == nil {
+			active = append(active, movie)
+		}
+	}
+	return active
+}
+
+func (m MovieController) ListDuplicates(filters request.Filters) (models.DuplicateCandidates, response.Metadata, error) {
+	candidates := models.DuplicateCandidates{}
+	active := activeMovies()
+	for i, first := range active {
+		for _, second := range active[i+1:] {
+			if normaliseTitle(first.Title) == normaliseTitle(second.Title) && first.Year == second.Year {
+				candidates = append(candidates, models.DuplicateCandidate{
+					Movies:     models.Movies{first, second},
+					Reason:     models.DuplicateReasonTitleYear,
+					Similarity: 1,
+				})
+			}
+		}
+	}
+
+	// determine ending index based on page limit
+	stop := filters.Offset() + filters.Limit
+	if stop > len(candidates) {
+		stop = len(candidates)
+	}
+
+	start := filters.Offset()
+	if start > stop {
+		start = stop
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, len(candidates))
+	return candidates[start:stop], metadata, nil
+}
+
+// normaliseTitle returns the title in lowercase without non-alphanumeric characters.
+func normaliseTitle(title string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, title)
+}
+
+func (m MovieController) Merge(survivorId int, duplicateIds []int, userId int) (models.Movie, error) {
+	survivor, err := m.Get(survivorId)
+	if err != nil {
+		return models.Movie{}, err
+	}
+
+	// duplicates are absorbed in order of their ids
+	ids := append([]int{}, duplicateIds...)
+	sort.Ints(ids)
+	for _, id := range ids {
+		duplicate, err := m.Get(id)
+		if err != nil {
+			return models.Movie{}, repository.ErrMissingDuplicate
+		}
+		survivor.Absorb(duplicate)
+	}
+
+	// merge nothing as mock data is not persistent
+	survivor.Version++
+	return survivor, nil
+}
+
+// redirects maps the ids of movies merged into other movies to the ids of the surviving movies.
+var redirects = map[int]int{
+	5: 2,
+}
+
+func (m MovieController) Redirect(oldId int) (int, error) {
+	movieId, exists := redirects[oldId]
+	if !exists {
+		return 0, repository.ErrRecordNotFound
+	}
+	return movieId, nil
 }
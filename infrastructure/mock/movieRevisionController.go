@@ -0,0 +1,141 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"sort"
+	"time"
+)
+
+type MovieRevisionController struct {
+	Data models.MovieRevisions
+}
+
+// NewMovieRevisionController creates a MovieRevisionController pointer with the data being
+// a copy of the movieRevisions slice to avoid persistent modification across tests.
+func NewMovieRevisionController() *MovieRevisionController {
+	newRevisions := make(models.MovieRevisions, len(movieRevisions))
+	copy(newRevisions, movieRevisions)
+	return &MovieRevisionController{Data: newRevisions}
+}
+
+var movieRevisions = models.MovieRevisions{
+	{
+		Id:       1,
+		MovieId:  1,
+		Version:  1,
+		Action:   models.RevisionActionCreate,
+		Snapshot: movies[0].Snapshot(),
+		UserId:   1,
+		Created:  MockDate,
+	},
+	{
+		Id:       2,
+		MovieId:  2,
+		Version:  1,
+		Action:   models.RevisionActionCreate,
+		Snapshot: movies[1].Snapshot(),
+		UserId:   1,
+		Created:  MockDate,
+	},
+	{
+		Id:       3,
+		MovieId:  4,
+		Version:  1,
+		Action:   models.RevisionActionCreate,
+		Snapshot: movies[2].Snapshot(),
+		UserId:   1,
+		Created:  MockDate,
+	},
+	{
+		Id:       4,
+		MovieId:  4,
+		Version:  1,
+		Action:   models.RevisionActionDelete,
+		Snapshot: movies[2].Snapshot(),
+		UserId:   1,
+		Created:  MockDate,
+	},
+}
+
+func (m MovieRevisionController) Insert(revisions ...*models.MovieRevision) error {
+	for i, revision := range revisions {
+		revision.Id = len(movieRevisions) + i + 1
+		revision.Created = MockDate
+	}
+	return nil
+}
+
+func (m MovieRevisionController) Get(movieId int, version int) (models.MovieRevision, error) {
+	for i := len(movieRevisions) - 1; i >= 0; i-- {
+		revision := movieRevisions[i]
+		if revision.MovieId == movieId && revision.Version == version && revision.Action != models.RevisionActionDelete {
+			return revision, nil
+		}
+	}
+	return models.MovieRevision{}, repository.ErrRecordNotFound
+}
+
+func (m MovieRevisionController) List(movieId int, filters request.Filters) (models.MovieRevisions, response.Metadata, error) {
+	revisionList := models.MovieRevisions{}
+	for _, revision := range movieRevisions {
+		if revision.MovieId == movieId {
+			revisionList = append(revisionList, revision)
+		}
+	}
+
+	// sort by id, which follows the order of versions
+	sort.SliceStable(revisionList, func(i, j int) bool {
+		if filters.SortDirection() == "DESC" {
+			return revisionList[i].Id > revisionList[j].Id
+		}
+		return revisionList[i].Id < revisionList[j].Id
+	})
+
+	// determine ending index based on page limit
+	stop := filters.Offset() + filters.Limit
+	if stop > len(revisionList) {
+		stop = len(revisionList)
+	}
+
+	start := filters.Offset()
+	if start > stop {
+		start = stop
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, len(revisionList))
+	return revisionList[start:stop], metadata, nil
+}
+
+func (m MovieRevisionController) PurgeRemoved(changedBefore time.Time) (int64, error) {
+	// find the latest change of each movie which no longer exists
+	lastChanged := map[int]time.Time{}
+	for _, revision := range movieRevisions {
+		if movieExists(revision.MovieId) {
+			continue
+		}
+		if revision.Created.After(lastChanged[revision.MovieId]) {
+			lastChanged[revision.MovieId] = revision.Created
+		}
+	}
+
+	var purged int64
+	for _, revision := range movieRevisions {
+		if changed, exists := lastChanged[revision.MovieId]; exists && changed.Before(changedBefore) {
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// movieExists reports whether the movie with the given id is stored, including in the trash.
+func movieExists(id int) bool {
+	for _, movie := range movies {
+		if movie.Id == id {
+			return true
+		}
+	}
+	return false
+}
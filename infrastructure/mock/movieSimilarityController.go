@@ -0,0 +1,46 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+)
+
+type MovieSimilarityController struct{}
+
+func NewMovieSimilarityController() *MovieSimilarityController {
+	return &MovieSimilarityController{}
+}
+
+// movieSimilarity is a precomputed similarity score of a pair of movies.
+type movieSimilarity struct {
+	movieId        int
+	similarMovieId int
+	score          float64
+}
+
+// movieSimilarities is ordered by movie id and descending score.
+var movieSimilarities = []movieSimilarity{
+	{movieId: 1, similarMovieId: 4, score: 0.385},
+	{movieId: 1, similarMovieId: 2, score: 0.364},
+	{movieId: 2, similarMovieId: 1, score: 0.364},
+	{movieId: 4, similarMovieId: 1, score: 0.385},
+}
+
+func (m MovieSimilarityController) List(movieId int, limit int) (models.SimilarMovies, error) {
+	similarMovies := models.SimilarMovies{}
+	for _, similarity := range movieSimilarities {
+		if similarity.movieId != movieId || len(similarMovies) == limit {
+			continue
+		}
+
+		for _, movie := range movies {
+			if movie.Id == similarity.similarMovieId && movie.Deleted == nil {
+				similarMovies = append(similarMovies, models.SimilarMovie{Movie: movie, Score: similarity.score})
+			}
+		}
+	}
+	return similarMovies, nil
+}
+
+func (m MovieSimilarityController) Refresh() (int, error) {
+	return len(movieSimilarities), nil
+}
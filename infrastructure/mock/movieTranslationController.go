@@ -0,0 +1,99 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+)
+
+type MovieTranslationController struct {
+	Data models.MovieTranslations
+}
+
+// NewMovieTranslationController creates a MovieTranslationController pointer with the data being
+// a copy of the movieTranslations slice to avoid persistent modification across tests.
+func NewMovieTranslationController() *MovieTranslationController {
+	newTranslations := make(models.MovieTranslations, len(movieTranslations))
+	copy(newTranslations, movieTranslations)
+	return &MovieTranslationController{Data: newTranslations}
+}
+
+// movieTranslations is ordered by movie id and language.
+var movieTranslations = models.MovieTranslations{
+	{
+		MovieId:  1,
+		Language: "es-419",
+		Title:    "Tren bala",
+		Created:  MockDate,
+		Updated:  MockDate,
+	},
+	{
+		MovieId:  1,
+		Language: "ja",
+		Title:    "ブレット・トレイン",
+		Created:  MockDate,
+		Updated:  MockDate,
+	},
+	{
+		MovieId:  2,
+		Language: "fr",
+		Title:    "Hamilton : la comédie musicale",
+		Synopsis: "L'histoire d'Alexander Hamilton, racontée en hip-hop.",
+		Created:  MockDate,
+		Updated:  MockDate,
+	},
+}
+
+func (m MovieTranslationController) List(movieId int) (models.MovieTranslations, error) {
+	translations := models.MovieTranslations{}
+	for _, translation := range m.Data {
+		if translation.MovieId == movieId {
+			translations = append(translations, translation)
+		}
+	}
+	return translations, nil
+}
+
+func (m MovieTranslationController) ListForMovies(movieIds []int) (map[int]models.MovieTranslations, error) {
+	translations := map[int]models.MovieTranslations{}
+	for _, id := range movieIds {
+		movieTranslations, _ := m.List(id)
+		if len(movieTranslations) > 0 {
+			translations[id] = movieTranslations
+		}
+	}
+	return translations, nil
+}
+
+// Upsert reports whether the translation would be inserted,
+// without changing anything as mock data is not persistent.
+func (m MovieTranslationController) Upsert(translation *models.MovieTranslation) (bool, error) {
+	found := false
+	for _, movie := range movies {
+		if movie.Id == translation.MovieId && movie.Deleted == nil {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false, repository.ErrRecordNotFound
+	}
+
+	translation.Created = MockDate
+	translation.Updated = MockDate
+	for _, existing := range m.Data {
+		if existing.MovieId == translation.MovieId && existing.Language == translation.Language {
+			translation.Created = existing.Created
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m MovieTranslationController) Delete(movieId int, language string) error {
+	for _, translation := range m.Data {
+		if translation.MovieId == movieId && translation.Language == language {
+			return nil
+		}
+	}
+	return repository.ErrRecordNotFound
+}
@@ -0,0 +1,45 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+)
+
+// NotificationPreferenceController keeps the saved preferences by user id,
+// with users who haven't saved any having the default preferences.
+type NotificationPreferenceController struct {
+	Data map[int]models.NotificationPreferences
+}
+
+// NewNotificationPreferenceController creates a NotificationPreferenceController pointer with the data being
+// a copy of the notificationPreferences map to avoid persistent modification across tests.
+func NewNotificationPreferenceController() *NotificationPreferenceController {
+	newPreferences := map[int]models.NotificationPreferences{}
+	for userId, preferences := range notificationPreferences {
+		newPreferences[userId] = preferences
+	}
+	return &NotificationPreferenceController{Data: newPreferences}
+}
+
+// notificationPreferences holds the saved preferences of the mock users,
+// where the third user has opted out of every category.
+var notificationPreferences = map[int]models.NotificationPreferences{
+	3: {},
+}
+
+func (n *NotificationPreferenceController) Get(userId int) (models.NotificationPreferences, error) {
+	if preferences, exists := n.Data[userId]; exists {
+		return preferences, nil
+	}
+	return models.DefaultNotificationPreferences, nil
+}
+
+func (n *NotificationPreferenceController) Update(userId int, preferences models.NotificationPreferences) error {
+	for _, user := range users {
+		if user.Id == userId {
+			n.Data[userId] = preferences
+			return nil
+		}
+	}
+	return repository.ErrRecordNotFound
+}
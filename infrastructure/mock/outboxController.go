@@ -0,0 +1,99 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"time"
+)
+
+// OutboxController keeps the enqueued emails in its data,
+// so tests can check the emails triggered by their requests.
+type OutboxController struct {
+	Data models.OutboxEmails
+}
+
+func NewOutboxController() *OutboxController {
+	return &OutboxController{Data: models.OutboxEmails{}}
+}
+
+func (o *OutboxController) Enqueue(email *models.OutboxEmail) error {
+	email.Id = len(o.Data) + 1
+	email.Status = models.OutboxStatusPending
+	email.Available = MockDate
+	email.Created = MockDate
+	o.Data = append(o.Data, *email)
+	return nil
+}
+
+// Last returns the most recently enqueued email, and false if none has been enqueued.
+func (o *OutboxController) Last() (models.OutboxEmail, bool) {
+	if len(o.Data) == 0 {
+		return models.OutboxEmail{}, false
+	}
+	return o.Data[len(o.Data)-1], true
+}
+
+func (o *OutboxController) Claim(limit int, lease time.Duration) (models.OutboxEmails, error) {
+	claimed := models.OutboxEmails{}
+	for i := range o.Data {
+		if len(claimed) == limit {
+			break
+		}
+		if o.Data[i].Status == models.OutboxStatusPending {
+			o.Data[i].Attempts++
+			claimed = append(claimed, o.Data[i])
+		}
+	}
+	return claimed, nil
+}
+
+func (o *OutboxController) MarkSent(id int) error {
+	return o.update(id, func(email *models.OutboxEmail) {
+		email.Status = models.OutboxStatusSent
+	})
+}
+
+func (o *OutboxController) Retry(id int, reason string, availableAt time.Time) error {
+	return o.update(id, func(email *models.OutboxEmail) {
+		email.LastError = reason
+		email.Available = availableAt
+	})
+}
+
+func (o *OutboxController) DeadLetter(id int, reason string) error {
+	return o.update(id, func(email *models.OutboxEmail) {
+		email.Status = models.OutboxStatusDead
+		email.LastError = reason
+	})
+}
+
+func (o *OutboxController) MarkSuppressed(id int, reason string) error {
+	return o.update(id, func(email *models.OutboxEmail) {
+		email.Status = models.OutboxStatusSuppressed
+		email.LastError = reason
+	})
+}
+
+// update applies the change to the pending email with the given id.
+func (o *OutboxController) update(id int, change func(email *models.OutboxEmail)) error {
+	for i := range o.Data {
+		if o.Data[i].Id == id && o.Data[i].Status == models.OutboxStatusPending {
+			change(&o.Data[i])
+			return nil
+		}
+	}
+	return repository.ErrRecordNotFound
+}
+
+func (o *OutboxController) Depth() (models.OutboxDepth, error) {
+	depth := models.OutboxDepth{}
+	for _, email := range o.Data {
+		switch email.Status {
+		case models.OutboxStatusPending:
+			depth.Pending++
+		case models.OutboxStatusDead:
+			depth.Dead++
+		}
+	}
+	return depth, nil
+}
@@ -20,6 +20,11 @@ var permissions = []struct {
 }{
 	{1, models.PermissionMoviesRead},
 	{2, models.PermissionMoviesWrite},
+	{3, models.PermissionMoviesExport},
+	{4, models.PermissionGenresWrite},
+	{5, models.PermissionMoviesMerge},
+	{6, models.PermissionEmailsManage},
+	{7, models.PermissionUsersRead},
 }
 
 type userPermission struct {
@@ -30,6 +35,11 @@ type userPermission struct {
 var usersPermissions = []userPermission{
 	{1, 1},
 	{1, 2},
+	{1, 3},
+	{1, 4},
+	{1, 5},
+	{1, 6},
+	{1, 7},
 	{2, 1},
 	{3, 1},
 }
@@ -0,0 +1,80 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"sort"
+	"strings"
+)
+
+type SearchController struct{}
+
+func NewSearchController() *SearchController {
+	return &SearchController{}
+}
+
+func (s SearchController) Search(query request.SearchQuery, filters request.Filters) (models.SearchResults, response.Metadata, error) {
+	results := models.SearchResults{}
+
+	if query.Type == "" || query.Type == models.SearchTypeMovie {
+		for _, movie := range movies {
+			if movie.Deleted == nil && matchesMovieTitle(movie, query.Title) && caseInsensitiveSubslice(query.Genres, movie.Genres) {
+				results = append(results, models.SearchResult{
+					Type:   models.SearchTypeMovie,
+					Id:     movie.Id,
+					Title:  movie.Title,
+					Year:   movie.Year,
+					Genres: movie.Genres,
+				})
+			}
+		}
+	}
+
+	if query.Type == "" || query.Type == models.SearchTypeSeries {
+		for _, s := range series {
+			if strings.Contains(s.Title, query.Title) && caseInsensitiveSubslice(query.Genres, s.Genres) {
+				results = append(results, models.SearchResult{
+					Type:   models.SearchTypeSeries,
+					Id:     s.Id,
+					Title:  s.Title,
+					Year:   s.StartYear,
+					Genres: s.Genres,
+				})
+			}
+		}
+	}
+
+	// sort by the filter, with ties ordered by type and id as in the database
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch filters.SortColumn(request.SearchFilterSortTitle) {
+		case request.SearchFilterSortYear:
+			if a.Year != b.Year {
+				return (a.Year < b.Year) != (filters.SortDirection() == "DESC")
+			}
+		default:
+			if a.Title != b.Title {
+				return (a.Title < b.Title) != (filters.SortDirection() == "DESC")
+			}
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Id < b.Id
+	})
+
+	// determine ending index based on page limit
+	stop := filters.Offset() + filters.Limit
+	if stop > len(results) {
+		stop = len(results)
+	}
+
+	start := filters.Offset()
+	if start > stop {
+		start = stop
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, len(results))
+	return results[start:stop], metadata, nil
+}
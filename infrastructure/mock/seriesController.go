@@ -0,0 +1,276 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/cmd/api/models/request"
+	"github.com/rhodeon/moviescreen/cmd/api/models/response"
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+	"github.com/rhodeon/moviescreen/internal/types"
+	"sort"
+	"strings"
+)
+
+type SeriesController struct {
+	Data models.SeriesList
+}
+
+// NewSeriesController creates a SeriesController pointer with the data being
+// a copy of the series slice to avoid persistent modification across tests.
+func NewSeriesController() *SeriesController {
+	newSeries := make(models.SeriesList, len(series))
+	copy(newSeries, series)
+	return &SeriesController{Data: newSeries}
+}
+
+var series = models.SeriesList{
+	{
+		Id:        1,
+		Title:     "Severance",
+		StartYear: 2022,
+		Genres:    []string{"Drama", "Thriller"},
+		Synopsis:  "Office workers have their memories surgically divided between their work and personal lives.",
+		Seasons: []models.Season{
+			{
+				Id:       1,
+				SeriesId: 1,
+				Number:   1,
+				AirDate:  mockAirDate("2022-02-18"),
+				Episodes: []models.Episode{
+					{Id: 1, SeasonNumber: 1, Number: 1, Title: "Good News About Hell", Runtime: 57, AirDate: mockAirDate("2022-02-18")},
+					{Id: 2, SeasonNumber: 1, Number: 2, Title: "Half Loop", Runtime: 53, AirDate: mockAirDate("2022-02-18")},
+					{Id: 3, SeasonNumber: 1, Number: 3, Title: "In Perpetuity", Runtime: 52, AirDate: mockAirDate("2022-02-25")},
+				},
+			},
+			{
+				Id:       2,
+				SeriesId: 1,
+				Number:   2,
+				AirDate:  mockAirDate("2025-01-17"),
+				Episodes: []models.Episode{
+					{Id: 4, SeasonNumber: 2, Number: 1, Title: "Hello, Ms. Cobel", Runtime: 50, AirDate: mockAirDate("2025-01-17")},
+				},
+			},
+		},
+		Version: 1,
+		Created: MockDate,
+		Updated: MockDate,
+	},
+	{
+		Id:        2,
+		Title:     "Arcane",
+		StartYear: 2021,
+		EndYear:   2024,
+		Genres:    []string{"Action", "Adventure", "Animation"},
+		Synopsis:  "Two sisters fight on opposite sides of a war between the cities of Piltover and Zaun.",
+		Seasons: []models.Season{
+			{
+				Id:       3,
+				SeriesId: 2,
+				Number:   1,
+				AirDate:  mockAirDate("2021-11-06"),
+				Episodes: []models.Episode{
+					{Id: 5, SeasonNumber: 1, Number: 1, Title: "Welcome to the Playground", Runtime: 43, AirDate: mockAirDate("2021-11-06")},
+				},
+			},
+		},
+		Version: 1,
+		Created: MockDate,
+		Updated: MockDate,
+	},
+}
+
+// mockAirDate parses an air date of the mock data.
+func mockAirDate(value string) *types.Date {
+	date, _ := types.ParseDate(value)
+	return &date
+}
+
+// withCounts returns the series with its numbers of seasons and episodes set.
+func withCounts(s models.Series) models.Series {
+	s.SeasonCount = len(s.Seasons)
+	s.EpisodeCount = 0
+	for _, season := range s.Seasons {
+		s.EpisodeCount += len(season.Episodes)
+	}
+	return s
+}
+
+func (s SeriesController) Create(series *models.Series) error {
+	series.Id = len(s.Data) + 1
+	series.Version = 1
+	series.Created = MockDate
+	series.Updated = MockDate
+	return nil
+}
+
+func (s SeriesController) Get(id int) (models.Series, error) {
+	for _, series := range s.Data {
+		if series.Id == id {
+			return withCounts(series), nil
+		}
+	}
+	return models.Series{}, repository.ErrRecordNotFound
+}
+
+// List leaves out the seasons of the series, as the database does.
+func (s SeriesController) List(query request.SeriesQuery, filters request.Filters) (models.SeriesList, response.Metadata, error) {
+	seriesList := models.SeriesList{}
+	for _, series := range s.Data {
+		if !strings.Contains(series.Title, query.Title) || !caseInsensitiveSubslice(query.Genres, series.Genres) {
+			continue
+		}
+		series = withCounts(series)
+		series.Seasons = nil
+		seriesList = append(seriesList, series)
+	}
+
+	sort.SliceStable(seriesList, func(i, j int) bool {
+		var less bool
+		switch filters.SortColumn(request.SeriesFilterSortId) {
+		case request.SeriesFilterSortTitle:
+			less = seriesList[i].Title < seriesList[j].Title
+		case request.SeriesFilterSortStartYear:
+			less = seriesList[i].StartYear < seriesList[j].StartYear
+		default:
+			less = seriesList[i].Id < seriesList[j].Id
+		}
+		if filters.SortDirection() == "DESC" {
+			return !less
+		}
+		return less
+	})
+
+	// determine ending index based on page limit
+	stop := filters.Offset() + filters.Limit
+	if stop > len(seriesList) {
+		stop = len(seriesList)
+	}
+
+	start := filters.Offset()
+	if start > stop {
+		start = stop
+	}
+
+	metadata := response.CalculateMetadata(filters.Page, filters.Limit, len(seriesList))
+	return seriesList[start:stop], metadata, nil
+}
+
+// Update increments the version of the series and sets its modification time to MockDate,
+// without changing anything as mock data is not persistent.
+func (s SeriesController) Update(series *models.Series) error {
+	existing, err := s.Get(series.Id)
+	if err != nil || existing.Version != series.Version {
+		return repository.ErrEditConflict
+	}
+	series.Version++
+	series.Updated = MockDate
+	return nil
+}
+
+func (s SeriesController) Delete(id int) error {
+	_, err := s.Get(id)
+	return err
+}
+
+// UpsertSeason checks the series and the order of the air dates,
+// without changing anything as mock data is not persistent.
+func (s SeriesController) UpsertSeason(season *models.Season) (bool, error) {
+	series, err := s.Get(season.SeriesId)
+	if err != nil {
+		return false, err
+	}
+
+	inserted := true
+	airDates := map[int]*types.Date{}
+	for _, existing := range series.Seasons {
+		if existing.Number == season.Number {
+			inserted = false
+			season.Id = existing.Id
+		} else {
+			airDates[existing.Number] = existing.AirDate
+		}
+	}
+	if !airsInOrder(airDates, season.Number, season.AirDate) {
+		return false, repository.ErrAirDateOrder
+	}
+
+	season.Created = MockDate
+	season.Updated = MockDate
+	return inserted, nil
+}
+
+func (s SeriesController) DeleteSeason(seriesId int, number int) error {
+	_, err := s.findSeason(seriesId, number)
+	return err
+}
+
+// UpsertEpisode checks the season and the order of the air dates,
+// without changing anything as mock data is not persistent.
+func (s SeriesController) UpsertEpisode(seriesId int, episode *models.Episode) (bool, error) {
+	season, err := s.findSeason(seriesId, episode.SeasonNumber)
+	if err != nil {
+		return false, err
+	}
+
+	inserted := true
+	airDates := map[int]*types.Date{}
+	for _, existing := range season.Episodes {
+		if existing.Number == episode.Number {
+			inserted = false
+			episode.Id = existing.Id
+		} else {
+			airDates[existing.Number] = existing.AirDate
+		}
+	}
+	if !airsInOrder(airDates, episode.Number, episode.AirDate) {
+		return false, repository.ErrAirDateOrder
+	}
+
+	episode.Created = MockDate
+	episode.Updated = MockDate
+	return inserted, nil
+}
+
+func (s SeriesController) DeleteEpisode(seriesId int, seasonNumber int, number int) error {
+	season, err := s.findSeason(seriesId, seasonNumber)
+	if err != nil {
+		return err
+	}
+	for _, episode := range season.Episodes {
+		if episode.Number == number {
+			return nil
+		}
+	}
+	return repository.ErrRecordNotFound
+}
+
+// findSeason returns the season of the series with the given number.
+func (s SeriesController) findSeason(seriesId int, number int) (models.Season, error) {
+	series, err := s.Get(seriesId)
+	if err != nil {
+		return models.Season{}, err
+	}
+	for _, season := range series.Seasons {
+		if season.Number == number {
+			return season, nil
+		}
+	}
+	return models.Season{}, repository.ErrRecordNotFound
+}
+
+// airsInOrder returns true if the air date doesn't come before the air dates of lower numbers
+// or after those of higher numbers, ignoring unknown air dates.
+func airsInOrder(airDates map[int]*types.Date, number int, airDate *types.Date) bool {
+	if airDate == nil {
+		return true
+	}
+	for otherNumber, otherDate := range airDates {
+		if otherDate == nil {
+			continue
+		}
+		if otherNumber < number && airDate.Before(otherDate.Time) || otherNumber > number && airDate.After(otherDate.Time) {
+			return false
+		}
+	}
+	return true
+}
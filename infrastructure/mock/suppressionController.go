@@ -0,0 +1,59 @@
+package mock
+
+import (
+	"github.com/rhodeon/moviescreen/domain/models"
+	"github.com/rhodeon/moviescreen/domain/repository"
+)
+
+// SuppressionController keeps the suppressions by normalized email address.
+type SuppressionController struct {
+	Data map[string]models.EmailSuppression
+}
+
+// NewSuppressionController creates a SuppressionController pointer with the data being
+// a copy of the suppressions map to avoid persistent modification across tests.
+func NewSuppressionController() *SuppressionController {
+	newSuppressions := map[string]models.EmailSuppression{}
+	for email, suppression := range suppressions {
+		newSuppressions[email] = suppression
+	}
+	return &SuppressionController{Data: newSuppressions}
+}
+
+// suppressions holds the suppressed addresses of the mock users,
+// where emails to the third user have bounced.
+var suppressions = map[string]models.EmailSuppression{
+	"johndoe@mail.com": {
+		Email:   "johndoe@mail.com",
+		Reason:  models.SuppressionReasonBounce,
+		Detail:  "550 5.1.1 mailbox unavailable",
+		Created: MockDate,
+	},
+}
+
+func (s *SuppressionController) Suppress(suppression *models.EmailSuppression) error {
+	suppression.Email = models.NormalizeEmail(suppression.Email)
+	suppression.Created = MockDate
+	if existing, exists := s.Data[suppression.Email]; exists {
+		suppression.Created = existing.Created
+	}
+	s.Data[suppression.Email] = *suppression
+	return nil
+}
+
+func (s *SuppressionController) Get(email string) (models.EmailSuppression, error) {
+	if suppression, exists := s.Data[models.NormalizeEmail(email)]; exists {
+		return suppression, nil
+	}
+	return models.EmailSuppression{}, repository.ErrRecordNotFound
+}
+
+func (s *SuppressionController) GetForEmails(emails []string) (map[string]models.EmailSuppression, error) {
+	found := map[string]models.EmailSuppression{}
+	for _, email := range emails {
+		if suppression, exists := s.Data[models.NormalizeEmail(email)]; exists {
+			found[suppression.Email] = suppression
+		}
+	}
+	return found, nil
+}
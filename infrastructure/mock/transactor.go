@@ -0,0 +1,22 @@
+package mock
+
+import (
+	"context"
+	"github.com/rhodeon/moviescreen/domain/repository"
+)
+
+// Transactor runs units of work directly on the mock repositories it's given,
+// as their changes aren't persisted and have nothing to discard.
+type Transactor struct {
+	Repositories *repository.Repositories
+}
+
+// NewTransactor creates a Transactor pointer running units of work on the repositories,
+// which can be set after creation when the repositories hold the transactor themselves.
+func NewTransactor(repositories *repository.Repositories) *Transactor {
+	return &Transactor{Repositories: repositories}
+}
+
+func (t *Transactor) WithinTx(_ context.Context, fn func(repositories repository.Repositories) error) error {
+	return fn(*t.Repositories)
+}
@@ -33,6 +33,7 @@ var users = []models.User{
 			Hash:      []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 		},
 		Activated: true,
+		Language:  "en",
 		Version:   1,
 		Created:   MockDate,
 	},
@@ -45,6 +46,7 @@ var users = []models.User{
 			Hash:      []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 		},
 		Activated: false,
+		Language:  "en",
 		Version:   0,
 		Created:   MockDate,
 	},
@@ -57,6 +59,7 @@ var users = []models.User{
 			Hash:      []byte("$2a$10$T.olpluq6ZZAisvfJVuLuOIXnqh/bN.9RCDiEu/tnnCgBqjesMkse.sP49rm"),
 		},
 		Activated: false,
+		Language:  "en",
 		Version:   0,
 		Created:   MockDate,
 	},
@@ -78,6 +81,16 @@ func (u *UserController) Register(user *models.User) error {
 	return nil
 }
 
+func (u *UserController) Get(id int) (models.User, error) {
+	for _, user := range u.Data {
+		if user.Id == id {
+			return user, nil
+		}
+	}
+
+	return models.User{}, repository.ErrRecordNotFound
+}
+
 func (u *UserController) GetByEmail(email string) (models.User, error) {
 	for _, user := range u.Data {
 		if user.Email == email {
@@ -0,0 +1,91 @@
+// Package storage provides implementations of repository.BlobStorage.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage stores objects as files under a directory of the local filesystem,
+// with each key being the path of its file relative to the directory.
+// Content types aren't kept, as they're derived from the file extensions when the files are served.
+type LocalStorage struct {
+	Dir string
+
+	// BaseUrl is the URL the directory is served from.
+	BaseUrl string
+}
+
+// Put writes the content to a temporary file which is then renamed to the path of the key,
+// so readers never see a partially written object.
+func (s LocalStorage) Put(key string, _ string, content io.Reader) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	// removing the temporary file fails harmlessly once it has been renamed
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// Delete removes the files of the keys, along with the directories left empty by their removal.
+func (s LocalStorage) Delete(keys ...string) error {
+	for _, key := range keys {
+		filename, err := s.path(key)
+		if err != nil {
+			return err
+		}
+
+		if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		// remove the parent directories up to the storage directory until one isn't empty
+		root := filepath.Clean(s.Dir)
+		for dir := filepath.Dir(filename); dir != root; dir = filepath.Dir(dir) {
+			if os.Remove(dir) != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (s LocalStorage) Url(key string) string {
+	return strings.TrimSuffix(s.BaseUrl, "/") + "/" + key
+}
+
+// path returns the path of the file of the key.
+// An error is returned for keys which would escape the storage directory.
+func (s LocalStorage) path(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(key)), nil
+}
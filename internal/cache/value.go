@@ -0,0 +1,48 @@
+// Package cache provides in-memory caching of values which are expensive to compute.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Value caches the result of a load function until it is older than its time to live.
+// It is safe for concurrent use.
+type Value[T any] struct {
+	ttl  time.Duration
+	load func() (T, error)
+
+	mu      sync.Mutex
+	value   T
+	expires time.Time
+}
+
+// NewValue returns a Value which is loaded with the function on first use and after expiring.
+func NewValue[T any](ttl time.Duration, load func() (T, error)) *Value[T] {
+	return &Value[T]{
+		ttl:  ttl,
+		load: load,
+	}
+}
+
+// Get returns the cached value, loading it first if it has expired.
+// Concurrent callers wait for a single load instead of each loading the value.
+// Errors aren't cached, so the next call loads the value again.
+func (v *Value[T]) Get() (T, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if time.Now().Before(v.expires) {
+		return v.value, nil
+	}
+
+	value, err := v.load()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	v.value = value
+	v.expires = time.Now().Add(v.ttl)
+	return value, nil
+}
@@ -0,0 +1,151 @@
+// Package imaging decodes uploaded images and scales them down into thumbnails,
+// using only the image packages of the standard library.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// Content types of the supported image formats.
+const (
+	ContentTypeJpeg = "image/jpeg"
+	ContentTypePng  = "image/png"
+)
+
+// ContentTypes holds the content types of the supported image formats.
+var ContentTypes = []string{ContentTypeJpeg, ContentTypePng}
+
+// Extensions maps the content types of the supported image formats to their file extensions.
+var Extensions = map[string]string{
+	ContentTypeJpeg: ".jpg",
+	ContentTypePng:  ".png",
+}
+
+// thumbnailQuality is the JPEG quality of thumbnails.
+const thumbnailQuality = 85
+
+var (
+	ErrUnsupportedFormat = errors.New("imaging: unsupported image format")
+	ErrTooLarge          = errors.New("imaging: image has too many pixels")
+)
+
+// DetectContentType returns the content type of the image data from its leading bytes,
+// regardless of any type declared by its uploader.
+// ErrUnsupportedFormat is returned if the data isn't in a supported format.
+func DetectContentType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if _, supported := Extensions[contentType]; !supported {
+		return "", ErrUnsupportedFormat
+	}
+	return contentType, nil
+}
+
+// Decode decodes the image data in the format of the content type.
+// The dimensions are checked before the pixels are decoded, and ErrTooLarge is returned
+// if the image has more than maxPixels pixels, so oversized images are rejected without being held in memory.
+func Decode(data []byte, contentType string, maxPixels int) (image.Image, error) {
+	var decodeConfig func(r *bytes.Reader) (image.Config, error)
+	var decode func(r *bytes.Reader) (image.Image, error)
+
+	switch contentType {
+	case ContentTypeJpeg:
+		decodeConfig = func(r *bytes.Reader) (image.Config, error) { return jpeg.DecodeConfig(r) }
+		decode = func(r *bytes.Reader) (image.Image, error) { return jpeg.Decode(r) }
+	case ContentTypePng:
+		decodeConfig = func(r *bytes.Reader) (image.Config, error) { return png.DecodeConfig(r) }
+		decode = func(r *bytes.Reader) (image.Image, error) { return png.Decode(r) }
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	config, err := decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width < 1 || config.Height < 1 || config.Width > maxPixels/config.Height {
+		return nil, ErrTooLarge
+	}
+
+	return decode(bytes.NewReader(data))
+}
+
+// Thumbnail scales the image down to the given width, keeping its aspect ratio, and encodes it as a JPEG.
+// Each pixel of the thumbnail is the average of the pixels of the image it covers,
+// and transparent areas are flattened onto a white background.
+// It returns the encoded thumbnail with its dimensions.
+func Thumbnail(img image.Image, width int) ([]byte, int, int, error) {
+	scaled := resize(flatten(img), width)
+
+	buf := &bytes.Buffer{}
+	err := jpeg.Encode(buf, scaled, &jpeg.Options{Quality: thumbnailQuality})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return buf.Bytes(), scaled.Rect.Dx(), scaled.Rect.Dy(), nil
+}
+
+// flatten draws the image onto an opaque white RGBA image with its origin at (0, 0).
+func flatten(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Rect, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Rect, img, bounds.Min, draw.Over)
+	return flat
+}
+
+// resize scales the opaque image to the given width with a box filter.
+// The height is rounded from the aspect ratio and is at least 1 pixel.
+func resize(src *image.RGBA, width int) *image.RGBA {
+	srcWidth, srcHeight := src.Rect.Dx(), src.Rect.Dy()
+	height := (srcHeight*width + srcWidth/2) / srcWidth
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		// the rows of the source covered by the row of the destination
+		top, bottom := span(y, height, srcHeight)
+
+		for x := 0; x < width; x++ {
+			left, right := span(x, width, srcWidth)
+
+			var r, g, b, n int
+			for sy := top; sy < bottom; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := left; sx < right; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					b += int(row[sx*4+2])
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8((r + n/2) / n)
+			dst.Pix[i+1] = uint8((g + n/2) / n)
+			dst.Pix[i+2] = uint8((b + n/2) / n)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+	return dst
+}
+
+// span returns the range of source pixels covered by a destination pixel along one axis,
+// where the destination has size pixels and the source has srcSize pixels.
+// The range holds at least one pixel.
+func span(i int, size int, srcSize int) (int, int) {
+	start := i * srcSize / size
+	end := (i + 1) * srcSize / size
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
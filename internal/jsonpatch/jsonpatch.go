@@ -0,0 +1,354 @@
+// Package jsonpatch applies RFC 7396 JSON merge patches and RFC 6902 JSON patches to JSON documents.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Supported JSON patch operations.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpTest    = "test"
+)
+
+var (
+	ErrInvalidPath          = errors.New("invalid path")
+	ErrPathNotFound         = errors.New("path not found")
+	ErrMissingValue         = errors.New("missing value")
+	ErrTestFailed           = errors.New("test failed")
+	ErrUnsupportedOperation = errors.New("unsupported operation")
+)
+
+// Operation is a single operation of a JSON patch.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+	From  string          `json:"from,omitempty"`
+}
+
+// OperationError is returned when an operation of a JSON patch cannot be applied.
+type OperationError struct {
+	Index int
+	Op    Operation
+	Err   error
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("operation %d (%s %s): %v", e.Index, e.Op.Op, e.Op.Path, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+// MergePatch applies the JSON merge patch to the document and returns the patched document.
+// Members of the patch with null values are removed from the document, and objects are merged recursively.
+func MergePatch(document []byte, patch []byte) ([]byte, error) {
+	var doc, patchDoc any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergeValue(doc, patchDoc))
+}
+
+// mergeValue recursively merges the patch into the target value.
+func mergeValue(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		// non-object patches replace the target entirely
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergeValue(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
+
+// Apply applies the operations of the JSON patch to the document in order
+// and returns the patched document.
+// An OperationError is returned for the first operation which fails, leaving the document unchanged.
+func Apply(document []byte, patch []byte) ([]byte, error) {
+	var doc any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patch))
+	decoder.DisallowUnknownFields()
+	var operations []Operation
+	if err := decoder.Decode(&operations); err != nil {
+		return nil, err
+	}
+
+	for i, operation := range operations {
+		var err error
+		doc, err = applyOperation(doc, operation)
+		if err != nil {
+			return nil, &OperationError{Index: i, Op: operation, Err: err}
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+// applyOperation applies a single operation to the document and returns the updated document.
+func applyOperation(doc any, operation Operation) (any, error) {
+	tokens, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case OpAdd:
+		value, err := decodeValue(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+		if len(tokens) == 0 {
+			return value, nil
+		}
+		return modify(doc, tokens, func(parent any, token string) (any, error) {
+			return add(parent, token, value)
+		})
+
+	case OpRemove:
+		if len(tokens) == 0 {
+			return nil, ErrInvalidPath
+		}
+		return modify(doc, tokens, remove)
+
+	case OpReplace:
+		value, err := decodeValue(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+		if len(tokens) == 0 {
+			return value, nil
+		}
+		return modify(doc, tokens, func(parent any, token string) (any, error) {
+			return replace(parent, token, value)
+		})
+
+	case OpTest:
+		value, err := decodeValue(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+		current, err := get(doc, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+
+	default:
+		return nil, ErrUnsupportedOperation
+	}
+}
+
+// decodeValue decodes the raw value of an operation, which must be present.
+func decodeValue(raw json.RawMessage) (any, error) {
+	if raw == nil {
+		return nil, ErrMissingValue
+	}
+
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into its unescaped reference tokens.
+// The empty pointer references the whole document and has no tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, ErrInvalidPath
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex converts the token to an index of an array, which must not exceed max.
+func arrayIndex(token string, max int) (int, error) {
+	// leading zeros and signs are not allowed
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.ContainsAny(token, "+-") {
+		return 0, ErrInvalidPath
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, ErrInvalidPath
+	}
+	if index > max {
+		return 0, ErrPathNotFound
+	}
+	return index, nil
+}
+
+// get returns the value referenced by the tokens.
+func get(doc any, tokens []string) (any, error) {
+	for _, token := range tokens {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, exists := node[token]
+			if !exists {
+				return nil, ErrPathNotFound
+			}
+			doc = value
+
+		case []any:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[index]
+
+		default:
+			return nil, ErrPathNotFound
+		}
+	}
+	return doc, nil
+}
+
+// modify walks the document to the parent of the value referenced by the tokens,
+// and replaces the parent with the result of fn.
+// The updated document is returned.
+func modify(doc any, tokens []string, fn func(parent any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return fn(doc, tokens[0])
+	}
+
+	switch node := doc.(type) {
+	case map[string]any:
+		child, exists := node[tokens[0]]
+		if !exists {
+			return nil, ErrPathNotFound
+		}
+
+		updated, err := modify(child, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[tokens[0]] = updated
+		return node, nil
+
+	case []any:
+		index, err := arrayIndex(tokens[0], len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := modify(node[index], tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = updated
+		return node, nil
+
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+// add sets the member of an object, or inserts the value into an array
+// with "-" appending to the end of the array.
+func add(parent any, token string, value any) (any, error) {
+	switch node := parent.(type) {
+	case map[string]any:
+		node[token] = value
+		return node, nil
+
+	case []any:
+		if token == "-" {
+			return append(node, value), nil
+		}
+
+		index, err := arrayIndex(token, len(node))
+		if err != nil {
+			return nil, err
+		}
+		node = append(node[:index], append([]any{value}, node[index:]...)...)
+		return node, nil
+
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+// remove deletes an existing member of an object or element of an array.
+func remove(parent any, token string) (any, error) {
+	switch node := parent.(type) {
+	case map[string]any:
+		if _, exists := node[token]; !exists {
+			return nil, ErrPathNotFound
+		}
+		delete(node, token)
+		return node, nil
+
+	case []any:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		return append(node[:index], node[index+1:]...), nil
+
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+// replace sets an existing member of an object or element of an array to the value.
+func replace(parent any, token string, value any) (any, error) {
+	switch node := parent.(type) {
+	case map[string]any:
+		if _, exists := node[token]; !exists {
+			return nil, ErrPathNotFound
+		}
+		node[token] = value
+		return node, nil
+
+	case []any:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = value
+		return node, nil
+
+	default:
+		return nil, ErrPathNotFound
+	}
+}
@@ -0,0 +1,272 @@
+package jsonpatch
+
+import (
+	"errors"
+	"github.com/rhodeon/moviescreen/internal/testhelpers"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	testCases := map[string]struct {
+		document string
+		patch    string
+		wantDoc  string
+	}{
+		"replaced member": {
+			document: `{"title":"Luca","year":2020}`,
+			patch:    `{"year":2021}`,
+			wantDoc:  `{"title":"Luca","year":2021}`,
+		},
+
+		"added member": {
+			document: `{"title":"Luca"}`,
+			patch:    `{"runtime":95}`,
+			wantDoc:  `{"runtime":95,"title":"Luca"}`,
+		},
+
+		"null removes member": {
+			document: `{"title":"Luca","tagline":"Summer"}`,
+			patch:    `{"tagline":null}`,
+			wantDoc:  `{"title":"Luca"}`,
+		},
+
+		"null of missing member": {
+			document: `{"title":"Luca"}`,
+			patch:    `{"tagline":null}`,
+			wantDoc:  `{"title":"Luca"}`,
+		},
+
+		"nested objects merged": {
+			document: `{"external_ids":{"imdb":"tt12801262","tmdb":"508943"}}`,
+			patch:    `{"external_ids":{"imdb":null,"tmdb":"1"}}`,
+			wantDoc:  `{"external_ids":{"tmdb":"1"}}`,
+		},
+
+		"arrays replaced": {
+			document: `{"genres":["Adventure","Family"]}`,
+			patch:    `{"genres":["Animation"]}`,
+			wantDoc:  `{"genres":["Animation"]}`,
+		},
+
+		"object merged into scalar": {
+			document: `{"external_ids":"none"}`,
+			patch:    `{"external_ids":{"imdb":"tt12801262"}}`,
+			wantDoc:  `{"external_ids":{"imdb":"tt12801262"}}`,
+		},
+
+		"non-object patch replaces document": {
+			document: `{"title":"Luca"}`,
+			patch:    `["Luca"]`,
+			wantDoc:  `["Luca"]`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			doc, err := MergePatch([]byte(tc.document), []byte(tc.patch))
+			testhelpers.AssertError(t, err, nil)
+			testhelpers.AssertEqual(t, string(doc), tc.wantDoc)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	testCases := map[string]struct {
+		document  string
+		patch     string
+		wantDoc   string
+		wantErr   error
+		wantIndex int
+	}{
+		"add member": {
+			document: `{"title":"Luca"}`,
+			patch:    `[{"op":"add","path":"/year","value":2021}]`,
+			wantDoc:  `{"title":"Luca","year":2021}`,
+		},
+
+		"add array element": {
+			document: `{"genres":["Adventure","Family"]}`,
+			patch:    `[{"op":"add","path":"/genres/1","value":"Animation"}]`,
+			wantDoc:  `{"genres":["Adventure","Animation","Family"]}`,
+		},
+
+		"add after last array element": {
+			document: `{"genres":["Adventure","Family"]}`,
+			patch:    `[{"op":"add","path":"/genres/2","value":"Animation"}]`,
+			wantDoc:  `{"genres":["Adventure","Family","Animation"]}`,
+		},
+
+		"add to end of array": {
+			document: `{"genres":["Adventure"]}`,
+			patch:    `[{"op":"add","path":"/genres/-","value":"Family"},{"op":"add","path":"/genres/-","value":"Animation"}]`,
+			wantDoc:  `{"genres":["Adventure","Family","Animation"]}`,
+		},
+
+		"add whole document": {
+			document: `{"title":"Luca"}`,
+			patch:    `[{"op":"add","path":"","value":{"title":"Soul"}}]`,
+			wantDoc:  `{"title":"Soul"}`,
+		},
+
+		"add out of range": {
+			document:  `{"genres":["Adventure","Family"]}`,
+			patch:     `[{"op":"add","path":"/genres/3","value":"Animation"}]`,
+			wantErr:   ErrPathNotFound,
+			wantIndex: 0,
+		},
+
+		"add under missing member": {
+			document:  `{"title":"Luca"}`,
+			patch:     `[{"op":"add","path":"/external_ids/imdb","value":"tt12801262"}]`,
+			wantErr:   ErrPathNotFound,
+			wantIndex: 0,
+		},
+
+		"add without value": {
+			document:  `{"title":"Luca"}`,
+			patch:     `[{"op":"add","path":"/year"}]`,
+			wantErr:   ErrMissingValue,
+			wantIndex: 0,
+		},
+
+		"remove member": {
+			document: `{"title":"Luca","tagline":"Summer"}`,
+			patch:    `[{"op":"remove","path":"/tagline"}]`,
+			wantDoc:  `{"title":"Luca"}`,
+		},
+
+		"remove array element": {
+			document: `{"genres":["Adventure","Animation","Family"]}`,
+			patch:    `[{"op":"remove","path":"/genres/1"}]`,
+			wantDoc:  `{"genres":["Adventure","Family"]}`,
+		},
+
+		"remove missing member": {
+			document:  `{"title":"Luca"}`,
+			patch:     `[{"op":"remove","path":"/tagline"}]`,
+			wantErr:   ErrPathNotFound,
+			wantIndex: 0,
+		},
+
+		"remove out of range": {
+			document:  `{"genres":["Adventure","Family"]}`,
+			patch:     `[{"op":"remove","path":"/genres/2"}]`,
+			wantErr:   ErrPathNotFound,
+			wantIndex: 0,
+		},
+
+		"remove end of array": {
+			document:  `{"genres":["Adventure","Family"]}`,
+			patch:     `[{"op":"remove","path":"/genres/-"}]`,
+			wantErr:   ErrInvalidPath,
+			wantIndex: 0,
+		},
+
+		"remove whole document": {
+			document:  `{"title":"Luca"}`,
+			patch:     `[{"op":"remove","path":""}]`,
+			wantErr:   ErrInvalidPath,
+			wantIndex: 0,
+		},
+
+		"replace member": {
+			document: `{"title":"Luca","year":2020}`,
+			patch:    `[{"op":"replace","path":"/year","value":2021}]`,
+			wantDoc:  `{"title":"Luca","year":2021}`,
+		},
+
+		"replace nested array element": {
+			document: `{"release_dates":[{"country":"US","date":"2021-06-18"}]}`,
+			patch:    `[{"op":"replace","path":"/release_dates/0/country","value":"IT"}]`,
+			wantDoc:  `{"release_dates":[{"country":"IT","date":"2021-06-18"}]}`,
+		},
+
+		"replace missing member": {
+			document:  `{"title":"Luca"}`,
+			patch:     `[{"op":"replace","path":"/year","value":2021}]`,
+			wantErr:   ErrPathNotFound,
+			wantIndex: 0,
+		},
+
+		"replace index with leading zero": {
+			document:  `{"genres":["Adventure","Family"]}`,
+			patch:     `[{"op":"replace","path":"/genres/01","value":"Animation"}]`,
+			wantErr:   ErrInvalidPath,
+			wantIndex: 0,
+		},
+
+		"replace under scalar": {
+			document:  `{"title":"Luca"}`,
+			patch:     `[{"op":"replace","path":"/title/0","value":"S"}]`,
+			wantErr:   ErrPathNotFound,
+			wantIndex: 0,
+		},
+
+		"passing test": {
+			document: `{"title":"Luca","version":1}`,
+			patch:    `[{"op":"test","path":"/version","value":1},{"op":"replace","path":"/title","value":"Soul"}]`,
+			wantDoc:  `{"title":"Soul","version":1}`,
+		},
+
+		"failing test": {
+			document:  `{"title":"Luca","version":2}`,
+			patch:     `[{"op":"replace","path":"/title","value":"Soul"},{"op":"test","path":"/version","value":1}]`,
+			wantErr:   ErrTestFailed,
+			wantIndex: 1,
+		},
+
+		"test of missing member": {
+			document:  `{"title":"Luca"}`,
+			patch:     `[{"op":"test","path":"/version","value":1}]`,
+			wantErr:   ErrPathNotFound,
+			wantIndex: 0,
+		},
+
+		"escaped pointer tokens": {
+			document: `{"a/b":1,"m~n":2}`,
+			patch:    `[{"op":"test","path":"/a~1b","value":1},{"op":"replace","path":"/m~0n","value":3}]`,
+			wantDoc:  `{"a/b":1,"m~n":3}`,
+		},
+
+		"tilde unescaped after slash": {
+			document: `{"~1":1}`,
+			patch:    `[{"op":"remove","path":"/~01"}]`,
+			wantDoc:  `{}`,
+		},
+
+		"pointer without leading slash": {
+			document:  `{"title":"Luca"}`,
+			patch:     `[{"op":"replace","path":"title","value":"Soul"}]`,
+			wantErr:   ErrInvalidPath,
+			wantIndex: 0,
+		},
+
+		"unsupported operation": {
+			document:  `{"title":"Luca","original_title":"Luca"}`,
+			patch:     `[{"op":"move","from":"/original_title","path":"/title"}]`,
+			wantErr:   ErrUnsupportedOperation,
+			wantIndex: 0,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			doc, err := Apply([]byte(tc.document), []byte(tc.patch))
+
+			if tc.wantErr == nil {
+				testhelpers.AssertError(t, err, nil)
+				testhelpers.AssertEqual(t, string(doc), tc.wantDoc)
+				return
+			}
+
+			// the failing operation is identified by its index, and no document is returned
+			var operationError *OperationError
+			if !errors.As(err, &operationError) {
+				t.Fatalf("\nGot Error:\t%+v\nWant Error:\t%+v", err, tc.wantErr)
+			}
+			testhelpers.AssertError(t, operationError.Err, tc.wantErr)
+			testhelpers.AssertEqual(t, operationError.Index, tc.wantIndex)
+			testhelpers.AssertStruct(t, doc, []byte(nil))
+		})
+	}
+}
@@ -3,74 +3,218 @@ package mailer
 import (
 	"bytes"
 	"embed"
-	"github.com/go-mail/mail/v2"
-	"html/template"
+	"errors"
+	"fmt"
+	"io"
 	"time"
 )
 
 //go:embed "templates"
 var templateFS embed.FS
 
-// The Mailer struct contains a dialer instance and the sender information for sending emails.
+// Email template files.
+const (
+	TemplateUserWelcome     = "user_welcome.gotmpl"
+	TemplateActivationToken = "activation_token.gotmpl"
+	TemplateResetPassword   = "reset_password.gotmpl"
+	TemplatePasswordChanged = "password_changed.gotmpl"
+)
+
+// PermanentError wraps failures which recur however often sending is retried,
+// like invalid templates and recipients rejected by the SMTP server.
+type PermanentError struct {
+	Err error
+
+	// Bounce is true if the failure is caused by the recipient address,
+	// which is invalid or was rejected by the SMTP server, so later emails to it would fail as well.
+	Bounce bool
+}
+
+func (e PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent returns true if the error of sending an email is a PermanentError.
+func IsPermanent(err error) bool {
+	return errors.As(err, &PermanentError{})
+}
+
+// IsBounce returns true if the error of sending an email is a PermanentError caused by the recipient address.
+func IsBounce(err error) bool {
+	permanentErr := PermanentError{}
+	return errors.As(err, &permanentErr) && permanentErr.Bounce
+}
+
+// The Mailer struct contains the templates parsed on creation,
+// the transport delivering emails and the sender information.
+// It's meant to be created once and shared, as it's safe for concurrent use.
 type Mailer struct {
-	dialer *mail.Dialer
-	sender string
+	// templates holds the parsed templates by language and file name.
+	templates    map[string]map[string]emailTemplate
+	branding     Branding
+	transport    Transport
+	sender       string
+	unsubscriber *Unsubscriber
+
+	// now returns the current time, which expiry times are shown relative to.
+	now func() time.Time
 }
 
-// New returns a Mailer pointer with the given SMTP settings.
-func New(host string, port int, username string, password string, sender string) *Mailer {
-	dialer := mail.NewDialer(host, port, username, password)
-	dialer.Timeout = 5 * time.Second
+// Email is an email to be rendered from a template and sent by the Mailer.
+type Email struct {
+	Recipient string
+	Template  string
+	// Language is the ISO 639-1 code of the language the template is rendered in,
+	// with the default language used if the template isn't translated to it.
+	Language string
+	Data     any
+
+	// UserId is the id of the user receiving a non-transactional email, who can unsubscribe from its category.
+	UserId int
+	// Category is the category of non-transactional emails, which is empty for transactional ones.
+	Category string
+}
 
-	return &Mailer{
-		dialer: dialer,
-		sender: sender,
+// New parses and checks the email templates, which are branded with the branding,
+// and returns a Mailer pointer delivering emails from the sender through the transport.
+// Non-transactional emails are given unsubscribe links signed by the unsubscriber.
+func New(transport Transport, sender string, branding Branding, unsubscriber *Unsubscriber) (*Mailer, error) {
+	m := &Mailer{
+		branding:     branding,
+		transport:    transport,
+		sender:       sender,
+		unsubscriber: unsubscriber,
+		now:          time.Now,
 	}
+
+	templates, err := m.parseTemplates()
+	if err != nil {
+		return nil, err
+	}
+	m.templates = templates
+	return m, nil
 }
 
-// Send takes the recipient email address, the name of the email template file, and any dynamic data for the template.
-func (m *Mailer) Send(recipient string, templateFile string, data any) error {
-	tmpl, err := template.New("email").ParseFS(templateFS, "templates/"+templateFile)
+// SetClock replaces the function returning the current time, which expiry times are shown relative to.
+// It allows emails to be rendered deterministically in tests, and mustn't be called while emails are being sent.
+func (m *Mailer) SetClock(now func() time.Time) {
+	m.now = now
+}
+
+// Send renders the email from its template and sends it,
+// with one-click List-Unsubscribe headers if it's a non-transactional email.
+// Sending is attempted once, with retries left to the caller.
+// Errors of the templates and permanent rejections by the transport are returned as a PermanentError.
+func (m *Mailer) Send(email Email) error {
+	msg, err := m.Render(email)
 	if err != nil {
 		return err
 	}
+	return m.transport.Deliver(msg)
+}
+
+// SendBatch sends the emails together if the transport supports batches, and one after another otherwise.
+// The returned errors correspond to the emails, with nil for sent ones, and are classified as in Send.
+func (m *Mailer) SendBatch(emails []Email) []error {
+	errs := make([]error, len(emails))
+
+	// only the successfully rendered emails are delivered
+	msgs := []Message{}
+	indexes := []int{}
+	for i, email := range emails {
+		msg, err := m.Render(email)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		msgs = append(msgs, msg)
+		indexes = append(indexes, i)
+	}
+
+	if batchTransport, ok := m.transport.(BatchTransport); ok && len(msgs) > 0 {
+		for i, err := range batchTransport.DeliverBatch(msgs) {
+			errs[indexes[i]] = err
+		}
+		return errs
+	}
+
+	for i, msg := range msgs {
+		errs[indexes[i]] = m.transport.Deliver(msg)
+	}
+	return errs
+}
+
+// Close releases the resources held by the transport, like open connections.
+func (m *Mailer) Close() error {
+	if closer, ok := m.transport.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// HasTemplate returns true if the template file exists, which means it exists in the default language.
+func (m *Mailer) HasTemplate(name string) bool {
+	_, exists := m.templates[DefaultLanguage][name]
+	return exists
+}
+
+// Now returns the current time of the clock which expiry times are shown relative to.
+func (m *Mailer) Now() time.Time {
+	return m.now()
+}
+
+// Render executes the template of the email in its language into a message without sending it,
+// returning template errors as a PermanentError.
+// The message of a non-transactional email holds the link unsubscribing its user from its category.
+func (m *Mailer) Render(email Email) (Message, error) {
+	unsubscribeUrl := ""
+	if email.Category != "" {
+		if email.UserId == 0 {
+			return Message{}, PermanentError{Err: fmt.Errorf("mailer: %q email without a user to unsubscribe", email.Category)}
+		}
+		unsubscribeUrl = m.unsubscriber.Url(email.UserId, email.Category)
+	}
+
+	language := email.Language
+	tmpl, exists := m.templates[language][email.Template]
+	if !exists {
+		language = DefaultLanguage
+		tmpl, exists = m.templates[language][email.Template]
+	}
+	if !exists {
+		return Message{}, PermanentError{Err: fmt.Errorf("mailer: unknown template %q", email.Template)}
+	}
 
 	subject := new(bytes.Buffer)
-	err = tmpl.ExecuteTemplate(subject, "subject", data)
+	err := tmpl.text.ExecuteTemplate(subject, "subject", email.Data)
 	if err != nil {
-		return err
+		return Message{}, PermanentError{Err: err}
 	}
 
 	plainBody := new(bytes.Buffer)
-	err = tmpl.ExecuteTemplate(plainBody, "plainBody", data)
+	err = tmpl.text.ExecuteTemplate(plainBody, "plainBody", email.Data)
 	if err != nil {
-		return err
+		return Message{}, PermanentError{Err: err}
 	}
 
 	htmlBody := new(bytes.Buffer)
-	err = tmpl.ExecuteTemplate(htmlBody, "htmlBody", data)
+	err = tmpl.html.ExecuteTemplate(htmlBody, "htmlBody", email.Data)
 	if err != nil {
-		return err
+		return Message{}, PermanentError{Err: err}
 	}
 
-	msg := mail.NewMessage()
-	msg.SetHeader("To", recipient)
-	msg.SetHeader("From", m.sender)
-	msg.SetHeader("Subject", subject.String())
-	msg.SetBody("text/plain", plainBody.String())
-	msg.AddAlternative("text/html", htmlBody.String())
-
-	// attempt sending mail 3 times
-	for i := 1; i <= 3; i++ {
-		err = m.dialer.DialAndSend(msg)
-		// return if no error occurs
-		if err == nil {
-			return nil
-		}
-
-		// make next attempt on failure with a delay of 5 seconds
-		time.Sleep(5 * time.Second)
-	}
+	return Message{
+		To:        email.Recipient,
+		From:      m.sender,
+		Subject:   subject.String(),
+		PlainBody: plainBody.String(),
+		HtmlBody:  htmlBody.String(),
+		Language:  language,
 
-	return err
+		UnsubscribeUrl: unsubscribeUrl,
+	}, nil
 }
@@ -0,0 +1,250 @@
+package mailer
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// smtpTimeout bounds dialing the SMTP server and each exchange with it.
+const smtpTimeout = 5 * time.Second
+
+// smtpConn is an open connection to the SMTP server which can send several messages.
+type smtpConn struct {
+	conn   net.Conn
+	client *smtp.Client
+}
+
+// close quits the session, falling back to closing the connection if the server doesn't respond.
+func (c *smtpConn) close() {
+	c.conn.SetDeadline(time.Now().Add(smtpTimeout))
+	if err := c.client.Quit(); err != nil {
+		c.client.Close()
+	}
+}
+
+// SmtpTransport delivers messages through an SMTP server over a bounded pool of persistent connections,
+// which are checked with a NOOP before being reused.
+type SmtpTransport struct {
+	host     string
+	port     int
+	username string
+	password string
+
+	// pool holds a slot for each connection which can be open at once.
+	// Slots of connections which haven't been opened, or have been closed, hold nil.
+	pool chan *smtpConn
+
+	closeOnce sync.Once
+}
+
+// NewSmtpTransport returns an SmtpTransport with the given SMTP settings,
+// keeping up to poolSize connections open at once.
+func NewSmtpTransport(host string, port int, username string, password string, poolSize int) *SmtpTransport {
+	pool := make(chan *smtpConn, poolSize)
+	for i := 0; i < poolSize; i++ {
+		pool <- nil
+	}
+
+	return &SmtpTransport{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		pool:     pool,
+	}
+}
+
+// Deliver sends the message over a pooled connection,
+// returning a PermanentError if the SMTP server rejects it permanently,
+// which is a bounce if the recipient is rejected.
+func (s *SmtpTransport) Deliver(msg Message) error {
+	return s.DeliverBatch([]Message{msg})[0]
+}
+
+// DeliverBatch sends the messages over a single pooled connection,
+// which is only replaced if it breaks before all the messages are sent.
+// Messages rejected by the SMTP server don't prevent the rest of the batch from being sent.
+func (s *SmtpTransport) DeliverBatch(msgs []Message) []error {
+	errs := make([]error, len(msgs))
+
+	c, err := s.acquire()
+	for i, msg := range msgs {
+		if c == nil {
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			c, err = s.acquire()
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+		}
+
+		// invalid addresses are rejected before anything is sent over the connection
+		errs[i] = s.send(c, msg)
+		if errs[i] == nil || IsPermanent(errs[i]) {
+			continue
+		}
+
+		// the connection can be reused after a rejection once the transaction is reset,
+		// but is discarded after any other failure
+		if isReply(errs[i]) && c.client.Reset() == nil {
+			if isPermanentRejection(errs[i]) {
+				errs[i] = PermanentError{Err: errs[i], Bounce: errors.As(errs[i], &rcptError{})}
+			}
+			continue
+		}
+		c.close()
+		s.release(nil)
+		c = nil
+	}
+
+	if c != nil {
+		s.release(c)
+	}
+	return errs
+}
+
+// send sends the message over the connection.
+func (s *SmtpTransport) send(c *smtpConn, msg Message) error {
+	from, err := netmail.ParseAddress(msg.From)
+	if err != nil {
+		return PermanentError{Err: err}
+	}
+	to, err := netmail.ParseAddress(msg.To)
+	if err != nil {
+		return PermanentError{Err: err, Bounce: true}
+	}
+
+	c.conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	if err = c.client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err = c.client.Rcpt(to.Address); err != nil {
+		return rcptError{err}
+	}
+
+	w, err := c.client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = msg.toMail().WriteTo(w); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// acquire takes a slot from the pool, blocking until one is free,
+// and returns its connection if it's still healthy or a new connection otherwise.
+// The slot is returned to the pool if no connection can be opened.
+func (s *SmtpTransport) acquire() (*smtpConn, error) {
+	c := <-s.pool
+	if c != nil {
+		c.conn.SetDeadline(time.Now().Add(smtpTimeout))
+		if c.client.Noop() == nil {
+			return c, nil
+		}
+		c.client.Close()
+	}
+
+	c, err := s.dial()
+	if err != nil {
+		s.release(nil)
+		return nil, err
+	}
+	return c, nil
+}
+
+// release returns the slot of the connection to the pool, with nil for a slot without a connection.
+func (s *SmtpTransport) release(c *smtpConn) {
+	s.pool <- c
+}
+
+// dial opens and authenticates a connection to the SMTP server,
+// using implicit TLS on port 465 and STARTTLS when the server supports it.
+func (s *SmtpTransport) dial() (*smtpConn, error) {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	tlsConfig := &tls.Config{ServerName: s.host}
+
+	conn, err := net.DialTimeout("tcp", addr, smtpTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if s.port == 465 {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if ok, _ := client.Extension("STARTTLS"); ok && s.port != 465 {
+		if err = client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	if ok, _ := client.Extension("AUTH"); ok && s.username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	return &smtpConn{conn: conn, client: client}, nil
+}
+
+// Close closes the connections of the pool, waiting for connections in use to be released.
+// The transport must not be used after it's closed.
+func (s *SmtpTransport) Close() error {
+	s.closeOnce.Do(func() {
+		for i := 0; i < cap(s.pool); i++ {
+			if c := <-s.pool; c != nil {
+				c.close()
+			}
+		}
+	})
+	return nil
+}
+
+// rcptError wraps replies of the SMTP server rejecting the recipient of a message,
+// which are told apart from rejections of the sender or content as they make the message bounce.
+type rcptError struct {
+	err error
+}
+
+func (e rcptError) Error() string {
+	return e.err.Error()
+}
+
+func (e rcptError) Unwrap() error {
+	return e.err
+}
+
+// isReply returns true if the error is a negative reply of the SMTP server,
+// after which the connection remains usable.
+func isReply(err error) bool {
+	replyErr := &textproto.Error{}
+	return errors.As(err, &replyErr)
+}
+
+// isPermanentRejection returns true if the error is a permanent negative reply (5xx) of the SMTP server
+// to sending a message, as opposed to temporary ones which may succeed later.
+func isPermanentRejection(err error) bool {
+	replyErr := &textproto.Error{}
+	return errors.As(err, &replyErr) && replyErr.Code >= 500
+}
@@ -0,0 +1,156 @@
+package mailer
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+	"math"
+	"path"
+	texttemplate "text/template"
+	"time"
+)
+
+// DefaultLanguage is the language every template is written in,
+// which emails fall back to if their template isn't translated to their language.
+const DefaultLanguage = "en"
+
+const (
+	// layoutFile is shared by the templates of all languages.
+	layoutFile = "templates/layout.gotmpl"
+
+	// partialsFile holds the templates shared by the templates of a language.
+	partialsFile = "partials.gotmpl"
+)
+
+// requiredTemplates are the templates each email template must define.
+var requiredTemplates = []string{"subject", "plainBody", "htmlBody"}
+
+// emailTemplate holds the parsings of the files of an email template.
+// The subject and plain text body are rendered as text, as html/template would escape them as HTML,
+// while the HTML body is rendered with the contextual escaping of html/template.
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *template.Template
+}
+
+// Branding holds the details of the brand shown in emails.
+type Branding struct {
+	Name    string
+	Color   string
+	LogoUrl string
+}
+
+// parseTemplates parses the email templates of each language, found in the directory named after its ISO 639-1 code,
+// together with the layout and the partials of the language.
+// An error is returned if a template doesn't define the required templates,
+// or exists in another language without existing in the default one.
+func (m *Mailer) parseTemplates() (map[string]map[string]emailTemplate, error) {
+	entries, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	templates := map[string]map[string]emailTemplate{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		language := entry.Name()
+		languageDir := path.Join("templates", language)
+		files, err := fs.Glob(templateFS, path.Join(languageDir, "*.gotmpl"))
+		if err != nil {
+			return nil, err
+		}
+
+		templates[language] = map[string]emailTemplate{}
+		for _, file := range files {
+			name := path.Base(file)
+			if name == partialsFile {
+				continue
+			}
+
+			patterns := []string{layoutFile, path.Join(languageDir, partialsFile), file}
+			funcs := m.templateFuncs(language)
+
+			textTmpl, err := texttemplate.New(name).Funcs(texttemplate.FuncMap(funcs)).ParseFS(templateFS, patterns...)
+			if err != nil {
+				return nil, err
+			}
+
+			htmlTmpl, err := template.New(name).Funcs(template.FuncMap(funcs)).ParseFS(templateFS, patterns...)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, required := range requiredTemplates {
+				if textTmpl.Lookup(required) == nil {
+					return nil, fmt.Errorf("mailer: template %s/%s doesn't define %q", language, name, required)
+				}
+			}
+			templates[language][name] = emailTemplate{text: textTmpl, html: htmlTmpl}
+		}
+	}
+
+	for language, languageTemplates := range templates {
+		for name := range languageTemplates {
+			if _, exists := templates[DefaultLanguage][name]; !exists {
+				return nil, fmt.Errorf("mailer: template %s/%s doesn't exist in the default language", language, name)
+			}
+		}
+	}
+
+	return templates, nil
+}
+
+// templateFuncs returns the functions available to the templates of the language.
+func (m *Mailer) templateFuncs(language string) map[string]any {
+	return map[string]any{
+		"brand":    func() Branding { return m.branding },
+		"language": func() string { return language },
+		"expiry": func(value any) (Expiry, error) {
+			return expiry(value, m.now())
+		},
+	}
+}
+
+// Expiry is the time left until an expiry time, rounded to the largest fitting unit.
+type Expiry struct {
+	Value int
+	// Unit is one of "day", "hour" and "minute".
+	Unit string
+	At   time.Time
+}
+
+// expiry returns the time left from now until the expiry time, which is either a time
+// or an RFC 3339 string as times are stored in the data of queued emails.
+func expiry(value any, now time.Time) (Expiry, error) {
+	var at time.Time
+	switch v := value.(type) {
+	case time.Time:
+		at = v
+	case string:
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return Expiry{}, err
+		}
+		at = parsed
+	default:
+		return Expiry{}, fmt.Errorf("expiry: unsupported value %v", value)
+	}
+
+	left := at.Sub(now)
+	e := Expiry{At: at.UTC()}
+	switch {
+	case left >= 24*time.Hour:
+		e.Value, e.Unit = int(math.Round(left.Hours()/24)), "day"
+	case left >= time.Hour:
+		e.Value, e.Unit = int(math.Round(left.Hours())), "hour"
+	default:
+		e.Value, e.Unit = int(math.Ceil(left.Minutes())), "minute"
+		if e.Value < 0 {
+			e.Value = 0
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,169 @@
+package mailer
+
+import (
+	"fmt"
+	"github.com/go-mail/mail/v2"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Message is an email rendered from a template, ready to be delivered.
+type Message struct {
+	To        string
+	From      string
+	Subject   string
+	PlainBody string
+	HtmlBody  string
+
+	// Language is the ISO 639-1 code of the language the message was rendered in.
+	Language string
+
+	// UnsubscribeUrl is the one-click unsubscribe link of non-transactional messages, which is empty for transactional ones.
+	UnsubscribeUrl string
+}
+
+// toMail converts the message to a go-mail message with the plain text and HTML bodies as alternatives.
+func (msg Message) toMail() *mail.Message {
+	m := mail.NewMessage()
+	m.SetHeader("To", msg.To)
+	m.SetHeader("From", msg.From)
+	m.SetHeader("Subject", msg.Subject)
+	m.SetDateHeader("Date", time.Now())
+	if msg.Language != "" {
+		m.SetHeader("Content-Language", msg.Language)
+	}
+	// mail clients unsubscribe with a POST request to the link, as described in RFC 8058
+	if msg.UnsubscribeUrl != "" {
+		m.SetHeader("List-Unsubscribe", "<"+msg.UnsubscribeUrl+">")
+		m.SetHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	m.SetBody("text/plain", msg.PlainBody)
+	m.AddAlternative("text/html", msg.HtmlBody)
+	return m
+}
+
+// Transport delivers rendered messages to their recipients, or wherever they are captured instead.
+type Transport interface {
+	Deliver(msg Message) error
+}
+
+// BatchTransport is implemented by transports which deliver several messages more efficiently together
+// than one at a time. The returned errors correspond to the messages, with nil for delivered ones.
+type BatchTransport interface {
+	Transport
+	DeliverBatch(msgs []Message) []error
+}
+
+// Transport names used to select a transport from the configuration.
+const (
+	TransportSmtp   = "smtp"
+	TransportFile   = "file"
+	TransportStdout = "stdout"
+	TransportMemory = "memory"
+)
+
+// FileTransport writes each message as a file in the "new" directory of a maildir,
+// where mail clients and tests can read them without a mail server.
+type FileTransport struct {
+	dir   string
+	count uint64
+}
+
+// NewFileTransport returns a FileTransport writing to the maildir at dir,
+// creating its directories if they don't exist.
+func NewFileTransport(dir string) (*FileTransport, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		err := os.MkdirAll(filepath.Join(dir, sub), 0o755)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &FileTransport{dir: dir}, nil
+}
+
+// Deliver writes the message to the "tmp" directory and moves it to "new" once it's complete,
+// so readers never see partially written messages.
+func (f *FileTransport) Deliver(msg Message) error {
+	name := fmt.Sprintf("%d.%d.moviescreen.eml", time.Now().UnixNano(), atomic.AddUint64(&f.count, 1))
+	tmpPath := filepath.Join(f.dir, "tmp", name)
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = msg.toMail().WriteTo(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(f.dir, "new", name))
+}
+
+// WriterTransport writes messages to a writer, one after another.
+type WriterTransport struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// NewStdoutTransport returns a WriterTransport which prints messages to the standard output.
+func NewStdoutTransport() *WriterTransport {
+	return &WriterTransport{writer: os.Stdout}
+}
+
+func (w *WriterTransport) Deliver(msg Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := msg.toMail().WriteTo(w.writer)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w.writer, "\n\n")
+	return err
+}
+
+// MemoryTransport keeps the delivered messages in memory, so tests can assert on them.
+type MemoryTransport struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+func (m *MemoryTransport) Deliver(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages returns a copy of the delivered messages in the order they were delivered.
+func (m *MemoryTransport) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message{}, m.messages...)
+}
+
+// Last returns the most recently delivered message, and false if none has been delivered.
+func (m *MemoryTransport) Last() (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.messages) == 0 {
+		return Message{}, false
+	}
+	return m.messages[len(m.messages)-1], true
+}
@@ -0,0 +1,72 @@
+package mailer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidUnsubscribeToken is returned for unsubscribe tokens which weren't signed with the secret.
+var ErrInvalidUnsubscribeToken = errors.New("mailer: invalid unsubscribe token")
+
+// Unsubscriber signs the one-click unsubscribe links of non-transactional emails and verifies their tokens,
+// so users can unsubscribe from a category of emails without authenticating.
+// Tokens don't expire, as unsubscribe links must keep working for as long as their emails are kept.
+type Unsubscriber struct {
+	secret []byte
+	url    string
+}
+
+// NewUnsubscriber returns an Unsubscriber signing tokens with the secret
+// into links to the unsubscribe endpoint at the URL.
+func NewUnsubscriber(secret string, url string) *Unsubscriber {
+	return &Unsubscriber{
+		secret: []byte(secret),
+		url:    url,
+	}
+}
+
+// Token returns the token unsubscribing the user from the category,
+// which holds the user id and category followed by their signature.
+func (u *Unsubscriber) Token(userId int, category string) string {
+	payload := fmt.Sprintf("%d.%s", userId, category)
+	return payload + "." + u.sign(payload)
+}
+
+// Url returns the link to the unsubscribe endpoint with the token of the user and category in its query.
+func (u *Unsubscriber) Url(userId int, category string) string {
+	return u.url + "?token=" + url.QueryEscape(u.Token(userId, category))
+}
+
+// Verify returns the user id and category of the token,
+// or ErrInvalidUnsubscribeToken if the token is malformed or its signature doesn't match.
+func (u *Unsubscriber) Verify(token string) (int, string, error) {
+	separator := strings.LastIndex(token, ".")
+	if separator < 0 {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+
+	payload, signature := token[:separator], token[separator+1:]
+	if !hmac.Equal([]byte(signature), []byte(u.sign(payload))) {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+
+	userIdString, category, found := strings.Cut(payload, ".")
+	userId, err := strconv.Atoi(userIdString)
+	if !found || err != nil {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	return userId, category, nil
+}
+
+// sign returns the HMAC-SHA256 signature of the payload, encoded for use in URLs.
+func (u *Unsubscriber) sign(payload string) string {
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
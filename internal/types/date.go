@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DateLayout is the ISO 8601 layout of dates.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without a time of day, encoded in JSON as "YYYY-MM-DD".
+type Date struct {
+	time.Time
+}
+
+// ParseDate parses a date in the ISO 8601 layout.
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	date, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"golang.org/x/text/language"
 	"regexp"
 	"strings"
 	"unicode"
@@ -51,3 +52,35 @@ func NoWhiteSpace(value string) bool {
 	}
 	return true
 }
+
+// LanguageCode returns true if the value is a lowercase ISO 639-1 language code, such as "en".
+func LanguageCode(value string) bool {
+	if !letters(value, 2, 'a', 'z') {
+		return false
+	}
+	_, err := language.ParseBase(value)
+	return err == nil
+}
+
+// CountryCode returns true if the value is an uppercase ISO 3166-1 alpha-2 country code, such as "GB".
+// Codes of regions which aren't countries, such as "EU", are rejected.
+func CountryCode(value string) bool {
+	if !letters(value, 2, 'A', 'Z') {
+		return false
+	}
+	region, err := language.ParseRegion(value)
+	return err == nil && region.IsCountry()
+}
+
+// letters returns true if the value consists of exactly n characters between first and last.
+func letters(value string, n int, first rune, last rune) bool {
+	if len(value) != n {
+		return false
+	}
+	for _, char := range value {
+		if char < first || char > last {
+			return false
+		}
+	}
+	return true
+}
@@ -32,3 +32,12 @@ func (v *Validator) Check(rule bool, field string, message string) {
 		v.AddError(field, message)
 	}
 }
+
+// FirstErrors returns the first error message of each invalid field.
+func (v *Validator) FirstErrors() map[string]string {
+	firstErrors := map[string]string{}
+	for field, errs := range v.Errors {
+		firstErrors[field] = errs[0]
+	}
+	return firstErrors
+}
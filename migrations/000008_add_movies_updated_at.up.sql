@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS movies
+    ADD COLUMN IF NOT EXISTS updated_at timestamp(0) with time zone NOT NULL DEFAULT now();
\ No newline at end of file
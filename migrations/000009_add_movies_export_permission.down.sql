@@ -0,0 +1,3 @@
+DELETE
+FROM permissions
+WHERE code = 'movies:export';
\ No newline at end of file
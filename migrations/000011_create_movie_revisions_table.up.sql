@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS movie_revisions
+(
+    id         BIGSERIAL                   NOT NULL PRIMARY KEY,
+    movie_id   BIGINT                      NOT NULL REFERENCES movies ON DELETE CASCADE,
+    version    INTEGER                     NOT NULL,
+    action     TEXT                        NOT NULL,
+    snapshot   JSONB                       NOT NULL,
+    diff       JSONB,
+    user_id    BIGINT                      REFERENCES users ON DELETE SET NULL,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS movie_revisions_movie_id_version_idx ON movie_revisions (movie_id, version);
+
+-- record the current state of existing movies as their initial revisions
+INSERT INTO movie_revisions (movie_id, version, action, snapshot, created_at)
+SELECT id,
+       version,
+       'create',
+       jsonb_build_object('title', title, 'year', year, 'runtime', runtime, 'genres', genres),
+       updated_at
+FROM movies;
\ No newline at end of file
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS genres
+(
+    id         BIGSERIAL                   NOT NULL PRIMARY KEY,
+    slug       TEXT                        NOT NULL UNIQUE,
+    name       TEXT                        NOT NULL UNIQUE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- genre_slug lowercases the name and joins its words with hyphens,
+-- so variants like "Sci-Fi", "sci-fi" and "SCI FI" share a slug
+CREATE OR REPLACE FUNCTION genre_slug(name TEXT) RETURNS TEXT AS
+$$
+SELECT trim(BOTH '-' FROM regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g'))
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- create a genre for each slug of the existing genres,
+-- named after its most frequent spelling
+INSERT INTO genres (slug, name)
+SELECT DISTINCT ON (slug) slug, name
+FROM (SELECT genre_slug(genre) AS slug, trim(genre) AS name, count(*) AS uses
+      FROM movies,
+           unnest(genres) AS genre
+      WHERE genre_slug(genre) <> ''
+      GROUP BY 1, 2) AS spellings
+ORDER BY slug, uses DESC, name;
+
+-- replace the genres of movies with their canonical names,
+-- keeping the first occurrence of any duplicates
+UPDATE movies
+SET genres = ARRAY(SELECT g.name
+                   FROM unnest(movies.genres) WITH ORDINALITY AS mg(name, position)
+                            JOIN genres g ON g.slug = genre_slug(mg.name)
+                   GROUP BY g.name
+                   ORDER BY min(mg.position));
+
+DROP FUNCTION genre_slug(TEXT);
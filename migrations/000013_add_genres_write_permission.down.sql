@@ -0,0 +1,3 @@
+DELETE
+FROM permissions
+WHERE code = 'genres:write';
\ No newline at end of file
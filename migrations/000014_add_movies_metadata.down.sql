@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS movies_certifications_idx;
+DROP INDEX IF EXISTS movies_countries_idx;
+DROP INDEX IF EXISTS movies_original_language_idx;
+
+ALTER TABLE IF EXISTS movies
+    DROP COLUMN IF EXISTS tagline,
+    DROP COLUMN IF EXISTS synopsis,
+    DROP COLUMN IF EXISTS certifications,
+    DROP COLUMN IF EXISTS release_dates,
+    DROP COLUMN IF EXISTS countries,
+    DROP COLUMN IF EXISTS original_language,
+    DROP COLUMN IF EXISTS original_title;
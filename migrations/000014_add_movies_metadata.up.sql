@@ -0,0 +1,12 @@
+ALTER TABLE IF EXISTS movies
+    ADD COLUMN IF NOT EXISTS original_title    text   NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS original_language text   NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS countries         text[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS release_dates     jsonb  NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS certifications    jsonb  NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS synopsis          text   NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tagline           text   NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS movies_original_language_idx ON movies (original_language);
+CREATE INDEX IF NOT EXISTS movies_countries_idx ON movies USING GIN (countries);
+CREATE INDEX IF NOT EXISTS movies_certifications_idx ON movies USING GIN (certifications jsonb_path_ops);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS movie_translations
+(
+    movie_id   BIGINT                      NOT NULL REFERENCES movies ON DELETE CASCADE,
+    language   TEXT                        NOT NULL,
+    title      TEXT                        NOT NULL,
+    synopsis   TEXT                        NOT NULL DEFAULT '',
+    tagline    TEXT                        NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (movie_id, language)
+);
+
+CREATE INDEX IF NOT EXISTS movie_translations_title_idx ON movie_translations USING GIN (to_tsvector('simple', title));
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS movies_external_ids_imdb_key;
+DROP INDEX IF EXISTS movies_external_ids_tmdb_key;
+DROP INDEX IF EXISTS movies_external_ids_wikidata_key;
+
+ALTER TABLE IF EXISTS movies
+    DROP COLUMN IF EXISTS external_ids;
@@ -0,0 +1,10 @@
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS external_ids JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE movies
+    ADD CONSTRAINT movies_external_ids_check CHECK (jsonb_typeof(external_ids) = 'object');
+
+-- each external id belongs to at most one movie, including those in the trash
+CREATE UNIQUE INDEX IF NOT EXISTS movies_external_ids_imdb_key ON movies ((external_ids ->> 'imdb'));
+CREATE UNIQUE INDEX IF NOT EXISTS movies_external_ids_tmdb_key ON movies ((external_ids ->> 'tmdb'));
+CREATE UNIQUE INDEX IF NOT EXISTS movies_external_ids_wikidata_key ON movies ((external_ids ->> 'wikidata'));
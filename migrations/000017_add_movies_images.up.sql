@@ -0,0 +1,9 @@
+-- the poster and backdrop hold the storage keys, URLs and dimensions of the images and their thumbnails,
+-- and are null for movies without them
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS poster   JSONB,
+    ADD COLUMN IF NOT EXISTS backdrop JSONB;
+
+ALTER TABLE movies
+    ADD CONSTRAINT movies_poster_check CHECK (jsonb_typeof(poster) = 'object'),
+    ADD CONSTRAINT movies_backdrop_check CHECK (jsonb_typeof(backdrop) = 'object');
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS collections
+(
+    id          BIGSERIAL                   NOT NULL PRIMARY KEY,
+    kind        TEXT                        NOT NULL,
+    name        TEXT                        NOT NULL,
+    description TEXT                        NOT NULL DEFAULT '',
+    public      BOOLEAN                     NOT NULL DEFAULT FALSE,
+    user_id     BIGINT                      REFERENCES users ON DELETE CASCADE,
+    created_at  TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT collections_kind_check CHECK (kind IN ('franchise', 'list')),
+    -- lists are owned by their curators, while franchises are official and always public
+    CONSTRAINT collections_owner_check CHECK ((kind = 'list') = (user_id IS NOT NULL)),
+    CONSTRAINT collections_public_check CHECK (kind = 'list' OR public)
+);
+
+CREATE INDEX IF NOT EXISTS collections_user_id_idx ON collections (user_id);
+
+CREATE TABLE IF NOT EXISTS collection_movies
+(
+    collection_id BIGINT  NOT NULL REFERENCES collections ON DELETE CASCADE,
+    movie_id      BIGINT  NOT NULL REFERENCES movies ON DELETE CASCADE,
+    position      INTEGER NOT NULL CHECK (position > 0),
+    PRIMARY KEY (collection_id, movie_id),
+    -- deferred so entries can be shifted in a single statement
+    CONSTRAINT collection_movies_position_key UNIQUE (collection_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE INDEX IF NOT EXISTS collection_movies_movie_id_idx ON collection_movies (movie_id);
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS series
+(
+    id         BIGSERIAL                   NOT NULL PRIMARY KEY,
+    title      TEXT                        NOT NULL,
+    start_year INTEGER                     NOT NULL,
+    end_year   INTEGER,
+    genres     TEXT[]                      NOT NULL,
+    synopsis   TEXT                        NOT NULL DEFAULT '',
+    version    INTEGER                     NOT NULL DEFAULT 1,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    -- regular television broadcasts started in 1928
+    CONSTRAINT series_start_year_check CHECK (start_year BETWEEN 1928 AND date_part('year', now())),
+    -- the end year is null for series which are still running
+    CONSTRAINT series_end_year_check CHECK (end_year BETWEEN start_year AND date_part('year', now())),
+    CONSTRAINT series_genres_length_check CHECK (array_length(genres, 1) > 0)
+);
+
+CREATE INDEX IF NOT EXISTS series_title_idx ON series USING GIN (to_tsvector('simple', title));
+CREATE INDEX IF NOT EXISTS series_genres_idx ON series USING GIN (genres);
+
+CREATE TABLE IF NOT EXISTS seasons
+(
+    id         BIGSERIAL                   NOT NULL PRIMARY KEY,
+    series_id  BIGINT                      NOT NULL REFERENCES series ON DELETE CASCADE,
+    number     INTEGER                     NOT NULL CHECK (number > 0),
+    title      TEXT                        NOT NULL DEFAULT '',
+    air_date   DATE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT seasons_series_id_number_key UNIQUE (series_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS episodes
+(
+    id         BIGSERIAL                   NOT NULL PRIMARY KEY,
+    season_id  BIGINT                      NOT NULL REFERENCES seasons ON DELETE CASCADE,
+    number     INTEGER                     NOT NULL CHECK (number > 0),
+    title      TEXT                        NOT NULL,
+    runtime    INTEGER                     NOT NULL CHECK (runtime > 0),
+    air_date   DATE,
+    synopsis   TEXT                        NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT episodes_season_id_number_key UNIQUE (season_id, number)
+);
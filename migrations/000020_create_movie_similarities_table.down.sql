@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS movie_similarities;
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- precomputed similarity scores between movies, replaced in full by a periodic refresh
+CREATE TABLE IF NOT EXISTS movie_similarities
+(
+    movie_id         BIGINT           NOT NULL REFERENCES movies ON DELETE CASCADE,
+    similar_movie_id BIGINT           NOT NULL REFERENCES movies ON DELETE CASCADE,
+    score            DOUBLE PRECISION NOT NULL CHECK (score > 0 AND score <= 1),
+    PRIMARY KEY (movie_id, similar_movie_id),
+    CONSTRAINT movie_similarities_distinct_check CHECK (movie_id <> similar_movie_id)
+);
+
+CREATE INDEX IF NOT EXISTS movie_similarities_score_idx ON movie_similarities (movie_id, score DESC);
@@ -0,0 +1,12 @@
+-- ids of movies merged into other movies, which are redirected to the surviving movies
+CREATE TABLE IF NOT EXISTS movie_redirects
+(
+    old_id     BIGINT                      NOT NULL PRIMARY KEY,
+    movie_id   BIGINT                      NOT NULL REFERENCES movies ON DELETE CASCADE,
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS movie_redirects_movie_id_idx ON movie_redirects (movie_id);
+
+-- trigram index for finding movies with similar titles
+CREATE INDEX IF NOT EXISTS movies_title_trgm_idx ON movies USING GIN (title gin_trgm_ops);
@@ -0,0 +1,18 @@
+-- emails queued in the same transactions as the changes which trigger them, and sent by the outbox workers
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id           BIGSERIAL                   NOT NULL PRIMARY KEY,
+    recipient    TEXT                        NOT NULL,
+    template     TEXT                        NOT NULL,
+    data         JSONB                       NOT NULL DEFAULT '{}',
+    status       TEXT                        NOT NULL DEFAULT 'pending',
+    attempts     INTEGER                     NOT NULL DEFAULT 0,
+    last_error   TEXT                        NOT NULL DEFAULT '',
+    available_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    created_at   TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    sent_at      TIMESTAMP(0) WITH TIME ZONE,
+    CONSTRAINT outbox_status_check CHECK (status IN ('pending', 'sent', 'dead'))
+);
+
+-- pending emails are claimed in order of availability
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (available_at, id) WHERE status = 'pending';
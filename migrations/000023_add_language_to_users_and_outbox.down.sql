@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS outbox
+    DROP COLUMN IF EXISTS language;
+
+ALTER TABLE IF EXISTS users
+    DROP COLUMN IF EXISTS language;
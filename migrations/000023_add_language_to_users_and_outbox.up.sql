@@ -0,0 +1,7 @@
+-- the ISO 639-1 code of the language emails are sent to the user in
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT 'en';
+
+-- queued emails keep the language of their recipient at the time they were triggered
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT 'en';
@@ -0,0 +1,3 @@
+-- previewing and test-sending the email templates is limited to operators
+INSERT INTO permissions(code)
+VALUES ('emails:manage');
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS outbox
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS user_id;
+
+DROP TABLE IF EXISTS notification_preferences;
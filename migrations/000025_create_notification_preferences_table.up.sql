@@ -0,0 +1,15 @@
+-- categories of non-transactional emails users have opted into,
+-- with users without a row having the default preferences
+CREATE TABLE IF NOT EXISTS notification_preferences
+(
+    user_id             BIGINT                      NOT NULL PRIMARY KEY REFERENCES users ON DELETE CASCADE,
+    security_alerts     BOOLEAN                     NOT NULL,
+    product_updates     BOOLEAN                     NOT NULL,
+    saved_search_alerts BOOLEAN                     NOT NULL,
+    updated_at          TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- non-transactional emails keep their recipient and category to be signed into their unsubscribe links
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS user_id BIGINT REFERENCES users ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT '';
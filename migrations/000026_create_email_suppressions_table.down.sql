@@ -0,0 +1,9 @@
+DELETE
+FROM outbox
+WHERE status = 'suppressed';
+
+ALTER TABLE IF EXISTS outbox
+    DROP CONSTRAINT IF EXISTS outbox_status_check,
+    ADD CONSTRAINT outbox_status_check CHECK (status IN ('pending', 'sent', 'dead'));
+
+DROP TABLE IF EXISTS email_suppressions;
@@ -0,0 +1,14 @@
+-- addresses emails are no longer sent to after hard bounces and spam complaints, keyed by their lowercased address
+CREATE TABLE IF NOT EXISTS email_suppressions
+(
+    email      TEXT                        NOT NULL PRIMARY KEY,
+    reason     TEXT                        NOT NULL,
+    detail     TEXT                        NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT email_suppressions_reason_check CHECK (reason IN ('bounce', 'complaint'))
+);
+
+-- emails to suppressed addresses are kept in the outbox without being sent
+ALTER TABLE outbox
+    DROP CONSTRAINT IF EXISTS outbox_status_check,
+    ADD CONSTRAINT outbox_status_check CHECK (status IN ('pending', 'sent', 'dead', 'suppressed'));
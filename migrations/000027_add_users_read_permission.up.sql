@@ -0,0 +1,3 @@
+-- viewing the accounts of other users, and whether emails are delivered to them, is limited to operators
+INSERT INTO permissions(code)
+VALUES ('users:read');
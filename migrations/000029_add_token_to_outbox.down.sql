@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS outbox
+    DROP COLUMN IF EXISTS token_lifetime;
+
+ALTER TABLE IF EXISTS outbox
+    DROP COLUMN IF EXISTS token_scope;
@@ -0,0 +1,12 @@
+-- tokens sent in emails are created by the outbox workers when the emails are sent,
+-- so the outbox keeps their scope and lifetime instead of their plaintext
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS token_scope TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS token_lifetime INTERVAL NOT NULL DEFAULT '0';
+
+-- dead-lettered emails are kept for inspection without the data they were rendered with
+UPDATE outbox
+SET data = '{}'
+WHERE status = 'dead';
@@ -0,0 +1,3 @@
+DELETE
+FROM permissions
+WHERE code = 'movies:merge';
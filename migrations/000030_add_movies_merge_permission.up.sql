@@ -0,0 +1,3 @@
+-- merging duplicate movies into one is limited to curators
+INSERT INTO permissions(code)
+VALUES ('movies:merge');